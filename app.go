@@ -1,21 +1,25 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"embed"
 	"errors"
-	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/rowjak/godb-orm/internal/config"
 	"github.com/rowjak/godb-orm/internal/database"
 	"github.com/rowjak/godb-orm/internal/generator"
+	"github.com/rowjak/godb-orm/internal/i18n"
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 //go:embed all:frontend/dist
@@ -39,155 +43,285 @@ type ColumnInfo struct {
 	DefaultValue    *string  `json:"defaultValue"`
 	EnumValues      []string `json:"enumValues,omitempty"`
 	Comment         string   `json:"comment,omitempty"`
+	Excluded        bool     `json:"excluded"`
 }
 
-// ConnectionStatus represents the current connection status
+// CodePreviewBatch holds the results of previewing several tables at once
+type CodePreviewBatch struct {
+	Code   map[string]string `json:"code"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// CodePreview holds generated code and its diff against the file on disk
+type CodePreview struct {
+	Code   string `json:"code"`
+	Path   string `json:"path"`
+	Exists bool   `json:"exists"`
+	Diff   string `json:"diff"`
+}
+
+// defaultOutputDir is the directory the GUI saves generated files to
+const defaultOutputDir = "./models"
+
+// demoDialect and demoDBName describe the demo database opened by OpenDemo
+const (
+	demoDialect = "mysql"
+	demoDBName  = "demo"
+)
+
+// previewConcurrency bounds the number of tables introspected in parallel
+const previewConcurrency = 8
+
+// ConnectionStatus represents the status of a connection
 type ConnectionStatus struct {
+	ID           string `json:"id"`
+	Active       bool   `json:"active"` // Set by GetConnections
 	Connected    bool   `json:"connected"`
 	Driver       string `json:"driver"`
 	Host         string `json:"host"`
 	DatabaseName string `json:"databaseName"`
+	Reconnecting bool   `json:"reconnecting"`
 	Error        string `json:"error,omitempty"`
+	Hint         string `json:"hint,omitempty"` // What the user can do about Error
 }
 
-// App struct holds the application state
+// healthCheckInterval is how often the connection is pinged in the background
+const healthCheckInterval = 10 * time.Second
+
+// EventConnectionStatus is emitted with a ConnectionStatus whenever the
+// connection drops or recovers
+const EventConnectionStatus = "connection:status"
+
+// schemaCheckInterval is how often the schema is compared with the last
+// snapshot in the background
+const schemaCheckInterval = time.Minute
+
+// EventSchemaChanged is emitted with a database.SchemaChange when tables were
+// added, dropped or altered since the schema was last fetched
+const EventSchemaChanged = "schema:changed"
+
+// App struct holds the application state. Each open database connection (a
+// connection tab) keeps its own state; bridge methods without a connection
+// ID work on the active one.
 type App struct {
-	ctx          context.Context
-	mu           sync.RWMutex
-	introspector database.DBIntrospector
-	dbConfig     *config.DBConfig
-	generator    *generator.Generator
-	connected    bool
+	ctx         context.Context
+	mu          sync.RWMutex
+	connections map[string]*connection // Open connections by ID
+	active      string                 // ID of the active connection, empty if none is open
+	nextID      int
+	savedConfig *config.DBConfig // Database configuration saved on disk
+	i18n        *i18n.Translator
 }
 
-// NewApp creates a new App application struct
+// NewApp creates a new App application struct. Messages start out in the
+// locale of the environment until the frontend calls SetLocale.
 func NewApp() *App {
-	return &App{}
+	return &App{
+		connections: make(map[string]*connection),
+		i18n:        i18n.New(i18n.Detect()),
+	}
 }
 
 // Startup is called when the app starts
 func (a *App) Startup(ctx context.Context) {
 	a.ctx = ctx
 
-	// Try to load saved configuration
-	cfg, err := config.LoadConfig()
+	// Try to load saved configuration (environment and project config included)
+	cfg, err := config.LoadEffectiveConfig()
 	if err == nil && cfg.Database.DBName != "" {
-		a.dbConfig = &cfg.Database
+		a.mu.Lock()
+		a.savedConfig = &cfg.Database
+		a.mu.Unlock()
 	}
 }
 
 // Greet returns a greeting for the given name (kept for testing)
 func (a *App) Greet(name string) string {
-	return "Hello " + name + ", welcome to godb-orm!"
+	return a.i18n.Sprintf(i18n.Greeting, name)
 }
 
-// GetSavedConfig returns the saved database configuration
-func (a *App) GetSavedConfig() *config.DBConfig {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
-	return a.dbConfig
+// SetLocale switches the language of the messages returned to the frontend,
+// given a language tag such as "id" or "en-US"
+func (a *App) SetLocale(locale string) error {
+	return a.i18n.SetLocale(locale)
 }
 
-// GetConnectionStatus returns the current connection status
-func (a *App) GetConnectionStatus() ConnectionStatus {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+// GetLocale returns the language of the messages returned to the frontend
+func (a *App) GetLocale() string {
+	return string(a.i18n.Locale())
+}
 
-	status := ConnectionStatus{
-		Connected: a.connected,
+// GetSupportedLocales returns the languages messages can be returned in
+func (a *App) GetSupportedLocales() []string {
+	var locales []string
+	for _, locale := range i18n.Supported() {
+		locales = append(locales, string(locale))
 	}
+	return locales
+}
+
+// errNotConnected returns ErrNotConnected in the current locale
+func (a *App) errNotConnected() error {
+	return a.i18n.Error(ErrNotConnected, i18n.NotConnected)
+}
 
-	if a.dbConfig != nil {
-		status.Driver = a.dbConfig.Driver
-		status.Host = a.dbConfig.Host
-		status.DatabaseName = a.dbConfig.DBName
+// GetSavedConfig returns the configuration of the active connection, or the
+// saved database configuration if none is open
+func (a *App) GetSavedConfig() *config.DBConfig {
+	c := a.conn("")
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.dbConfig != nil {
+		return c.dbConfig
 	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.savedConfig
+}
 
-	return status
+// GetConnectionStatus returns the current connection status
+func (a *App) GetConnectionStatus() ConnectionStatus {
+	c := a.conn("")
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.connectionStatus()
 }
 
 // ConnectDB attempts to connect to the database with the given configuration
-// This is the main method called from frontend to establish a connection
+// This is the main method called from frontend to establish a connection.
+// It replaces the database of the active connection; OpenConnection opens
+// another connection alongside it.
 func (a *App) ConnectDB(cfg config.DBConfig) error {
-	a.mu.Lock()
-	defer a.mu.Unlock()
-
-	// Close existing connection if any
-	if a.introspector != nil {
-		a.introspector.Close()
-		a.introspector = nil
-		a.generator = nil
-		a.connected = false
+	c := a.conn("")
+	if c.id == "" {
+		_, err := a.OpenConnection(cfg)
+		return err
 	}
+	return c.connect(cfg)
+}
+
+// GetRecentConnections returns previously successful connections, most recent first
+func (a *App) GetRecentConnections() ([]config.RecentConnection, error) {
+	return config.LoadRecentConnections()
+}
+
+// RemoveRecentConnection removes a connection from the history
+func (a *App) RemoveRecentConnection(key string) error {
+	return config.RemoveRecentConnection(key)
+}
 
-	// Create new introspector based on driver
-	introspector, err := database.NewIntrospector(&cfg)
+// ReconnectRecent connects to a connection from the history. The history
+// holds no passwords: an empty password falls back to the saved config when
+// it refers to the same connection.
+func (a *App) ReconnectRecent(key string, password string) error {
+	recent, err := config.LoadRecentConnections()
 	if err != nil {
-		return fmt.Errorf("failed to create introspector: %w", err)
+		return err
 	}
 
-	// Attempt connection
-	if err := introspector.Connect(); err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+	for _, r := range recent {
+		if r.Key() != key {
+			continue
+		}
+		if password == "" {
+			password = a.savedPassword(r)
+		}
+		return a.ConnectDB(r.DBConfig(password))
 	}
 
-	// Store state
-	a.introspector = introspector
-	a.dbConfig = &cfg
-	a.generator = generator.NewGenerator(introspector)
-	a.connected = true
+	return a.i18n.Errorf(i18n.NoRecentConnection, key)
+}
 
-	// Save configuration for future use
-	fullCfg := &config.Config{
-		Database: cfg,
-		Generator: config.GeneratorConfig{
-			Tables:    "*",
-			OutputDir: "./models",
-		},
+// ReconnectLast reconnects to the most recent connection on startup.
+// It reports false without error if there is no history.
+func (a *App) ReconnectLast() (bool, error) {
+	recent, err := config.LoadRecentConnections()
+	if err != nil || len(recent) == 0 {
+		return false, err
 	}
-	if err := config.SaveConfig(fullCfg); err != nil {
-		// Log warning but don't fail the connection
-		log.Printf("Warning: Could not save config: %v", err)
+
+	if err := a.ReconnectRecent(recent[0].Key(), ""); err != nil {
+		return false, err
 	}
+	return true, nil
+}
 
-	return nil
+// savedPassword returns the password of the saved config if it refers to
+// the same connection as r
+func (a *App) savedPassword(r config.RecentConnection) string {
+	saved := a.GetSavedConfig()
+	if saved == nil || config.RecentConnectionFrom(*saved).Key() != r.Key() {
+		return ""
+	}
+	return saved.Password
 }
 
-// DisconnectDB closes the database connection
+// DisconnectDB closes the active connection. The most recently opened
+// remaining connection, if any, becomes active.
 func (a *App) DisconnectDB() error {
-	a.mu.Lock()
-	defer a.mu.Unlock()
+	c := a.conn("")
+	if c.id == "" {
+		return nil
+	}
+	return a.CloseConnection(c.id)
+}
 
-	if a.introspector != nil {
-		if err := a.introspector.Close(); err != nil {
-			return fmt.Errorf("failed to close connection: %w", err)
-		}
-		a.introspector = nil
-		a.generator = nil
-		a.connected = false
+// RefreshSchema re-reads the schema, typically after a schema:changed event,
+// and returns what changed since it was last fetched. The frontend then
+// reloads the table list and previews.
+func (a *App) RefreshSchema() (database.SchemaChange, error) {
+	c := a.conn("")
+	c.recoverConnection()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.connected || c.introspector == nil {
+		return database.SchemaChange{}, a.errNotConnected()
 	}
 
-	return nil
+	snapshot, err := database.TakeSnapshot(c.introspector)
+	if err != nil {
+		return database.SchemaChange{}, a.i18n.WithHint(a.i18n.Errorf(i18n.FetchTables, err), c.dbConfig.Driver)
+	}
+
+	var change database.SchemaChange
+	if c.schemaSnapshot != nil {
+		change = c.schemaSnapshot.Diff(snapshot)
+	}
+	c.resetSchemaSnapshot()
+	c.schemaSnapshot = snapshot
+	return change, nil
 }
 
 // IsPostgres returns true if the connected database is PostgreSQL
 func (a *App) IsPostgres() bool {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
-	return a.dbConfig != nil && a.dbConfig.Driver == "postgres"
+	c := a.conn("")
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dbConfig != nil && c.dbConfig.Driver == "postgres"
 }
 
 // FetchSchemas returns a list of schemas for PostgreSQL databases
 func (a *App) FetchSchemas() ([]string, error) {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	return a.FetchSchemasFor("")
+}
 
-	if !a.connected || a.introspector == nil {
-		return nil, ErrNotConnected
+// FetchSchemasFor returns the schemas of the connection with the given ID
+func (a *App) FetchSchemasFor(id string) ([]string, error) {
+	c := a.conn(id)
+	c.recoverConnection()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.connected || c.introspector == nil {
+		return nil, a.errNotConnected()
 	}
 
 	// Check if it's a PostgreSQL connection
-	if pgIntrospector, ok := a.introspector.(*database.PostgresIntrospector); ok {
+	if pgIntrospector, ok := c.introspector.(*database.PostgresIntrospector); ok {
 		return pgIntrospector.GetSchemas()
 	}
 
@@ -197,16 +331,18 @@ func (a *App) FetchSchemas() ([]string, error) {
 
 // SetSchema sets the current schema for PostgreSQL databases
 func (a *App) SetSchema(schema string) error {
-	a.mu.Lock()
-	defer a.mu.Unlock()
+	c := a.conn("")
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	if !a.connected || a.introspector == nil {
-		return ErrNotConnected
+	if !c.connected || c.introspector == nil {
+		return a.errNotConnected()
 	}
 
 	// Check if it's a PostgreSQL connection
-	if pgIntrospector, ok := a.introspector.(*database.PostgresIntrospector); ok {
+	if pgIntrospector, ok := c.introspector.(*database.PostgresIntrospector); ok {
 		pgIntrospector.SetSchema(schema)
+		c.resetSchemaSnapshot()
 		return nil
 	}
 
@@ -216,63 +352,136 @@ func (a *App) SetSchema(schema string) error {
 
 // GetCurrentSchema returns the current schema for PostgreSQL databases
 func (a *App) GetCurrentSchema() string {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	c := a.conn("")
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-	if a.introspector == nil {
+	if c.introspector == nil {
 		return ""
 	}
 
 	// Check if it's a PostgreSQL connection
-	if pgIntrospector, ok := a.introspector.(*database.PostgresIntrospector); ok {
+	if pgIntrospector, ok := c.introspector.(*database.PostgresIntrospector); ok {
 		return pgIntrospector.GetCurrentSchema()
 	}
 
 	// For MySQL, return database name
-	if a.dbConfig != nil {
-		return a.dbConfig.DBName
+	if c.dbConfig != nil {
+		return c.dbConfig.DBName
 	}
 	return ""
 }
 
 // FetchTables returns a list of table names from the connected database
 func (a *App) FetchTables() ([]string, error) {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	return a.FetchTablesFor("")
+}
+
+// FetchTablesFor returns the table names of the connection with the given ID
+func (a *App) FetchTablesFor(id string) ([]string, error) {
+	c := a.conn(id)
+	c.recoverConnection()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-	if !a.connected || a.introspector == nil {
-		return nil, ErrNotConnected
+	if !c.connected || c.introspector == nil {
+		return nil, a.errNotConnected()
 	}
 
-	tables, err := a.introspector.GetTables()
+	tables, err := c.introspector.GetTables()
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch tables: %w", err)
+		return nil, a.i18n.WithHint(a.i18n.Errorf(i18n.FetchTables, err), c.dbConfig.Driver)
 	}
 
 	return tables, nil
 }
 
+// FetchTableStats returns the estimated row count and size of every table,
+// by table name, so the table list can point out the big tables. Databases
+// without cheap statistics return an empty map.
+func (a *App) FetchTableStats() (map[string]database.TableStats, error) {
+	c := a.conn("")
+	c.recoverConnection()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.connected || c.introspector == nil {
+		return nil, a.errNotConnected()
+	}
+
+	provider, ok := c.introspector.(database.StatsProvider)
+	if !ok {
+		return map[string]database.TableStats{}, nil
+	}
+	stats, err := provider.TableStats()
+	if err != nil {
+		return nil, a.i18n.WithHint(a.i18n.Errorf(i18n.FetchTableStats, err), c.dbConfig.Driver)
+	}
+	return stats, nil
+}
+
+// AnalyzeColumn samples the values of a column: its null ratio, number of
+// distinct values and most frequent values, to help decide whether it
+// should be an enum or a nullable pointer. Only the first rows of the table
+// are read (database.DefaultAnalyzeSample), so it is cheap on big tables.
+func (a *App) AnalyzeColumn(tableName, columnName string) (*database.ColumnStats, error) {
+	c := a.conn("")
+	c.recoverConnection()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.connected || c.introspector == nil {
+		return nil, a.errNotConnected()
+	}
+
+	analyzer, ok := c.introspector.(database.ColumnAnalyzer)
+	if !ok {
+		return nil, a.i18n.Errorf(i18n.NoColumnAnalysis, c.dbConfig.Driver)
+	}
+	stats, err := analyzer.AnalyzeColumn(tableName, columnName, database.DefaultAnalyzeSample)
+	if err != nil {
+		return nil, a.i18n.WithHint(a.i18n.Errorf(i18n.AnalyzeColumn, tableName, columnName, err), c.dbConfig.Driver)
+	}
+	return stats, nil
+}
+
 // FetchTableSchema returns detailed column information for a specific table
 func (a *App) FetchTableSchema(tableName string) ([]ColumnInfo, error) {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	return a.FetchTableSchemaFor("", tableName)
+}
+
+// FetchTableSchemaFor returns the columns of a table of the connection with
+// the given ID, e.g. to compare it with the same table of the active one
+func (a *App) FetchTableSchemaFor(id string, tableName string) ([]ColumnInfo, error) {
+	c := a.conn(id)
+	c.recoverConnection()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-	if !a.connected || a.introspector == nil {
-		return nil, ErrNotConnected
+	if !c.connected || c.introspector == nil {
+		return nil, a.errNotConnected()
 	}
 
-	columns, err := a.introspector.GetColumns(tableName)
+	columns, err := c.introspector.GetColumns(tableName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch schema for table %s: %w", tableName, err)
+		return nil, a.i18n.WithHint(a.i18n.Errorf(i18n.FetchTableSchema, tableName, err), c.dbConfig.Driver)
 	}
 
 	// Create type mapper for Go type conversion
 	typeMapper := generator.NewTypeMapper()
+	override := c.generator.TableOverride(tableName)
 
 	// Convert to ColumnInfo for frontend
 	var columnInfos []ColumnInfo
 	for _, col := range columns {
 		goType, _, _ := typeMapper.GetGoType(col.RawType, col.IsNullable)
+		if columnOverride := override.Column(col.Name); columnOverride.Type != "" {
+			goType = columnOverride.Type
+		}
 
 		info := ColumnInfo{
 			Name:            col.Name,
@@ -285,6 +494,7 @@ func (a *App) FetchTableSchema(tableName string) ([]ColumnInfo, error) {
 			DefaultValue:    col.DefaultValue,
 			EnumValues:      col.EnumValues,
 			Comment:         col.Comment,
+			Excluded:        override.Excludes(col.Name),
 		}
 		columnInfos = append(columnInfos, info)
 	}
@@ -292,68 +502,615 @@ func (a *App) FetchTableSchema(tableName string) ([]ColumnInfo, error) {
 	return columnInfos, nil
 }
 
-// GetCodePreview generates and returns the Go struct code for a table
-func (a *App) GetCodePreview(tableName string) (string, error) {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+// SuggestEnums samples the string columns of a table and proposes an enum
+// type for each with few distinct values. Suggestions are accepted with
+// AcceptEnum or rejected with RejectEnum.
+func (a *App) SuggestEnums(tableName string) ([]generator.EnumSuggestion, error) {
+	c := a.conn("")
+	c.recoverConnection()
 
-	if !a.connected || a.generator == nil {
-		return "", ErrNotConnected
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.connected || c.generator == nil {
+		return nil, a.errNotConnected()
 	}
 
-	code, err := a.generator.GenerateString(tableName)
+	analyzer, ok := c.introspector.(database.ColumnAnalyzer)
+	if !ok {
+		return nil, a.i18n.Errorf(i18n.NoColumnAnalysis, c.dbConfig.Driver)
+	}
+	suggestions, err := c.generator.SuggestEnums(tableName, analyzer)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate code for table %s: %w", tableName, err)
+		return nil, a.i18n.WithHint(a.i18n.Errorf(i18n.SuggestEnums, tableName, err), c.dbConfig.Driver)
 	}
+	return suggestions, nil
+}
 
-	return code, nil
+// AcceptEnum generates a column as an enum type with the given values and
+// persists the choice in the project config
+func (a *App) AcceptEnum(tableName, columnName string, values []string) error {
+	return a.setColumnEnum(tableName, columnName, values)
 }
 
-// GetCodePreviewMultiple generates code preview for multiple tables
-func (a *App) GetCodePreviewMultiple(tableNames []string) (map[string]string, error) {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+// RejectEnum records in the project config that a column should stay a
+// plain string, so its enum isn't suggested again
+func (a *App) RejectEnum(tableName, columnName string) error {
+	return a.setColumnEnum(tableName, columnName, nil)
+}
 
-	if !a.connected || a.generator == nil {
-		return nil, ErrNotConnected
+// setColumnEnum sets the enum values of a column, or marks its suggestion
+// rejected if there are none, keeping the rest of the table's override
+func (a *App) setColumnEnum(tableName, columnName string, values []string) error {
+	c := a.conn("")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.connected || c.generator == nil {
+		return a.errNotConnected()
+	}
+
+	override := c.generator.TableOverride(tableName)
+	columns := make(map[string]config.ColumnOverride, len(override.Columns)+1)
+	for name, col := range override.Columns {
+		if !strings.EqualFold(name, columnName) {
+			columns[name] = col
+		}
 	}
+	col := override.Column(columnName)
+	col.Enum = values
+	col.NoEnum = len(values) == 0
+	columns[columnName] = col
+	override.Columns = columns
+	return c.saveTableOverride(tableName, override)
+}
+
+// GetTableOverride returns the generation overrides configured for a table
+func (a *App) GetTableOverride(tableName string) (config.TableOverride, error) {
+	c := a.conn("")
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.connected || c.generator == nil {
+		return config.TableOverride{}, a.errNotConnected()
+	}
+
+	return c.generator.TableOverride(tableName), nil
+}
+
+// SetTableOverride sets the struct name, package, output file and column
+// overrides for a table and persists them in the project config
+func (a *App) SetTableOverride(tableName string, override config.TableOverride) error {
+	c := a.conn("")
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	results := make(map[string]string)
+	if !c.connected || c.generator == nil {
+		return a.errNotConnected()
+	}
+
+	return c.saveTableOverride(tableName, override)
+}
+
+// SetExcludedColumns sets the columns left out of a table's generated struct
+// and persists the selection in the project config
+func (a *App) SetExcludedColumns(tableName string, columns []string) error {
+	c := a.conn("")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.connected || c.generator == nil {
+		return a.errNotConnected()
+	}
+
+	override := c.generator.TableOverride(tableName)
+	override.ExcludeColumns = columns
+	return c.saveTableOverride(tableName, override)
+}
+
+// saveTableOverride persists an override and applies it to the generator.
+// The caller must hold the write lock.
+func (c *connection) saveTableOverride(tableName string, override config.TableOverride) error {
+	if err := config.SetTableOverride(tableName, override); err != nil {
+		return c.app.i18n.Errorf(i18n.SaveOverrides, tableName, err)
+	}
+	c.generator.SetTableOverride(tableName, override)
+	return nil
+}
+
+// GetTablePrefix returns the table prefix left out of struct and file names
+func (a *App) GetTablePrefix() (string, error) {
+	c := a.conn("")
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.connected || c.generator == nil {
+		return "", a.errNotConnected()
+	}
+
+	return c.generator.TablePrefix(), nil
+}
+
+// SetTablePrefix sets the table prefix (e.g., wp_) left out of struct and
+// file names and persists it as naming.table_prefix in the global config
+func (a *App) SetTablePrefix(prefix string) error {
+	c := a.conn("")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.connected || c.generator == nil {
+		return a.errNotConnected()
+	}
+
+	if err := config.SetValue("naming.table_prefix", prefix); err != nil {
+		return a.i18n.Errorf(i18n.SaveTablePrefix, err)
+	}
+	c.generator.SetTablePrefix(prefix)
+	return nil
+}
+
+// GetOutputPath returns the file path a table is written to in outputDir,
+// honouring any file name override
+func (a *App) GetOutputPath(tableName string, outputDir string) (string, error) {
+	c := a.conn("")
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.connected || c.generator == nil {
+		return "", a.errNotConnected()
+	}
+
+	return c.generator.FilePath(tableName, outputDir), nil
+}
+
+// GetCodePreview generates the Go struct code for a table and diffs it
+// against the existing file at the target path, if any
+func (a *App) GetCodePreview(tableName string) (CodePreview, error) {
+	return a.GetCodePreviewFor("", tableName)
+}
+
+// GetCodePreviewFor generates the code preview of a table of the connection
+// with the given ID
+func (a *App) GetCodePreviewFor(id string, tableName string) (CodePreview, error) {
+	c := a.conn(id)
+	c.recoverConnection()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.connected || c.generator == nil {
+		return CodePreview{}, a.errNotConnected()
+	}
+
+	preview, err := c.generator.Preview(tableName, defaultOutputDir)
+	if err != nil {
+		return CodePreview{}, a.i18n.Errorf(i18n.GenerateTable, tableName, err)
+	}
+
+	return CodePreview{
+		Code:   preview.Code,
+		Path:   preview.Path,
+		Exists: preview.Exists,
+		Diff:   preview.Diff,
+	}, nil
+}
+
+// GetCodePreviewMultiple generates code previews for multiple tables in parallel.
+// Tables that fail are reported in Errors instead of failing the whole batch.
+func (a *App) GetCodePreviewMultiple(tableNames []string) (CodePreviewBatch, error) {
+	c := a.conn("")
+	c.recoverConnection()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	batch := CodePreviewBatch{
+		Code:   make(map[string]string),
+		Errors: make(map[string]string),
+	}
+
+	if !c.connected || c.generator == nil {
+		return batch, a.errNotConnected()
+	}
+
+	var (
+		wg      sync.WaitGroup
+		resMu   sync.Mutex
+		workers = make(chan struct{}, previewConcurrency)
+	)
 	for _, tableName := range tableNames {
-		code, err := a.generator.GenerateString(tableName)
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(tableName string) {
+			defer wg.Done()
+			defer func() { <-workers }()
+
+			code, err := c.generator.GenerateString(tableName)
+
+			resMu.Lock()
+			defer resMu.Unlock()
+			if err != nil {
+				batch.Errors[tableName] = err.Error()
+				return
+			}
+			batch.Code[tableName] = code
+		}(tableName)
+	}
+	wg.Wait()
+
+	return batch, nil
+}
+
+// GenerateFromDDL generates structs from pasted CREATE TABLE statements
+// without a database connection. fallbackDialect (mysql or postgres) is used
+// when the dialect cannot be detected from the statements.
+func (a *App) GenerateFromDDL(ddl string, fallbackDialect string) (CodePreviewBatch, error) {
+	batch := CodePreviewBatch{
+		Code:   make(map[string]string),
+		Errors: make(map[string]string),
+	}
+
+	introspector := database.NewDDLIntrospectorFromSource(ddl, fallbackDialect)
+	if err := introspector.Connect(); err != nil {
+		return batch, err
+	}
+	defer introspector.Close()
+
+	tables, err := introspector.GetTables()
+	if err != nil {
+		return batch, err
+	}
+
+	fullCfg, err := config.LoadConfig()
+	if err != nil {
+		log.Printf("Warning: Could not load config: %v", err)
+		fullCfg = config.DefaultConfig()
+	}
+	gen := generator.NewGeneratorWithConfig(introspector, generatorConfig(fullCfg.Generator))
+
+	for _, tableName := range tables {
+		code, err := gen.GenerateString(tableName)
 		if err != nil {
-			return nil, fmt.Errorf("failed to generate code for table %s: %w", tableName, err)
+			batch.Errors[tableName] = err.Error()
+			continue
 		}
-		results[tableName] = code
+		batch.Code[tableName] = code
+	}
+
+	return batch, nil
+}
+
+// generatorConfig builds the generator settings from the persisted generator
+// defaults (package, null strategy, tag style, relations, type mappings) and the
+// project-level settings (table overrides, subpackages, template, header,
+// footer, type rules and table prefix)
+func generatorConfig(genCfg config.GeneratorConfig) generator.GeneratorConfig {
+	return mergeGeneratorConfig(genCfg, loadProjectConfig())
+}
+
+// presetGeneratorConfig is generatorConfig with the generator options of a
+// preset applied to both the persisted defaults and the project settings
+func presetGeneratorConfig(genCfg config.GeneratorConfig, preset config.Preset) (generator.GeneratorConfig, error) {
+	project := loadProjectConfig()
+	genCfg, err := preset.Apply(genCfg)
+	if err != nil {
+		return generator.GeneratorConfig{}, err
+	}
+	if project.Generator, err = preset.Apply(project.Generator); err != nil {
+		return generator.GeneratorConfig{}, err
+	}
+	return mergeGeneratorConfig(genCfg, project), nil
+}
+
+// loadProjectConfig loads the effective configuration, falling back to the
+// defaults if it can't be read
+func loadProjectConfig() *config.Config {
+	project, err := config.LoadEffectiveConfig()
+	if err != nil {
+		log.Printf("Warning: Could not load project config: %v", err)
+		project = config.DefaultConfig()
 	}
+	return project
+}
 
-	return results, nil
+// mergeGeneratorConfig builds the generator settings of generatorConfig from
+// the persisted generator defaults and the project config
+func mergeGeneratorConfig(genCfg config.GeneratorConfig, project *config.Config) generator.GeneratorConfig {
+	return generator.GeneratorConfig{
+		PackageName:    genCfg.PackageName,
+		NullStrategy:   generator.NullStrategy(genCfg.NullStrategy),
+		TagStyle:       generator.TagStyle(genCfg.TagStyle),
+		Relations:      generator.RelationMode(genCfg.Relations),
+		InferRelations: project.Generator.InferRelations,
+		Hstore:         generator.HstoreMode(genCfg.Hstore),
+		Vector:         generator.VectorMode(genCfg.Vector),
+		Spatial:        generator.SpatialMode(genCfg.Spatial),
+		Bit:            generator.BitMode(genCfg.Bit),
+		DateTime:       generator.DateTimeMode(genCfg.DateTime),
+		RelationRules:  genCfg.RelationRules,
+		Overrides:      project.Generator.Overrides,
+		TemplateFile:   project.Generator.Template,
+		Header:         project.Generator.Header,
+		Footer:         project.Generator.Footer,
+		GoGenerate:     project.Generator.GoGenerate,
+		TypeRules:      project.Generator.TypeRules,
+		AutoCreate:     project.Generator.AutoCreateTime,
+		AutoUpdate:     project.Generator.AutoUpdateTime,
+		TablePrefix:    project.Naming.TablePrefix,
+		Acronyms:       project.Naming.Acronyms,
+		NoAcronyms:     project.Naming.DisableAcronyms,
+		Subpackages:    project.Generator.Subpackages,
+		FieldOrder:     generator.FieldOrder(genCfg.FieldOrder),
+		NoPrimaryKey:   generator.NoPrimaryKeyMode(genCfg.NoPrimaryKey),
+		Style:          generator.Style(project.Generator.Style),
+		MaxLineWidth:   genCfg.MaxLineWidth,
+		FilePattern:    genCfg.FilePattern,
+		BuildTag:       genCfg.BuildTag,
+		Hooks:          project.Generator.Hooks,
+		Scopes:         project.Generator.Scopes,
+		TenantColumn:   project.Generator.TenantColumn,
+		WithTx:         project.Generator.WithTx,
+		Repositories:   project.Generator.Repositories,
+		MockTool:       generator.MockTool(project.Generator.MockTool),
+		Factories:      project.Generator.Factories,
+		PrivateFields:  project.Generator.PrivateFields,
+		Filters:        project.Generator.Filters,
+		Pagination:     project.Generator.Pagination,
+		Swagger:        project.Generator.Swagger,
+		GormOptions:    project.Generator.GormTag,
+		ExtraTags:      project.Generator.ExtraTags,
+		Sensitive:      project.Generator.Sensitive,
+		WriteOnly:      project.Generator.SensitiveWriteOnly,
+	}
 }
 
 // SaveCodeToFile saves the generated code for a table to a file
 func (a *App) SaveCodeToFile(tableName string, filePath string) error {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	c := a.conn("")
+	code, err := c.generateCode(tableName)
+	if err != nil {
+		return err
+	}
+
+	run := config.NewGenerationRun([]string{tableName})
+	if err := a.backUpFiles(run, filePath); err != nil {
+		return err
+	}
+	if err := c.writeModelFile(filePath, code); err != nil {
+		run.DiscardBackups()
+		return err
+	}
+	recordGeneration(run)
+	return c.writeSupportFiles(tableName, filePath)
+}
+
+// SaveCodeAs asks for a destination with the native "Save As…" dialog and
+// saves the generated code for a table there. It returns the chosen path,
+// or an empty string if the dialog was cancelled.
+func (a *App) SaveCodeAs(tableName string) (string, error) {
+	c := a.conn("")
+	code, err := c.generateCode(tableName)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.RLock()
+	defaultPath := c.generator.FilePath(tableName, defaultOutputDir)
+	c.mu.RUnlock()
+
+	defaultDir, err := filepath.Abs(filepath.Dir(defaultPath))
+	if err != nil {
+		defaultDir = ""
+	}
+
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:            a.i18n.Sprintf(i18n.SaveModelTitle, tableName),
+		DefaultDirectory: defaultDir,
+		DefaultFilename:  filepath.Base(defaultPath),
+		Filters: []runtime.FileFilter{
+			{DisplayName: a.i18n.Sprintf(i18n.GoFilesFilter), Pattern: "*.go"},
+		},
+	})
+	if err != nil {
+		return "", a.i18n.Errorf(i18n.OpenSaveDialog, err)
+	}
+	if filePath == "" {
+		return "", nil
+	}
+
+	run := config.NewGenerationRun([]string{tableName})
+	if err := a.backUpFiles(run, filePath); err != nil {
+		return "", err
+	}
+	if err := c.writeModelFile(filePath, code); err != nil {
+		run.DiscardBackups()
+		return "", err
+	}
+	recordGeneration(run)
+	if err := c.writeSupportFiles(tableName, filePath); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// ExportArchive generates the given tables (all tables if none are given)
+// into a .zip or .tar.gz archive and saves it where the user chooses in the
+// native "Save As…" dialog. It returns the chosen path, or an empty string
+// if the dialog was cancelled.
+func (a *App) ExportArchive(tableNames []string, format string) (string, error) {
+	c := a.conn("")
+	archiveFormat, err := generator.ParseArchiveFormat(format)
+	if err != nil {
+		return "", err
+	}
+
+	c.recoverConnection()
+
+	c.mu.RLock()
+	if !c.connected || c.generator == nil {
+		c.mu.RUnlock()
+		return "", a.errNotConnected()
+	}
+	var buf bytes.Buffer
+	err = c.generator.WriteArchive(&buf, tableNames, archiveFormat)
+	c.mu.RUnlock()
+	if err != nil {
+		return "", err
+	}
+
+	defaultDir, err := filepath.Abs(".")
+	if err != nil {
+		defaultDir = ""
+	}
+
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:            a.i18n.Sprintf(i18n.ExportModelsTitle),
+		DefaultDirectory: defaultDir,
+		DefaultFilename:  "models" + archiveFormat.Extension(),
+		Filters: []runtime.FileFilter{
+			{DisplayName: a.i18n.Sprintf(i18n.ArchivesFilter, archiveFormat.Extension()), Pattern: "*" + archiveFormat.Extension()},
+		},
+	})
+	if err != nil {
+		return "", a.i18n.Errorf(i18n.OpenSaveDialog, err)
+	}
+	if filePath == "" {
+		return "", nil
+	}
+
+	if err := a.writeCodeFile(filePath, buf.Bytes()); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// ExportDictionary writes the data dictionary of every table (name, type,
+// nullability, default and comment of each column) as a .csv or .xlsx file
+// where the user chooses in the native "Save As…" dialog. It returns the
+// chosen path, or an empty string if the dialog was cancelled.
+func (a *App) ExportDictionary(format string) (string, error) {
+	c := a.conn("")
+	dictionaryFormat, err := generator.ParseDictionaryFormat(format)
+	if err != nil {
+		return "", err
+	}
+
+	c.recoverConnection()
+
+	c.mu.RLock()
+	if !c.connected || c.generator == nil {
+		c.mu.RUnlock()
+		return "", a.errNotConnected()
+	}
+	var buf bytes.Buffer
+	err = c.generator.WriteDictionary(&buf, nil, dictionaryFormat)
+	c.mu.RUnlock()
+	if err != nil {
+		return "", err
+	}
+
+	defaultDir, err := filepath.Abs(".")
+	if err != nil {
+		defaultDir = ""
+	}
+
+	filePath, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:            a.i18n.Sprintf(i18n.ExportDictionaryTitle),
+		DefaultDirectory: defaultDir,
+		DefaultFilename:  "data_dictionary" + dictionaryFormat.Extension(),
+		Filters: []runtime.FileFilter{
+			{DisplayName: a.i18n.Sprintf(i18n.SpreadsheetsFilter, dictionaryFormat.Extension()), Pattern: "*" + dictionaryFormat.Extension()},
+		},
+	})
+	if err != nil {
+		return "", a.i18n.Errorf(i18n.OpenSaveDialog, err)
+	}
+	if filePath == "" {
+		return "", nil
+	}
+
+	if err := a.writeCodeFile(filePath, buf.Bytes()); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// CopyCodeToClipboard copies the generated code for a table to the system clipboard
+func (a *App) CopyCodeToClipboard(tableName string) error {
+	c := a.conn("")
+	code, err := c.generateCode(tableName)
+	if err != nil {
+		return err
+	}
+
+	if err := runtime.ClipboardSetText(a.ctx, string(code)); err != nil {
+		return a.i18n.Errorf(i18n.CopyToClipboard, err)
+	}
+	return nil
+}
+
+// generateCode generates the code for a table. The lock is released on return,
+// so callers can show dialogs or write files without blocking other bridge calls.
+func (c *connection) generateCode(tableName string) ([]byte, error) {
+	c.recoverConnection()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.connected || c.generator == nil {
+		return nil, c.app.errNotConnected()
+	}
+
+	code, err := c.generator.Generate(tableName)
+	if err != nil {
+		return nil, c.app.i18n.Errorf(i18n.GenerateTable, tableName, err)
+	}
+	return code, nil
+}
 
-	if !a.connected || a.generator == nil {
-		return ErrNotConnected
+// writeSupportFiles writes the helper files (e.g., the Hstore type) a saved model needs next to it
+func (c *connection) writeSupportFiles(tableName, filePath string) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.connected || c.generator == nil {
+		return c.app.errNotConnected()
 	}
+	return c.generator.WriteSupportFiles(tableName, filePath)
+}
 
-	// Generate the code
-	code, err := a.generator.Generate(tableName)
+// writeModelFile writes a generated model to filePath, keeping the
+// hand-added tags and fields of the file it replaces. It refuses to write a
+// model that redeclares what other files of its package declare.
+func (c *connection) writeModelFile(filePath string, code []byte) error {
+	code, err := generator.MergeExisting(code, filePath)
 	if err != nil {
-		return fmt.Errorf("failed to generate code for table %s: %w", tableName, err)
+		return c.app.i18n.Errorf(i18n.WriteFile, filePath, err)
 	}
 
+	c.mu.RLock()
+	gen := c.generator
+	c.mu.RUnlock()
+	if gen == nil {
+		return c.app.errNotConnected()
+	}
+	if err := gen.CheckConflicts([]generator.OutputFile{{Path: filePath, Content: code}}); err != nil {
+		return c.app.i18n.Errorf(i18n.WriteFile, filePath, err)
+	}
+	return c.app.writeCodeFile(filePath, code)
+}
+
+// writeCodeFile writes generated code to filePath, creating its directory
+func (a *App) writeCodeFile(filePath string, code []byte) error {
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(filePath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		return a.i18n.Errorf(i18n.CreateDirectory, dir, err)
 	}
 
 	// Write to file
 	if err := os.WriteFile(filePath, code, 0644); err != nil {
-		return fmt.Errorf("failed to write file %s: %w", filePath, err)
+		return a.i18n.Errorf(i18n.WriteFile, filePath, err)
 	}
 
 	return nil
@@ -361,42 +1118,168 @@ func (a *App) SaveCodeToFile(tableName string, filePath string) error {
 
 // SaveAllToDirectory saves all tables to a directory
 func (a *App) SaveAllToDirectory(outputDir string) ([]string, error) {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	c := a.conn("")
+	c.recoverConnection()
 
-	if !a.connected || a.generator == nil {
-		return nil, ErrNotConnected
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.connected || c.introspector == nil {
+		return nil, a.errNotConnected()
 	}
 
-	filePaths, err := a.generator.GenerateAll(outputDir)
+	tableNames, err := c.introspector.GetTables()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate all tables: %w", err)
+		return nil, a.i18n.Errorf(i18n.GenerateAll, err)
 	}
 
-	return filePaths, nil
+	return c.writeTables(c.generator, tableNames, outputDir)
 }
 
 // SaveSelectedToDirectory saves selected tables to a directory
 func (a *App) SaveSelectedToDirectory(tableNames []string, outputDir string) ([]string, error) {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
+	c := a.conn("")
+	c.recoverConnection()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 
-	if !a.connected || a.generator == nil {
-		return nil, ErrNotConnected
+	if !c.connected || c.generator == nil {
+		return nil, a.errNotConnected()
 	}
 
-	var filePaths []string
+	return c.writeTables(c.generator, tableNames, outputDir)
+}
+
+// GetPresets returns the names of the presets in the project config
+// (presets.<name>), for the preset dropdown
+func (a *App) GetPresets() ([]string, error) {
+	cfg, err := config.LoadEffectiveConfig()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.PresetNames(), nil
+}
+
+// SavePresetToDirectory saves the tables of a preset to a directory,
+// generated with the preset's generator options (all tables if the preset
+// lists none). The settings of the connection are left as they are.
+func (a *App) SavePresetToDirectory(name string, outputDir string) ([]string, error) {
+	c := a.conn("")
+	c.recoverConnection()
+
+	preset, err := loadProjectConfig().Preset(name)
+	if err != nil {
+		return nil, err
+	}
+	fullCfg, err := config.LoadConfig()
+	if err != nil {
+		log.Printf("Warning: Could not load config: %v", err)
+		fullCfg = config.DefaultConfig()
+	}
+	genCfg, err := presetGeneratorConfig(fullCfg.Generator, preset)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if !c.connected || c.introspector == nil {
+		return nil, a.errNotConnected()
+	}
+
+	tableNames := preset.Tables
+	if len(tableNames) == 0 {
+		if tableNames, err = c.introspector.GetTables(); err != nil {
+			return nil, a.i18n.Errorf(i18n.GenerateAll, err)
+		}
+	}
+	return c.writeTables(generator.NewGeneratorWithConfig(c.introspector, genCfg), tableNames, outputDir)
+}
+
+// writeTables generates tables into outputDir with gen, skipping tables
+// without a primary key, and records the files written in the generation
+// history. The files are written as one transaction, once backed up: if one
+// can't be backed up or written, none is changed. The caller must hold the
+// lock.
+func (c *connection) writeTables(gen *generator.Generator, tableNames []string, outputDir string) ([]string, error) {
+	var files []generator.OutputFile
 	for _, tableName := range tableNames {
-		filePath, err := a.generator.GenerateToFile(tableName, outputDir)
+		tableFiles, err := gen.GenerateFiles(tableName, outputDir)
+		if errors.Is(err, generator.ErrNoPrimaryKey) {
+			continue
+		}
 		if err != nil {
-			return filePaths, fmt.Errorf("failed to generate %s: %w", tableName, err)
+			return nil, c.app.i18n.Errorf(i18n.GenerateTable, tableName, err)
 		}
-		filePaths = append(filePaths, filePath)
+		files = append(files, tableFiles...)
 	}
 
+	if err := gen.CheckConflicts(files); err != nil {
+		return nil, c.app.i18n.Errorf(i18n.WriteModels, err)
+	}
+
+	run := config.NewGenerationRun(tableNames)
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.Path
+	}
+	if err := c.app.backUpFiles(run, paths...); err != nil {
+		return nil, err
+	}
+	if err := generator.WriteFiles(files); err != nil {
+		run.DiscardBackups()
+		return nil, c.app.i18n.Errorf(i18n.WriteModels, err)
+	}
+
+	var filePaths []string
+	for _, file := range files {
+		if file.Table != "" {
+			filePaths = append(filePaths, file.Path)
+		}
+	}
+	recordGeneration(run)
 	return filePaths, nil
 }
 
+// GetGenerationHistory returns the files written by past generations, most
+// recent first, for the history panel
+func (a *App) GetGenerationHistory() ([]config.GenerationRun, error) {
+	return config.LoadGenerationHistory()
+}
+
+// RestoreFile puts back the content a file had before a generation run,
+// undoing what the run wrote to it. A file the run created is removed.
+func (a *App) RestoreFile(runID string, filePath string) error {
+	if err := config.RestoreFile(runID, filePath); err != nil {
+		return a.i18n.Errorf(i18n.RestoreFile, filePath, err)
+	}
+	return nil
+}
+
+// backUpFiles records the files run is about to write and backs up their
+// current content. The files must not be written if it fails.
+func (a *App) backUpFiles(run *config.GenerationRun, paths ...string) error {
+	for _, path := range paths {
+		previous, readErr := os.ReadFile(path)
+		run.Record(path, previous, readErr)
+	}
+	if err := run.BackUp(); err != nil {
+		run.DiscardBackups()
+		return a.i18n.Errorf(i18n.BackUpFiles, err)
+	}
+	return nil
+}
+
+// recordGeneration adds a generation run, backed up by backUpFiles, to the
+// history. Failing to record it doesn't fail the generation.
+func recordGeneration(run *config.GenerationRun) {
+	if err := config.SaveGenerationRun(run); err != nil {
+		log.Printf("Warning: Could not update generation history: %v", err)
+	}
+}
+
 // StartGUI launches the Wails GUI application
 func StartGUI() {
 	app := NewApp()
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// passwordKey is the config key whose value config get and config set mask
+const passwordKey = "database.password"
+
+// configReveal makes config get print the password in clear text
+var configReveal bool
+
+// displayValue masks the password for printing unless reveal is set, so it
+// doesn't end up in terminal scrollback or CI logs
+func displayValue(key, value string, reveal bool) string {
+	if key == passwordKey && value != "" && !reveal {
+		return "********"
+	}
+	return value
+}
+
+// configCmd groups the subcommands that manage persisted defaults
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage generator defaults in ~/.godb-orm/config.yaml",
+	Long: `Read and write persisted defaults without editing YAML by hand.
+
+Example usage:
+  godb-orm config set generator.package models
+  godb-orm config get generator.output_dir
+  godb-orm config get database.password --reveal
+  godb-orm config unset generator.null_strategy
+  godb-orm config path`,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a configuration value",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := config.SetValue(args[0], args[1]); err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitUsage)
+		}
+		fmt.Printf("✅ %s = %s\n", args[0], displayValue(args[0], args[1], false))
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Print the effective value of a key (or all keys)",
+	Long: `Print the effective value of a key, or of all keys.
+
+The password is masked unless it is asked for by key with --reveal.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		keys := config.Keys()
+		if len(args) == 1 {
+			keys = []string{args[0]}
+		}
+
+		for _, key := range keys {
+			value, err := config.GetValue(key)
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				os.Exit(ExitUsage)
+			}
+			if len(args) == 1 {
+				fmt.Println(displayValue(key, value, configReveal))
+			} else {
+				fmt.Printf("%s = %s\n", key, displayValue(key, value, false))
+			}
+		}
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a configuration value so the default applies",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := config.UnsetValue(args[0]); err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitUsage)
+		}
+		fmt.Printf("✅ %s unset\n", args[0])
+	},
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the path of the global config file",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := config.ConfigPath()
+		if err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitUsage)
+		}
+		fmt.Println(path)
+	},
+}
+
+func init() {
+	configGetCmd.Flags().BoolVar(&configReveal, "reveal", false, "Print "+passwordKey+" in clear text when asked for by key")
+	configCmd.AddCommand(configSetCmd, configGetCmd, configUnsetCmd, configPathCmd)
+	rootCmd.AddCommand(configCmd)
+}
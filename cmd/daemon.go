@@ -0,0 +1,410 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+	"github.com/rowjak/godb-orm/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	daemonInterval       time.Duration
+	daemonOnce           bool
+	daemonPostHook       string
+	daemonWebhook        string
+	daemonWebhookHeaders []string
+)
+
+// webhookEvent is the event_type of the webhook payload
+const webhookEvent = "godb-orm.models-regenerated"
+
+// webhookPayload is posted to --webhook after models were regenerated. Its
+// shape is that of a GitHub repository_dispatch event, so the webhook can
+// point at the dispatches endpoint and a workflow can open the pull request.
+type webhookPayload struct {
+	EventType     string              `json:"event_type"`
+	ClientPayload regenerationSummary `json:"client_payload"`
+}
+
+// regenerationSummary describes one regeneration of the daemon
+type regenerationSummary struct {
+	Database   string                 `json:"database"`
+	SchemaHash string                 `json:"schema_hash"`
+	Change     *database.SchemaChange `json:"change,omitempty"` // Nil on the first run
+	Tables     []string               `json:"tables"`
+	Files      []string               `json:"files"`
+	Branch     string                 `json:"branch,omitempty"`
+	Commit     string                 `json:"commit,omitempty"`
+}
+
+// daemonCmd regenerates models whenever the schema changes
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Regenerate models periodically when the schema changes",
+	Long: `Introspect the database every --interval and regenerate the models when
+the schema changed since the last run, for teams whose DBAs evolve the
+schema outside the repository. Unchanged tables are skipped using the cache.
+
+After regeneration, in this order and only if files changed:
+  --post-hook   runs a shell command (e.g. go build ./...). The environment
+                has GODB_ORM_SCHEMA_HASH, GODB_ORM_OUTPUT_DIR and GODB_ORM_FILES
+                (one path per line). A failing hook stops the run.
+  --git-commit  commits the changes (see --git-branch and --git-patch)
+  --webhook     POSTs a JSON summary. The payload is a GitHub
+                repository_dispatch event, so a workflow can open the PR.
+                Header values expand environment variables.
+A step that fails is retried on every run, for the files of the failed run,
+until it succeeds. The pending steps are kept in ` + pendingFileName + ` in the
+output directory, so --once runs from cron retry them too; --once exits
+non-zero when the run failed.
+
+Example usage:
+  godb-orm daemon -d shop --driver postgres -o ./models --interval 1h
+  godb-orm daemon -d shop --driver postgres -o ./models --git-branch \
+    --post-hook 'go build ./...' \
+    --webhook https://api.github.com/repos/acme/shop/dispatches \
+    --webhook-header 'Authorization: Bearer $GITHUB_TOKEN'`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg = configFromFlags(cmd)
+		if cfg.Database.DDLFile != "" {
+			fmt.Println("❌ Error: daemon watches a live database, not a dump (--ddl)")
+			os.Exit(ExitUsage)
+		}
+		if cfg.Database.DBName == "" {
+			fmt.Println("❌ Error: Database name is required (--db or -d)")
+			os.Exit(ExitUsage)
+		}
+		if daemonInterval <= 0 {
+			fmt.Println("❌ Error: --interval must be positive")
+			os.Exit(ExitUsage)
+		}
+		if gitPatch != "" && (gitCommit || gitBranch != "") {
+			fmt.Println("❌ Error: --git-patch writes a patch instead of committing; drop --git-commit and --git-branch")
+			os.Exit(ExitUsage)
+		}
+
+		introspector := connectIntrospector(cfg)
+		defer introspector.Close()
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		d := &daemon{cfg: cfg, introspector: introspector}
+		pending, err := loadPendingSteps(cfg.Generator.OutputDir)
+		if err != nil {
+			fmt.Printf("⚠️  Warning: Could not load pending steps: %v\n", err)
+		}
+		d.pending = pending
+
+		fmt.Printf("⏰ Watching %s every %s\n", cfg.Database.DBName, daemonInterval)
+		for {
+			code := d.run()
+			if daemonOnce {
+				if code != ExitOK {
+					introspector.Close()
+					os.Exit(code)
+				}
+				return
+			}
+			select {
+			case <-ctx.Done():
+				fmt.Println("👋 Daemon stopped")
+				return
+			case <-time.After(daemonInterval):
+			}
+		}
+	},
+}
+
+// daemon holds the state kept between regeneration runs
+type daemon struct {
+	cfg          *config.Config
+	introspector database.DBIntrospector
+	snapshot     database.SchemaSnapshot // Schema of the last successful run
+	pending      *pendingSteps           // Steps of a failed run, retried until they succeed
+}
+
+// pendingFileName is the file in the output directory keeping the pending
+// steps between daemon processes
+const pendingFileName = ".godb-orm.pending"
+
+// pendingSteps tracks the post-hook, git and webhook steps for the files
+// written since the steps last succeeded. The cache skips those files on
+// the next run, so the daemon keeps them, in memory and in pendingFileName,
+// until every step succeeded.
+type pendingSteps struct {
+	Files     []string `json:"files"`     // Files written and not yet through every step
+	Hooked    bool     `json:"hooked"`    // The post-hook succeeded for Files
+	Committed bool     `json:"committed"` // The git step succeeded for Files
+	Branch    string   `json:"branch,omitempty"`
+	Commit    string   `json:"commit,omitempty"`
+}
+
+// loadPendingSteps reads the steps a previous process left pending in
+// outputDir, or returns nil if there are none
+func loadPendingSteps(outputDir string) (*pendingSteps, error) {
+	data, err := os.ReadFile(filepath.Join(outputDir, pendingFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var steps pendingSteps
+	if err := json.Unmarshal(data, &steps); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", pendingFileName, err)
+	}
+	return &steps, nil
+}
+
+// savePendingSteps writes steps to outputDir, or removes the file if steps
+// is nil
+func savePendingSteps(outputDir string, steps *pendingSteps) error {
+	path := filepath.Join(outputDir, pendingFileName)
+	if steps == nil {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(steps, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// run regenerates the models if the schema changed since the last run and
+// returns the exit code of the run. Errors are reported and retried on the
+// next run: a failed read or table keeps the baseline, and steps that
+// failed stay pending even if the schema didn't change again.
+func (d *daemon) run() int {
+	fmt.Printf("\n🔄 %s Checking schema...\n", time.Now().Format(time.RFC3339))
+	snapshot, err := database.TakeSnapshot(d.introspector)
+	if err != nil {
+		fmt.Printf("❌ Error reading schema: %v\n", err)
+		printHint(err, d.cfg.Database.Driver, "")
+		// Reconnect for the next run, in case the connection went stale
+		d.introspector.Close()
+		if err := d.introspector.Connect(); err != nil {
+			fmt.Printf("❌ Error reconnecting: %v\n", err)
+		}
+		return ExitConnection
+	}
+
+	summary := regenerationSummary{Database: d.cfg.Database.DBName, SchemaHash: snapshot.Hash()}
+	if d.snapshot != nil {
+		change := d.snapshot.Diff(snapshot)
+		switch {
+		case !change.Empty():
+			summary.Change = &change
+			fmt.Printf("📐 Schema changed: %d added, %d removed, %d changed\n", len(change.Added), len(change.Removed), len(change.Changed))
+		case d.pending != nil:
+			fmt.Printf("🔁 Schema unchanged, retrying the steps for %d file(s)\n", len(d.pending.Files))
+		default:
+			fmt.Printf("⏭️  Schema unchanged (%s)\n", summary.SchemaHash[:12])
+			return ExitOK
+		}
+	}
+
+	if !d.regenerate(&summary) {
+		return ExitGeneration
+	}
+	// Only a complete run moves the baseline, so failures are retried
+	d.snapshot = snapshot
+	return ExitOK
+}
+
+// regenerate writes the models of the configured tables and runs the
+// post-hook, git and webhook steps if any file changed in this run or is
+// still pending from a failed one. It reports whether every step succeeded.
+func (d *daemon) regenerate(summary *regenerationSummary) bool {
+	tables, err := configuredTables(d.introspector, d.cfg)
+	if err != nil {
+		fmt.Printf("❌ Error getting tables: %v\n", err)
+		return false
+	}
+	summary.Tables = tables
+
+	// A fresh generator per run, so nothing introspected earlier is reused
+	gen := newGenerator(d.introspector, d.cfg)
+	if err := gen.Err(); err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		return false
+	}
+	cache, err := generator.LoadCache(d.cfg.Generator.OutputDir)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not load cache: %v\n", err)
+	}
+
+	steps := d.pending
+	if steps == nil {
+		steps = &pendingSteps{}
+	}
+	d.pending = steps
+
+	failed := 0
+	for _, tableName := range tables {
+		filePath, written, err := gen.GenerateToFileIncremental(tableName, d.cfg.Generator.OutputDir, cache)
+		if errors.Is(err, generator.ErrNoPrimaryKey) {
+			continue
+		}
+		if err != nil {
+			fmt.Printf("  ❌ %s: %v\n", tableName, err)
+			failed++
+			continue
+		}
+		if written {
+			fmt.Printf("  ✅ %s -> %s\n", tableName, filePath)
+			steps.add(filePath)
+		}
+	}
+	// The pending steps are saved first: once the cache is, the next
+	// process skips the files
+	if len(steps.Files) > 0 {
+		d.savePending()
+	}
+	if err := cache.Save(); err != nil {
+		fmt.Printf("⚠️  Warning: Could not save cache: %v\n", err)
+	}
+	if failed > 0 {
+		fmt.Printf("❌ %d table(s) failed to generate, retrying next run\n", failed)
+		return false
+	}
+	if len(steps.Files) == 0 {
+		d.pending = nil
+		d.savePending()
+		fmt.Println("⏭️  No model changed")
+		return true
+	}
+	summary.Files = steps.Files
+
+	if daemonPostHook != "" && !steps.Hooked {
+		if err := runPostHook(daemonPostHook, summary, d.cfg.Generator.OutputDir); err != nil {
+			fmt.Printf("❌ Post-hook failed, retrying next run: %v\n", err)
+			return false
+		}
+		steps.Hooked = true
+		d.savePending()
+	}
+	if gitEnabled() && !steps.Committed {
+		result, err := commitModels(d.introspector, d.cfg, tables, nil)
+		if err != nil {
+			fmt.Printf("❌ Error, retrying next run: %v\n", err)
+			return false
+		}
+		steps.Committed = true
+		steps.Branch, steps.Commit = result.Branch, result.Commit
+		d.savePending()
+	}
+	summary.Branch, summary.Commit = steps.Branch, steps.Commit
+	if daemonWebhook != "" {
+		if err := postWebhook(daemonWebhook, daemonWebhookHeaders, summary); err != nil {
+			fmt.Printf("❌ Webhook failed, retrying next run: %v\n", err)
+			return false
+		}
+		fmt.Printf("📨 Notified %s\n", daemonWebhook)
+	}
+	d.pending = nil
+	d.savePending()
+	return true
+}
+
+// savePending persists the pending steps, reporting a failure as a warning
+func (d *daemon) savePending() {
+	if err := savePendingSteps(d.cfg.Generator.OutputDir, d.pending); err != nil {
+		fmt.Printf("⚠️  Warning: Could not save pending steps: %v\n", err)
+	}
+}
+
+// add records a file written by a run. The steps that already succeeded
+// didn't see its new content, so they run again.
+func (p *pendingSteps) add(path string) {
+	p.Hooked, p.Committed = false, false
+	p.Branch, p.Commit = "", ""
+	for _, file := range p.Files {
+		if file == path {
+			return
+		}
+	}
+	p.Files = append(p.Files, path)
+}
+
+// runPostHook runs the post-hook command in the shell, with the run
+// described in its environment
+func runPostHook(command string, summary *regenerationSummary, outputDir string) error {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	hook := exec.Command(shell, flag, command)
+	hook.Stdout = os.Stdout
+	hook.Stderr = os.Stderr
+	hook.Env = append(os.Environ(),
+		"GODB_ORM_SCHEMA_HASH="+summary.SchemaHash,
+		"GODB_ORM_OUTPUT_DIR="+outputDir,
+		"GODB_ORM_FILES="+strings.Join(summary.Files, "\n"),
+	)
+	fmt.Printf("🪝 Running %s\n", command)
+	return hook.Run()
+}
+
+// postWebhook POSTs the summary to url as a repository_dispatch event.
+// Headers are "Name: value" pairs whose values expand environment variables.
+func postWebhook(url string, headers []string, summary *regenerationSummary) error {
+	body, err := json.Marshal(webhookPayload{EventType: webhookEvent, ClientPayload: *summary})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for _, header := range headers {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok {
+			return fmt.Errorf("invalid header %q (expected Name: value)", header)
+		}
+		req.Header.Set(strings.TrimSpace(name), os.ExpandEnv(strings.TrimSpace(value)))
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
+
+func init() {
+	daemonCmd.Flags().DurationVar(&daemonInterval, "interval", time.Hour, "Time between schema checks, e.g. 30m or 1h")
+	daemonCmd.Flags().BoolVar(&daemonOnce, "once", false, "Check and regenerate once, then exit (for cron)")
+	daemonCmd.Flags().StringVar(&daemonPostHook, "post-hook", "", "Shell command run after models changed, e.g. 'go build ./...'")
+	daemonCmd.Flags().StringVar(&daemonWebhook, "webhook", "", "URL to POST a JSON summary to after models changed")
+	daemonCmd.Flags().StringArrayVar(&daemonWebhookHeaders, "webhook-header", nil, "Header sent to the webhook, as 'Name: value' (repeatable; $VARS are expanded)")
+	daemonCmd.Flags().BoolVar(&gitCommit, "git-commit", false, "Commit the changes in the output directory after each regeneration")
+	daemonCmd.Flags().StringVar(&gitBranch, "git-branch", "", "Commit on this branch, created from HEAD if missing (bare flag or \""+gitBranchAuto+"\": godb-orm/schema-<hash>)")
+	daemonCmd.Flags().Lookup("git-branch").NoOptDefVal = gitBranchAuto
+	daemonCmd.Flags().StringVar(&gitPatch, "git-patch", "", "Stage the changes and write them to this patch file instead of committing")
+	rootCmd.AddCommand(daemonCmd)
+}
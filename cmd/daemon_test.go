@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/pkg/databasetest"
+)
+
+func TestDaemonRetriesFailedSteps(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the post-hook is a POSIX shell command")
+	}
+	dir := t.TempDir()
+	hookLog := filepath.Join(dir, "hook.log")
+	ready := filepath.Join(dir, "ready")
+	previous := daemonPostHook
+	daemonPostHook = "test -f '" + ready + "' && echo \"$GODB_ORM_FILES\" >> '" + hookLog + "'"
+	t.Cleanup(func() { daemonPostHook = previous })
+
+	cfg := &config.Config{
+		Database:  config.DBConfig{DBName: "shop"},
+		Generator: config.GeneratorConfig{OutputDir: filepath.Join(dir, "models"), PackageName: "models"},
+	}
+	fake := databasetest.New("postgres",
+		databasetest.Table("users", databasetest.PrimaryKey(databasetest.Column("id", "bigint"))),
+	)
+
+	// The hook fails, so the run does and its file stays pending
+	d := &daemon{cfg: cfg, introspector: fake}
+	if code := d.run(); code != ExitGeneration {
+		t.Fatalf("run() = %d, want %d", code, ExitGeneration)
+	}
+
+	// A new process, as with --once from cron, picks the steps up from disk
+	// although the cache now skips the file
+	pending, err := loadPendingSteps(cfg.Generator.OutputDir)
+	if err != nil || pending == nil || len(pending.Files) != 1 {
+		t.Fatalf("loadPendingSteps() = %+v, %v; want the users file", pending, err)
+	}
+	if err := os.WriteFile(ready, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	d = &daemon{cfg: cfg, introspector: fake, pending: pending}
+	if code := d.run(); code != ExitOK {
+		t.Fatalf("run() = %d, want %d", code, ExitOK)
+	}
+
+	ran, err := os.ReadFile(hookLog)
+	if err != nil || string(ran) != pending.Files[0]+"\n" {
+		t.Errorf("hook ran with %q, %v; want the users file", ran, err)
+	}
+	if pending, err := loadPendingSteps(cfg.Generator.OutputDir); pending != nil || err != nil {
+		t.Errorf("loadPendingSteps() after success = %+v, %v; want none", pending, err)
+	}
+}
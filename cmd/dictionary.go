@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/rowjak/godb-orm/internal/database"
+	"github.com/rowjak/godb-orm/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dictionaryFormat string
+	dictionaryFile   string
+)
+
+// dictionaryCmd exports table and column metadata for analysts
+var dictionaryCmd = &cobra.Command{
+	Use:   "dictionary",
+	Short: "Export a data dictionary of the schema to CSV or Excel",
+	Long: `Export the metadata of the selected tables to a spreadsheet for analysts
+and auditors: a row per column with its table, position, type, nullability,
+primary key, default value and comment, plus the table's comment.
+
+The file defaults to data_dictionary.csv (or .xlsx); --file - writes the
+dictionary to stdout.
+
+Example usage:
+  godb-orm dictionary -d shop --driver postgres
+  godb-orm dictionary --ddl schema.sql --format xlsx --file shop.xlsx
+  godb-orm dictionary -d shop -t users,orders --file - > users.csv`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg = configFromFlags(cmd)
+		format, err := generator.ParseDictionaryFormat(dictionaryFormat)
+		if err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitUsage)
+		}
+		if cfg.Database.DBName == "" && cfg.Database.DDLFile == "" {
+			fmt.Println("❌ Error: Database name is required (--db or -d)")
+			os.Exit(ExitUsage)
+		}
+
+		// Connect without the usual progress output, which would corrupt
+		// the dictionary written to stdout
+		introspector, err := database.NewIntrospector(&cfg.Database)
+		if err == nil {
+			err = introspector.Connect()
+		}
+		if err != nil {
+			fmt.Printf("❌ Error connecting to database: %v\n", err)
+			printHint(err, cfg.Database.Driver, "")
+			os.Exit(ExitConnection)
+		}
+		defer introspector.Close()
+
+		var tables []string
+		if cfg.Generator.Tables != "*" && cfg.Generator.Tables != "" {
+			tables = splitTables(cfg.Generator.Tables)
+		}
+
+		var buf bytes.Buffer
+		if err := newGenerator(introspector, cfg).WriteDictionary(&buf, tables, format); err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			printHint(err, cfg.Database.Driver, "")
+			os.Exit(ExitConnection)
+		}
+
+		if dictionaryFile == "-" {
+			os.Stdout.Write(buf.Bytes())
+			return
+		}
+		path := dictionaryFile
+		if path == "" {
+			path = "data_dictionary" + format.Extension()
+		}
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			fmt.Printf("❌ Error: failed to write file: %v\n", err)
+			os.Exit(ExitGeneration)
+		}
+		fmt.Printf("✅ %s\n", path)
+	},
+}
+
+func init() {
+	dictionaryCmd.Flags().StringVar(&dictionaryFormat, "format", "csv", "Dictionary format: csv or xlsx")
+	dictionaryCmd.Flags().StringVar(&dictionaryFile, "file", "", "Output file, - for stdout (default data_dictionary.csv or .xlsx)")
+	rootCmd.AddCommand(dictionaryCmd)
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rowjak/godb-orm/internal/database"
+	"github.com/spf13/cobra"
+)
+
+var doctorSchema string
+
+// doctorCmd checks the connection and catalog privileges before generation
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check connectivity and the privileges introspection needs",
+	Long: `Check that the database is reachable and that the user may read the
+catalogs godb-orm introspects, before generating models:
+
+  network   the host and port accept TCP connections
+  ssl       the server offers SSL (MySQL and PostgreSQL)
+  login     the credentials and database name are accepted
+  MySQL     tables visible in information_schema, SELECT grants (SHOW GRANTS)
+  Postgres  pg_catalog access, USAGE on the schema, SELECT on its tables
+
+Missing grants are listed with the statement that adds them. Exits with
+code 2 if a check fails.
+
+Example usage:
+  godb-orm doctor -H db.internal -u app -d shop --driver mysql
+  godb-orm doctor --driver postgres -d shop --schema billing`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg = configFromFlags(cmd)
+		if cfg.Database.DDLFile != "" {
+			fmt.Println("❌ Error: doctor checks a live database, not a dump (--ddl)")
+			os.Exit(ExitUsage)
+		}
+		if cfg.Database.DBName == "" {
+			fmt.Println("❌ Error: Database name is required (--db or -d)")
+			os.Exit(ExitUsage)
+		}
+
+		fmt.Printf("🩺 Checking %s database %s as %s...\n", cfg.Database.Driver, cfg.Database.DBName, cfg.Database.User)
+		failed := 0
+		for _, check := range database.Diagnose(&cfg.Database, doctorSchema) {
+			icon := "✅"
+			switch check.Status {
+			case database.CheckWarn:
+				icon = "⚠️ "
+			case database.CheckFail:
+				icon = "❌"
+				failed++
+			}
+			fmt.Printf("  %s %s: %s\n", icon, check.Name, check.Detail)
+			if check.Fix != "" {
+				fmt.Printf("     🔧 %s\n", check.Fix)
+			}
+			if check.Err != nil {
+				printHint(check.Err, cfg.Database.Driver, "     ")
+			}
+		}
+
+		if failed > 0 {
+			fmt.Printf("\n❌ %d check(s) failed\n", failed)
+			os.Exit(ExitConnection)
+		}
+		fmt.Println("\n🎉 Ready to generate models!")
+	},
+}
+
+func init() {
+	doctorCmd.Flags().StringVar(&doctorSchema, "schema", "", "PostgreSQL schema to check (default public)")
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rowjak/godb-orm/internal/fixtures"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fixtureDialect string
+	fixtureDump    bool
+)
+
+// fixturesCmd generates models from the built-in fixture schemas
+var fixturesCmd = &cobra.Command{
+	Use:   "fixtures",
+	Short: "Generate models from the built-in fixture schemas",
+	Long: `Generate models from the reference schemas used by godb-orm's own
+golden-file tests, with the current generator settings. Useful for checking
+custom templates, headers and overrides without a database. Each dialect is
+written to its own subdirectory of the output directory.
+
+Example usage:
+  godb-orm fixtures --template ./struct.tmpl -o ./fixture-models
+  godb-orm fixtures --dialect postgres -o ./fixture-models
+  godb-orm fixtures --dump -o ./schemas`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg = configFromFlags(cmd)
+
+		dialects := fixtures.Dialects
+		if fixtureDialect != "" {
+			dialects = []string{fixtureDialect}
+		}
+
+		for _, dialect := range dialects {
+			if fixtureDump {
+				if err := dumpFixture(dialect, cfg.Generator.OutputDir); err != nil {
+					fmt.Printf("❌ Error: %v\n", err)
+					os.Exit(ExitUsage)
+				}
+				continue
+			}
+
+			introspector, err := fixtures.Introspector(dialect)
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				os.Exit(ExitUsage)
+			}
+
+			outputDir := filepath.Join(cfg.Generator.OutputDir, dialect)
+			genCfg := *cfg
+			genCfg.Generator.OutputDir = outputDir
+			gen := newGenerator(introspector, &genCfg)
+
+			fmt.Printf("\n🔄 Generating %s fixture models into %s...\n", dialect, outputDir)
+			filePaths, err := gen.GenerateAll(outputDir)
+			for _, filePath := range filePaths {
+				fmt.Printf("  ✅ %s\n", filePath)
+			}
+			if err != nil {
+				fmt.Printf("❌ Error: %v\n", err)
+				os.Exit(ExitGeneration)
+			}
+		}
+	},
+}
+
+// dumpFixture writes the fixture schema of a dialect to <dir>/<dialect>.sql
+func dumpFixture(dialect, dir string) error {
+	ddl, err := fixtures.DDL(dialect)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	path := filepath.Join(dir, dialect+".sql")
+	if err := os.WriteFile(path, []byte(ddl), 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	fmt.Printf("✅ %s\n", path)
+	return nil
+}
+
+func init() {
+	fixturesCmd.Flags().StringVar(&fixtureDialect, "dialect", "", "Only use the fixture schema of this dialect (mysql or postgres)")
+	fixturesCmd.Flags().BoolVar(&fixtureDump, "dump", false, "Write the fixture schemas as SQL files instead of generating models")
+	rootCmd.AddCommand(fixturesCmd)
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+	"github.com/rowjak/godb-orm/internal/vcs"
+)
+
+// gitBranchAuto is the --git-branch value (and the value of a bare
+// --git-branch) naming the branch after the schema hash
+const gitBranchAuto = "auto"
+
+// gitEnabled reports whether generated changes are to be committed or
+// written as a patch
+func gitEnabled() bool {
+	return gitCommit || gitBranch != "" || gitPatch != ""
+}
+
+// gitResult describes where commitModels recorded the changes
+type gitResult struct {
+	Branch string // Branch committed on, empty for the current branch
+	Commit string // Abbreviated commit hash, empty if nothing was committed
+}
+
+// commitModels stages the changes generation made in the output directory
+// and the files it wrote outside of it (handlers, DI providers, jet tables,
+// plugin output), prints a summary and commits them, or writes them to the
+// --git-patch file.
+// The commit message records the tables and a hash of the schema they were
+// generated from.
+func commitModels(introspector database.DBIntrospector, cfg *config.Config, tables, outside []string) (gitResult, error) {
+	var result gitResult
+	repo, err := vcs.Open(cfg.Generator.OutputDir)
+	if err != nil {
+		return result, err
+	}
+	// The daemon's retry state is not part of the models
+	repo.Exclude(pendingFileName)
+	if err := repo.Include(outside...); err != nil {
+		return result, err
+	}
+	if err := repo.Stage(); err != nil {
+		return result, err
+	}
+	changes, err := repo.Changes()
+	if err != nil {
+		return result, err
+	}
+	if len(changes) == 0 {
+		fmt.Println("\n📝 No model changes to commit")
+		return result, nil
+	}
+
+	snapshot, err := database.TakeSnapshot(introspector)
+	if err != nil {
+		return result, fmt.Errorf("failed to hash schema: %w", err)
+	}
+	hash := snapshot.Hash()
+
+	fmt.Printf("\n📝 %d changed file(s):\n", len(changes))
+	for _, change := range changes {
+		fmt.Printf("  %s %s\n", strings.TrimSpace(change.Status), change.Path)
+	}
+
+	if gitPatch != "" {
+		patch, err := repo.StagedDiff()
+		if err != nil {
+			return result, err
+		}
+		if err := os.WriteFile(gitPatch, patch, 0644); err != nil {
+			return result, fmt.Errorf("failed to write patch: %w", err)
+		}
+		fmt.Printf("✅ Staged; patch written to %s\n", gitPatch)
+		return result, nil
+	}
+
+	if gitBranch != "" {
+		branch := gitBranch
+		if branch == gitBranchAuto {
+			branch = "godb-orm/schema-" + hash[:12]
+		}
+		if err := repo.Branch(branch); err != nil {
+			return result, err
+		}
+		result.Branch = branch
+		fmt.Printf("🌿 On branch %s\n", branch)
+	}
+
+	result.Commit, err = repo.Commit(commitMessage(cfg, tables, changes, hash))
+	if err != nil {
+		return result, err
+	}
+	fmt.Printf("✅ Committed %s\n", result.Commit)
+	return result, nil
+}
+
+// commitMessage builds the standardized message of a regeneration commit
+func commitMessage(cfg *config.Config, tables []string, changes []vcs.Change, hash string) string {
+	source := cfg.Database.DBName
+	if cfg.Database.DDLFile != "" {
+		source = filepath.Base(cfg.Database.DDLFile)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "godb-orm: regenerate models from %s (schema %s)\n\n", source, hash[:12])
+	fmt.Fprintf(&b, "Tables: %s\n", strings.Join(tables, ", "))
+	b.WriteString("Files:\n")
+	for _, change := range changes {
+		fmt.Fprintf(&b, "  %s %s\n", strings.TrimSpace(change.Status), change.Path)
+	}
+	fmt.Fprintf(&b, "\nSchema-Hash: %s\n", hash)
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rowjak/godb-orm/internal/database"
+	"github.com/spf13/cobra"
+)
+
+var (
+	inferFile   string
+	inferTable  string
+	inferCreate bool
+)
+
+// inferCmd generates a model from a CSV or Parquet data file
+var inferCmd = &cobra.Command{
+	Use:   "infer",
+	Short: "Generate a model from a CSV, TSV or Parquet file",
+	Long: `Generate a Go struct from a data file instead of a database, e.g. to
+model an ingest pipeline. CSV and TSV column types are inferred from the
+header and the first --sample rows; Parquet files are typed from the schema
+in their footer. --driver (mysql or postgres) selects the SQL dialect of the
+optional CREATE TABLE statement.
+
+Example usage:
+  godb-orm infer --file data.csv -o ./models
+  godb-orm infer --file events.parquet --table-name event --create-table --driver postgres
+  godb-orm infer --file export.tsv --sample 10000`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg = configFromFlags(cmd)
+
+		introspector := database.NewFileIntrospector(inferFile, inferTable, cfg.Database.Driver, cfg.Database.SampleSize)
+		if err := introspector.Connect(); err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitUsage)
+		}
+		defer introspector.Close()
+
+		tables, err := introspector.GetTables()
+		if err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitUsage)
+		}
+		columns, err := introspector.GetColumns(tables[0])
+		if err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitUsage)
+		}
+		fmt.Printf("✅ Inferred %d columns from %s\n", len(columns), inferFile)
+
+		gen := newGenerator(introspector, cfg)
+		fmt.Printf("\n🛠️  Generating model to %s...\n", cfg.Generator.OutputDir)
+		filePath, err := gen.GenerateToFile(tables[0], cfg.Generator.OutputDir)
+		if err != nil {
+			fmt.Printf("  ❌ %s: %v\n", tables[0], err)
+			os.Exit(ExitGeneration)
+		}
+		fmt.Printf("  ✅ %s -> %s\n", tables[0], filePath)
+
+		if inferCreate {
+			filePath, err := gen.GenerateSchemaSQLToFile(tables, cfg.Generator.OutputDir, introspector.Dialect())
+			if err != nil {
+				fmt.Printf("  ❌ schema: %v\n", err)
+				os.Exit(ExitGeneration)
+			}
+			fmt.Printf("  ✅ schema -> %s\n", filePath)
+		}
+
+		fmt.Println("\n🎉 Model generation complete!")
+	},
+}
+
+func init() {
+	inferCmd.Flags().StringVar(&inferFile, "file", "", "CSV, TSV or Parquet file to infer the model from")
+	inferCmd.Flags().StringVar(&inferTable, "table-name", "", "Table name of the model (default: the file name)")
+	inferCmd.Flags().BoolVar(&inferCreate, "create-table", false, "Also write a CREATE TABLE statement to schema.sql")
+	inferCmd.MarkFlagRequired("file")
+	rootCmd.AddCommand(inferCmd)
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rowjak/godb-orm/internal/database"
+	"github.com/rowjak/godb-orm/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintFormat   string
+	lintSeverity string
+	lintFailOn   string
+)
+
+// lintCmd reports schema smells that affect the generated models
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Report schema smells that affect the generated models",
+	Long: `Check the selected tables for schema smells relevant to Go models:
+
+  no-primary-key  error    GORM can't Save, Update or Delete single rows
+                           (info with generator.no_primary_key skip or read_only)
+  unknown-type    warning  the column maps to interface{}
+  nullable-bool   warning  NULL reads as false (info with pointer types)
+  untyped-enum    info     an enum column is generated as a plain string
+
+Columns are checked with the types they would be generated with, so type
+rules and column overrides silence the issues they fix. --format json
+prints the issues as a JSON array for CI tooling. Exits with code 5 if an
+issue is at least as severe as --fail-on.
+
+Example usage:
+  godb-orm lint -d shop --driver postgres
+  godb-orm lint --ddl schema.sql --format json --severity warning
+  godb-orm lint -d shop -t users,orders --fail-on warning`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg = configFromFlags(cmd)
+		minSeverity, err := generator.ParseLintSeverity(lintSeverity)
+		if err == nil && lintFailOn != "none" {
+			_, err = generator.ParseLintSeverity(lintFailOn)
+		}
+		if err == nil && lintFormat != "text" && lintFormat != "json" {
+			err = fmt.Errorf("unknown format %q (want text or json)", lintFormat)
+		}
+		if err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitUsage)
+		}
+		if cfg.Database.DBName == "" && cfg.Database.DDLFile == "" {
+			fmt.Println("❌ Error: Database name is required (--db or -d)")
+			os.Exit(ExitUsage)
+		}
+
+		// Connect without the usual progress output, which would corrupt
+		// the JSON report
+		introspector, err := database.NewIntrospector(&cfg.Database)
+		if err == nil {
+			err = introspector.Connect()
+		}
+		if err != nil {
+			fmt.Printf("❌ Error connecting to database: %v\n", err)
+			printHint(err, cfg.Database.Driver, "")
+			os.Exit(ExitConnection)
+		}
+		defer introspector.Close()
+
+		tables := splitTables(cfg.Generator.Tables)
+		if cfg.Generator.Tables == "*" || cfg.Generator.Tables == "" {
+			if tables, err = introspector.GetTables(); err != nil {
+				fmt.Printf("❌ Error getting tables: %v\n", err)
+				printHint(err, cfg.Database.Driver, "")
+				os.Exit(ExitConnection)
+			}
+		}
+
+		gen := newGenerator(introspector, cfg)
+		issues := []generator.LintIssue{}
+		for _, table := range tables {
+			found, err := gen.Lint(table)
+			if err != nil {
+				fmt.Printf("❌ %s: %v\n", table, err)
+				os.Exit(ExitConnection)
+			}
+			for _, issue := range found {
+				if issue.Severity.AtLeast(minSeverity) {
+					issues = append(issues, issue)
+				}
+			}
+		}
+
+		if lintFormat == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			encoder.Encode(issues)
+		} else {
+			printLintIssues(issues, len(tables))
+		}
+
+		if lintFailOn == "none" {
+			return
+		}
+		failOn, _ := generator.ParseLintSeverity(lintFailOn)
+		for _, issue := range issues {
+			if issue.Severity.AtLeast(failOn) {
+				os.Exit(ExitLint)
+			}
+		}
+	},
+}
+
+// printLintIssues prints the lint issues grouped by table
+func printLintIssues(issues []generator.LintIssue, tables int) {
+	if len(issues) == 0 {
+		fmt.Printf("✅ No issues in %d table(s)\n", tables)
+		return
+	}
+
+	table := ""
+	for _, issue := range issues {
+		if issue.Table != table {
+			table = issue.Table
+			fmt.Printf("\n📋 %s\n", table)
+		}
+		icon := "ℹ️ "
+		switch issue.Severity {
+		case generator.LintWarning:
+			icon = "⚠️ "
+		case generator.LintError:
+			icon = "❌"
+		}
+		location := ""
+		if issue.Column != "" {
+			location = issue.Column + ": "
+		}
+		fmt.Printf("  %s %s%s [%s]\n", icon, location, issue.Message, issue.Rule)
+	}
+	fmt.Printf("\n%d issue(s) in %d table(s)\n", len(issues), tables)
+}
+
+func init() {
+	lintCmd.Flags().StringVar(&lintFormat, "format", "text", "Report format: text or json")
+	lintCmd.Flags().StringVar(&lintSeverity, "severity", string(generator.LintInfo), "Least severe issues reported: info, warning or error")
+	lintCmd.Flags().StringVar(&lintFailOn, "fail-on", string(generator.LintError), "Exit with code 5 on issues this severe: info, warning, error or none")
+	rootCmd.AddCommand(lintCmd)
+}
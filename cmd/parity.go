@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+	"github.com/rowjak/godb-orm/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	parityFormat string
+	parityTarget config.DBConfig
+)
+
+// parityCmd compares the same schema in two databases
+var parityCmd = &cobra.Command{
+	Use:   "parity",
+	Short: "Compare column types and nullability of a schema in two databases",
+	Long: `Compare the tables of the source database (the usual connection flags)
+with the same tables in a target database (the --target-* flags), e.g. a
+MySQL production database and the PostgreSQL database it is migrated to.
+
+Reported differences:
+  missing_table   a table exists in only one database
+  missing_column  a column exists in only one database
+  type            the column types map to different Go types (int vs bigint)
+  nullable        NULL in one database, NOT NULL in the other
+  length          different string lengths (varchar(255) vs varchar(100))
+  precision       different decimal precision or scale
+
+Table and column names match case-insensitively. Unset --target-* flags
+take the source's value, so comparing two databases on one server only
+needs --target-db. Either side may be a dump (--ddl, --target-ddl).
+--format json prints the differences as a JSON array. Exits with code 6
+if there are differences.
+
+Example usage:
+  godb-orm parity -H mysql.prod -u app -d shop --driver mysql \
+    --target-host pg.staging --target-port 5432 --target-driver postgres
+  godb-orm parity --ddl mysql.sql --target-ddl postgres.sql --format json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg = configFromFlags(cmd)
+		if parityFormat != "text" && parityFormat != "json" {
+			fmt.Printf("❌ Error: unknown format %q (want text or json)\n", parityFormat)
+			os.Exit(ExitUsage)
+		}
+		target := parityTargetConfig(cmd, cfg.Database)
+		if cfg.Database == target {
+			fmt.Println("❌ Error: the target is the source database; set a --target-* flag")
+			os.Exit(ExitUsage)
+		}
+
+		source := connectParity(cfg.Database, "source")
+		defer source.Close()
+		targetIntrospector := connectParity(target, "target")
+		defer targetIntrospector.Close()
+
+		var tables []string
+		if cfg.Generator.Tables != "*" && cfg.Generator.Tables != "" {
+			tables = splitTables(cfg.Generator.Tables)
+		}
+		issues, err := generator.CompareParity(newGenerator(source, cfg), newGenerator(targetIntrospector, cfg), tables)
+		if err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitConnection)
+		}
+		if issues == nil {
+			issues = []generator.ParityIssue{}
+		}
+
+		if parityFormat == "json" {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			encoder.Encode(issues)
+		} else {
+			printParityIssues(issues)
+		}
+		if len(issues) > 0 {
+			os.Exit(ExitMismatch)
+		}
+	},
+}
+
+// parityTargetConfig returns the target connection: the source's with the
+// --target-* flags that were set applied
+func parityTargetConfig(cmd *cobra.Command, source config.DBConfig) config.DBConfig {
+	target := source
+	flags := cmd.Flags()
+	if flags.Changed("target-host") {
+		target.Host = parityTarget.Host
+	}
+	if flags.Changed("target-port") {
+		target.Port = parityTarget.Port
+	}
+	if flags.Changed("target-user") {
+		target.User = parityTarget.User
+	}
+	if flags.Changed("target-pass") {
+		target.Password = parityTarget.Password
+	}
+	if flags.Changed("target-db") {
+		target.DBName = parityTarget.DBName
+	}
+	if flags.Changed("target-driver") {
+		target.Driver = parityTarget.Driver
+	}
+	if flags.Changed("target-ddl") {
+		target.DDLFile = parityTarget.DDLFile
+	}
+	return target
+}
+
+// connectParity connects one side of the comparison, exiting on failure.
+// Nothing is printed on success, keeping the JSON report clean.
+func connectParity(dbCfg config.DBConfig, side string) database.DBIntrospector {
+	introspector, err := database.NewIntrospector(&dbCfg)
+	if err == nil {
+		err = introspector.Connect()
+	}
+	if err != nil {
+		fmt.Printf("❌ Error connecting to the %s database: %v\n", side, err)
+		printHint(err, dbCfg.Driver, "")
+		os.Exit(ExitConnection)
+	}
+	return introspector
+}
+
+// printParityIssues prints the differences grouped by table
+func printParityIssues(issues []generator.ParityIssue) {
+	if len(issues) == 0 {
+		fmt.Println("✅ The schemas match")
+		return
+	}
+
+	table := ""
+	for _, issue := range issues {
+		if issue.Table != table {
+			table = issue.Table
+			fmt.Printf("\n📋 %s\n", table)
+		}
+		switch {
+		case issue.Kind == generator.ParityMissingTable && issue.Target == "":
+			fmt.Println("  ❌ only in the source")
+		case issue.Kind == generator.ParityMissingTable:
+			fmt.Println("  ❌ only in the target")
+		case issue.Kind == generator.ParityMissingColumn && issue.Target == "":
+			fmt.Printf("  ❌ %s: only in the source (%s)\n", issue.Column, issue.Source)
+		case issue.Kind == generator.ParityMissingColumn:
+			fmt.Printf("  ❌ %s: only in the target (%s)\n", issue.Column, issue.Target)
+		default:
+			fmt.Printf("  ⚠️  %s: %s %s → %s\n", issue.Column, issue.Kind, issue.Source, issue.Target)
+		}
+	}
+	fmt.Printf("\n%d difference(s)\n", len(issues))
+}
+
+func init() {
+	parityCmd.Flags().StringVar(&parityFormat, "format", "text", "Report format: text or json")
+	parityCmd.Flags().StringVar(&parityTarget.Host, "target-host", "", "Target database host (default: the source's)")
+	parityCmd.Flags().IntVar(&parityTarget.Port, "target-port", 0, "Target database port (default: the source's)")
+	parityCmd.Flags().StringVar(&parityTarget.User, "target-user", "", "Target database user (default: the source's)")
+	parityCmd.Flags().StringVar(&parityTarget.Password, "target-pass", "", "Target database password (default: the source's)")
+	parityCmd.Flags().StringVar(&parityTarget.DBName, "target-db", "", "Target database name (default: the source's)")
+	parityCmd.Flags().StringVar(&parityTarget.Driver, "target-driver", "", "Target database driver (default: the source's)")
+	parityCmd.Flags().StringVar(&parityTarget.DDLFile, "target-ddl", "", "Read the target schema from a schema-only dump instead of connecting")
+	rootCmd.AddCommand(parityCmd)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -8,24 +9,88 @@ import (
 	"github.com/rowjak/godb-orm/internal/config"
 	"github.com/rowjak/godb-orm/internal/database"
 	"github.com/rowjak/godb-orm/internal/generator"
+	"github.com/rowjak/godb-orm/internal/i18n"
+	"github.com/rowjak/godb-orm/internal/telemetry"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// Database connection flags
-	host     string
-	port     int
-	user     string
-	password string
-	dbName   string
-	driver   string
+	host       string
+	port       int
+	user       string
+	password   string
+	dbName     string
+	driver     string
+	timeout    int
+	ddlFile    string
+	vitess     bool
+	inferFKs   bool
+	sampleSize int
 
 	// Generator flags
-	table     string
-	outputDir string
+	table        string
+	presetName   string
+	outputDir    string
+	packageName  string
+	templateFile string
+	tablePrefix  string
+	acronyms     []string
+	noAcronyms   bool
+	filePattern  string
+	buildTag     string
+
+	// CI and cache flags
+	ciMode    bool
+	checkMode bool
+	noCache   bool
+
+	// Git flags
+	gitCommit bool
+	gitBranch string
+	gitPatch  string
+
+	// Extra output flags
+	withConstants bool
+	scanHelpers   bool
+	queryBuilder  string
+	hooks         bool
+	scopes        bool
+	withTx        bool
+	repositories  bool
+	factories     bool
+	privateFields bool
+	filters       bool
+	pagination    bool
+	swagger       bool
+	inferRels     bool
+	withSchemaSQL bool
+	withAvro      bool
+	withGormGen   bool
+	withJet       bool
+	withHandlers  string
+	withDI        string
+	style         string
+	debeziumTopic string
+	plugins       []string
+
+	// Telemetry flags
+	otlpEndpoint string
 
 	// Configuration
-	cfg *config.Config
+	cfg         *config.Config
+	existingCfg *config.Config
+)
+
+// Exit codes returned by the CLI so scripts and CI pipelines can tell failures apart
+const (
+	ExitOK         = 0 // Success
+	ExitUsage      = 1 // Invalid flags or configuration
+	ExitConnection = 2 // Could not connect to or query the database
+	ExitGeneration = 3 // One or more models failed to generate
+	ExitOutdated   = 4 // --check found models that would change on regeneration
+	ExitLint       = 5 // lint found an issue at least as severe as --fail-on
+	ExitMismatch   = 6 // parity found differences between the two schemas
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -37,23 +102,12 @@ from your database tables. It supports MySQL and PostgreSQL databases.
 
 Example usage:
   godb-orm --host localhost --port 3306 --user root --db mydb --driver mysql
-  godb-orm -H localhost -P 3306 -u root -d mydb --driver mysql --table users`,
+  godb-orm -H localhost -P 3306 -u root -d mydb --driver mysql --table users
+  godb-orm --ci --check -d mydb --driver mysql -o ./models
+  godb-orm --ddl schema.sql -o ./models`,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Build configuration from flags
-		cfg = &config.Config{
-			Database: config.DBConfig{
-				Host:     host,
-				Port:     port,
-				User:     user,
-				Password: password,
-				DBName:   dbName,
-				Driver:   driver,
-			},
-			Generator: config.GeneratorConfig{
-				Tables:    table,
-				OutputDir: outputDir,
-			},
-		}
+		cfg = configFromFlags(cmd)
 
 		// Display current configuration
 		fmt.Println("🚀 GoDB-Orm - Database Model Generator")
@@ -63,100 +117,581 @@ Example usage:
 		fmt.Printf("User:     %s\n", cfg.Database.User)
 		fmt.Printf("Database: %s\n", cfg.Database.DBName)
 		fmt.Printf("Driver:   %s\n", cfg.Database.Driver)
+		if cfg.Database.DDLFile != "" {
+			fmt.Printf("DDL file: %s\n", cfg.Database.DDLFile)
+		}
+		if presetName != "" {
+			fmt.Printf("Preset:   %s\n", presetName)
+		}
 		fmt.Printf("Tables:   %s\n", cfg.Generator.Tables)
 		fmt.Printf("Output:   %s\n", cfg.Generator.OutputDir)
 		fmt.Println("======================================")
 
 		// Validate required fields
-		if cfg.Database.DBName == "" {
-			fmt.Println("❌ Error: Database name is required (--db or -d)")
-			os.Exit(1)
+		if cfg.Database.DBName == "" && cfg.Database.DDLFile == "" {
+			fmt.Println("❌ Error: Database name is required (--db or -d) unless reading a dump (--ddl)")
+			os.Exit(ExitUsage)
 		}
-
-		// Save configuration for future use
-		if err := config.SaveConfig(cfg); err != nil {
-			fmt.Printf("⚠️  Warning: Could not save config: %v\n", err)
-		} else {
-			fmt.Println("✅ Configuration saved to ~/.godb-orm/config.yaml")
+		if gitPatch != "" && (gitCommit || gitBranch != "") {
+			fmt.Println("❌ Error: --git-patch writes a patch instead of committing; drop --git-commit and --git-branch")
+			os.Exit(ExitUsage)
+		}
+		if ciMode && cfg.Database.Driver == "" {
+			fmt.Println("❌ Error: Database driver is required in CI mode (--driver)")
+			os.Exit(ExitUsage)
 		}
 
-		// TODO: Implement actual database connection and model generation
-		fmt.Println("\n📋 CLI mode is ready. Model generation will be implemented in Stage 2.")
+		// Save the flags given for future use (never in CI mode, nor for a
+		// preset, whose tables and options are not meant as the defaults)
+		if !ciMode && presetName == "" {
+			if values := explicitFlagValues(cmd); len(values) > 0 {
+				if err := config.SaveValues(values); err != nil {
+					fmt.Printf("⚠️  Warning: Could not save config: %v\n", err)
+				} else {
+					fmt.Println("✅ Configuration saved to ~/.godb-orm/config.yaml")
+				}
+			}
+		}
 
 		// Generate models if all required parameters are present
-		if cfg.Database.DBName != "" && cfg.Database.Driver != "" {
-			fmt.Println("\n🔄 Connecting to database...")
-
-			introspector, err := database.NewIntrospector(&cfg.Database)
-			if err != nil {
-				fmt.Printf("❌ Error: %v\n", err)
-				os.Exit(1)
+		if (cfg.Database.DBName != "" || cfg.Database.DDLFile != "") && cfg.Database.Driver != "" {
+			if cfg.Database.DDLFile != "" {
+				fmt.Println("\n🔄 Reading schema from DDL file...")
+			} else {
+				fmt.Println("\n🔄 Connecting to database...")
 			}
 
-			if err := introspector.Connect(); err != nil {
-				fmt.Printf("❌ Error connecting to database: %v\n", err)
-				os.Exit(1)
-			}
-			defer introspector.Close()
+			tp := newTelemetry(cfg)
+			run := tp.Start("godb-orm generate", telemetry.String("db.system", cfg.Database.Driver))
 
-			fmt.Println("✅ Connected to database successfully!")
+			connect := run.Child("connect")
+			introspector := connectIntrospector(cfg)
+			connect.End()
+			defer introspector.Close()
+			introspector = telemetry.WrapIntrospector(introspector, tp, run)
 
-			gen := generator.NewGenerator(introspector)
+			gen := newGenerator(introspector, cfg)
+			if gen.ImportPath() != "" {
+				fmt.Printf("📦 Package: %s (%s)\n", gen.PackageName(), gen.ImportPath())
+			} else {
+				fmt.Printf("📦 Package: %s\n", gen.PackageName())
+			}
 
 			// Get tables to generate
-			var tablesToGenerate []string
-			if cfg.Generator.Tables == "*" || cfg.Generator.Tables == "" {
-				tables, err := introspector.GetTables()
-				if err != nil {
-					fmt.Printf("❌ Error getting tables: %v\n", err)
-					os.Exit(1)
-				}
-				tablesToGenerate = tables
-				fmt.Printf("📋 Found %d tables\n", len(tables))
-			} else {
-				tablesToGenerate = splitTables(cfg.Generator.Tables)
+			tablesToGenerate := selectTables(introspector, cfg)
+
+			if checkMode {
+				code := checkModels(gen, tablesToGenerate, cfg.Generator.OutputDir)
+				run.End()
+				flushTelemetry(tp)
+				os.Exit(code)
 			}
 
 			// Generate models
 			fmt.Printf("\n🛠️  Generating models to %s...\n", cfg.Generator.OutputDir)
+			var cache *generator.Cache
+			if !noCache {
+				var err error
+				if cache, err = generator.LoadCache(cfg.Generator.OutputDir); err != nil {
+					fmt.Printf("⚠️  Warning: Could not load cache: %v\n", err)
+				}
+			}
+
+			failed := 0
+			var generated []string
+			var outside []string // Files written next to the output directory, committed with it
 			for _, tableName := range tablesToGenerate {
-				filePath, err := gen.GenerateToFile(tableName, cfg.Generator.OutputDir)
+				span := run.Child("generate.table", telemetry.String("db.sql.table", tableName))
+				filePath, written, err := gen.GenerateToFileIncremental(tableName, cfg.Generator.OutputDir, cache)
+				span.SetAttributes(telemetry.Bool("godb_orm.written", written))
+				span.Fail(err)
+				span.End()
+				if errors.Is(err, generator.ErrNoPrimaryKey) {
+					fmt.Printf("  ⚠️  %s skipped: no primary key (generator.no_primary_key)\n", tableName)
+					continue
+				}
 				if err != nil {
 					fmt.Printf("  ❌ %s: %v\n", tableName, err)
+					printHint(err, cfg.Database.Driver, "     ")
+					failed++
+					continue
+				}
+				generated = append(generated, tableName)
+				if !written {
+					fmt.Printf("  ⏭️  %s (unchanged)\n", tableName)
 					continue
 				}
 				fmt.Printf("  ✅ %s -> %s\n", tableName, filePath)
 			}
 
+			if err := cache.Save(); err != nil {
+				fmt.Printf("⚠️  Warning: Could not save cache: %v\n", err)
+			}
+
+			// The query builder helpers refer to the table name constants
+			if withConstants || (queryBuilder != "" && queryBuilder != string(generator.QueryBuilderNone)) {
+				filePath, err := gen.GenerateConstantsToFile(tablesToGenerate, cfg.Generator.OutputDir)
+				if err != nil {
+					fmt.Printf("  ❌ constants: %v\n", err)
+					failed++
+				} else {
+					fmt.Printf("  ✅ constants -> %s\n", filePath)
+				}
+			}
+
+			if withGormGen {
+				filePath, err := gen.GenerateGormGenToFile(generated, cfg.Generator.OutputDir)
+				if err != nil {
+					fmt.Printf("  ❌ gorm gen: %v\n", err)
+					failed++
+				} else {
+					fmt.Printf("  ✅ gorm gen -> %s\n", filePath)
+				}
+			}
+
+			if withHandlers != "" {
+				files, err := gen.GenerateHandlersToFiles(generated, cfg.Generator.OutputDir, withHandlers, cfg.Database.Driver)
+				for _, filePath := range files {
+					fmt.Printf("  ✅ handlers -> %s\n", filePath)
+				}
+				outside = append(outside, files...)
+				if err != nil {
+					fmt.Printf("  ❌ handlers: %v\n", err)
+					failed++
+				}
+			}
+
+			if withDI != "" {
+				filePath, err := gen.GenerateDIToFile(generated, cfg.Generator.OutputDir, withDI, withHandlers != "")
+				if err != nil {
+					fmt.Printf("  ❌ di: %v\n", err)
+					failed++
+				} else {
+					fmt.Printf("  ✅ di -> %s\n", filePath)
+					outside = append(outside, filePath)
+				}
+			}
+
+			if withJet {
+				files, err := gen.GenerateJetTablesToFiles(tablesToGenerate, cfg.Generator.OutputDir, cfg.Database.Driver)
+				for _, filePath := range files {
+					fmt.Printf("  ✅ jet -> %s\n", filePath)
+				}
+				outside = append(outside, files...)
+				if err != nil {
+					fmt.Printf("  ❌ jet: %v\n", err)
+					failed++
+				}
+			}
+
+			if withSchemaSQL {
+				filePath, err := gen.GenerateSchemaSQLToFile(tablesToGenerate, cfg.Generator.OutputDir, cfg.Database.Driver)
+				if err != nil {
+					fmt.Printf("  ❌ schema: %v\n", err)
+					failed++
+				} else {
+					fmt.Printf("  ✅ schema -> %s\n", filePath)
+				}
+			}
+
+			if withAvro {
+				files, err := gen.GenerateAvroSchemasToFiles(tablesToGenerate, cfg.Generator.OutputDir)
+				for _, filePath := range files {
+					fmt.Printf("  ✅ avro -> %s\n", filePath)
+				}
+				if err != nil {
+					fmt.Printf("  ❌ avro: %v\n", err)
+					failed++
+				}
+			}
+
+			if debeziumTopic != "" {
+				files, err := gen.GenerateDebeziumSchemasToFiles(tablesToGenerate, cfg.Generator.OutputDir, debeziumTopic, cfg.Database.Driver)
+				for _, filePath := range files {
+					fmt.Printf("  ✅ debezium -> %s\n", filePath)
+				}
+				if err != nil {
+					fmt.Printf("  ❌ debezium: %v\n", err)
+					failed++
+				}
+			}
+
+			for _, plugin := range cfg.Generator.Plugins {
+				files, err := gen.GeneratePluginFiles(plugin, tablesToGenerate, cfg.Generator.OutputDir, cfg.Database.Driver)
+				if err != nil {
+					fmt.Printf("  ❌ plugin %s: %v\n", plugin.Name, err)
+					failed++
+					continue
+				}
+				for _, filePath := range files {
+					fmt.Printf("  ✅ %s -> %s\n", plugin.Name, filePath)
+				}
+				outside = append(outside, files...)
+			}
+
+			run.SetAttributes(telemetry.Int("godb_orm.table_count", len(tablesToGenerate)), telemetry.Int("godb_orm.failed_count", failed))
+			run.End()
+			flushTelemetry(tp)
+
+			if failed > 0 && ciMode {
+				fmt.Printf("\n❌ %d table(s) failed to generate\n", failed)
+				os.Exit(ExitGeneration)
+			}
+
+			if gitEnabled() {
+				if failed > 0 {
+					fmt.Println("\n⚠️  Warning: Not committing, some tables failed to generate")
+				} else if _, err := commitModels(introspector, cfg, tablesToGenerate, outside); err != nil {
+					fmt.Printf("❌ Error: %v\n", err)
+					os.Exit(ExitGeneration)
+				}
+			}
+
 			fmt.Println("\n🎉 Model generation complete!")
 		}
 	},
 }
 
+// generateCmd is the root command under an explicit verb, e.g.
+// godb-orm generate --preset api-models
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate models from database tables (same as godb-orm without a subcommand)",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		rootCmd.Run(cmd, args)
+	},
+}
+
+// checkModels compares freshly generated code with the files on disk without
+// writing anything. It returns the process exit code.
+func checkModels(gen *generator.Generator, tables []string, outputDir string) int {
+	fmt.Printf("\n🔍 Checking models in %s...\n", outputDir)
+
+	outdated := 0
+	for _, tableName := range tables {
+		upToDate, err := gen.IsUpToDate(tableName, outputDir)
+		if err != nil {
+			fmt.Printf("  ❌ %s: %v\n", tableName, err)
+			return ExitGeneration
+		}
+		if !upToDate {
+			fmt.Printf("  ⚠️  %s is out of date\n", tableName)
+			outdated++
+		}
+	}
+
+	if outdated > 0 {
+		fmt.Printf("\n❌ %d model(s) would change on regeneration\n", outdated)
+		return ExitOutdated
+	}
+
+	fmt.Println("\n✅ All models are up to date")
+	return ExitOK
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
-		os.Exit(1)
+		os.Exit(ExitUsage)
 	}
 }
 
 func init() {
-	// Load existing config as defaults
-	existingCfg, _ := config.LoadConfig()
+	// Load existing config as defaults (flags > env > project config > global config)
+	var err error
+	existingCfg, err = config.LoadEffectiveConfig()
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not load config: %v\n", err)
+		existingCfg = config.DefaultConfig()
+	}
 
 	// Database connection flags
-	rootCmd.Flags().StringVarP(&host, "host", "H", existingCfg.Database.Host, "Database host")
-	rootCmd.Flags().IntVarP(&port, "port", "P", existingCfg.Database.Port, "Database port")
-	rootCmd.Flags().StringVarP(&user, "user", "u", existingCfg.Database.User, "Database user")
-	rootCmd.Flags().StringVarP(&password, "pass", "p", existingCfg.Database.Password, "Database password")
-	rootCmd.Flags().StringVarP(&dbName, "db", "d", existingCfg.Database.DBName, "Database name")
-	rootCmd.Flags().StringVar(&driver, "driver", existingCfg.Database.Driver, "Database driver (mysql/postgres)")
+	rootCmd.PersistentFlags().StringVarP(&host, "host", "H", existingCfg.Database.Host, "Database host")
+	rootCmd.PersistentFlags().IntVarP(&port, "port", "P", existingCfg.Database.Port, "Database port")
+	rootCmd.PersistentFlags().StringVarP(&user, "user", "u", existingCfg.Database.User, "Database user")
+	// The configured password is not the default, so it never shows in --help
+	rootCmd.PersistentFlags().StringVarP(&password, "pass", "p", "", "Database password (overrides database.password and GODB_PASSWORD)")
+	rootCmd.PersistentFlags().StringVarP(&dbName, "db", "d", existingCfg.Database.DBName, "Database name")
+	rootCmd.PersistentFlags().StringVar(&driver, "driver", existingCfg.Database.Driver, "Database driver (mysql/postgres/firebird/db2/trino/duckdb/mongodb)")
+	rootCmd.PersistentFlags().IntVar(&timeout, "query-timeout", existingCfg.Database.QueryTimeout, "Introspection query timeout in seconds")
+	rootCmd.PersistentFlags().BoolVar(&vitess, "vitess", existingCfg.Database.Vitess, "Adapt MySQL introspection to Vitess/PlanetScale keyspaces")
+	rootCmd.PersistentFlags().BoolVar(&inferFKs, "infer-fks", existingCfg.Database.InferForeignKeys, "Infer foreign keys from <table>_id columns when none are declared (MySQL/Vitess)")
+	rootCmd.PersistentFlags().IntVar(&sampleSize, "sample", existingCfg.Database.SampleSize, "Documents sampled per MongoDB collection, or rows per CSV file, to infer fields (0 for the default of 100)")
+	rootCmd.PersistentFlags().StringVar(&ddlFile, "ddl", existingCfg.Database.DDLFile, "Read the schema from a mysqldump --no-data or pg_dump --schema-only file instead of connecting")
 
 	// Generator flags
-	rootCmd.Flags().StringVarP(&table, "table", "t", existingCfg.Generator.Tables, "Table name(s) to generate (* for all)")
-	rootCmd.Flags().StringVarP(&outputDir, "out", "o", existingCfg.Generator.OutputDir, "Output directory for generated files")
+	rootCmd.PersistentFlags().StringVarP(&table, "table", "t", existingCfg.Generator.Tables, "Table name(s) to generate (* for all)")
+	rootCmd.PersistentFlags().StringVar(&presetName, "preset", "", "Generate the tables of a preset from the project config (presets.<name>) with its generator options")
+	rootCmd.PersistentFlags().StringVarP(&outputDir, "out", "o", existingCfg.Generator.OutputDir, "Output directory for generated files")
+	rootCmd.PersistentFlags().StringVar(&packageName, "package", existingCfg.Generator.PackageName, "Package name for generated files (default: the package of existing files in the output directory, else models)")
+	rootCmd.PersistentFlags().StringVar(&templateFile, "template", existingCfg.Generator.Template, "Custom struct template file (text/template, see TemplateData)")
+	rootCmd.PersistentFlags().StringVar(&filePattern, "file-pattern", existingCfg.Generator.FilePattern, "Model file name template, e.g. {{.Table}}.gen.go or {{.Table}}_model.go")
+	rootCmd.PersistentFlags().StringVar(&buildTag, "build-tag", existingCfg.Generator.BuildTag, "Build constraint added as //go:build to every generated file, e.g. !nomodels")
+	rootCmd.PersistentFlags().StringVar(&tablePrefix, "table-prefix", existingCfg.Naming.TablePrefix, "Table prefix (e.g., wp_) left out of struct and file names")
+	rootCmd.PersistentFlags().StringSliceVar(&acronyms, "acronyms", existingCfg.Naming.Acronyms, "Acronyms upcased in Go names besides ID, URL, API, ... (e.g., SKU,VAT; -DB removes one)")
+	rootCmd.PersistentFlags().BoolVar(&noAcronyms, "no-acronyms", existingCfg.Naming.DisableAcronyms, "Keep Go names in plain PascalCase (UserId instead of UserID)")
+
+	// CI and cache flags
+	rootCmd.Flags().BoolVar(&ciMode, "ci", false, "Non-interactive mode: never writes the global config and fails with distinct exit codes")
+	rootCmd.Flags().BoolVar(&checkMode, "check", false, "Fail if regenerating would change any file (writes nothing)")
+	rootCmd.Flags().BoolVar(&withConstants, "constants", false, "Also generate "+generator.ConstantsFileName+" with table and column name constants")
+	rootCmd.Flags().BoolVar(&withGormGen, "gorm-gen", false, "Also generate a gorm.io/gen runner ("+generator.GormGenDir+"/main.go) building the type-safe query API of the models")
+	rootCmd.Flags().StringVar(&withHandlers, "with-handlers", "", "Also generate REST handlers for gin, echo or chi (package "+generator.HandlersDir+" next to the output directory, implies --repositories) and a runnable "+generator.HandlersMainFile+" if missing")
+	rootCmd.Flags().StringVar(&withDI, "di", "", "Also generate dependency-injection providers for wire or fx (package "+generator.DIDir+" next to the output directory, implies --repositories) wiring *gorm.DB, repositories and handlers")
+	rootCmd.Flags().BoolVar(&withJet, "jet", false, "Also generate go-jet table descriptors (package "+generator.JetDir+" next to the output directory) for jet's SQL builder")
+	rootCmd.Flags().BoolVar(&withSchemaSQL, "schema-sql", false, "Also export "+generator.SchemaFileName+" with CREATE TABLE statements (sqlc-compatible)")
+	rootCmd.Flags().BoolVar(&withAvro, "avro", false, "Also export an Avro schema (<table>"+generator.AvroFileExt+") per table for streaming its rows, e.g. CDC into Kafka")
+	rootCmd.Flags().StringVar(&debeziumTopic, "debezium", "", "Also export the Debezium key/value schemas (<table>"+generator.DebeziumFileExt+") of each table's CDC topic, given the connector's topic prefix")
+	rootCmd.PersistentFlags().BoolVar(&scanHelpers, "scan-helpers", false, "Generate Columns() and ScanRow(*sql.Rows) helpers for database/sql users")
+	rootCmd.PersistentFlags().StringVar(&queryBuilder, "query-builder", "", "Generate SelectColumns(), InsertColumns() and InsertValues() helpers for squirrel or goqu (implies --constants)")
+	rootCmd.PersistentFlags().BoolVar(&hooks, "hooks", existingCfg.Generator.Hooks, "Generate a BeforeCreate hook assigning uuid.New() to UUID primary keys")
+	rootCmd.PersistentFlags().BoolVar(&scopes, "scopes", existingCfg.Generator.Scopes, "Generate a TenantScope scope for tables with a tenant column (generator.tenant_column, default tenant_id)")
+	rootCmd.PersistentFlags().BoolVar(&inferRels, "infer-relations", existingCfg.Generator.InferRelations, "Generate association fields for <singular_table>_id columns without a declared foreign key, marked // inferred")
+	rootCmd.PersistentFlags().BoolVar(&withTx, "with-tx", existingCfg.Generator.WithTx, "Generate a WithTx transaction helper per model")
+	rootCmd.PersistentFlags().BoolVar(&repositories, "repositories", existingCfg.Generator.Repositories, "Generate a <Model>Repository interface with a GORM implementation and a go:generate line for mockery (generator.mock_tool: mockgen for gomock)")
+	rootCmd.PersistentFlags().BoolVar(&factories, "with-factories", existingCfg.Generator.Factories, "Generate a New<Model>(opts ...) constructor with a functional option per column")
+	rootCmd.PersistentFlags().BoolVar(&privateFields, "private-fields", existingCfg.Generator.PrivateFields, "Generate unexported fields with getters, setters and a ToMap() method (not with --style full, --repositories, --pagination or relations)")
+	rootCmd.PersistentFlags().BoolVar(&filters, "filters", existingCfg.Generator.Filters, "Generate a <Model>Filter struct whose Apply method builds WHERE clauses")
+	rootCmd.PersistentFlags().BoolVar(&pagination, "pagination", existingCfg.Generator.Pagination, "Generate a List<Models>(db, page, size, filter) helper returning {Items, Total, Page}")
+	rootCmd.PersistentFlags().StringVar(&style, "style", existingCfg.Generator.Style, "Code generated per table: model (structs) or full (also CRUD functions and relationship loaders, like sqlboiler)")
+	rootCmd.PersistentFlags().BoolVar(&swagger, "swagger", existingCfg.Generator.Swagger, "Annotate models for swaggo (swag init) with model comments and format/example tags")
+	rootCmd.Flags().BoolVar(&noCache, "no-cache", false, "Regenerate all tables, ignoring "+generator.CacheFileName)
+	rootCmd.PersistentFlags().StringVar(&otlpEndpoint, "otlp-endpoint", existingCfg.Telemetry.OTLPEndpoint, "Export traces and metrics of the run to this OTLP/HTTP collector (default $OTEL_EXPORTER_OTLP_ENDPOINT)")
+	rootCmd.Flags().StringArrayVar(&plugins, "plugin", nil, "Run generator plugin "+generator.PluginExecutablePrefix+"<name> (name or name=outdir, repeatable; default: plugins from config)")
+	rootCmd.Flags().BoolVar(&gitCommit, "git-commit", false, "Stage the changes in the output directory and commit them with a message recording the schema hash")
+	rootCmd.Flags().StringVar(&gitBranch, "git-branch", "", "Commit on this branch, created from HEAD if missing (bare flag or \""+gitBranchAuto+"\": godb-orm/schema-<hash>)")
+	rootCmd.Flags().Lookup("git-branch").NoOptDefVal = gitBranchAuto
+	rootCmd.Flags().StringVar(&gitPatch, "git-patch", "", "Stage the changes in the output directory and write them to this patch file instead of committing")
+
+	// generate takes the same flags as the root command
+	generateCmd.Flags().AddFlagSet(rootCmd.Flags())
+	rootCmd.AddCommand(generateCmd)
+}
+
+// savedFlags maps the flags remembered as defaults to their config keys
+var savedFlags = map[string]string{
+	"host":          "database.host",
+	"port":          "database.port",
+	"user":          "database.user",
+	"pass":          "database.password",
+	"db":            "database.dbname",
+	"driver":        "database.driver",
+	"query-timeout": "database.query_timeout",
+	"table":         "generator.tables",
+	"out":           "generator.output_dir",
+	"package":       "generator.package",
+	"style":         "generator.style",
+	"file-pattern":  "generator.file_pattern",
+	"build-tag":     "generator.build_tag",
+	"table-prefix":  "naming.table_prefix",
+}
+
+// explicitFlagValues returns the config values of the remembered flags
+// given on the command line. Values from the environment, a .env file or
+// the project config are left out, so they never end up in the global file.
+func explicitFlagValues(cmd *cobra.Command) map[string]string {
+	values := make(map[string]string)
+	for name, key := range savedFlags {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			values[key] = flag.Value.String()
+		}
+	}
+	return values
+}
+
+// configFromFlags builds the configuration from the parsed command-line
+// flags. The tables and generator options of the --preset, if any, apply on
+// top of the configured ones; flags given on the command line override both.
+func configFromFlags(cmd *cobra.Command) *config.Config {
+	gen := existingCfg.Generator
+	if presetName != "" {
+		preset, err := existingCfg.Preset(presetName)
+		if err == nil {
+			gen, err = preset.Apply(gen)
+		}
+		if err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitUsage)
+		}
+	}
+
+	cfg := &config.Config{
+		Database: config.DBConfig{
+			Host:             host,
+			Port:             port,
+			User:             user,
+			Password:         flagOr(cmd, "pass", password, existingCfg.Database.Password),
+			DBName:           dbName,
+			Driver:           driver,
+			QueryTimeout:     timeout,
+			DDLFile:          ddlFile,
+			Vitess:           vitess,
+			InferForeignKeys: inferFKs,
+			SampleSize:       sampleSize,
+		},
+		Generator: config.GeneratorConfig{
+			Tables:             flagOr(cmd, "table", table, gen.Tables),
+			OutputDir:          flagOr(cmd, "out", outputDir, gen.OutputDir),
+			PackageName:        flagOr(cmd, "package", packageName, gen.PackageName),
+			NullStrategy:       gen.NullStrategy,
+			TagStyle:           gen.TagStyle,
+			Relations:          gen.Relations,
+			InferRelations:     flagOr(cmd, "infer-relations", inferRels, gen.InferRelations),
+			Hstore:             gen.Hstore,
+			Vector:             gen.Vector,
+			Spatial:            gen.Spatial,
+			Bit:                gen.Bit,
+			DateTime:           gen.DateTime,
+			FieldOrder:         gen.FieldOrder,
+			NoPrimaryKey:       gen.NoPrimaryKey,
+			Style:              flagOr(cmd, "style", style, gen.Style),
+			MaxLineWidth:       gen.MaxLineWidth,
+			FilePattern:        flagOr(cmd, "file-pattern", filePattern, gen.FilePattern),
+			BuildTag:           flagOr(cmd, "build-tag", buildTag, gen.BuildTag),
+			RelationRules:      gen.RelationRules,
+			Overrides:          gen.Overrides,
+			Template:           flagOr(cmd, "template", templateFile, gen.Template),
+			Header:             gen.Header,
+			Footer:             gen.Footer,
+			GoGenerate:         gen.GoGenerate,
+			Hooks:              flagOr(cmd, "hooks", hooks, gen.Hooks),
+			Scopes:             flagOr(cmd, "scopes", scopes, gen.Scopes),
+			TenantColumn:       gen.TenantColumn,
+			WithTx:             flagOr(cmd, "with-tx", withTx, gen.WithTx),
+			Repositories:       flagOr(cmd, "repositories", repositories, gen.Repositories),
+			MockTool:           gen.MockTool,
+			Factories:          flagOr(cmd, "with-factories", factories, gen.Factories),
+			PrivateFields:      flagOr(cmd, "private-fields", privateFields, gen.PrivateFields),
+			Filters:            flagOr(cmd, "filters", filters, gen.Filters),
+			Pagination:         flagOr(cmd, "pagination", pagination, gen.Pagination),
+			Swagger:            flagOr(cmd, "swagger", swagger, gen.Swagger),
+			GormTag:            gen.GormTag,
+			ExtraTags:          gen.ExtraTags,
+			Sensitive:          gen.Sensitive,
+			SensitiveWriteOnly: gen.SensitiveWriteOnly,
+			TypeRules:          gen.TypeRules,
+			AutoCreateTime:     gen.AutoCreateTime,
+			AutoUpdateTime:     gen.AutoUpdateTime,
+			Subpackages:        gen.Subpackages,
+			Plugins:            pluginsFromFlags(gen.Plugins),
+		},
+		Naming: config.NamingConfig{
+			TablePrefix:     tablePrefix,
+			Acronyms:        acronyms,
+			DisableAcronyms: noAcronyms,
+		},
+		Telemetry: config.TelemetryConfig{
+			OTLPEndpoint: otlpEndpoint,
+			ServiceName:  existingCfg.Telemetry.ServiceName,
+			Headers:      existingCfg.Telemetry.Headers,
+		},
+		Presets: existingCfg.Presets,
+	}
+
+	// Handlers and DI providers are built on the repositories
+	if withHandlers != "" || withDI != "" {
+		cfg.Generator.Repositories = true
+	}
+	return cfg
+}
+
+// flagOr returns value, the variable of a flag of cmd, if the flag was given
+// on the command line, else fallback
+func flagOr[T any](cmd *cobra.Command, name string, value, fallback T) T {
+	if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+		return value
+	}
+	return fallback
+}
+
+// pluginsFromFlags resolves --plugin name[=outdir] flags. A plugin also
+// listed in configured keeps its path and parameter. Without flags the
+// configured plugins are used.
+func pluginsFromFlags(configured []config.PluginConfig) []config.PluginConfig {
+	if len(plugins) == 0 {
+		return configured
+	}
+
+	var resolved []config.PluginConfig
+	for _, spec := range plugins {
+		name, out, _ := strings.Cut(spec, "=")
+		plugin := config.PluginConfig{Name: name}
+		for _, c := range configured {
+			if c.Name == name {
+				plugin = c
+				break
+			}
+		}
+		if out != "" {
+			plugin.Out = out
+		}
+		resolved = append(resolved, plugin)
+	}
+	return resolved
+}
+
+// newGenerator creates a generator configured from the generator and naming
+// settings. The package name and module import path are detected from the
+// output directory unless a package name is configured.
+func newGenerator(introspector database.DBIntrospector, cfg *config.Config) *generator.Generator {
+	genCfg := cfg.Generator
+	pkgName, importPath, err := generator.ResolvePackage(genCfg.OutputDir, genCfg.PackageName)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not detect module path: %v\n", err)
+	}
+
+	return generator.NewGeneratorWithConfig(introspector, generator.GeneratorConfig{
+		PackageName:    pkgName,
+		ImportPath:     importPath,
+		NullStrategy:   generator.NullStrategy(genCfg.NullStrategy),
+		TagStyle:       generator.TagStyle(genCfg.TagStyle),
+		ScanHelpers:    scanHelpers,
+		QueryBuilder:   generator.QueryBuilder(queryBuilder),
+		Relations:      generator.RelationMode(genCfg.Relations),
+		InferRelations: genCfg.InferRelations,
+		Hstore:         generator.HstoreMode(genCfg.Hstore),
+		Vector:         generator.VectorMode(genCfg.Vector),
+		Spatial:        generator.SpatialMode(genCfg.Spatial),
+		Bit:            generator.BitMode(genCfg.Bit),
+		DateTime:       generator.DateTimeMode(genCfg.DateTime),
+		RelationRules:  genCfg.RelationRules,
+		Overrides:      genCfg.Overrides,
+		TemplateFile:   genCfg.Template,
+		Header:         genCfg.Header,
+		Footer:         genCfg.Footer,
+		GoGenerate:     genCfg.GoGenerate,
+		TypeRules:      genCfg.TypeRules,
+		AutoCreate:     genCfg.AutoCreateTime,
+		AutoUpdate:     genCfg.AutoUpdateTime,
+		TablePrefix:    cfg.Naming.TablePrefix,
+		Acronyms:       cfg.Naming.Acronyms,
+		NoAcronyms:     cfg.Naming.DisableAcronyms,
+		Subpackages:    genCfg.Subpackages,
+		FieldOrder:     generator.FieldOrder(genCfg.FieldOrder),
+		NoPrimaryKey:   generator.NoPrimaryKeyMode(genCfg.NoPrimaryKey),
+		Style:          generator.Style(genCfg.Style),
+		MaxLineWidth:   genCfg.MaxLineWidth,
+		FilePattern:    genCfg.FilePattern,
+		BuildTag:       genCfg.BuildTag,
+		Hooks:          genCfg.Hooks,
+		Scopes:         genCfg.Scopes,
+		TenantColumn:   genCfg.TenantColumn,
+		WithTx:         genCfg.WithTx,
+		Repositories:   genCfg.Repositories,
+		MockTool:       generator.MockTool(genCfg.MockTool),
+		Factories:      genCfg.Factories,
+		PrivateFields:  genCfg.PrivateFields,
+		Filters:        genCfg.Filters,
+		Pagination:     genCfg.Pagination,
+		Swagger:        genCfg.Swagger,
+		GormOptions:    genCfg.GormTag,
+		ExtraTags:      genCfg.ExtraTags,
+		Sensitive:      genCfg.Sensitive,
+		WriteOnly:      genCfg.SensitiveWriteOnly,
+	})
 }
 
 // splitTables splits a comma-separated list of table names
@@ -170,3 +705,65 @@ func splitTables(tables string) []string {
 	}
 	return result
 }
+
+// connectIntrospector connects to the configured database (or reads the
+// configured dump), exiting on failure. For dumps the detected dialect
+// replaces the configured driver.
+func connectIntrospector(cfg *config.Config) database.DBIntrospector {
+	introspector, err := database.NewIntrospector(&cfg.Database)
+	if err != nil {
+		fmt.Printf("❌ Error: %v\n", err)
+		os.Exit(ExitUsage)
+	}
+
+	if err := introspector.Connect(); err != nil {
+		fmt.Printf("❌ Error connecting to database: %v\n", err)
+		printHint(err, cfg.Database.Driver, "")
+		os.Exit(ExitConnection)
+	}
+
+	if ddl, ok := introspector.(*database.DDLIntrospector); ok {
+		// Dialect detected from the dump drives SQL output
+		cfg.Database.Driver = ddl.Dialect()
+		fmt.Printf("✅ Loaded %s schema from %s\n", ddl.Dialect(), cfg.Database.DDLFile)
+	} else {
+		fmt.Println("✅ Connected to database successfully!")
+	}
+	return introspector
+}
+
+// selectTables returns the configured tables, or every table for "*",
+// exiting if the table list can't be read
+func selectTables(introspector database.DBIntrospector, cfg *config.Config) []string {
+	tables, err := configuredTables(introspector, cfg)
+	if err != nil {
+		fmt.Printf("❌ Error getting tables: %v\n", err)
+		printHint(err, cfg.Database.Driver, "")
+		os.Exit(ExitConnection)
+	}
+	return tables
+}
+
+// configuredTables returns the configured tables, or every table for "*"
+func configuredTables(introspector database.DBIntrospector, cfg *config.Config) ([]string, error) {
+	if cfg.Generator.Tables != "*" && cfg.Generator.Tables != "" {
+		return splitTables(cfg.Generator.Tables), nil
+	}
+	tables, err := introspector.GetTables()
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("📋 Found %d tables\n", len(tables))
+	return tables, nil
+}
+
+// cliMessages formats CLI hints; the CLI speaks English
+var cliMessages = i18n.New(i18n.English)
+
+// printHint prints what the user can do about a classified database error
+// (wrong password, missing grant, ...), indented by indent
+func printHint(err error, driver, indent string) {
+	if hint := cliMessages.Hint(err, driver); hint != "" {
+		fmt.Printf("%s💡 %s\n", indent, cliMessages.Sprintf(i18n.Hint, hint))
+	}
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rowjak/godb-orm/internal/database"
+	"github.com/rowjak/godb-orm/internal/server"
+	"github.com/rowjak/godb-orm/internal/telemetry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr  string
+	serveToken string
+)
+
+// serveCmd runs the HTTP/JSON API
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve table metadata and generated models over HTTP",
+	Long: `Run an HTTP/JSON API next to the database so CI jobs and other tools can
+list tables, fetch metadata and download generated models without database
+credentials of their own. Models are generated with the current settings.
+
+Endpoints:
+  GET /healthz                  liveness and database connectivity
+  GET /api/tables               table names
+  GET /api/tables/{table}       table metadata
+  GET /api/tables/{table}/code  generated model file
+  GET /api/models.zip           zip of generated models (?tables=a,b to select)
+
+Set --token (or GODB_SERVE_TOKEN) to require "Authorization: Bearer <token>".
+It listens on 127.0.0.1:8080 by default; any other than a loopback --addr
+needs a token, as the API serves the schema to everyone who can reach it.
+With --otlp-endpoint, a span per request and introspection query is exported
+every 10 seconds.
+
+Example usage:
+  godb-orm serve -H localhost -P 5432 -u app -d shop --driver postgres --addr :8080 --token "$TOKEN"
+  curl -H "Authorization: Bearer $TOKEN" -o models.zip http://db-host:8080/api/models.zip`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg = configFromFlags(cmd)
+
+		if serveToken == "" && !isLoopbackAddr(serveAddr) {
+			fmt.Printf("❌ Error: --addr %s is reachable from other hosts; set --token (or GODB_SERVE_TOKEN), or listen on 127.0.0.1\n", serveAddr)
+			os.Exit(ExitUsage)
+		}
+		if cfg.Database.DBName == "" && cfg.Database.DDLFile == "" {
+			fmt.Println("❌ Error: Database name is required (--db or -d) unless reading a dump (--ddl)")
+			os.Exit(ExitUsage)
+		}
+
+		introspector, err := database.NewIntrospector(&cfg.Database)
+		if err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitUsage)
+		}
+
+		if err := introspector.Connect(); err != nil {
+			fmt.Printf("❌ Error connecting to database: %v\n", err)
+			printHint(err, cfg.Database.Driver, "")
+			os.Exit(ExitConnection)
+		}
+		defer introspector.Close()
+
+		tp := newTelemetry(cfg)
+		traced := telemetry.WrapIntrospector(introspector, tp, nil)
+
+		gen := newGenerator(traced, cfg)
+		if err := gen.Err(); err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitUsage)
+		}
+
+		srv := &http.Server{
+			Addr:              serveAddr,
+			Handler:           tp.Handler(server.New(traced, gen, serveToken).Handler()),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			srv.Shutdown(shutdownCtx)
+		}()
+
+		telemetryDone := make(chan struct{})
+		go func() {
+			tp.Run(ctx, telemetryInterval, warnTelemetry)
+			close(telemetryDone)
+		}()
+
+		fmt.Printf("🌐 Serving %s on %s\n", cfg.Database.DBName, serveAddr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+		<-telemetryDone
+		fmt.Println("👋 Server stopped")
+	},
+}
+
+// isLoopbackAddr reports whether a listen address only accepts connections
+// from this host. An empty host listens on every interface.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:8080", "Address to listen on; other than loopback requires --token")
+	serveCmd.Flags().StringVar(&serveToken, "token", os.Getenv("GODB_SERVE_TOKEN"), "Bearer token required by API requests (default $GODB_SERVE_TOKEN)")
+	rootCmd.AddCommand(serveCmd)
+}
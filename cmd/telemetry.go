@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/telemetry"
+)
+
+// telemetryInterval is how often `serve` exports telemetry
+const telemetryInterval = 10 * time.Second
+
+// newTelemetry returns the telemetry provider configured by cfg, or nil
+// when no OTLP endpoint is set
+func newTelemetry(cfg *config.Config) *telemetry.Provider {
+	return telemetry.New(telemetry.Config{
+		Endpoint:    cfg.Telemetry.OTLPEndpoint,
+		ServiceName: cfg.Telemetry.ServiceName,
+		Headers:     cfg.Telemetry.Headers,
+	})
+}
+
+// flushTelemetry exports buffered telemetry; an unreachable collector only
+// warrants a warning
+func flushTelemetry(tp *telemetry.Provider) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	warnTelemetry(tp.Flush(ctx))
+}
+
+// warnTelemetry prints a telemetry export error, if any
+func warnTelemetry(err error) {
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not export telemetry: %v\n", err)
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rowjak/godb-orm/internal/database"
+	"github.com/rowjak/godb-orm/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// tuiCmd launches the terminal table browser
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse tables and generate models in the terminal",
+	Long: `Launch an interactive terminal UI that mirrors the GUI: list tables,
+inspect columns, preview the generated code and generate selected tables.
+Useful on servers without a display for the Wails GUI.
+
+Example usage:
+  godb-orm tui -H localhost -P 3306 -u root -d mydb --driver mysql -o ./models
+  godb-orm tui --ddl schema.sql -o ./models`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg = configFromFlags(cmd)
+
+		if cfg.Database.DBName == "" && cfg.Database.DDLFile == "" {
+			fmt.Println("❌ Error: Database name is required (--db or -d) unless reading a dump (--ddl)")
+			os.Exit(ExitUsage)
+		}
+
+		introspector, err := database.NewIntrospector(&cfg.Database)
+		if err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(ExitUsage)
+		}
+
+		if err := introspector.Connect(); err != nil {
+			fmt.Printf("❌ Error connecting to database: %v\n", err)
+			os.Exit(ExitConnection)
+		}
+		defer introspector.Close()
+
+		gen := newGenerator(introspector, cfg)
+		if err := tui.Run(introspector, gen, cfg.Generator.OutputDir); err != nil {
+			fmt.Printf("❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
@@ -0,0 +1,452 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+	"github.com/rowjak/godb-orm/internal/fixtures"
+	"github.com/rowjak/godb-orm/internal/generator"
+	"github.com/rowjak/godb-orm/internal/i18n"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// connection is one database connection of the App (a connection tab) with
+// its own introspector, generator and health monitor. Its lock guards the
+// fields below it, so a slow query on one connection doesn't hold up bridge
+// calls on another.
+type connection struct {
+	id  string
+	app *App
+
+	mu           sync.RWMutex
+	introspector database.DBIntrospector
+	dbConfig     *config.DBConfig
+	generator    *generator.Generator
+	connected    bool
+	reconnecting bool
+	lastError    string
+	lastHint     string
+	stopMonitor  context.CancelFunc
+
+	schemaSnapshot database.SchemaSnapshot // Schema as last fetched, nil until the first check
+	schemaNotified database.SchemaChange   // Change last emitted, so it is emitted once
+	schemaEpoch    int                     // Bumped whenever schemaSnapshot is reset or refreshed
+}
+
+// conn returns the connection with the given ID, or the active connection
+// for an empty ID. An unknown ID yields a connection that was never
+// connected, so bridge methods report ErrNotConnected.
+func (a *App) conn(id string) *connection {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if id == "" {
+		id = a.active
+	}
+	if c, ok := a.connections[id]; ok {
+		return c
+	}
+	return &connection{id: id, app: a}
+}
+
+// OpenConnection connects to another database alongside the open ones (a
+// new connection tab) and makes it the active connection. It returns the
+// connection's ID, which keyed bridge methods such as FetchTablesFor take.
+func (a *App) OpenConnection(cfg config.DBConfig) (string, error) {
+	return a.openTab(func(c *connection) error { return c.connect(cfg) })
+}
+
+// OpenDemo opens the demo database in a new connection tab and makes it the
+// active connection, so the whole flow (browse, preview, generate) can be
+// tried without a database. The demo is the MySQL fixture schema of a small
+// shop (users, orders and order items), read from its embedded DDL. It
+// returns the connection's ID.
+func (a *App) OpenDemo() (string, error) {
+	return a.openTab((*connection).connectDemo)
+}
+
+// openTab adds a connection tab connected by connect and makes it the active
+// connection. The tab is only added if connect succeeds.
+func (a *App) openTab(connect func(c *connection) error) (string, error) {
+	a.mu.Lock()
+	a.nextID++
+	c := &connection{id: fmt.Sprintf("conn-%d", a.nextID), app: a}
+	a.mu.Unlock()
+
+	if err := connect(c); err != nil {
+		return "", err
+	}
+
+	a.mu.Lock()
+	a.connections[c.id] = c
+	a.active = c.id
+	a.mu.Unlock()
+	return c.id, nil
+}
+
+// GetConnections returns the status of every open connection, in the order
+// they were opened
+func (a *App) GetConnections() []ConnectionStatus {
+	a.mu.RLock()
+	connections := make([]*connection, 0, len(a.connections))
+	for _, c := range a.connections {
+		connections = append(connections, c)
+	}
+	active := a.active
+	a.mu.RUnlock()
+
+	statuses := make([]ConnectionStatus, 0, len(connections))
+	for _, c := range connections {
+		c.mu.RLock()
+		status := c.connectionStatus()
+		c.mu.RUnlock()
+		status.Active = c.id == active
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return connectionNumber(statuses[i].ID) < connectionNumber(statuses[j].ID)
+	})
+	return statuses
+}
+
+// SwitchConnection makes an open connection the active one, which the
+// bridge methods without a connection ID work on
+func (a *App) SwitchConnection(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.connections[id]; !ok {
+		return a.i18n.Errorf(i18n.UnknownConnection, id)
+	}
+	a.active = id
+	return nil
+}
+
+// CloseConnection closes a connection and removes its tab. If it was the
+// active connection, the most recently opened remaining one becomes active.
+func (a *App) CloseConnection(id string) error {
+	a.mu.Lock()
+	c, ok := a.connections[id]
+	if !ok {
+		a.mu.Unlock()
+		return a.i18n.Errorf(i18n.UnknownConnection, id)
+	}
+	delete(a.connections, id)
+	if a.active == id {
+		a.active = a.lastConnection()
+	}
+	a.mu.Unlock()
+
+	return c.close()
+}
+
+// lastConnection returns the ID of the most recently opened connection, or
+// an empty string if none is open. The caller must hold the lock.
+func (a *App) lastConnection() string {
+	last := ""
+	for id := range a.connections {
+		if last == "" || connectionNumber(id) > connectionNumber(last) {
+			last = id
+		}
+	}
+	return last
+}
+
+// connectionNumber returns the sequence number of a connection ID
+func connectionNumber(id string) int {
+	var n int
+	fmt.Sscanf(id, "conn-%d", &n)
+	return n
+}
+
+// connect (re)connects to the database with the given configuration,
+// replacing the connection's previous database, if any
+func (c *connection) connect(cfg config.DBConfig) error {
+	a := c.app
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Close existing connection if any
+	c.stopMonitoring()
+	if c.introspector != nil {
+		c.introspector.Close()
+		c.introspector = nil
+		c.generator = nil
+		c.connected = false
+	}
+
+	// The connection form doesn't expose the query timeout; keep the configured one
+	if cfg.QueryTimeout == 0 {
+		if saved, err := config.LoadEffectiveConfig(); err == nil {
+			cfg.QueryTimeout = saved.Database.QueryTimeout
+		}
+	}
+
+	// Create new introspector based on driver
+	introspector, err := database.NewIntrospector(&cfg)
+	if err != nil {
+		return a.i18n.Errorf(i18n.CreateIntrospector, err)
+	}
+
+	// Attempt connection
+	if err := introspector.Connect(); err != nil {
+		return a.i18n.WithHint(a.i18n.Errorf(i18n.Connect, err), cfg.Driver)
+	}
+
+	// Keep persisted generator defaults (package, null strategy, tag style, relations)
+	fullCfg, err := config.LoadConfig()
+	if err != nil {
+		log.Printf("Warning: Could not load config: %v", err)
+		fullCfg = config.DefaultConfig()
+	}
+
+	// Store state
+	c.introspector = introspector
+	c.dbConfig = &cfg
+	c.generator = generator.NewGeneratorWithConfig(introspector, generatorConfig(fullCfg.Generator))
+	c.connected = true
+	c.reconnecting = false
+	c.lastError = ""
+	c.lastHint = ""
+	c.startMonitoring()
+
+	// Save configuration for future use
+	a.mu.Lock()
+	a.savedConfig = &cfg
+	a.mu.Unlock()
+	if err := config.SaveConnection(cfg); err != nil {
+		// Log warning but don't fail the connection
+		log.Printf("Warning: Could not save config: %v", err)
+	}
+	if err := config.AddRecentConnection(cfg); err != nil {
+		log.Printf("Warning: Could not update connection history: %v", err)
+	}
+
+	return nil
+}
+
+// connectDemo connects to the demo schema (see OpenDemo). Unlike connect,
+// it leaves the saved config and the recent connections alone.
+func (c *connection) connectDemo() error {
+	introspector, err := fixtures.Introspector(demoDialect)
+	if err != nil {
+		return c.app.i18n.Errorf(i18n.Connect, err)
+	}
+
+	fullCfg, err := config.LoadConfig()
+	if err != nil {
+		log.Printf("Warning: Could not load config: %v", err)
+		fullCfg = config.DefaultConfig()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.introspector = introspector
+	c.dbConfig = &config.DBConfig{Driver: demoDialect, DBName: demoDBName}
+	c.generator = generator.NewGeneratorWithConfig(introspector, generatorConfig(fullCfg.Generator))
+	c.connected = true
+	c.startMonitoring()
+	return nil
+}
+
+// close stops monitoring and closes the database connection
+func (c *connection) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stopMonitoring()
+	if c.introspector != nil {
+		if err := c.introspector.Close(); err != nil {
+			return c.app.i18n.Errorf(i18n.CloseConnection, err)
+		}
+		c.introspector = nil
+		c.generator = nil
+		c.connected = false
+		c.reconnecting = false
+		c.lastError = ""
+		c.lastHint = ""
+	}
+	return nil
+}
+
+// connectionStatus builds the current status. The caller must hold the lock.
+func (c *connection) connectionStatus() ConnectionStatus {
+	status := ConnectionStatus{
+		ID:           c.id,
+		Connected:    c.connected,
+		Reconnecting: c.reconnecting,
+		Error:        c.lastError,
+		Hint:         c.lastHint,
+	}
+
+	if c.dbConfig != nil {
+		status.Driver = c.dbConfig.Driver
+		status.Host = c.dbConfig.Host
+		status.DatabaseName = c.dbConfig.DBName
+	}
+
+	return status
+}
+
+// startMonitoring starts the background health check for the current
+// connection. The caller must hold the write lock.
+func (c *connection) startMonitoring() {
+	c.resetSchemaSnapshot()
+	ctx, cancel := context.WithCancel(context.Background())
+	c.stopMonitor = cancel
+	go c.monitorConnection(ctx)
+}
+
+// stopMonitoring stops the background health check, if running.
+// The caller must hold the write lock.
+func (c *connection) stopMonitoring() {
+	if c.stopMonitor != nil {
+		c.stopMonitor()
+		c.stopMonitor = nil
+	}
+}
+
+// monitorConnection pings the database periodically to detect dropped
+// connections (laptop sleep, VPN drop) and re-establishes them. It also
+// watches the schema for changes made while the app is open.
+func (c *connection) monitorConnection(ctx context.Context) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	schemaTicker := time.NewTicker(schemaCheckInterval)
+	defer schemaTicker.Stop()
+
+	c.checkSchema(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkConnection(ctx)
+		case <-schemaTicker.C:
+			c.checkSchema(ctx)
+		}
+	}
+}
+
+// checkSchema snapshots the schema and notifies the frontend if it differs
+// from the last fetched one. The first check only records the snapshot.
+func (c *connection) checkSchema(ctx context.Context) {
+	c.mu.RLock()
+	if !c.connected || c.reconnecting || c.introspector == nil {
+		c.mu.RUnlock()
+		return
+	}
+	epoch := c.schemaEpoch
+	snapshot, err := database.TakeSnapshot(c.introspector)
+	c.mu.RUnlock()
+	if err != nil {
+		// Connection problems are reported by the health check
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// The connection or schema may have been switched while snapshotting
+	if ctx.Err() != nil || epoch != c.schemaEpoch {
+		return
+	}
+	if c.schemaSnapshot == nil {
+		c.schemaSnapshot = snapshot
+		return
+	}
+
+	change := c.schemaSnapshot.Diff(snapshot)
+	if change.Empty() || reflect.DeepEqual(change, c.schemaNotified) {
+		return
+	}
+	c.schemaNotified = change
+	if c.app.ctx != nil {
+		runtime.EventsEmit(c.app.ctx, EventSchemaChanged, change, c.id)
+	}
+}
+
+// resetSchemaSnapshot forgets the last schema snapshot, so the next check
+// records a new one. The caller must hold the write lock.
+func (c *connection) resetSchemaSnapshot() {
+	c.schemaSnapshot = nil
+	c.schemaNotified = database.SchemaChange{}
+	c.schemaEpoch++
+}
+
+// checkConnection pings the database and starts recovery if the ping fails
+func (c *connection) checkConnection(ctx context.Context) {
+	c.mu.RLock()
+	pinger, ok := c.introspector.(database.Pinger)
+	reconnecting := c.reconnecting
+	c.mu.RUnlock()
+
+	if !ok {
+		return
+	}
+	if !reconnecting {
+		err := pinger.Ping()
+		if err == nil {
+			return
+		}
+
+		c.mu.Lock()
+		// The connection may have been replaced while pinging
+		if ctx.Err() != nil {
+			c.mu.Unlock()
+			return
+		}
+		c.reconnecting = true
+		c.setLastError(err)
+		c.emitStatus()
+		c.mu.Unlock()
+	}
+
+	c.recoverConnection()
+}
+
+// recoverConnection re-establishes a dropped connection. It is a no-op
+// while the connection is healthy, so bridge methods call it before
+// introspecting to recover transparently.
+func (c *connection) recoverConnection() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.reconnecting || c.introspector == nil {
+		return
+	}
+
+	// Reconnecting in place keeps the generator and schema selection intact
+	c.introspector.Close()
+	if err := c.introspector.Connect(); err != nil {
+		c.setLastError(err)
+		return
+	}
+
+	c.reconnecting = false
+	c.lastError = ""
+	c.lastHint = ""
+	c.emitStatus()
+}
+
+// setLastError records a connection error and the hint for it.
+// The caller must hold the write lock.
+func (c *connection) setLastError(err error) {
+	c.lastError = err.Error()
+	c.lastHint = c.app.i18n.Hint(err, c.dbConfig.Driver)
+}
+
+// emitStatus notifies the frontend of the connection status.
+// The caller must hold the lock.
+func (c *connection) emitStatus() {
+	if c.app.ctx != nil {
+		runtime.EventsEmit(c.app.ctx, EventConnectionStatus, c.connectionStatus())
+	}
+}
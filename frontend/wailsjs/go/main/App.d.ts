@@ -2,35 +2,117 @@
 // This file is automatically generated. DO NOT EDIT
 import {config} from '../models';
 import {main} from '../models';
+import {database} from '../models';
+import {generator} from '../models';
+
+export function AcceptEnum(arg1:string,arg2:string,arg3:Array<string>):Promise<void>;
+
+export function AnalyzeColumn(arg1:string,arg2:string):Promise<database.ColumnStats>;
+
+export function CloseConnection(arg1:string):Promise<void>;
 
 export function ConnectDB(arg1:config.DBConfig):Promise<void>;
 
+export function CopyCodeToClipboard(arg1:string):Promise<void>;
+
 export function DisconnectDB():Promise<void>;
 
+export function ExportArchive(arg1:Array<string>,arg2:string):Promise<string>;
+
+export function ExportDictionary(arg1:string):Promise<string>;
+
 export function FetchSchemas():Promise<Array<string>>;
 
+export function FetchSchemasFor(arg1:string):Promise<Array<string>>;
+
 export function FetchTableSchema(arg1:string):Promise<Array<main.ColumnInfo>>;
 
+export function FetchTableSchemaFor(arg1:string,arg2:string):Promise<Array<main.ColumnInfo>>;
+
+export function FetchTableStats():Promise<Record<string, database.TableStats>>;
+
 export function FetchTables():Promise<Array<string>>;
 
-export function GetCodePreview(arg1:string):Promise<string>;
+export function FetchTablesFor(arg1:string):Promise<Array<string>>;
+
+export function GenerateFromDDL(arg1:string,arg2:string):Promise<main.CodePreviewBatch>;
 
-export function GetCodePreviewMultiple(arg1:Array<string>):Promise<Record<string, string>>;
+export function GetCodePreview(arg1:string):Promise<main.CodePreview>;
+
+export function GetCodePreviewFor(arg1:string,arg2:string):Promise<main.CodePreview>;
+
+export function GetCodePreviewMultiple(arg1:Array<string>):Promise<main.CodePreviewBatch>;
 
 export function GetConnectionStatus():Promise<main.ConnectionStatus>;
 
+export function GetConnections():Promise<Array<main.ConnectionStatus>>;
+
 export function GetCurrentSchema():Promise<string>;
 
+export function GetGenerationHistory():Promise<Array<config.GenerationRun>>;
+
+export function GetLocale():Promise<string>;
+
+export function GetOutputPath(arg1:string,arg2:string):Promise<string>;
+
+export function GetPresets():Promise<Array<string>>;
+
+export function GetRecentConnections():Promise<Array<config.RecentConnection>>;
+
 export function GetSavedConfig():Promise<config.DBConfig>;
 
+export function GetSupportedLocales():Promise<Array<string>>;
+
+export function GetTableOverride(arg1:string):Promise<config.TableOverride>;
+
+export function GetTablePrefix():Promise<string>;
+
 export function Greet(arg1:string):Promise<string>;
 
 export function IsPostgres():Promise<boolean>;
 
+export function OpenConnection(arg1:config.DBConfig):Promise<string>;
+
+export function OpenDemo():Promise<string>;
+
+export function OpenDirectory(arg1:string):Promise<void>;
+
+export function OpenInEditor(arg1:string):Promise<void>;
+
+export function ReconnectLast():Promise<boolean>;
+
+export function ReconnectRecent(arg1:string,arg2:string):Promise<void>;
+
+export function RefreshSchema():Promise<database.SchemaChange>;
+
+export function RejectEnum(arg1:string,arg2:string):Promise<void>;
+
+export function RemoveRecentConnection(arg1:string):Promise<void>;
+
+export function RestoreFile(arg1:string,arg2:string):Promise<void>;
+
 export function SaveAllToDirectory(arg1:string):Promise<Array<string>>;
 
+export function SaveCodeAs(arg1:string):Promise<string>;
+
 export function SaveCodeToFile(arg1:string,arg2:string):Promise<void>;
 
+export function SavePresetToDirectory(arg1:string,arg2:string):Promise<Array<string>>;
+
 export function SaveSelectedToDirectory(arg1:Array<string>,arg2:string):Promise<Array<string>>;
 
+export function SelectOutputDirectory():Promise<string>;
+
+export function SetExcludedColumns(arg1:string,arg2:Array<string>):Promise<void>;
+
+export function SetLocale(arg1:string):Promise<void>;
+
 export function SetSchema(arg1:string):Promise<void>;
+
+export function SetTableOverride(arg1:string,arg2:config.TableOverride):Promise<void>;
+
+export function SetTablePrefix(arg1:string):Promise<void>;
+
+export function SuggestEnums(arg1:string):Promise<Array<generator.EnumSuggestion>>;
+
+export function SwitchConnection(arg1:string):Promise<void>;
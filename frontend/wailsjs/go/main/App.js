@@ -2,30 +2,78 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AcceptEnum(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AcceptEnum'](arg1, arg2, arg3);
+}
+
+export function AnalyzeColumn(arg1, arg2) {
+  return window['go']['main']['App']['AnalyzeColumn'](arg1, arg2);
+}
+
+export function CloseConnection(arg1) {
+  return window['go']['main']['App']['CloseConnection'](arg1);
+}
+
 export function ConnectDB(arg1) {
   return window['go']['main']['App']['ConnectDB'](arg1);
 }
 
+export function CopyCodeToClipboard(arg1) {
+  return window['go']['main']['App']['CopyCodeToClipboard'](arg1);
+}
+
 export function DisconnectDB() {
   return window['go']['main']['App']['DisconnectDB']();
 }
 
+export function ExportArchive(arg1, arg2) {
+  return window['go']['main']['App']['ExportArchive'](arg1, arg2);
+}
+
+export function ExportDictionary(arg1) {
+  return window['go']['main']['App']['ExportDictionary'](arg1);
+}
+
 export function FetchSchemas() {
   return window['go']['main']['App']['FetchSchemas']();
 }
 
+export function FetchSchemasFor(arg1) {
+  return window['go']['main']['App']['FetchSchemasFor'](arg1);
+}
+
 export function FetchTableSchema(arg1) {
   return window['go']['main']['App']['FetchTableSchema'](arg1);
 }
 
+export function FetchTableSchemaFor(arg1, arg2) {
+  return window['go']['main']['App']['FetchTableSchemaFor'](arg1, arg2);
+}
+
+export function FetchTableStats() {
+  return window['go']['main']['App']['FetchTableStats']();
+}
+
 export function FetchTables() {
   return window['go']['main']['App']['FetchTables']();
 }
 
+export function FetchTablesFor(arg1) {
+  return window['go']['main']['App']['FetchTablesFor'](arg1);
+}
+
+export function GenerateFromDDL(arg1, arg2) {
+  return window['go']['main']['App']['GenerateFromDDL'](arg1, arg2);
+}
+
 export function GetCodePreview(arg1) {
   return window['go']['main']['App']['GetCodePreview'](arg1);
 }
 
+export function GetCodePreviewFor(arg1, arg2) {
+  return window['go']['main']['App']['GetCodePreviewFor'](arg1, arg2);
+}
+
 export function GetCodePreviewMultiple(arg1) {
   return window['go']['main']['App']['GetCodePreviewMultiple'](arg1);
 }
@@ -34,14 +82,50 @@ export function GetConnectionStatus() {
   return window['go']['main']['App']['GetConnectionStatus']();
 }
 
+export function GetConnections() {
+  return window['go']['main']['App']['GetConnections']();
+}
+
 export function GetCurrentSchema() {
   return window['go']['main']['App']['GetCurrentSchema']();
 }
 
+export function GetGenerationHistory() {
+  return window['go']['main']['App']['GetGenerationHistory']();
+}
+
+export function GetLocale() {
+  return window['go']['main']['App']['GetLocale']();
+}
+
+export function GetOutputPath(arg1, arg2) {
+  return window['go']['main']['App']['GetOutputPath'](arg1, arg2);
+}
+
+export function GetPresets() {
+  return window['go']['main']['App']['GetPresets']();
+}
+
+export function GetRecentConnections() {
+  return window['go']['main']['App']['GetRecentConnections']();
+}
+
 export function GetSavedConfig() {
   return window['go']['main']['App']['GetSavedConfig']();
 }
 
+export function GetSupportedLocales() {
+  return window['go']['main']['App']['GetSupportedLocales']();
+}
+
+export function GetTableOverride(arg1) {
+  return window['go']['main']['App']['GetTableOverride'](arg1);
+}
+
+export function GetTablePrefix() {
+  return window['go']['main']['App']['GetTablePrefix']();
+}
+
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
@@ -50,18 +134,94 @@ export function IsPostgres() {
   return window['go']['main']['App']['IsPostgres']();
 }
 
+export function OpenConnection(arg1) {
+  return window['go']['main']['App']['OpenConnection'](arg1);
+}
+
+export function OpenDemo() {
+  return window['go']['main']['App']['OpenDemo']();
+}
+
+export function OpenDirectory(arg1) {
+  return window['go']['main']['App']['OpenDirectory'](arg1);
+}
+
+export function OpenInEditor(arg1) {
+  return window['go']['main']['App']['OpenInEditor'](arg1);
+}
+
+export function ReconnectLast() {
+  return window['go']['main']['App']['ReconnectLast']();
+}
+
+export function ReconnectRecent(arg1, arg2) {
+  return window['go']['main']['App']['ReconnectRecent'](arg1, arg2);
+}
+
+export function RefreshSchema() {
+  return window['go']['main']['App']['RefreshSchema']();
+}
+
+export function RejectEnum(arg1, arg2) {
+  return window['go']['main']['App']['RejectEnum'](arg1, arg2);
+}
+
+export function RemoveRecentConnection(arg1) {
+  return window['go']['main']['App']['RemoveRecentConnection'](arg1);
+}
+
+export function RestoreFile(arg1, arg2) {
+  return window['go']['main']['App']['RestoreFile'](arg1, arg2);
+}
+
 export function SaveAllToDirectory(arg1) {
   return window['go']['main']['App']['SaveAllToDirectory'](arg1);
 }
 
+export function SaveCodeAs(arg1) {
+  return window['go']['main']['App']['SaveCodeAs'](arg1);
+}
+
 export function SaveCodeToFile(arg1, arg2) {
   return window['go']['main']['App']['SaveCodeToFile'](arg1, arg2);
 }
 
+export function SavePresetToDirectory(arg1, arg2) {
+  return window['go']['main']['App']['SavePresetToDirectory'](arg1, arg2);
+}
+
 export function SaveSelectedToDirectory(arg1, arg2) {
   return window['go']['main']['App']['SaveSelectedToDirectory'](arg1, arg2);
 }
 
+export function SelectOutputDirectory() {
+  return window['go']['main']['App']['SelectOutputDirectory']();
+}
+
+export function SetExcludedColumns(arg1, arg2) {
+  return window['go']['main']['App']['SetExcludedColumns'](arg1, arg2);
+}
+
+export function SetLocale(arg1) {
+  return window['go']['main']['App']['SetLocale'](arg1);
+}
+
 export function SetSchema(arg1) {
   return window['go']['main']['App']['SetSchema'](arg1);
 }
+
+export function SetTableOverride(arg1, arg2) {
+  return window['go']['main']['App']['SetTableOverride'](arg1, arg2);
+}
+
+export function SetTablePrefix(arg1) {
+  return window['go']['main']['App']['SetTablePrefix'](arg1);
+}
+
+export function SuggestEnums(arg1) {
+  return window['go']['main']['App']['SuggestEnums'](arg1);
+}
+
+export function SwitchConnection(arg1) {
+  return window['go']['main']['App']['SwitchConnection'](arg1);
+}
@@ -1,5 +1,25 @@
 export namespace config {
 	
+	export class ColumnOverride {
+	    Type: string;
+	    Import: string;
+	    Tags: string;
+	    Enum: string[];
+	    NoEnum: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ColumnOverride(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.Type = source["Type"];
+	        this.Import = source["Import"];
+	        this.Tags = source["Tags"];
+	        this.Enum = source["Enum"];
+	        this.NoEnum = source["NoEnum"];
+	    }
+	}
 	export class DBConfig {
 	    Host: string;
 	    Port: number;
@@ -7,6 +27,11 @@ export namespace config {
 	    Password: string;
 	    DBName: string;
 	    Driver: string;
+	    QueryTimeout: number;
+	    DDLFile: string;
+	    Vitess: boolean;
+	    InferForeignKeys: boolean;
+	    SampleSize: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new DBConfig(source);
@@ -20,6 +45,261 @@ export namespace config {
 	        this.Password = source["Password"];
 	        this.DBName = source["DBName"];
 	        this.Driver = source["Driver"];
+	        this.QueryTimeout = source["QueryTimeout"];
+	        this.DDLFile = source["DDLFile"];
+	        this.Vitess = source["Vitess"];
+	        this.InferForeignKeys = source["InferForeignKeys"];
+	        this.SampleSize = source["SampleSize"];
+	    }
+	}
+	export class GeneratedFile {
+	    path: string;
+	    created: boolean;
+	    backup?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GeneratedFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.created = source["created"];
+	        this.backup = source["backup"];
+	    }
+	}
+	export class GenerationRun {
+	    id: string;
+	    // Go type: time
+	    time: any;
+	    tables: string[];
+	    files: GeneratedFile[];
+	
+	    static createFrom(source: any = {}) {
+	        return new GenerationRun(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.time = this.convertValues(source["time"], null);
+	        this.tables = source["tables"];
+	        this.files = this.convertValues(source["files"], GeneratedFile);
+	    }
+	
+	convertValues(a: any, classs: any, asMap: boolean = false): any {
+	    if (!a) {
+	        return a;
+	    }
+	    if (a.slice && a.map) {
+	        return (a as any[]).map(elem => this.convertValues(elem, classs));
+	    } else if ("object" === typeof a) {
+	        if (asMap) {
+	            for (const key of Object.keys(a)) {
+	                a[key] = new classs(a[key]);
+	            }
+	            return a;
+	        }
+	        return new classs(a);
+	    }
+	    return a;
+	}
+	}
+	export class RecentConnection {
+	    host: string;
+	    port: number;
+	    user: string;
+	    dbname: string;
+	    driver: string;
+	    queryTimeout?: number;
+	    // Go type: time
+	    lastUsed: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecentConnection(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.host = source["host"];
+	        this.port = source["port"];
+	        this.user = source["user"];
+	        this.dbname = source["dbname"];
+	        this.driver = source["driver"];
+	        this.queryTimeout = source["queryTimeout"];
+	        this.lastUsed = this.convertValues(source["lastUsed"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TableOverride {
+	    ExcludeColumns: string[];
+	    StructName: string;
+	    Package: string;
+	    FileName: string;
+	    PrimaryKey: string[];
+	    Columns: Record<string, ColumnOverride>;
+	
+	    static createFrom(source: any = {}) {
+	        return new TableOverride(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ExcludeColumns = source["ExcludeColumns"];
+	        this.StructName = source["StructName"];
+	        this.Package = source["Package"];
+	        this.FileName = source["FileName"];
+	        this.PrimaryKey = source["PrimaryKey"];
+	        this.Columns = this.convertValues(source["Columns"], ColumnOverride, true);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace database {
+	
+	export class ValueCount {
+	    value: string;
+	    count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ValueCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.count = source["count"];
+	    }
+	}
+	export class ColumnStats {
+	    sampledRows: number;
+	    complete: boolean;
+	    nullCount: number;
+	    nullRatio: number;
+	    distinctCount: number;
+	    topValues: ValueCount[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ColumnStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sampledRows = source["sampledRows"];
+	        this.complete = source["complete"];
+	        this.nullCount = source["nullCount"];
+	        this.nullRatio = source["nullRatio"];
+	        this.distinctCount = source["distinctCount"];
+	        this.topValues = this.convertValues(source["topValues"], ValueCount);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SchemaChange {
+	    added: string[];
+	    removed: string[];
+	    changed: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new SchemaChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.added = source["added"];
+	        this.removed = source["removed"];
+	        this.changed = source["changed"];
+	    }
+	}
+	export class TableStats {
+	    rows: number;
+	    bytes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TableStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rows = source["rows"];
+	        this.bytes = source["bytes"];
+	    }
+	}
+
+}
+
+export namespace generator {
+	
+	export class EnumSuggestion {
+	    column: string;
+	    typeName: string;
+	    values: string[];
+	    sampledRows: number;
+	    complete: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new EnumSuggestion(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.column = source["column"];
+	        this.typeName = source["typeName"];
+	        this.values = source["values"];
+	        this.sampledRows = source["sampledRows"];
+	        this.complete = source["complete"];
 	    }
 	}
 
@@ -27,6 +307,38 @@ export namespace config {
 
 export namespace main {
 	
+	export class CodePreviewBatch {
+	    code: Record<string, string>;
+	    errors?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new CodePreviewBatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.errors = source["errors"];
+	    }
+	}
+	export class CodePreview {
+	    code: string;
+	    path: string;
+	    exists: boolean;
+	    diff: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CodePreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.code = source["code"];
+	        this.path = source["path"];
+	        this.exists = source["exists"];
+	        this.diff = source["diff"];
+	    }
+	}
 	export class ColumnInfo {
 	    name: string;
 	    dataType: string;
@@ -38,6 +350,7 @@ export namespace main {
 	    defaultValue?: string;
 	    enumValues?: string[];
 	    comment?: string;
+	    excluded: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new ColumnInfo(source);
@@ -55,14 +368,19 @@ export namespace main {
 	        this.defaultValue = source["defaultValue"];
 	        this.enumValues = source["enumValues"];
 	        this.comment = source["comment"];
+	        this.excluded = source["excluded"];
 	    }
 	}
 	export class ConnectionStatus {
+	    id: string;
+	    active: boolean;
 	    connected: boolean;
 	    driver: string;
 	    host: string;
 	    databaseName: string;
+	    reconnecting: boolean;
 	    error?: string;
+	    hint?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ConnectionStatus(source);
@@ -70,11 +388,15 @@ export namespace main {
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.active = source["active"];
 	        this.connected = source["connected"];
 	        this.driver = source["driver"];
 	        this.host = source["host"];
 	        this.databaseName = source["databaseName"];
+	        this.reconnecting = source["reconnecting"];
 	        this.error = source["error"];
+	        this.hint = source["hint"];
 	    }
 	}
 
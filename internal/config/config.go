@@ -3,7 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -16,20 +18,237 @@ type DBConfig struct {
 	Password string `yaml:"password" mapstructure:"password"`
 	DBName   string `yaml:"dbname" mapstructure:"dbname"`
 	Driver   string `yaml:"driver" mapstructure:"driver"`
+	// QueryTimeout is the per-query introspection timeout in seconds (0 uses DefaultQueryTimeout)
+	QueryTimeout int `yaml:"query_timeout" mapstructure:"query_timeout"`
+	// DDLFile reads the schema from a schema-only SQL dump instead of connecting
+	DDLFile string `yaml:"ddl_file" mapstructure:"ddl_file"`
+	// Vitess adapts MySQL introspection to Vitess and PlanetScale keyspaces
+	Vitess bool `yaml:"vitess" mapstructure:"vitess"`
+	// InferForeignKeys adds foreign keys implied by <table>_id columns
+	// (MySQL and Vitess), for schemas that declare none
+	InferForeignKeys bool `yaml:"infer_foreign_keys" mapstructure:"infer_foreign_keys"`
+	// SampleSize is the number of documents sampled per MongoDB collection,
+	// or rows per CSV file, to infer its fields (0 uses DefaultSampleSize)
+	SampleSize int `yaml:"sample_size" mapstructure:"sample_size"`
 }
 
+// DefaultQueryTimeout is the introspection query timeout in seconds used when none is configured
+const DefaultQueryTimeout = 30
+
+// DefaultSampleSize is the number of documents or rows sampled when none is configured
+const DefaultSampleSize = 100
+
 // GeneratorConfig holds generator-specific options
 type GeneratorConfig struct {
-	Tables    string `yaml:"tables" mapstructure:"tables"`
-	OutputDir string `yaml:"output_dir" mapstructure:"output_dir"`
+	Tables       string `yaml:"tables" mapstructure:"tables"`
+	OutputDir    string `yaml:"output_dir" mapstructure:"output_dir"`
+	PackageName  string `yaml:"package" mapstructure:"package"`
+	NullStrategy string `yaml:"null_strategy" mapstructure:"null_strategy"`
+	TagStyle     string `yaml:"tag_style" mapstructure:"tag_style"`
+	// Relations selects the association fields to emit: none, belongs_to or all
+	Relations string `yaml:"relations" mapstructure:"relations"`
+	// InferRelations adds association fields for <singular_table>_id
+	// columns without a declared foreign key (turns on belongs_to relations
+	// when relations is none)
+	InferRelations bool `yaml:"infer_relations" mapstructure:"infer_relations"`
+	// Hstore selects the Go type for PostgreSQL hstore columns: pgtype or map
+	Hstore string `yaml:"hstore" mapstructure:"hstore"`
+	// Vector selects the Go type for pgvector columns: pgvector or float32
+	Vector string `yaml:"vector" mapstructure:"vector"`
+	// Spatial selects the Go type for MySQL spatial columns: wkb or orb
+	Spatial string `yaml:"spatial" mapstructure:"spatial"`
+	// Bit selects the Go type for MySQL BIT(n>1) columns: bytes or uint64
+	Bit string `yaml:"bit" mapstructure:"bit"`
+	// DateTime selects the Go type for date-time columns without a time zone: time, local or string
+	DateTime string `yaml:"datetime" mapstructure:"datetime"`
+	// FieldOrder orders struct fields: ordinal, pk_first, alphabetical or grouped
+	FieldOrder string `yaml:"field_order" mapstructure:"field_order"`
+	// NoPrimaryKey selects how tables without a primary key are generated:
+	// generate, skip or read_only
+	NoPrimaryKey string `yaml:"no_primary_key" mapstructure:"no_primary_key"`
+	// Style selects how much code is generated per table: model (structs
+	// only) or full (also CRUD functions and relationship loaders)
+	Style string `yaml:"style" mapstructure:"style"`
+	// MaxLineWidth is the width generated struct field lines should fit in
+	// (0 for no limit): over-long lines drop a type: option GORM would infer
+	// anyway and move trailing comments above the field
+	MaxLineWidth int `yaml:"max_line_width" mapstructure:"max_line_width"`
+	// FilePattern is the template for model file names, e.g. {{.Table}}.gen.go
+	FilePattern string `yaml:"file_pattern" mapstructure:"file_pattern"`
+	// BuildTag is a build constraint added as //go:build to every generated file
+	BuildTag string `yaml:"build_tag" mapstructure:"build_tag"`
+	// RelationRules restricts association fields per table, keyed by table name
+	RelationRules map[string]RelationRule `yaml:"relation_rules" mapstructure:"relation_rules"`
+	// Overrides customizes individual tables, keyed by table name (project config)
+	Overrides map[string]TableOverride `yaml:"overrides" mapstructure:"overrides"`
+	// Template is a custom struct template file replacing the built-in one
+	Template string `yaml:"template" mapstructure:"template"`
+	// Header is prepended and Footer appended to every generated Go file.
+	// Both are templates over the struct template data; lines that are not
+	// already comments are commented out.
+	Header string `yaml:"header" mapstructure:"header"`
+	Footer string `yaml:"footer" mapstructure:"footer"`
+	// GoGenerate adds a //go:generate line re-running godb-orm for the table
+	GoGenerate bool `yaml:"go_generate" mapstructure:"go_generate"`
+	// Hooks emits a BeforeCreate hook assigning uuid.New() to UUID primary
+	// keys without a database default
+	Hooks bool `yaml:"hooks" mapstructure:"hooks"`
+	// Scopes emits a TenantScope scope for tables with the tenant column
+	Scopes bool `yaml:"scopes" mapstructure:"scopes"`
+	// TenantColumn is the column scoped by TenantScope (default tenant_id)
+	TenantColumn string `yaml:"tenant_column" mapstructure:"tenant_column"`
+	// WithTx emits a WithTx transaction helper per model
+	WithTx bool `yaml:"with_tx" mapstructure:"with_tx"`
+	// Repositories emits a <Model>Repository interface with a GORM
+	// implementation per model, for dependency-injected services
+	Repositories bool `yaml:"repositories" mapstructure:"repositories"`
+	// MockTool is the mock generator the go:generate line above each
+	// repository runs: mockery (default) or mockgen
+	MockTool string `yaml:"mock_tool" mapstructure:"mock_tool"`
+	// Factories emits a New<Model> constructor with a functional option per
+	// column, starting from the column defaults
+	Factories bool `yaml:"factories" mapstructure:"factories"`
+	// PrivateFields emits unexported fields with exported getters and
+	// setters and a ToMap() method, for encapsulated domain models
+	PrivateFields bool `yaml:"private_fields" mapstructure:"private_fields"`
+	// Filters emits a <Model>Filter struct with a pointer field per column
+	// and an Apply(*gorm.DB) method adding the set fields as WHERE clauses
+	Filters bool `yaml:"filters" mapstructure:"filters"`
+	// Pagination emits a List<Models>(db, page, size, filter) helper
+	// returning the page's rows and the total count; implies Filters
+	Pagination bool `yaml:"pagination" mapstructure:"pagination"`
+	// Swagger documents models for swaggo: a "<Model> model" comment and
+	// format, example and swaggertype tags that swag init understands
+	Swagger bool `yaml:"swagger" mapstructure:"swagger"`
+	// GormTag selects the optional gorm tag options to emit: column, type,
+	// default, not_null, size, precision and comment (default the first four)
+	GormTag []string `yaml:"gorm_tag" mapstructure:"gorm_tag"`
+	// ExtraTags adds struct tag sets after the JSON tag: xml, yaml,
+	// mapstructure or bson, each optionally with its own naming policy
+	// (e.g., yaml:camel; default the JSON tag style)
+	ExtraTags []string `yaml:"extra_tags" mapstructure:"extra_tags"`
+	// Sensitive lists column patterns (e.g., *password*, users.ssn) whose
+	// fields are tagged json:"-" so they never leak through API responses
+	Sensitive []string `yaml:"sensitive" mapstructure:"sensitive"`
+	// SensitiveWriteOnly also tags sensitive columns gorm:"->:false", so
+	// they are written but never read back from the database
+	SensitiveWriteOnly bool `yaml:"sensitive_write_only" mapstructure:"sensitive_write_only"`
+	// AutoCreateTime and AutoUpdateTime name the audit columns tagged
+	// autoCreateTime/autoUpdateTime (default created_at and updated_at; an
+	// empty list disables the tag)
+	AutoCreateTime []string `yaml:"auto_create_time" mapstructure:"auto_create_time"`
+	AutoUpdateTime []string `yaml:"auto_update_time" mapstructure:"auto_update_time"`
+	// Subpackages route the models of matching tables into subdirectories
+	// of the output directory, each with its own package (project config)
+	Subpackages []SubpackageRule `yaml:"subpackages" mapstructure:"subpackages"`
+	// TypeRules map database types to Go types, taking precedence over the
+	// built-in mappings (e.g., for extension types)
+	TypeRules []TypeRule `yaml:"type_rules" mapstructure:"type_rules"`
+	// Plugins are external generators run after the Go models (project config)
+	Plugins []PluginConfig `yaml:"plugins" mapstructure:"plugins"`
+}
+
+// TypeRule maps database types matching a regular expression to a Go type
+type TypeRule struct {
+	Match  string `yaml:"match" mapstructure:"match"`   // Regexp matched against the lowercase raw type, e.g. ^hstore$
+	Type   string `yaml:"type" mapstructure:"type"`     // Go type, e.g. pgtype.Hstore
+	Import string `yaml:"import" mapstructure:"import"` // Import path required by Type, if any
+}
+
+// SubpackageRule routes tables whose name matches a glob pattern into a
+// subdirectory of the output directory
+type SubpackageRule struct {
+	Match   string `yaml:"match" mapstructure:"match"`     // Glob matched against the table name, e.g. billing_*
+	Dir     string `yaml:"dir" mapstructure:"dir"`         // Directory relative to the output directory, e.g. billing
+	Package string `yaml:"package" mapstructure:"package"` // Package name (defaults to the last element of Dir)
+}
+
+// Matches reports whether the rule applies to a table. The pattern uses
+// path.Match syntax and is matched case-insensitively.
+func (r SubpackageRule) Matches(table string) bool {
+	matched, err := path.Match(strings.ToLower(r.Match), strings.ToLower(table))
+	return err == nil && matched
+}
+
+// PackageName returns the package name of the rule's directory
+func (r SubpackageRule) PackageName() string {
+	if r.Package != "" {
+		return r.Package
+	}
+	return path.Base(filepath.ToSlash(r.Dir))
+}
+
+// PluginConfig configures an external generator plugin
+type PluginConfig struct {
+	Name      string `yaml:"name" mapstructure:"name"`           // Runs godb-orm-gen-<name> from PATH
+	Path      string `yaml:"path" mapstructure:"path"`           // Explicit executable, overriding the PATH lookup
+	Out       string `yaml:"out" mapstructure:"out"`             // Output directory (defaults to the models directory)
+	Parameter string `yaml:"parameter" mapstructure:"parameter"` // Free-form option string passed to the plugin
+}
+
+// RelationRule restricts the relations emitted for one table. Entries match
+// either the related table name or the generated field name.
+type RelationRule struct {
+	Allow []string `yaml:"allow" mapstructure:"allow"` // If set, only these relations are emitted
+	Deny  []string `yaml:"deny" mapstructure:"deny"`   // These relations are never emitted
+}
+
+// Permits reports whether a relation to relatedTable named fieldName may be emitted
+func (r RelationRule) Permits(relatedTable, fieldName string) bool {
+	matches := func(list []string) bool {
+		for _, entry := range list {
+			if entry == relatedTable || entry == fieldName {
+				return true
+			}
+		}
+		return false
+	}
+
+	if matches(r.Deny) {
+		return false
+	}
+	return len(r.Allow) == 0 || matches(r.Allow)
+}
+
+// NamingConfig holds options for naming generated structs and files
+type NamingConfig struct {
+	// TablePrefix is shared by every table (e.g., wp_) and left out of
+	// struct and file names; TableName() keeps the full name
+	TablePrefix string `yaml:"table_prefix" mapstructure:"table_prefix"`
+	// Acronyms are upcased in Go names besides the built-in ones (ID, URL,
+	// API, ...), spelled as given (SKU, VAT, OAuth); -NAME removes a
+	// built-in one
+	Acronyms []string `yaml:"acronyms" mapstructure:"acronyms"`
+	// DisableAcronyms leaves Go names in plain PascalCase (UserId, ApiUrl)
+	DisableAcronyms bool `yaml:"disable_acronyms" mapstructure:"disable_acronyms"`
+}
+
+// TelemetryConfig holds options for exporting traces and metrics of
+// introspection and generation runs over OTLP
+type TelemetryConfig struct {
+	// OTLPEndpoint is the OTLP/HTTP base URL of a collector, e.g.
+	// http://localhost:4318; empty falls back to OTEL_EXPORTER_OTLP_ENDPOINT
+	// and disables telemetry if that is unset too
+	OTLPEndpoint string `yaml:"otlp_endpoint" mapstructure:"otlp_endpoint"`
+	// ServiceName is the service.name resource attribute (default godb-orm)
+	ServiceName string `yaml:"service_name" mapstructure:"service_name"`
+	// Headers are sent with every export, e.g. a collector API key
+	Headers map[string]string `yaml:"headers" mapstructure:"headers"`
 }
 
 // Config holds the complete application configuration
 type Config struct {
 	Database  DBConfig        `yaml:"database" mapstructure:"database"`
 	Generator GeneratorConfig `yaml:"generator" mapstructure:"generator"`
+	Naming    NamingConfig    `yaml:"naming" mapstructure:"naming"`
+	Telemetry TelemetryConfig `yaml:"telemetry" mapstructure:"telemetry"`
+	// Presets are named selections of tables and generator options, by name
+	Presets map[string]Preset `yaml:"presets" mapstructure:"presets"`
 }
 
+// ProjectConfigFile is the name of the per-project config file looked up in the working directory
+const ProjectConfigFile = ".godb-orm.yaml"
+
 // configDir returns the configuration directory path
 func configDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
@@ -48,25 +267,20 @@ func configFilePath() (string, error) {
 	return filepath.Join(dir, "config.yaml"), nil
 }
 
-// SaveConfig saves the configuration to ~/.godb-orm/config.yaml
+// SaveConfig saves the configuration to ~/.godb-orm/config.yaml. Only the
+// keys below are written; the other settings of the file (acronyms,
+// telemetry, presets, ...) are kept as they are.
 func SaveConfig(cfg *Config) error {
-	dir, err := configDir()
-	if err != nil {
-		return err
-	}
-
-	// Create config directory if not exists
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create config directory: %w", err)
-	}
-
-	configPath, err := configFilePath()
+	settings, err := readGlobalSettings()
 	if err != nil {
 		return err
 	}
 
 	v := viper.New()
 	v.SetConfigType("yaml")
+	if err := v.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to merge config: %w", err)
+	}
 
 	// Set values
 	v.Set("database.host", cfg.Database.Host)
@@ -75,15 +289,30 @@ func SaveConfig(cfg *Config) error {
 	v.Set("database.password", cfg.Database.Password)
 	v.Set("database.dbname", cfg.Database.DBName)
 	v.Set("database.driver", cfg.Database.Driver)
+	v.Set("database.query_timeout", cfg.Database.QueryTimeout)
 	v.Set("generator.tables", cfg.Generator.Tables)
 	v.Set("generator.output_dir", cfg.Generator.OutputDir)
-
-	// Write config file
-	if err := v.WriteConfigAs(configPath); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
+	v.Set("generator.package", cfg.Generator.PackageName)
+	v.Set("generator.null_strategy", cfg.Generator.NullStrategy)
+	v.Set("generator.tag_style", cfg.Generator.TagStyle)
+	v.Set("generator.relations", cfg.Generator.Relations)
+	v.Set("generator.hstore", cfg.Generator.Hstore)
+	v.Set("generator.vector", cfg.Generator.Vector)
+	v.Set("generator.spatial", cfg.Generator.Spatial)
+	v.Set("generator.bit", cfg.Generator.Bit)
+	v.Set("generator.datetime", cfg.Generator.DateTime)
+	v.Set("generator.field_order", cfg.Generator.FieldOrder)
+	v.Set("generator.no_primary_key", cfg.Generator.NoPrimaryKey)
+	v.Set("generator.style", cfg.Generator.Style)
+	v.Set("generator.max_line_width", cfg.Generator.MaxLineWidth)
+	v.Set("generator.file_pattern", cfg.Generator.FilePattern)
+	v.Set("generator.build_tag", cfg.Generator.BuildTag)
+	v.Set("naming.table_prefix", cfg.Naming.TablePrefix)
+	if len(cfg.Generator.RelationRules) > 0 {
+		v.Set("generator.relation_rules", cfg.Generator.RelationRules)
 	}
 
-	return nil
+	return writeGlobalSettings(v.AllSettings())
 }
 
 // LoadConfig loads the configuration from ~/.godb-orm/config.yaml
@@ -115,18 +344,114 @@ func LoadConfig() (*Config, error) {
 	return &cfg, nil
 }
 
+// LoadEffectiveConfig loads the configuration from every source, with the
+// following precedence (highest first):
+//
+//  1. environment variables (GODB_HOST, GODB_PASSWORD, ...), including a local .env file
+//  2. project config (./.godb-orm.yaml)
+//  3. global config (~/.godb-orm/config.yaml)
+//  4. built-in defaults
+//
+// Command-line flags take precedence over all of these and are applied by the caller.
+func LoadEffectiveConfig() (*Config, error) {
+	v, err := effectiveViper()
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// effectiveViper builds a viper instance layering defaults, global config,
+// project config and environment variables
+func effectiveViper() (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+
+	// Built-in defaults
+	defaults := DefaultConfig()
+	v.SetDefault("database.host", defaults.Database.Host)
+	v.SetDefault("database.port", defaults.Database.Port)
+	v.SetDefault("database.user", defaults.Database.User)
+	v.SetDefault("database.password", defaults.Database.Password)
+	v.SetDefault("database.dbname", defaults.Database.DBName)
+	v.SetDefault("database.driver", defaults.Database.Driver)
+	v.SetDefault("database.query_timeout", defaults.Database.QueryTimeout)
+	v.SetDefault("generator.tables", defaults.Generator.Tables)
+	v.SetDefault("generator.output_dir", defaults.Generator.OutputDir)
+	v.SetDefault("generator.package", defaults.Generator.PackageName)
+	v.SetDefault("generator.null_strategy", defaults.Generator.NullStrategy)
+	v.SetDefault("generator.tag_style", defaults.Generator.TagStyle)
+	v.SetDefault("generator.relations", defaults.Generator.Relations)
+	v.SetDefault("generator.hstore", defaults.Generator.Hstore)
+	v.SetDefault("generator.vector", defaults.Generator.Vector)
+	v.SetDefault("generator.spatial", defaults.Generator.Spatial)
+	v.SetDefault("generator.bit", defaults.Generator.Bit)
+	v.SetDefault("generator.datetime", defaults.Generator.DateTime)
+	v.SetDefault("generator.field_order", defaults.Generator.FieldOrder)
+	v.SetDefault("generator.no_primary_key", defaults.Generator.NoPrimaryKey)
+	v.SetDefault("generator.style", defaults.Generator.Style)
+	v.SetDefault("generator.max_line_width", defaults.Generator.MaxLineWidth)
+	v.SetDefault("generator.file_pattern", defaults.Generator.FilePattern)
+	v.SetDefault("generator.build_tag", defaults.Generator.BuildTag)
+	v.SetDefault("naming.table_prefix", defaults.Naming.TablePrefix)
+
+	// Global config, then project config on top
+	globalPath, err := configFilePath()
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range []string{globalPath, ProjectConfigFile} {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		v.SetConfigFile(path)
+		if err := v.MergeInConfig(); err != nil {
+			return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+		}
+	}
+
+	// Environment variables (a local .env file fills in unset variables)
+	if err := LoadDotEnv(DotEnvFile); err != nil {
+		return nil, err
+	}
+	if err := bindEnv(v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
 // DefaultConfig returns a default configuration
 func DefaultConfig() *Config {
 	return &Config{
 		Database: DBConfig{
-			Host:   "localhost",
-			Port:   3306,
-			User:   "root",
-			Driver: "mysql",
+			Host:         "localhost",
+			Port:         3306,
+			User:         "root",
+			Driver:       "mysql",
+			QueryTimeout: DefaultQueryTimeout,
 		},
 		Generator: GeneratorConfig{
-			Tables:    "*",
-			OutputDir: "./output",
+			Tables:       "*",
+			OutputDir:    "./output",
+			NullStrategy: "zero",
+			TagStyle:     "snake",
+			Relations:    "none",
+			Hstore:       "pgtype",
+			Vector:       "pgvector",
+			Spatial:      "wkb",
+			Bit:          "bytes",
+			DateTime:     "time",
+			FieldOrder:   "ordinal",
+			NoPrimaryKey: "generate",
+			Style:        "model",
+			FilePattern:  "{{.Table}}.go",
 		},
 	}
 }
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSaveConfigKeepsOtherSettings(t *testing.T) {
+	chdir(t, t.TempDir())
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	writeFile(t, filepath.Join(home, ".godb-orm", "config.yaml"), `naming:
+  acronyms: [SKU, VAT]
+generator:
+  hooks: true
+  sensitive: [password_hash]
+telemetry:
+  otlp_endpoint: http://localhost:4318
+  service_name: models
+presets:
+  api-models:
+    tables: [users]
+`)
+
+	cfg := DefaultConfig()
+	cfg.Database.DBName = "shop"
+	if err := SaveConfig(cfg); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+
+	saved, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if saved.Database.DBName != "shop" {
+		t.Errorf("DBName = %q; want the saved value", saved.Database.DBName)
+	}
+	if !reflect.DeepEqual(saved.Naming.Acronyms, []string{"SKU", "VAT"}) {
+		t.Errorf("Acronyms = %v; want [SKU VAT] kept", saved.Naming.Acronyms)
+	}
+	if !saved.Generator.Hooks {
+		t.Error("generator.hooks lost")
+	}
+	if !reflect.DeepEqual(saved.Generator.Sensitive, []string{"password_hash"}) {
+		t.Errorf("Sensitive = %v; want [password_hash] kept", saved.Generator.Sensitive)
+	}
+	if saved.Telemetry.OTLPEndpoint != "http://localhost:4318" || saved.Telemetry.ServiceName != "models" {
+		t.Errorf("Telemetry = %+v; want the endpoint and service name kept", saved.Telemetry)
+	}
+	if !reflect.DeepEqual(saved.Presets["api-models"].Tables, []string{"users"}) {
+		t.Errorf("Presets = %+v; want api-models kept", saved.Presets)
+	}
+}
+
+func TestSaveConnectionSkipsEnvPassword(t *testing.T) {
+	chdir(t, t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GODB_PASSWORD", "topsecret")
+
+	cfg := DBConfig{Host: "db", Port: 5432, User: "app", Password: "topsecret", DBName: "shop", Driver: "postgres"}
+	if err := SaveConnection(cfg); err != nil {
+		t.Fatalf("SaveConnection() error = %v", err)
+	}
+	saved, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if saved.Database.Password != "" {
+		t.Errorf("Password = %q; want the environment password left out", saved.Database.Password)
+	}
+	if saved.Database.Host != "db" || saved.Database.Port != 5432 || saved.Database.DBName != "shop" {
+		t.Errorf("Database = %+v; want the connection saved", saved.Database)
+	}
+
+	// A password typed in the form is remembered
+	cfg.Password = "typed"
+	if err := SaveConnection(cfg); err != nil {
+		t.Fatalf("SaveConnection() error = %v", err)
+	}
+	if saved, _ := LoadConfig(); saved.Database.Password != "typed" {
+		t.Errorf("Password = %q; want typed", saved.Database.Password)
+	}
+}
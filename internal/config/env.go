@@ -0,0 +1,119 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix is the prefix for environment variables (e.g., GODB_HOST, GODB_PASSWORD)
+const EnvPrefix = "GODB"
+
+// DotEnvFile is the name of the local .env file read from the working directory
+const DotEnvFile = ".env"
+
+// envBindings maps configuration keys to their environment variable names
+var envBindings = map[string]string{
+	"database.host":            EnvPrefix + "_HOST",
+	"database.port":            EnvPrefix + "_PORT",
+	"database.user":            EnvPrefix + "_USER",
+	"database.password":        EnvPrefix + "_PASSWORD",
+	"database.dbname":          EnvPrefix + "_DBNAME",
+	"database.driver":          EnvPrefix + "_DRIVER",
+	"database.query_timeout":   EnvPrefix + "_QUERY_TIMEOUT",
+	"generator.tables":         EnvPrefix + "_TABLES",
+	"generator.output_dir":     EnvPrefix + "_OUTPUT_DIR",
+	"generator.package":        EnvPrefix + "_PACKAGE",
+	"generator.null_strategy":  EnvPrefix + "_NULL_STRATEGY",
+	"generator.tag_style":      EnvPrefix + "_TAG_STYLE",
+	"generator.relations":      EnvPrefix + "_RELATIONS",
+	"generator.hstore":         EnvPrefix + "_HSTORE",
+	"generator.vector":         EnvPrefix + "_VECTOR",
+	"generator.spatial":        EnvPrefix + "_SPATIAL",
+	"generator.bit":            EnvPrefix + "_BIT",
+	"generator.datetime":       EnvPrefix + "_DATETIME",
+	"generator.field_order":    EnvPrefix + "_FIELD_ORDER",
+	"generator.no_primary_key": EnvPrefix + "_NO_PRIMARY_KEY",
+	"generator.style":          EnvPrefix + "_STYLE",
+	"generator.max_line_width": EnvPrefix + "_MAX_LINE_WIDTH",
+	"generator.file_pattern":   EnvPrefix + "_FILE_PATTERN",
+	"generator.build_tag":      EnvPrefix + "_BUILD_TAG",
+	"naming.table_prefix":      EnvPrefix + "_TABLE_PREFIX",
+}
+
+// bindEnv binds every known configuration key to its environment variable
+func bindEnv(v *viper.Viper) error {
+	for key, env := range envBindings {
+		if err := v.BindEnv(key, env); err != nil {
+			return fmt.Errorf("failed to bind %s: %w", env, err)
+		}
+	}
+	return nil
+}
+
+// LoadDotEnv reads KEY=VALUE pairs from a .env file and exports them to the
+// process environment. Variables that are already set are left untouched, so
+// the real environment always wins over the file. A missing file is not an error.
+func LoadDotEnv(path string) error {
+	values, err := parseDotEnv(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	for key, value := range values {
+		if _, exists := os.LookupEnv(key); exists {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// parseDotEnv parses a .env file into a map. Blank lines, comments and an
+// optional "export " prefix are supported; values may be single or double quoted.
+func parseDotEnv(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		} else if idx := strings.Index(value, " #"); idx != -1 {
+			// Strip trailing comments from unquoted values
+			value = strings.TrimSpace(value[:idx])
+		}
+
+		values[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// chdir switches into dir for the duration of the test
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseDotEnv(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	writeFile(t, path, `# comment
+GODB_HOST=db.local
+export GODB_USER=admin
+GODB_PASSWORD="s3cr3t # not a comment"
+GODB_DBNAME=app # trailing comment
+
+INVALID_LINE
+`)
+
+	values, err := parseDotEnv(path)
+	if err != nil {
+		t.Fatalf("parseDotEnv() error = %v", err)
+	}
+
+	expected := map[string]string{
+		"GODB_HOST":     "db.local",
+		"GODB_USER":     "admin",
+		"GODB_PASSWORD": "s3cr3t # not a comment",
+		"GODB_DBNAME":   "app",
+	}
+	if len(values) != len(expected) {
+		t.Errorf("parseDotEnv() = %v; want %v", values, expected)
+	}
+	for key, want := range expected {
+		if got := values[key]; got != want {
+			t.Errorf("parseDotEnv()[%q] = %q; want %q", key, got, want)
+		}
+	}
+}
+
+func TestLoadEffectiveConfig_Precedence(t *testing.T) {
+	home := t.TempDir()
+	project := t.TempDir()
+	t.Setenv("HOME", home)
+	chdir(t, project)
+
+	writeFile(t, filepath.Join(home, ".godb-orm", "config.yaml"), `database:
+  host: global-host
+  user: global-user
+  dbname: global-db
+  driver: mysql
+generator:
+  output_dir: ./global
+`)
+	writeFile(t, filepath.Join(project, ProjectConfigFile), `database:
+  user: project-user
+  dbname: project-db
+`)
+	writeFile(t, filepath.Join(project, DotEnvFile), "GODB_DBNAME=dotenv-db\nGODB_PASSWORD=dotenv-pass\n")
+	t.Setenv("GODB_PASSWORD", "env-pass")
+	t.Setenv("GODB_DBNAME", "")
+	os.Unsetenv("GODB_DBNAME")
+
+	cfg, err := LoadEffectiveConfig()
+	if err != nil {
+		t.Fatalf("LoadEffectiveConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"global only", cfg.Database.Host, "global-host"},
+		{"project over global", cfg.Database.User, "project-user"},
+		{".env over project", cfg.Database.DBName, "dotenv-db"},
+		{"env over .env", cfg.Database.Password, "env-pass"},
+		{"global generator", cfg.Generator.OutputDir, "./global"},
+		{"default", cfg.Generator.Tables, "*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %q; want %q", tt.got, tt.expected)
+			}
+		})
+	}
+}
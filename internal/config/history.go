@@ -0,0 +1,233 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// MaxGenerationRuns is the number of generation runs kept in the history.
+// The backups of older runs are deleted.
+const MaxGenerationRuns = 50
+
+// historyFileName is the generation history stored next to the global config
+const historyFileName = "history.json"
+
+// backupsDirName holds the prior content of overwritten files, one
+// directory per generation run
+const backupsDirName = "backups"
+
+// GenerationRun is a generation from the GUI: the tables generated and the
+// files written
+type GenerationRun struct {
+	ID     string          `json:"id"`
+	Time   time.Time       `json:"time"`
+	Tables []string        `json:"tables"`
+	Files  []GeneratedFile `json:"files"`
+
+	previous map[string][]byte // Content before the run, by path, until backed up
+}
+
+// GeneratedFile is a file written by a generation run
+type GeneratedFile struct {
+	Path    string `json:"path"`             // Absolute path of the file
+	Created bool   `json:"created"`          // The file didn't exist before the run
+	Backup  string `json:"backup,omitempty"` // Copy of the content before the run
+}
+
+// NewGenerationRun starts a history entry for generating the given tables
+func NewGenerationRun(tables []string) *GenerationRun {
+	now := time.Now()
+	return &GenerationRun{
+		ID:       strconv.FormatInt(now.UnixNano(), 10),
+		Time:     now,
+		Tables:   tables,
+		previous: make(map[string][]byte),
+	}
+}
+
+// Record adds a file the run writes, given its content before the run
+// as returned by os.ReadFile; a read error means the run created the file
+func (r *GenerationRun) Record(path string, previous []byte, readErr error) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	for _, f := range r.Files {
+		if f.Path == path {
+			return
+		}
+	}
+
+	r.Files = append(r.Files, GeneratedFile{Path: path, Created: readErr != nil})
+	if readErr == nil {
+		r.previous[path] = previous
+	}
+}
+
+// historyFilePath returns the full path to the generation history file
+func historyFilePath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, historyFileName), nil
+}
+
+// backupsDir returns the directory holding the backups of a run
+func backupsDir(runID string) (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, backupsDirName, runID), nil
+}
+
+// LoadGenerationHistory returns the generation history, most recent first
+func LoadGenerationHistory() ([]GenerationRun, error) {
+	path, err := historyFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []GenerationRun{}, nil
+		}
+		return nil, fmt.Errorf("failed to read generation history: %w", err)
+	}
+
+	var history []GenerationRun
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse generation history: %w", err)
+	}
+	return history, nil
+}
+
+// BackUp writes the prior content of the recorded files to the run's
+// backup directory. Call it before overwriting the files: if it fails, they
+// must be left untouched, as the run couldn't be undone.
+func (r *GenerationRun) BackUp() error {
+	dir, err := backupsDir(r.ID)
+	if err != nil {
+		return err
+	}
+	for i := range r.Files {
+		f := &r.Files[i]
+		previous, ok := r.previous[f.Path]
+		if !ok {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return fmt.Errorf("failed to create backup directory: %w", err)
+		}
+		// Numbered, since files of different directories may share a name
+		f.Backup = filepath.Join(dir, strconv.Itoa(i)+"_"+filepath.Base(f.Path))
+		if err := os.WriteFile(f.Backup, previous, 0600); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", f.Path, err)
+		}
+		delete(r.previous, f.Path)
+	}
+	return nil
+}
+
+// DiscardBackups removes the backups of a run whose files were not
+// written after all
+func (r *GenerationRun) DiscardBackups() {
+	if dir, err := backupsDir(r.ID); err == nil {
+		os.RemoveAll(dir)
+	}
+}
+
+// SaveGenerationRun adds the run to the top of the history, backing up the
+// files not backed up yet by BackUp. Runs that wrote no files are not
+// recorded.
+func SaveGenerationRun(run *GenerationRun) error {
+	if len(run.Files) == 0 {
+		return nil
+	}
+	if err := run.BackUp(); err != nil {
+		return err
+	}
+
+	history, err := LoadGenerationHistory()
+	if err != nil {
+		// A corrupt history is replaced rather than blocking generation
+		history = nil
+	}
+
+	updated := append([]GenerationRun{*run}, history...)
+	if len(updated) > MaxGenerationRuns {
+		for _, old := range updated[MaxGenerationRuns:] {
+			if oldDir, err := backupsDir(old.ID); err == nil {
+				os.RemoveAll(oldDir)
+			}
+		}
+		updated = updated[:MaxGenerationRuns]
+	}
+
+	return saveGenerationHistory(updated)
+}
+
+// RestoreFile puts back the content a file had before a generation run. A
+// file the run created is removed.
+func RestoreFile(runID, path string) error {
+	history, err := LoadGenerationHistory()
+	if err != nil {
+		return err
+	}
+
+	for _, run := range history {
+		if run.ID != runID {
+			continue
+		}
+		for _, f := range run.Files {
+			if f.Path != path {
+				continue
+			}
+			if f.Created {
+				if err := os.Remove(f.Path); err != nil && !os.IsNotExist(err) {
+					return fmt.Errorf("failed to remove %s: %w", f.Path, err)
+				}
+				return nil
+			}
+
+			previous, err := os.ReadFile(f.Backup)
+			if err != nil {
+				return fmt.Errorf("failed to read backup of %s: %w", f.Path, err)
+			}
+			if err := os.MkdirAll(filepath.Dir(f.Path), 0755); err != nil {
+				return fmt.Errorf("failed to create directory: %w", err)
+			}
+			if err := os.WriteFile(f.Path, previous, 0644); err != nil {
+				return fmt.Errorf("failed to restore %s: %w", f.Path, err)
+			}
+			return nil
+		}
+		return fmt.Errorf("file %s was not written by generation run %s", path, runID)
+	}
+	return fmt.Errorf("no generation run %s", runID)
+}
+
+// saveGenerationHistory writes the history file
+func saveGenerationHistory(history []GenerationRun) error {
+	path, err := historyFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode generation history: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write generation history: %w", err)
+	}
+	return nil
+}
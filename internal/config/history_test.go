@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerationHistoryRestore(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	out := t.TempDir()
+
+	existing := filepath.Join(out, "user.go")
+	created := filepath.Join(out, "order.go")
+	if err := os.WriteFile(existing, []byte("// hand-edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := NewGenerationRun([]string{"users", "orders"})
+	for _, path := range []string{existing, created} {
+		previous, readErr := os.ReadFile(path)
+		if err := os.WriteFile(path, []byte("// generated\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		run.Record(path, previous, readErr)
+	}
+	if err := SaveGenerationRun(run); err != nil {
+		t.Fatalf("SaveGenerationRun() error = %v", err)
+	}
+
+	history, err := LoadGenerationHistory()
+	if err != nil {
+		t.Fatalf("LoadGenerationHistory() error = %v", err)
+	}
+	if len(history) != 1 || len(history[0].Files) != 2 {
+		t.Fatalf("history = %+v; want one run with two files", history)
+	}
+	if history[0].Files[0].Created || !history[0].Files[1].Created {
+		t.Errorf("files = %+v; want only order.go created", history[0].Files)
+	}
+
+	if err := RestoreFile(run.ID, existing); err != nil {
+		t.Fatalf("RestoreFile(%s) error = %v", existing, err)
+	}
+	if data, _ := os.ReadFile(existing); string(data) != "// hand-edited\n" {
+		t.Errorf("restored content = %q; want the content before the run", data)
+	}
+
+	// Restoring a file the run created removes it
+	if err := RestoreFile(run.ID, created); err != nil {
+		t.Fatalf("RestoreFile(%s) error = %v", created, err)
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Errorf("order.go still exists after restore, stat error = %v", err)
+	}
+
+	if err := RestoreFile("missing", existing); err == nil {
+		t.Error("RestoreFile() of an unknown run succeeded")
+	}
+}
+
+func TestGenerationHistoryTrimsOldRuns(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "user.go")
+	if err := os.WriteFile(path, []byte("v0"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var first *GenerationRun
+	for i := 0; i <= MaxGenerationRuns; i++ {
+		run := NewGenerationRun([]string{"users"})
+		run.ID = fmt.Sprintf("run-%d", i)
+		previous, readErr := os.ReadFile(path)
+		run.Record(path, previous, readErr)
+		if err := SaveGenerationRun(run); err != nil {
+			t.Fatalf("SaveGenerationRun() error = %v", err)
+		}
+		if first == nil {
+			first = run
+		}
+	}
+
+	history, _ := LoadGenerationHistory()
+	if len(history) != MaxGenerationRuns {
+		t.Errorf("len(history) = %d; want %d", len(history), MaxGenerationRuns)
+	}
+	if _, err := os.Stat(filepath.Dir(first.Files[0].Backup)); !os.IsNotExist(err) {
+		t.Errorf("backups of the oldest run still exist, stat error = %v", err)
+	}
+}
+
+func TestGenerationRunBackUpFails(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	// A file where the backups directory belongs makes every backup fail
+	writeFile(t, filepath.Join(home, ".godb-orm", backupsDirName), "")
+
+	path := filepath.Join(t.TempDir(), "user.go")
+	if err := os.WriteFile(path, []byte("// hand-edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := NewGenerationRun([]string{"users"})
+	previous, readErr := os.ReadFile(path)
+	run.Record(path, previous, readErr)
+	if err := run.BackUp(); err == nil {
+		t.Fatal("BackUp() succeeded; want an error")
+	}
+	if err := SaveGenerationRun(run); err == nil {
+		t.Error("SaveGenerationRun() succeeded without a backup")
+	}
+	if history, _ := LoadGenerationHistory(); len(history) != 0 {
+		t.Errorf("history = %+v; want no run recorded", history)
+	}
+}
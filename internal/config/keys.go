@@ -0,0 +1,283 @@
+package config
+
+import (
+	"fmt"
+	"go/build/constraint"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// keyValidators lists every key that can be managed with `godb-orm config`
+// together with a validator for its value (nil accepts any value)
+var keyValidators = map[string]func(string) error{
+	"database.host":            nil,
+	"database.port":            validatePort,
+	"database.user":            nil,
+	"database.password":        nil,
+	"database.dbname":          nil,
+	"database.driver":          oneOf("mysql", "postgres", "postgresql", "firebird", "db2", "trino", "duckdb", "mongodb", "mongo"),
+	"database.query_timeout":   validatePositiveInt,
+	"generator.tables":         nil,
+	"generator.output_dir":     nil,
+	"generator.package":        nil,
+	"generator.null_strategy":  oneOf("zero", "pointer"),
+	"generator.tag_style":      oneOf("snake", "camel"),
+	"generator.relations":      oneOf("none", "belongs_to", "all"),
+	"generator.hstore":         oneOf("pgtype", "map"),
+	"generator.vector":         oneOf("pgvector", "float32"),
+	"generator.spatial":        oneOf("wkb", "orb"),
+	"generator.bit":            oneOf("bytes", "uint64"),
+	"generator.datetime":       oneOf("time", "local", "string"),
+	"generator.field_order":    oneOf("ordinal", "pk_first", "alphabetical", "grouped"),
+	"generator.no_primary_key": oneOf("generate", "skip", "read_only"),
+	"generator.style":          oneOf("model", "full"),
+	"generator.max_line_width": validateNonNegativeInt,
+	"generator.file_pattern":   validateFilePattern,
+	"generator.build_tag":      validateBuildTag,
+	"naming.table_prefix":      nil,
+}
+
+// intKeys lists the keys stored as integers
+var intKeys = map[string]bool{
+	"database.port":            true,
+	"database.query_timeout":   true,
+	"generator.max_line_width": true,
+}
+
+// Keys returns all configuration keys in sorted order
+func Keys() []string {
+	keys := make([]string, 0, len(keyValidators))
+	for key := range keyValidators {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ConfigPath returns the path of the global configuration file
+func ConfigPath() (string, error) {
+	return configFilePath()
+}
+
+// GetValue returns the effective value of a configuration key, taking
+// environment variables and project config into account
+func GetValue(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+
+	v, err := effectiveViper()
+	if err != nil {
+		return "", err
+	}
+	return v.GetString(key), nil
+}
+
+// SetValue validates and stores a value in the global configuration file
+func SetValue(key, value string) error {
+	return SaveValues(map[string]string{key: value})
+}
+
+// SaveValues validates and stores several values in the global configuration
+// file at once, keeping its other settings
+func SaveValues(values map[string]string) error {
+	for key, value := range values {
+		if err := ValidateValue(key, value); err != nil {
+			return err
+		}
+	}
+
+	settings, err := readGlobalSettings()
+	if err != nil {
+		return err
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to merge config: %w", err)
+	}
+
+	for key, value := range values {
+		if intKeys[key] {
+			number, _ := strconv.Atoi(value)
+			v.Set(key, number)
+		} else {
+			v.Set(key, value)
+		}
+	}
+
+	return writeGlobalSettings(v.AllSettings())
+}
+
+// SaveConnection stores the connection settings of the GUI form in the
+// global configuration file. A password that comes from the environment
+// (GODB_PASSWORD, possibly from a .env file) is never written to disk.
+func SaveConnection(cfg DBConfig) error {
+	values := map[string]string{
+		"database.host":   cfg.Host,
+		"database.port":   strconv.Itoa(cfg.Port),
+		"database.user":   cfg.User,
+		"database.dbname": cfg.DBName,
+		"database.driver": cfg.Driver,
+	}
+	if env, ok := os.LookupEnv(envBindings["database.password"]); !ok || env != cfg.Password {
+		values["database.password"] = cfg.Password
+	}
+	return SaveValues(values)
+}
+
+// ValidateValue checks a value for a configuration key without storing it
+func ValidateValue(key, value string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	if validate := keyValidators[key]; validate != nil {
+		if err := validate(value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// UnsetValue removes a key from the global configuration file so the default applies again
+func UnsetValue(key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	settings, err := readGlobalSettings()
+	if err != nil {
+		return err
+	}
+
+	section, name, _ := strings.Cut(key, ".")
+	if nested, ok := settings[section].(map[string]interface{}); ok {
+		delete(nested, name)
+	}
+
+	return writeGlobalSettings(settings)
+}
+
+// checkKey returns an error listing the valid keys if key is unknown
+func checkKey(key string) error {
+	if _, ok := keyValidators[key]; !ok {
+		return fmt.Errorf("unknown config key %q (valid keys: %s)", key, strings.Join(Keys(), ", "))
+	}
+	return nil
+}
+
+// readGlobalSettings reads the global config file into a nested map
+func readGlobalSettings() (map[string]interface{}, error) {
+	configPath, err := configFilePath()
+	if err != nil {
+		return nil, err
+	}
+	return readSettingsFile(configPath)
+}
+
+// writeGlobalSettings writes a nested map to the global config file
+func writeGlobalSettings(settings map[string]interface{}) error {
+	configPath, err := configFilePath()
+	if err != nil {
+		return err
+	}
+	return writeSettingsFile(configPath, settings)
+}
+
+// readSettingsFile reads a YAML config file into a nested map.
+// A missing file yields an empty map.
+func readSettingsFile(path string) (map[string]interface{}, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return map[string]interface{}{}, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return v.AllSettings(), nil
+}
+
+// writeSettingsFile writes a nested map to a YAML config file
+func writeSettingsFile(path string, settings map[string]interface{}) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to merge config: %w", err)
+	}
+	if err := v.WriteConfigAs(path); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// validatePort checks that a value is a valid TCP port
+func validatePort(value string) error {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("%q is not a valid port", value)
+	}
+	return nil
+}
+
+// validatePositiveInt checks that a value is a positive integer
+func validatePositiveInt(value string) error {
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 1 {
+		return fmt.Errorf("%q is not a positive integer", value)
+	}
+	return nil
+}
+
+// validateNonNegativeInt checks that a value is zero or a positive integer
+func validateNonNegativeInt(value string) error {
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		return fmt.Errorf("%q is not a non-negative integer", value)
+	}
+	return nil
+}
+
+// validateFilePattern checks that a file name pattern produces a .go file.
+// The template itself is checked by the generator, which defines its functions.
+func validateFilePattern(value string) error {
+	if !strings.HasSuffix(value, ".go") || strings.HasSuffix(value, "_test.go") {
+		return fmt.Errorf("%q must end in .go (and not _test.go)", value)
+	}
+	return nil
+}
+
+// validateBuildTag checks that a value is a valid build constraint expression
+func validateBuildTag(value string) error {
+	if value == "" {
+		return nil
+	}
+	if _, err := constraint.Parse("//go:build " + value); err != nil {
+		return fmt.Errorf("%q is not a valid build constraint: %w", value, err)
+	}
+	return nil
+}
+
+// oneOf returns a validator accepting only the given values
+func oneOf(allowed ...string) func(string) error {
+	return func(value string) error {
+		for _, a := range allowed {
+			if value == a {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q must be one of: %s", value, strings.Join(allowed, ", "))
+	}
+}
@@ -0,0 +1,155 @@
+package config
+
+import "strings"
+
+// TableOverride customizes generation for a single table
+type TableOverride struct {
+	// ExcludeColumns lists columns left out of the generated struct
+	ExcludeColumns []string `yaml:"exclude_columns" mapstructure:"exclude_columns"`
+	// StructName replaces the struct name derived from the table name
+	StructName string `yaml:"struct_name" mapstructure:"struct_name"`
+	// Package replaces the package clause of the generated file
+	Package string `yaml:"package" mapstructure:"package"`
+	// FileName replaces the output file name, relative to the output directory
+	FileName string `yaml:"file_name" mapstructure:"file_name"`
+	// PrimaryKey designates the columns of a logical key, tagged
+	// primaryKey, for a table without a primary key
+	PrimaryKey []string `yaml:"primary_key" mapstructure:"primary_key"`
+	// Columns customizes individual fields, keyed by column name
+	Columns map[string]ColumnOverride `yaml:"columns" mapstructure:"columns"`
+}
+
+// ColumnOverride customizes the generated field for a single column
+type ColumnOverride struct {
+	Type   string   `yaml:"type" mapstructure:"type"`       // Go type replacing the mapped type
+	Import string   `yaml:"import" mapstructure:"import"`   // Import path required by Type, if any
+	Tags   string   `yaml:"tags" mapstructure:"tags"`       // Struct tags added to (or replacing same-key) generated tags
+	Enum   []string `yaml:"enum" mapstructure:"enum"`       // Values of a string column generated as a named type with constants
+	NoEnum bool     `yaml:"no_enum" mapstructure:"no_enum"` // An enum suggested for the column was rejected
+}
+
+// IsZero reports whether the column override changes nothing
+func (o ColumnOverride) IsZero() bool {
+	return o.Type == "" && o.Import == "" && o.Tags == "" && len(o.Enum) == 0 && !o.NoEnum
+}
+
+// IsZero reports whether the override changes nothing
+func (o TableOverride) IsZero() bool {
+	if len(o.ExcludeColumns) > 0 || len(o.PrimaryKey) > 0 || o.StructName != "" || o.Package != "" || o.FileName != "" {
+		return false
+	}
+	for _, col := range o.Columns {
+		if !col.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// Column returns the override configured for a column
+func (o TableOverride) Column(name string) ColumnOverride {
+	return LookupTable(o.Columns, name)
+}
+
+// Excludes reports whether a column is excluded from the generated struct
+func (o TableOverride) Excludes(column string) bool {
+	for _, excluded := range o.ExcludeColumns {
+		if strings.EqualFold(excluded, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// LookupTable returns the setting for a table (or column) from a map keyed by name.
+// Viper lowercases map keys when reading config files, so the lookup falls
+// back to a case-insensitive match.
+func LookupTable[T any](settings map[string]T, name string) T {
+	if value, ok := settings[name]; ok {
+		return value
+	}
+	if value, ok := settings[strings.ToLower(name)]; ok {
+		return value
+	}
+	var zero T
+	return zero
+}
+
+// SetTableOverride stores the override for a table in the project config
+// file (./.godb-orm.yaml), removing the entry if the override is empty
+func SetTableOverride(table string, override TableOverride) error {
+	settings, err := readSettingsFile(ProjectConfigFile)
+	if err != nil {
+		return err
+	}
+
+	generator, ok := settings["generator"].(map[string]interface{})
+	if !ok {
+		generator = map[string]interface{}{}
+		settings["generator"] = generator
+	}
+	overrides, ok := generator["overrides"].(map[string]interface{})
+	if !ok {
+		overrides = map[string]interface{}{}
+		generator["overrides"] = overrides
+	}
+
+	key := strings.ToLower(table)
+	if override.IsZero() {
+		delete(overrides, key)
+	} else {
+		overrides[key] = override.settings()
+	}
+
+	return writeSettingsFile(ProjectConfigFile, settings)
+}
+
+// settings converts the override to the nested map stored in the config
+// file, omitting empty values
+func (o TableOverride) settings() map[string]interface{} {
+	settings := map[string]interface{}{}
+	if len(o.ExcludeColumns) > 0 {
+		settings["exclude_columns"] = o.ExcludeColumns
+	}
+	if o.StructName != "" {
+		settings["struct_name"] = o.StructName
+	}
+	if o.Package != "" {
+		settings["package"] = o.Package
+	}
+	if o.FileName != "" {
+		settings["file_name"] = o.FileName
+	}
+	if len(o.PrimaryKey) > 0 {
+		settings["primary_key"] = o.PrimaryKey
+	}
+
+	columns := map[string]interface{}{}
+	for name, col := range o.Columns {
+		if col.IsZero() {
+			continue
+		}
+		column := map[string]interface{}{}
+		if col.Type != "" {
+			column["type"] = col.Type
+		}
+		if col.Import != "" {
+			column["import"] = col.Import
+		}
+		if col.Tags != "" {
+			column["tags"] = col.Tags
+		}
+		if len(col.Enum) > 0 {
+			column["enum"] = col.Enum
+		}
+		if col.NoEnum {
+			column["no_enum"] = true
+		}
+		columns[strings.ToLower(name)] = column
+	}
+	if len(columns) > 0 {
+		settings["columns"] = columns
+	}
+
+	return settings
+}
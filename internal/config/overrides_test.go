@@ -0,0 +1,84 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetTableOverride(t *testing.T) {
+	chdir(t, t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	writeFile(t, ProjectConfigFile, "generator:\n  output_dir: ./models\n")
+
+	if err := SetTableOverride("Users", TableOverride{ExcludeColumns: []string{"password_hash"}}); err != nil {
+		t.Fatalf("SetTableOverride() error = %v", err)
+	}
+
+	cfg, err := LoadEffectiveConfig()
+	if err != nil {
+		t.Fatalf("LoadEffectiveConfig() error = %v", err)
+	}
+	if cfg.Generator.OutputDir != "./models" {
+		t.Errorf("OutputDir = %q; want existing setting preserved", cfg.Generator.OutputDir)
+	}
+	override := LookupTable(cfg.Generator.Overrides, "Users")
+	if !reflect.DeepEqual(override.ExcludeColumns, []string{"password_hash"}) {
+		t.Errorf("ExcludeColumns = %v; want [password_hash]", override.ExcludeColumns)
+	}
+	if !override.Excludes("PASSWORD_HASH") {
+		t.Error("Excludes() should match column names case-insensitively")
+	}
+
+	// An empty override removes the entry
+	if err := SetTableOverride("Users", TableOverride{}); err != nil {
+		t.Fatalf("SetTableOverride() error = %v", err)
+	}
+	cfg, err = LoadEffectiveConfig()
+	if err != nil {
+		t.Fatalf("LoadEffectiveConfig() error = %v", err)
+	}
+	if override := LookupTable(cfg.Generator.Overrides, "Users"); !override.IsZero() {
+		t.Errorf("override = %+v; want removed", override)
+	}
+}
+
+func TestSetTableOverride_Columns(t *testing.T) {
+	chdir(t, t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	override := TableOverride{
+		StructName: "Account",
+		Package:    "billing",
+		FileName:   "billing/account.go",
+		PrimaryKey: []string{"account_no"},
+		Columns: map[string]ColumnOverride{
+			"Balance": {Type: "decimal.Decimal", Import: "github.com/shopspring/decimal", Tags: `validate:"gte=0"`},
+			"status":  {Enum: []string{"active", "closed"}},
+			"kind":    {NoEnum: true},
+			"notes":   {},
+		},
+	}
+	if err := SetTableOverride("accounts", override); err != nil {
+		t.Fatalf("SetTableOverride() error = %v", err)
+	}
+
+	cfg, err := LoadEffectiveConfig()
+	if err != nil {
+		t.Fatalf("LoadEffectiveConfig() error = %v", err)
+	}
+	got := LookupTable(cfg.Generator.Overrides, "accounts")
+	if got.StructName != "Account" || got.Package != "billing" || got.FileName != "billing/account.go" {
+		t.Errorf("override = %+v; want struct name, package and file name preserved", got)
+	}
+	if !reflect.DeepEqual(got.PrimaryKey, []string{"account_no"}) {
+		t.Errorf("PrimaryKey = %v; want [account_no]", got.PrimaryKey)
+	}
+	for _, name := range []string{"Balance", "status", "kind"} {
+		if col := got.Column(name); !reflect.DeepEqual(col, override.Columns[name]) {
+			t.Errorf("Column(%s) = %+v; want %+v", name, col, override.Columns[name])
+		}
+	}
+	if len(got.Columns) != 3 {
+		t.Errorf("Columns = %v; want empty column overrides dropped", got.Columns)
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Preset is a named selection of tables with the generator options to
+// generate them with, kept in the project config:
+//
+//	presets:
+//	  api-models:
+//	    tables: [users, orders]
+//	    generator:
+//	      null_strategy: pointer
+//	      repositories: true
+type Preset struct {
+	// Tables lists the tables generated with the preset
+	Tables []string `yaml:"tables" mapstructure:"tables"`
+	// Generator holds options of the generator section, by their config
+	// key, replacing the configured ones
+	Generator map[string]interface{} `yaml:"generator" mapstructure:"generator"`
+}
+
+// PresetNames returns the names of the configured presets in sorted order
+func (c *Config) PresetNames() []string {
+	names := make([]string, 0, len(c.Presets))
+	for name := range c.Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Preset returns the preset with the given name. Names are matched
+// case-insensitively, as viper lowercases config keys.
+func (c *Config) Preset(name string) (Preset, error) {
+	preset, ok := c.Presets[strings.ToLower(name)]
+	if !ok {
+		if len(c.Presets) == 0 {
+			return Preset{}, fmt.Errorf("unknown preset %q (no presets in %s)", name, ProjectConfigFile)
+		}
+		return Preset{}, fmt.Errorf("unknown preset %q (valid presets: %s)", name, strings.Join(c.PresetNames(), ", "))
+	}
+	return preset, nil
+}
+
+// Apply returns genCfg with the preset's tables and generator options. An
+// option must be a key of the generator section, with a value valid for it.
+func (p Preset) Apply(genCfg GeneratorConfig) (GeneratorConfig, error) {
+	for key, value := range p.Generator {
+		if validate := keyValidators["generator."+key]; validate != nil {
+			if err := validate(fmt.Sprint(value)); err != nil {
+				return genCfg, fmt.Errorf("invalid value for preset option %s: %w", key, err)
+			}
+		}
+	}
+
+	// Decoding onto genCfg only changes the options the preset sets
+	v := viper.New()
+	if err := v.MergeConfigMap(p.Generator); err != nil {
+		return genCfg, fmt.Errorf("failed to read preset options: %w", err)
+	}
+	if err := v.UnmarshalExact(&genCfg); err != nil {
+		return genCfg, fmt.Errorf("invalid preset options: %w", err)
+	}
+
+	if len(p.Tables) > 0 {
+		genCfg.Tables = strings.Join(p.Tables, ",")
+	}
+	return genCfg, nil
+}
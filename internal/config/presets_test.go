@@ -0,0 +1,60 @@
+package config
+
+import "testing"
+
+func TestPresets(t *testing.T) {
+	chdir(t, t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	writeFile(t, ProjectConfigFile, `generator:
+  output_dir: ./models
+  null_strategy: zero
+presets:
+  api-models:
+    tables: [users, orders]
+    generator:
+      null_strategy: pointer
+      repositories: true
+  broken:
+    generator:
+      null_strategy: nil
+  typo:
+    generator:
+      nul_strategy: pointer
+`)
+
+	cfg, err := LoadEffectiveConfig()
+	if err != nil {
+		t.Fatalf("LoadEffectiveConfig() error = %v", err)
+	}
+	if names := cfg.PresetNames(); len(names) != 3 || names[0] != "api-models" {
+		t.Errorf("PresetNames() = %v; want [api-models broken typo]", names)
+	}
+
+	preset, err := cfg.Preset("API-Models")
+	if err != nil {
+		t.Fatalf("Preset() error = %v", err)
+	}
+	genCfg, err := preset.Apply(cfg.Generator)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if genCfg.Tables != "users,orders" || genCfg.NullStrategy != "pointer" || !genCfg.Repositories {
+		t.Errorf("Apply() = tables %q, null strategy %q, repositories %v; want the preset's", genCfg.Tables, genCfg.NullStrategy, genCfg.Repositories)
+	}
+	if genCfg.OutputDir != "./models" {
+		t.Errorf("OutputDir = %q; want the configured one kept", genCfg.OutputDir)
+	}
+	if cfg.Generator.NullStrategy != "zero" {
+		t.Error("Apply() changed the configuration it was given")
+	}
+
+	for _, name := range []string{"broken", "typo"} {
+		preset, _ := cfg.Preset(name)
+		if _, err := preset.Apply(cfg.Generator); err == nil {
+			t.Errorf("Apply() of preset %s error = nil", name)
+		}
+	}
+	if _, err := cfg.Preset("missing"); err == nil {
+		t.Error("Preset() of an unknown preset error = nil")
+	}
+}
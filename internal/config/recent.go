@@ -0,0 +1,148 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// MaxRecentConnections is the number of connections kept in the history
+const MaxRecentConnections = 10
+
+// recentFileName is the history file stored next to the global config
+const recentFileName = "recent.json"
+
+// RecentConnection is a previously successful connection. Passwords are
+// never written to the history.
+type RecentConnection struct {
+	Host         string    `json:"host"`
+	Port         int       `json:"port"`
+	User         string    `json:"user"`
+	DBName       string    `json:"dbname"`
+	Driver       string    `json:"driver"`
+	QueryTimeout int       `json:"queryTimeout,omitempty"`
+	LastUsed     time.Time `json:"lastUsed"`
+}
+
+// Key identifies a connection independent of when it was used,
+// e.g. "mysql://root@localhost:3306/app"
+func (r RecentConnection) Key() string {
+	return r.Driver + "://" + r.User + "@" + r.Host + ":" + strconv.Itoa(r.Port) + "/" + r.DBName
+}
+
+// DBConfig returns the connection settings with the given password
+func (r RecentConnection) DBConfig(password string) DBConfig {
+	return DBConfig{
+		Host:         r.Host,
+		Port:         r.Port,
+		User:         r.User,
+		Password:     password,
+		DBName:       r.DBName,
+		Driver:       r.Driver,
+		QueryTimeout: r.QueryTimeout,
+	}
+}
+
+// RecentConnectionFrom converts connection settings to a history entry
+func RecentConnectionFrom(cfg DBConfig) RecentConnection {
+	return RecentConnection{
+		Host:         cfg.Host,
+		Port:         cfg.Port,
+		User:         cfg.User,
+		DBName:       cfg.DBName,
+		Driver:       cfg.Driver,
+		QueryTimeout: cfg.QueryTimeout,
+	}
+}
+
+// recentFilePath returns the full path to the history file
+func recentFilePath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, recentFileName), nil
+}
+
+// LoadRecentConnections returns the connection history, most recent first
+func LoadRecentConnections() ([]RecentConnection, error) {
+	path, err := recentFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []RecentConnection{}, nil
+		}
+		return nil, fmt.Errorf("failed to read connection history: %w", err)
+	}
+
+	var recent []RecentConnection
+	if err := json.Unmarshal(data, &recent); err != nil {
+		return nil, fmt.Errorf("failed to parse connection history: %w", err)
+	}
+	return recent, nil
+}
+
+// AddRecentConnection moves a connection to the top of the history
+func AddRecentConnection(cfg DBConfig) error {
+	recent, err := LoadRecentConnections()
+	if err != nil {
+		// A corrupt history is replaced rather than blocking connections
+		recent = nil
+	}
+
+	entry := RecentConnectionFrom(cfg)
+	entry.LastUsed = time.Now()
+
+	updated := []RecentConnection{entry}
+	for _, r := range recent {
+		if r.Key() != entry.Key() && len(updated) < MaxRecentConnections {
+			updated = append(updated, r)
+		}
+	}
+
+	return saveRecentConnections(updated)
+}
+
+// RemoveRecentConnection deletes a connection from the history by key
+func RemoveRecentConnection(key string) error {
+	recent, err := LoadRecentConnections()
+	if err != nil {
+		return err
+	}
+
+	updated := make([]RecentConnection, 0, len(recent))
+	for _, r := range recent {
+		if r.Key() != key {
+			updated = append(updated, r)
+		}
+	}
+
+	return saveRecentConnections(updated)
+}
+
+// saveRecentConnections writes the history file
+func saveRecentConnections(recent []RecentConnection) error {
+	path, err := recentFilePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(recent, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode connection history: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write connection history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAddRecentConnection(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	first := DBConfig{Host: "localhost", Port: 3306, User: "root", Password: "secret", DBName: "app", Driver: "mysql"}
+	second := DBConfig{Host: "db.internal", Port: 5432, User: "postgres", DBName: "shop", Driver: "postgres"}
+
+	for _, cfg := range []DBConfig{first, second, first} {
+		if err := AddRecentConnection(cfg); err != nil {
+			t.Fatalf("AddRecentConnection() error = %v", err)
+		}
+	}
+
+	recent, err := LoadRecentConnections()
+	if err != nil {
+		t.Fatalf("LoadRecentConnections() error = %v", err)
+	}
+	if len(recent) != 2 {
+		t.Fatalf("len(recent) = %d; want duplicates collapsed to 2", len(recent))
+	}
+	if recent[0].Key() != "mysql://root@localhost:3306/app" {
+		t.Errorf("recent[0] = %s; want most recently used first", recent[0].Key())
+	}
+
+	// Passwords never reach the history file
+	path, _ := recentFilePath()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("history file contains the password:\n%s", data)
+	}
+
+	if err := RemoveRecentConnection(recent[1].Key()); err != nil {
+		t.Fatalf("RemoveRecentConnection() error = %v", err)
+	}
+	recent, _ = LoadRecentConnections()
+	if len(recent) != 1 {
+		t.Errorf("len(recent) = %d; want 1 after removal", len(recent))
+	}
+}
+
+func TestAddRecentConnection_Limit(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for i := 0; i < MaxRecentConnections+5; i++ {
+		cfg := DBConfig{Host: "localhost", Port: 3306, User: "root", DBName: fmt.Sprintf("db%d", i), Driver: "mysql"}
+		if err := AddRecentConnection(cfg); err != nil {
+			t.Fatalf("AddRecentConnection() error = %v", err)
+		}
+	}
+
+	recent, err := LoadRecentConnections()
+	if err != nil {
+		t.Fatalf("LoadRecentConnections() error = %v", err)
+	}
+	if len(recent) != MaxRecentConnections {
+		t.Errorf("len(recent) = %d; want %d", len(recent), MaxRecentConnections)
+	}
+}
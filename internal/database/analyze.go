@@ -0,0 +1,105 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// DefaultAnalyzeSample is the number of rows AnalyzeColumn reads when the
+// caller doesn't choose
+const DefaultAnalyzeSample = 10000
+
+// maxTopValues bounds the most frequent values reported by AnalyzeColumn
+const maxTopValues = 10
+
+// ValueCount is a value of a column and how often it occurs in the sample
+type ValueCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// ColumnStats summarizes a sample of a column's values, to help decide
+// whether it should be modeled as an enum or a nullable pointer
+type ColumnStats struct {
+	SampledRows   int64        `json:"sampledRows"`   // Rows read, at most the sample size
+	Complete      bool         `json:"complete"`      // The sample covered the whole table
+	NullCount     int64        `json:"nullCount"`     // NULLs in the sample
+	NullRatio     float64      `json:"nullRatio"`     // NullCount / SampledRows, 0 for an empty table
+	DistinctCount int64        `json:"distinctCount"` // Distinct non-NULL values in the sample
+	TopValues     []ValueCount `json:"topValues"`     // Most frequent non-NULL values, most frequent first
+}
+
+// ColumnAnalyzer is implemented by introspectors that can sample the values
+// of a column
+type ColumnAnalyzer interface {
+	// AnalyzeColumn samples up to sampleRows rows of a column (0 uses
+	// DefaultAnalyzeSample)
+	AnalyzeColumn(tableName, columnName string, sampleRows int) (*ColumnStats, error)
+}
+
+// AnalyzeColumn samples the values of a MySQL column
+func (m *MySQLIntrospector) AnalyzeColumn(tableName, columnName string, sampleRows int) (*ColumnStats, error) {
+	return m.analyzeColumn(quoteMySQLIdent(m.cfg.DBName)+"."+quoteMySQLIdent(tableName), quoteMySQLIdent(columnName), sampleRows)
+}
+
+// AnalyzeColumn samples the values of a PostgreSQL column of the current
+// schema. Values are compared as text, so json and array columns work too.
+func (p *PostgresIntrospector) AnalyzeColumn(tableName, columnName string, sampleRows int) (*ColumnStats, error) {
+	return p.analyzeColumn(p.regclass(tableName), pq.QuoteIdentifier(columnName)+"::text", sampleRows)
+}
+
+// analyzeColumn samples the column expression of a quoted table. Both
+// queries only read the first sampleRows rows, so they stay cheap on big
+// tables.
+func (b *BaseIntrospector) analyzeColumn(table, column string, sampleRows int) (*ColumnStats, error) {
+	if b.db == nil {
+		return nil, fmt.Errorf("not connected")
+	}
+	if sampleRows <= 0 {
+		sampleRows = DefaultAnalyzeSample
+	}
+	sample := fmt.Sprintf("(SELECT %s AS v FROM %s LIMIT %d) sample", column, table, sampleRows)
+
+	ctx, cancel := b.queryContext()
+	defer cancel()
+
+	stats := &ColumnStats{}
+	var nonNull int64
+	query := "SELECT COUNT(*), COUNT(v), COUNT(DISTINCT v) FROM " + sample
+	if err := b.db.QueryRowContext(ctx, query).Scan(&stats.SampledRows, &nonNull, &stats.DistinctCount); err != nil {
+		return nil, b.wrapQueryError(ctx, err, "failed to analyze column", "column analysis query")
+	}
+	stats.Complete = stats.SampledRows < int64(sampleRows)
+	stats.NullCount = stats.SampledRows - nonNull
+	if stats.SampledRows > 0 {
+		stats.NullRatio = float64(stats.NullCount) / float64(stats.SampledRows)
+	}
+
+	query = fmt.Sprintf("SELECT v, COUNT(*) FROM %s WHERE v IS NOT NULL GROUP BY v ORDER BY COUNT(*) DESC, v LIMIT %d", sample, maxTopValues)
+	rows, err := b.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, b.wrapQueryError(ctx, err, "failed to analyze column", "column analysis query")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var value sql.NullString
+		var count int64
+		if err := rows.Scan(&value, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan column value: %w", err)
+		}
+		stats.TopValues = append(stats.TopValues, ValueCount{Value: value.String, Count: count})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, b.wrapQueryError(ctx, err, "failed to read column values", "column analysis query")
+	}
+	return stats, nil
+}
+
+// quoteMySQLIdent quotes a MySQL identifier with backticks
+func quoteMySQLIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
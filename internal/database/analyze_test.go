@@ -0,0 +1,23 @@
+package database
+
+import "testing"
+
+func TestQuoteMySQLIdent(t *testing.T) {
+	tests := map[string]string{
+		"users":    "`users`",
+		"order by": "`order by`",
+		"odd`name": "`odd``name`",
+	}
+	for name, want := range tests {
+		if got := quoteMySQLIdent(name); got != want {
+			t.Errorf("quoteMySQLIdent(%q) = %s; want %s", name, got, want)
+		}
+	}
+}
+
+func TestAnalyzeColumnNotConnected(t *testing.T) {
+	m := &MySQLIntrospector{}
+	if _, err := m.analyzeColumn("`users`", "`status`", 0); err == nil {
+		t.Error("analyzeColumn() without a connection succeeded, want an error")
+	}
+}
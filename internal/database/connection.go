@@ -1,24 +1,66 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/rowjak/godb-orm/internal/config"
 )
 
-// NewIntrospector creates a new database introspector based on the driver
+// ErrQueryTimeout is returned (wrapped) when an introspection query exceeds the configured timeout
+var ErrQueryTimeout = errors.New("query timeout exceeded")
+
+// NewIntrospector creates a new database introspector based on the driver,
+// or a DDL introspector if a schema dump file is configured
 func NewIntrospector(cfg *config.DBConfig) (DBIntrospector, error) {
+	if cfg.DDLFile != "" {
+		return NewDDLIntrospector(cfg.DDLFile, cfg.Driver), nil
+	}
+
 	switch cfg.Driver {
 	case "mysql":
 		return NewMySQLIntrospector(cfg), nil
 	case "postgres", "postgresql":
 		return NewPostgresIntrospector(cfg), nil
+	case "firebird":
+		return NewFirebirdIntrospector(cfg), nil
+	case "db2":
+		return NewDB2Introspector(cfg), nil
+	case "trino":
+		return NewTrinoIntrospector(cfg), nil
+	case "duckdb":
+		return NewDuckDBIntrospector(cfg), nil
+	case "mongodb", "mongo":
+		return NewMongoIntrospector(cfg), nil
 	default:
 		return nil, fmt.Errorf("unsupported database driver: %s", cfg.Driver)
 	}
 }
 
+// requireDriver returns an error explaining how to enable a database/sql
+// driver that is only compiled in with a build tag
+func requireDriver(driver, tag string) error {
+	for _, name := range sql.Drivers() {
+		if name == driver {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s support is not compiled in (rebuild godb-orm with -tags %s)", tag, tag)
+}
+
+// baseTypeName returns a type name without parameters, e.g.
+// "timestamp(3) with time zone" -> "timestamp" and "array(varchar)" -> "array"
+func baseTypeName(dataType string) string {
+	if i := strings.IndexAny(dataType, "( "); i >= 0 {
+		return dataType[:i]
+	}
+	return dataType
+}
+
 // BaseIntrospector provides common functionality for database introspection
 type BaseIntrospector struct {
 	cfg *config.DBConfig
@@ -33,7 +75,64 @@ func (b *BaseIntrospector) Close() error {
 	return nil
 }
 
+// Ping checks that the database connection is still alive
+func (b *BaseIntrospector) Ping() error {
+	if b.db == nil {
+		return errors.New("not connected")
+	}
+
+	ctx, cancel := b.queryContext()
+	defer cancel()
+
+	if err := b.db.PingContext(ctx); err != nil {
+		return b.wrapQueryError(ctx, err, "failed to ping database", "connection check")
+	}
+	return nil
+}
+
+// queryTimeout returns the configured per-query timeout
+func (b *BaseIntrospector) queryTimeout() time.Duration {
+	if b.cfg.QueryTimeout > 0 {
+		return time.Duration(b.cfg.QueryTimeout) * time.Second
+	}
+	return time.Duration(config.DefaultQueryTimeout) * time.Second
+}
+
+// queryContext returns a context that expires after the configured query timeout
+func (b *BaseIntrospector) queryContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), b.queryTimeout())
+}
+
+// wrapQueryError turns a deadline error into an actionable ErrQueryTimeout
+// describing what was being queried; other errors are wrapped with action
+// and, if the driver error code is known, the matching ErrAuthFailed,
+// ErrPermissionDenied, ...
+func (b *BaseIntrospector) wrapQueryError(ctx context.Context, err error, action, what string) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s exceeded %s (increase query_timeout or check database load): %w",
+			what, b.queryTimeout(), ErrQueryTimeout)
+	}
+	if kind := classifyError(err); kind != nil {
+		return fmt.Errorf("%s: %w (%w)", action, err, kind)
+	}
+	return fmt.Errorf("%s: %w", action, err)
+}
+
 // DB returns the underlying database connection
 func (b *BaseIntrospector) DB() *sql.DB {
 	return b.db
 }
+
+// splitForeignKeys sorts foreign keys into those declared on the table
+// (belongs-to) and those referencing it from other tables (has-many).
+// A self-referencing key appears in both lists.
+func splitForeignKeys(meta *TableMetadata, foreignKeys []ForeignKey) {
+	for _, fk := range foreignKeys {
+		if fk.Table == meta.Name {
+			meta.ForeignKeys = append(meta.ForeignKeys, fk)
+		}
+		if fk.ReferencedTable == meta.Name {
+			meta.ReferencedBy = append(meta.ReferencedBy, fk)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+//go:build db2
+
+package database
+
+// go_ibm_db uses cgo and needs the Db2 CLI driver; see its README for the
+// IBM_DB_HOME, CGO_CFLAGS and CGO_LDFLAGS setup
+import _ "github.com/ibmdb/go_ibm_db"
@@ -0,0 +1,289 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/config"
+)
+
+// db2Driver is the database/sql driver name registered by
+// github.com/ibmdb/go_ibm_db (compiled in with -tags db2)
+const db2Driver = "go_ibm_db"
+
+// DB2Introspector implements database introspection for IBM Db2 for
+// Linux, UNIX and Windows through the SYSCAT catalog views
+type DB2Introspector struct {
+	BaseIntrospector
+	currentSchema string
+}
+
+// NewDB2Introspector creates a new Db2 introspector. The schema defaults to
+// the connecting user, as in Db2 itself.
+func NewDB2Introspector(cfg *config.DBConfig) *DB2Introspector {
+	return &DB2Introspector{
+		BaseIntrospector: BaseIntrospector{cfg: cfg},
+		currentSchema:    strings.ToUpper(cfg.User),
+	}
+}
+
+// SetSchema sets the current schema to use for table queries
+func (d *DB2Introspector) SetSchema(schema string) {
+	d.currentSchema = strings.ToUpper(schema)
+}
+
+// GetCurrentSchema returns the current schema
+func (d *DB2Introspector) GetCurrentSchema() string {
+	return d.currentSchema
+}
+
+// Connect establishes a connection to the Db2 database
+func (d *DB2Introspector) Connect() error {
+	if err := requireDriver(db2Driver, "db2"); err != nil {
+		return err
+	}
+
+	dsn := fmt.Sprintf("HOSTNAME=%s;PORT=%d;DATABASE=%s;UID=%s;PWD=%s",
+		d.cfg.Host,
+		d.cfg.Port,
+		d.cfg.DBName,
+		d.cfg.User,
+		d.cfg.Password,
+	)
+
+	db, err := sql.Open(db2Driver, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to open Db2 connection: %w", err)
+	}
+
+	ctx, cancel := d.queryContext()
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return d.wrapQueryError(ctx, err, "failed to ping Db2", "connection to Db2")
+	}
+
+	d.db = db
+	return nil
+}
+
+// Dialect returns the SQL dialect of the schema
+func (d *DB2Introspector) Dialect() string {
+	return "db2"
+}
+
+// GetTables returns a list of table names in the current schema
+func (d *DB2Introspector) GetTables() ([]string, error) {
+	query := `
+		SELECT TABNAME
+		FROM SYSCAT.TABLES
+		WHERE TABSCHEMA = ? AND TYPE = 'T'
+		ORDER BY TABNAME
+	`
+
+	ctx, cancel := d.queryContext()
+	defer cancel()
+
+	rows, err := d.db.QueryContext(ctx, query, d.currentSchema)
+	if err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to query tables", "table list query")
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var tableName string
+		if err := rows.Scan(&tableName); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+		tables = append(tables, tableName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to read tables", "table list query")
+	}
+
+	return tables, nil
+}
+
+// GetColumns returns column metadata for a specific table
+func (d *DB2Introspector) GetColumns(tableName string) ([]ColumnMetadata, error) {
+	query := `
+		SELECT
+			COLNAME,
+			TYPENAME,
+			LENGTH,
+			SCALE,
+			NULLS,
+			DEFAULT,
+			REMARKS,
+			IDENTITY,
+			KEYSEQ
+		FROM SYSCAT.COLUMNS
+		WHERE TABSCHEMA = ? AND TABNAME = ?
+		ORDER BY COLNO
+	`
+
+	ctx, cancel := d.queryContext()
+	defer cancel()
+
+	rows, err := d.db.QueryContext(ctx, query, d.currentSchema, tableName)
+	if err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to query columns", fmt.Sprintf("table %s metadata query", tableName))
+	}
+	defer rows.Close()
+
+	var columns []ColumnMetadata
+	for rows.Next() {
+		var (
+			columnName   string
+			typeName     string
+			length       int
+			scale        int
+			nulls        string
+			defaultValue sql.NullString
+			remarks      sql.NullString
+			identity     string
+			keySeq       sql.NullInt64
+		)
+		if err := rows.Scan(&columnName, &typeName, &length, &scale, &nulls,
+			&defaultValue, &remarks, &identity, &keySeq); err != nil {
+			return nil, fmt.Errorf("failed to scan column: %w", err)
+		}
+
+		dataType, rawType := db2Type(typeName, length, scale)
+		col := ColumnMetadata{
+			Name:            columnName,
+			DataType:        dataType,
+			RawType:         rawType,
+			IsNullable:      nulls == "Y",
+			IsPrimaryKey:    keySeq.Valid,
+			IsAutoIncrement: identity == "Y",
+			Comment:         remarks.String,
+			OrdinalPosition: len(columns) + 1,
+		}
+		if defaultValue.Valid {
+			value := defaultValue.String
+			col.DefaultValue = &value
+		}
+		switch dataType {
+		case "char", "varchar", "graphic", "vargraphic":
+			col.CharMaxLength = &length
+		case "decimal":
+			col.NumericPrecision = &length
+			col.NumericScale = &scale
+		}
+		columns = append(columns, col)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to read columns", fmt.Sprintf("table %s metadata query", tableName))
+	}
+
+	return columns, nil
+}
+
+// GetTableMetadata returns full metadata for a specific table
+func (d *DB2Introspector) GetTableMetadata(tableName string) (*TableMetadata, error) {
+	columns, err := d.GetColumns(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	var tableComment sql.NullString
+	query := `
+		SELECT REMARKS
+		FROM SYSCAT.TABLES
+		WHERE TABSCHEMA = ? AND TABNAME = ?
+	`
+	ctx, cancel := d.queryContext()
+	defer cancel()
+
+	err = d.db.QueryRowContext(ctx, query, d.currentSchema, tableName).Scan(&tableComment)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, d.wrapQueryError(ctx, err, "failed to get table comment", fmt.Sprintf("table %s comment query", tableName))
+	}
+
+	meta := &TableMetadata{
+		Schema:  d.currentSchema,
+		Name:    tableName,
+		Columns: columns,
+		Comment: tableComment.String,
+	}
+
+	foreignKeys, err := d.getForeignKeys(tableName)
+	if err != nil {
+		return nil, err
+	}
+	splitForeignKeys(meta, foreignKeys)
+
+	return meta, nil
+}
+
+// getForeignKeys returns single-column foreign keys declared on or referencing a table
+func (d *DB2Introspector) getForeignKeys(tableName string) ([]ForeignKey, error) {
+	query := `
+		SELECT
+			CONSTNAME,
+			TABNAME,
+			TRIM(FK_COLNAMES),
+			REFTABNAME,
+			TRIM(PK_COLNAMES)
+		FROM SYSCAT.REFERENCES
+		WHERE TABSCHEMA = ?
+			AND REFTABSCHEMA = ?
+			AND COLCOUNT = 1
+			AND (TABNAME = ? OR REFTABNAME = ?)
+		ORDER BY TABNAME, CONSTNAME
+	`
+
+	ctx, cancel := d.queryContext()
+	defer cancel()
+
+	rows, err := d.db.QueryContext(ctx, query, d.currentSchema, d.currentSchema, tableName, tableName)
+	if err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to query foreign keys", fmt.Sprintf("table %s foreign key query", tableName))
+	}
+	defer rows.Close()
+
+	var foreignKeys []ForeignKey
+	for rows.Next() {
+		var fk ForeignKey
+		if err := rows.Scan(&fk.Name, &fk.Table, &fk.Column, &fk.ReferencedTable, &fk.ReferencedColumn); err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key: %w", err)
+		}
+		foreignKeys = append(foreignKeys, fk)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to read foreign keys", fmt.Sprintf("table %s foreign key query", tableName))
+	}
+
+	return foreignKeys, nil
+}
+
+// db2Type returns the normalized data type and the raw type of a Db2 column
+// from its SYSCAT.COLUMNS type name, length and scale. For DECIMAL the
+// length is the precision; for TIMESTAMP the scale is the fractional
+// seconds precision.
+func db2Type(typeName string, length, scale int) (dataType, rawType string) {
+	dataType = strings.ToLower(strings.TrimSpace(typeName))
+	switch dataType {
+	case "character":
+		dataType = "char"
+		return dataType, fmt.Sprintf("char(%d)", length)
+	case "varchar", "graphic", "vargraphic", "binary", "varbinary":
+		return dataType, fmt.Sprintf("%s(%d)", dataType, length)
+	case "decimal":
+		return dataType, fmt.Sprintf("decimal(%d,%d)", length, scale)
+	case "timestamp":
+		if scale != 6 {
+			return dataType, fmt.Sprintf("timestamp(%d)", scale)
+		}
+	case "double":
+		dataType = "double precision"
+	}
+	return dataType, dataType
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+)
+
+func TestDB2Type(t *testing.T) {
+	tests := []struct {
+		typeName          string
+		length, scale     int
+		dataType, rawType string
+	}{
+		{"INTEGER", 4, 0, "integer", "integer"},
+		{"CHARACTER", 3, 0, "char", "char(3)"},
+		{"VARCHAR", 255, 0, "varchar", "varchar(255)"},
+		{"DECIMAL", 11, 2, "decimal", "decimal(11,2)"},
+		{"TIMESTAMP", 10, 6, "timestamp", "timestamp"},
+		{"TIMESTAMP", 10, 0, "timestamp", "timestamp(0)"},
+		{"DOUBLE", 8, 0, "double precision", "double precision"},
+		{"CLOB", 1048576, 0, "clob", "clob"},
+	}
+	for _, tt := range tests {
+		dataType, rawType := db2Type(tt.typeName, tt.length, tt.scale)
+		if dataType != tt.dataType || rawType != tt.rawType {
+			t.Errorf("db2Type(%q, %d, %d) = %q, %q; want %q, %q", tt.typeName, tt.length, tt.scale, dataType, rawType, tt.dataType, tt.rawType)
+		}
+	}
+}
+
+func TestDB2Introspector_Schema(t *testing.T) {
+	introspector := NewDB2Introspector(&config.DBConfig{Driver: "db2", User: "db2inst1"})
+	if got := introspector.GetCurrentSchema(); got != "DB2INST1" {
+		t.Errorf("GetCurrentSchema() = %q; want DB2INST1", got)
+	}
+	introspector.SetSchema("sales")
+	if got := introspector.GetCurrentSchema(); got != "SALES" {
+		t.Errorf("GetCurrentSchema() = %q; want SALES", got)
+	}
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"fmt"
+	"os"
+)
+
+// DDLIntrospector implements read-only introspection over a schema-only SQL
+// dump (mysqldump --no-data or pg_dump --schema-only), for when the database
+// itself is not reachable
+type DDLIntrospector struct {
+	path    string // Dump file, or empty when parsing source directly
+	source  string
+	dialect string
+	schema  *ddlSchema
+}
+
+// NewDDLIntrospector creates an introspector for the dump at path. The
+// dialect is detected from the dump; fallbackDialect ("mysql" or "postgres")
+// is used when the content is inconclusive.
+func NewDDLIntrospector(path, fallbackDialect string) *DDLIntrospector {
+	return &DDLIntrospector{path: path, dialect: fallbackDialect}
+}
+
+// NewDDLIntrospectorFromSource creates an introspector over CREATE TABLE
+// statements held in memory, e.g. pasted into the GUI
+func NewDDLIntrospectorFromSource(source, fallbackDialect string) *DDLIntrospector {
+	return &DDLIntrospector{source: source, dialect: fallbackDialect}
+}
+
+// Connect reads and parses the dump
+func (d *DDLIntrospector) Connect() error {
+	src := d.source
+	if d.path != "" {
+		data, err := os.ReadFile(d.path)
+		if err != nil {
+			return fmt.Errorf("failed to read DDL file: %w", err)
+		}
+		src = string(data)
+	}
+
+	if detected := detectDDLDialect(src); detected != "" {
+		d.dialect = detected
+	}
+	if d.dialect == "postgresql" {
+		d.dialect = "postgres"
+	}
+	if d.dialect != "mysql" && d.dialect != "postgres" {
+		return fmt.Errorf("unsupported DDL dialect: %q (use mysql or postgres)", d.dialect)
+	}
+
+	schema, err := parseDDL(src, d.dialect)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", d.origin(), err)
+	}
+	if len(schema.tables) == 0 {
+		return fmt.Errorf("no CREATE TABLE statements found in %s", d.origin())
+	}
+
+	d.schema = schema
+	return nil
+}
+
+// Close releases the parsed schema
+func (d *DDLIntrospector) Close() error {
+	d.schema = nil
+	return nil
+}
+
+// Ping reports whether the dump has been loaded
+func (d *DDLIntrospector) Ping() error {
+	if d.schema == nil {
+		return fmt.Errorf("%s not loaded", d.origin())
+	}
+	return nil
+}
+
+// Dialect returns the SQL dialect of the dump: mysql or postgres
+func (d *DDLIntrospector) Dialect() string {
+	return d.dialect
+}
+
+// GetTables returns the tables defined in the dump, sorted by name
+func (d *DDLIntrospector) GetTables() ([]string, error) {
+	if err := d.Ping(); err != nil {
+		return nil, err
+	}
+	return d.schema.tableNames(), nil
+}
+
+// GetColumns returns column metadata for a table defined in the dump
+func (d *DDLIntrospector) GetColumns(tableName string) ([]ColumnMetadata, error) {
+	table, err := d.table(tableName)
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]ColumnMetadata, len(table.columns))
+	copy(columns, table.columns)
+	return columns, nil
+}
+
+// GetTableMetadata returns full metadata for a table defined in the dump
+func (d *DDLIntrospector) GetTableMetadata(tableName string) (*TableMetadata, error) {
+	columns, err := d.GetColumns(tableName)
+	if err != nil {
+		return nil, err
+	}
+	table := d.schema.tables[tableName]
+
+	meta := &TableMetadata{
+		Schema:  table.schema,
+		Name:    table.name,
+		Comment: table.comment,
+		Columns: columns,
+	}
+	splitForeignKeys(meta, d.schema.foreignKeys)
+
+	return meta, nil
+}
+
+// table returns the parsed table, or an error if it is not in the dump
+func (d *DDLIntrospector) table(tableName string) (*ddlTable, error) {
+	if err := d.Ping(); err != nil {
+		return nil, err
+	}
+	table, ok := d.schema.tables[tableName]
+	if !ok {
+		return nil, fmt.Errorf("table %s not found in %s: %w", tableName, d.origin(), ErrTableNotFound)
+	}
+	return table, nil
+}
+
+// origin describes where the DDL came from, for error messages
+func (d *DDLIntrospector) origin() string {
+	if d.path == "" {
+		return "DDL source"
+	}
+	return "DDL file " + d.path
+}
@@ -0,0 +1,224 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const mysqlDump = "-- MySQL dump 10.13  Distrib 8.0.36, for Linux (x86_64)\n" +
+	"/*!40101 SET NAMES utf8mb4 */;\n" +
+	"DROP TABLE IF EXISTS `users`;\n" +
+	"CREATE TABLE `users` (\n" +
+	"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+	"  `email` varchar(255) NOT NULL COMMENT 'Login, unique',\n" +
+	"  `status` enum('active','banned') DEFAULT 'active',\n" +
+	"  `balance` decimal(10,2) DEFAULT NULL,\n" +
+	"  `created_at` datetime DEFAULT CURRENT_TIMESTAMP,\n" +
+	"  PRIMARY KEY (`id`),\n" +
+	"  UNIQUE KEY `users_email` (`email`)\n" +
+	") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='Registered users';\n" +
+	"CREATE TABLE `orders` (\n" +
+	"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+	"  `user_id` bigint unsigned NOT NULL,\n" +
+	"  `location` point NOT NULL /*!80003 SRID 4326 */,\n" +
+	"  PRIMARY KEY (`id`),\n" +
+	"  KEY `orders_user` (`user_id`),\n" +
+	"  CONSTRAINT `orders_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE\n" +
+	") ENGINE=InnoDB;\n"
+
+const postgresDump = `--
+-- PostgreSQL database dump
+--
+SET search_path = '';
+
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    email character varying(255) NOT NULL,
+    tags text[],
+    price numeric(12,4) DEFAULT 0,
+    meta jsonb DEFAULT '{}'::jsonb,
+    created_at timestamp with time zone DEFAULT now()
+);
+
+COMMENT ON TABLE public.users IS 'Registered users';
+COMMENT ON COLUMN public.users.email IS 'Login';
+
+CREATE SEQUENCE public.users_id_seq AS integer START WITH 1;
+
+CREATE TABLE public.orders (
+    id bigserial PRIMARY KEY,
+    user_id integer REFERENCES public.users(id),
+    note text,
+    embedding public.vector(3)
+);
+
+CREATE FUNCTION public.touch() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at := now(); -- not a statement end
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+`
+
+func loadDDL(t *testing.T, content string) *DDLIntrospector {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "schema.sql")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	d := NewDDLIntrospector(path, "")
+	if err := d.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	return d
+}
+
+func TestDDLIntrospector_MySQLDump(t *testing.T) {
+	d := loadDDL(t, mysqlDump)
+	if d.Dialect() != "mysql" {
+		t.Errorf("Dialect() = %q, want mysql", d.Dialect())
+	}
+
+	tables, _ := d.GetTables()
+	if !reflect.DeepEqual(tables, []string{"orders", "users"}) {
+		t.Errorf("GetTables() = %v", tables)
+	}
+
+	meta, err := d.GetTableMetadata("users")
+	if err != nil {
+		t.Fatalf("GetTableMetadata() error = %v", err)
+	}
+	if meta.Comment != "Registered users" {
+		t.Errorf("Comment = %q", meta.Comment)
+	}
+
+	cols := meta.Columns
+	if len(cols) != 5 {
+		t.Fatalf("got %d columns, want 5", len(cols))
+	}
+	id := cols[0]
+	if id.DataType != "bigint" || id.RawType != "bigint unsigned" || !id.IsUnsigned ||
+		!id.IsPrimaryKey || !id.IsAutoIncrement || id.IsNullable {
+		t.Errorf("id = %+v", id)
+	}
+	email := cols[1]
+	if email.RawType != "varchar(255)" || email.CharMaxLength == nil || *email.CharMaxLength != 255 ||
+		email.Comment != "Login, unique" || email.IsNullable {
+		t.Errorf("email = %+v", email)
+	}
+	status := cols[2]
+	if !reflect.DeepEqual(status.EnumValues, []string{"active", "banned"}) ||
+		status.DefaultValue == nil || *status.DefaultValue != "active" {
+		t.Errorf("status = %+v", status)
+	}
+	balance := cols[3]
+	if balance.DefaultValue != nil || balance.NumericPrecision == nil || *balance.NumericPrecision != 10 ||
+		balance.NumericScale == nil || *balance.NumericScale != 2 {
+		t.Errorf("balance = %+v", balance)
+	}
+	if cols[4].DefaultValue == nil || *cols[4].DefaultValue != "CURRENT_TIMESTAMP" {
+		t.Errorf("created_at default = %v", cols[4].DefaultValue)
+	}
+
+	want := []ForeignKey{{Name: "orders_user_id_fkey", Table: "orders", Column: "user_id", ReferencedTable: "users", ReferencedColumn: "id"}}
+	if !reflect.DeepEqual(meta.ReferencedBy, want) {
+		t.Errorf("ReferencedBy = %+v", meta.ReferencedBy)
+	}
+
+	orders, err := d.GetColumns("orders")
+	if err != nil {
+		t.Fatalf("GetColumns(orders) error = %v", err)
+	}
+	if location := orders[2]; location.RawType != "point" || location.SRID == nil || *location.SRID != 4326 || location.IsNullable {
+		t.Errorf("location = %+v", location)
+	}
+}
+
+func TestDDLIntrospector_PostgresDump(t *testing.T) {
+	d := loadDDL(t, postgresDump)
+	if d.Dialect() != "postgres" {
+		t.Errorf("Dialect() = %q, want postgres", d.Dialect())
+	}
+
+	meta, err := d.GetTableMetadata("users")
+	if err != nil {
+		t.Fatalf("GetTableMetadata() error = %v", err)
+	}
+	if meta.Schema != "public" || meta.Comment != "Registered users" {
+		t.Errorf("meta = %q/%q", meta.Schema, meta.Comment)
+	}
+
+	tests := []struct {
+		name     string
+		dataType string
+		rawType  string
+	}{
+		{"id", "integer", "integer"},
+		{"email", "varchar", "varchar(255)"},
+		{"tags", "[]text", "[]text"},
+		{"price", "numeric", "numeric(12,4)"},
+		{"meta", "jsonb", "jsonb"},
+		{"created_at", "timestamptz", "timestamptz"},
+	}
+	for i, tt := range tests {
+		col := meta.Columns[i]
+		if col.Name != tt.name || col.DataType != tt.dataType || col.RawType != tt.rawType {
+			t.Errorf("column %d = %s %s (%s), want %s %s (%s)", i, col.Name, col.DataType, col.RawType, tt.name, tt.dataType, tt.rawType)
+		}
+	}
+
+	id := meta.Columns[0]
+	if !id.IsPrimaryKey || !id.IsAutoIncrement || id.IsNullable {
+		t.Errorf("id = %+v", id)
+	}
+	if meta.Columns[1].Comment != "Login" {
+		t.Errorf("email comment = %q", meta.Columns[1].Comment)
+	}
+	if def := meta.Columns[4].DefaultValue; def == nil || *def != "'{}'::jsonb" {
+		t.Errorf("meta default = %v", def)
+	}
+
+	orders, err := d.GetTableMetadata("orders")
+	if err != nil {
+		t.Fatalf("GetTableMetadata(orders) error = %v", err)
+	}
+	orderID := orders.Columns[0]
+	if orderID.DataType != "bigint" || !orderID.IsPrimaryKey || !orderID.IsAutoIncrement {
+		t.Errorf("orders.id = %+v", orderID)
+	}
+	if embedding := orders.Columns[3]; embedding.DataType != "vector" || embedding.RawType != "vector(3)" {
+		t.Errorf("orders.embedding = %s (%s), want vector (vector(3))", embedding.DataType, embedding.RawType)
+	}
+	if len(orders.ForeignKeys) != 1 || orders.ForeignKeys[0].ReferencedTable != "users" {
+		t.Errorf("ForeignKeys = %+v", orders.ForeignKeys)
+	}
+
+	if _, err := d.GetColumns("missing"); err == nil {
+		t.Error("GetColumns() should fail for tables not in the dump")
+	}
+}
+
+func TestDDLIntrospector_Source(t *testing.T) {
+	d := NewDDLIntrospectorFromSource("CREATE TABLE posts (id serial PRIMARY KEY, title varchar(80) NOT NULL)", "postgres")
+	if err := d.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	cols, err := d.GetColumns("posts")
+	if err != nil {
+		t.Fatalf("GetColumns() error = %v", err)
+	}
+	if len(cols) != 2 || !cols[0].IsAutoIncrement || cols[1].RawType != "varchar(80)" {
+		t.Errorf("GetColumns() = %+v", cols)
+	}
+
+	if err := NewDDLIntrospectorFromSource("SELECT 1;", "mysql").Connect(); err == nil {
+		t.Error("Connect() should fail without CREATE TABLE statements")
+	}
+}
@@ -0,0 +1,977 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ddlTokenKind classifies a token of a SQL dump
+type ddlTokenKind int
+
+const (
+	ddlIdent       ddlTokenKind = iota // Bare identifier or keyword
+	ddlQuotedIdent                     // `quoted` or "quoted" identifier
+	ddlString                          // 'string' or $$dollar-quoted$$ literal
+	ddlNumber                          // Numeric literal
+	ddlPunct                           // Punctuation such as ( ) , ; . ::
+)
+
+// ddlToken is a lexed token. start/end are byte offsets into the source,
+// used to recover expressions (e.g., defaults) verbatim.
+type ddlToken struct {
+	kind  ddlTokenKind
+	text  string // Identifier name, unescaped string value, or punctuation
+	start int
+	end   int
+}
+
+// ddlTable is a table reconstructed from CREATE TABLE and ALTER TABLE statements
+type ddlTable struct {
+	schema     string
+	name       string
+	comment    string
+	columns    []ColumnMetadata
+	primaryKey []string
+}
+
+// ddlSchema is the parsed content of a schema-only SQL dump
+type ddlSchema struct {
+	dialect     string
+	tables      map[string]*ddlTable
+	foreignKeys []ForeignKey
+}
+
+// parseDDL parses the CREATE TABLE, ALTER TABLE and COMMENT ON statements of a
+// mysqldump --no-data or pg_dump --schema-only file. Other statements are ignored.
+func parseDDL(src, dialect string) (*ddlSchema, error) {
+	tokens, err := lexDDL(src, dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &ddlSchema{dialect: dialect, tables: make(map[string]*ddlTable)}
+
+	start := 0
+	for i, tok := range tokens {
+		if tok.kind != ddlPunct || tok.text != ";" {
+			continue
+		}
+		if err := schema.parseStatement(src, tokens[start:i]); err != nil {
+			return nil, err
+		}
+		start = i + 1
+	}
+	if err := schema.parseStatement(src, tokens[start:]); err != nil {
+		return nil, err
+	}
+
+	schema.applyPrimaryKeys()
+	return schema, nil
+}
+
+// detectDDLDialect guesses whether a dump comes from MySQL or PostgreSQL.
+// It returns an empty string if the content is inconclusive.
+func detectDDLDialect(src string) string {
+	switch {
+	case strings.Contains(src, "PostgreSQL database dump"), strings.Contains(src, "SET search_path"),
+		strings.Contains(src, "::"), strings.Contains(src, "OWNER TO"):
+		return "postgres"
+	case strings.Contains(src, "MySQL dump"), strings.Contains(src, "MariaDB dump"),
+		strings.Contains(src, "`"), strings.Contains(src, "ENGINE="):
+		return "mysql"
+	}
+	return ""
+}
+
+// lexDDL splits SQL source into tokens, dropping comments
+func lexDDL(src, dialect string) ([]ddlToken, error) {
+	var tokens []ddlToken
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+
+		case c == '-' && strings.HasPrefix(src[i:], "--"), c == '#' && dialect == "mysql":
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+
+		case c == '/' && strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment at offset %d", i)
+			}
+			// mysqldump hides SRID clauses from older servers in versioned
+			// comments, e.g. /*!80003 SRID 4326 */
+			if body := strings.TrimLeft(src[i+2:i+2+end], "!0123456789"); dialect == "mysql" && src[i+2] == '!' &&
+				strings.HasPrefix(strings.ToUpper(strings.TrimSpace(body)), "SRID") {
+				inner, err := lexDDL(body, dialect)
+				if err != nil {
+					return nil, err
+				}
+				tokens = append(tokens, inner...)
+			}
+			i += end + 4
+
+		case c == '\'':
+			value, next, err := lexString(src, i, dialect == "mysql")
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, ddlToken{kind: ddlString, text: value, start: i, end: next})
+			i = next
+
+		case (c == 'E' || c == 'e') && i+1 < len(src) && src[i+1] == '\'':
+			// PostgreSQL escape string E'...'
+			value, next, err := lexString(src, i+1, true)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, ddlToken{kind: ddlString, text: value, start: i, end: next})
+			i = next
+
+		case c == '`' || c == '"':
+			end := i + 1
+			var b strings.Builder
+			for {
+				if end >= len(src) {
+					return nil, fmt.Errorf("unterminated identifier at offset %d", i)
+				}
+				if src[end] == c {
+					if end+1 < len(src) && src[end+1] == c {
+						b.WriteByte(c)
+						end += 2
+						continue
+					}
+					break
+				}
+				b.WriteByte(src[end])
+				end++
+			}
+			tokens = append(tokens, ddlToken{kind: ddlQuotedIdent, text: b.String(), start: i, end: end + 1})
+			i = end + 1
+
+		case c == '$' && dollarTag(src[i:]) != "":
+			tag := dollarTag(src[i:])
+			end := strings.Index(src[i+len(tag):], tag)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated dollar-quoted string at offset %d", i)
+			}
+			bodyStart := i + len(tag)
+			next := bodyStart + end + len(tag)
+			tokens = append(tokens, ddlToken{kind: ddlString, text: src[bodyStart : bodyStart+end], start: i, end: next})
+			i = next
+
+		case c >= '0' && c <= '9':
+			end := i
+			for end < len(src) && (isDigit(src[end]) || src[end] == '.' || src[end] == 'e' || src[end] == 'E') {
+				end++
+			}
+			tokens = append(tokens, ddlToken{kind: ddlNumber, text: src[i:end], start: i, end: end})
+			i = end
+
+		case isIdentStart(c):
+			end := i
+			for end < len(src) && (isIdentStart(src[end]) || isDigit(src[end]) || src[end] == '$') {
+				end++
+			}
+			tokens = append(tokens, ddlToken{kind: ddlIdent, text: src[i:end], start: i, end: end})
+			i = end
+
+		case c == ':' && strings.HasPrefix(src[i:], "::"):
+			tokens = append(tokens, ddlToken{kind: ddlPunct, text: "::", start: i, end: i + 2})
+			i += 2
+
+		default:
+			tokens = append(tokens, ddlToken{kind: ddlPunct, text: string(c), start: i, end: i + 1})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// lexString reads a single-quoted string starting at src[start]. Doubled
+// quotes are always unescaped; backslash escapes only if backslashEscapes.
+func lexString(src string, start int, backslashEscapes bool) (string, int, error) {
+	var b strings.Builder
+	i := start + 1
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c == '\\' && backslashEscapes && i+1 < len(src):
+			switch src[i+1] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case '0':
+				b.WriteByte(0)
+			default:
+				b.WriteByte(src[i+1])
+			}
+			i += 2
+		case c == '\'':
+			if i+1 < len(src) && src[i+1] == '\'' {
+				b.WriteByte('\'')
+				i += 2
+				continue
+			}
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string at offset %d", start)
+}
+
+// dollarTag returns the opening tag of a dollar-quoted string ($$ or $tag$)
+// at the start of s, or an empty string
+func dollarTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '$' {
+			return s[:i+1]
+		}
+		if !isIdentStart(s[i]) && !isDigit(s[i]) {
+			return ""
+		}
+	}
+	return ""
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// ddlStatement is a cursor over the tokens of one statement
+type ddlStatement struct {
+	src    string
+	tokens []ddlToken
+	pos    int
+}
+
+// peekKeyword reports whether the tokens at the cursor are the given keywords
+func (s *ddlStatement) peekKeyword(keywords ...string) bool {
+	for i, kw := range keywords {
+		if s.pos+i >= len(s.tokens) {
+			return false
+		}
+		tok := s.tokens[s.pos+i]
+		if tok.kind != ddlIdent || !strings.EqualFold(tok.text, kw) {
+			return false
+		}
+	}
+	return true
+}
+
+// acceptKeyword advances past the given keywords if they are next
+func (s *ddlStatement) acceptKeyword(keywords ...string) bool {
+	if !s.peekKeyword(keywords...) {
+		return false
+	}
+	s.pos += len(keywords)
+	return true
+}
+
+// peekPunct reports whether the next token is the given punctuation
+func (s *ddlStatement) peekPunct(p string) bool {
+	return s.pos < len(s.tokens) && s.tokens[s.pos].kind == ddlPunct && s.tokens[s.pos].text == p
+}
+
+// done reports whether all tokens have been consumed
+func (s *ddlStatement) done() bool {
+	return s.pos >= len(s.tokens)
+}
+
+// name reads a possibly schema-qualified name and returns its parts
+func (s *ddlStatement) name() (schema, name string, ok bool) {
+	var parts []string
+	for s.pos < len(s.tokens) {
+		tok := s.tokens[s.pos]
+		if tok.kind != ddlIdent && tok.kind != ddlQuotedIdent {
+			break
+		}
+		parts = append(parts, tok.text)
+		s.pos++
+		if !s.peekPunct(".") {
+			break
+		}
+		s.pos++
+	}
+
+	switch len(parts) {
+	case 0:
+		return "", "", false
+	case 1:
+		return "", parts[0], true
+	default:
+		return parts[len(parts)-2], parts[len(parts)-1], true
+	}
+}
+
+// errUnbalanced is returned when a parenthesized group is never closed
+var errUnbalanced = errors.New("unbalanced parentheses")
+
+// group returns the tokens inside the parenthesized group at the cursor
+// and advances past it. It reports false, leaving the cursor in place, if the
+// group is never closed.
+func (s *ddlStatement) group() ([]ddlToken, bool) {
+	if !s.peekPunct("(") {
+		return nil, false
+	}
+	depth := 0
+	for i := s.pos; i < len(s.tokens); i++ {
+		tok := s.tokens[i]
+		if tok.kind != ddlPunct {
+			continue
+		}
+		switch tok.text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				inner := s.tokens[s.pos+1 : i]
+				s.pos = i + 1
+				return inner, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// nameList reads a parenthesized, comma-separated list of column names
+func (s *ddlStatement) nameList() []string {
+	inner, ok := s.group()
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, part := range splitTopLevel(inner) {
+		if len(part) > 0 && (part[0].kind == ddlIdent || part[0].kind == ddlQuotedIdent) {
+			names = append(names, part[0].text)
+		}
+	}
+	return names
+}
+
+// splitTopLevel splits tokens on commas outside parentheses
+func splitTopLevel(tokens []ddlToken) [][]ddlToken {
+	var parts [][]ddlToken
+	depth, start := 0, 0
+	for i, tok := range tokens {
+		if tok.kind != ddlPunct {
+			continue
+		}
+		switch tok.text {
+		case "(", "[":
+			depth++
+		case ")", "]":
+			depth--
+		case ",":
+			if depth == 0 {
+				parts = append(parts, tokens[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(tokens) {
+		parts = append(parts, tokens[start:])
+	}
+	return parts
+}
+
+// parseStatement dispatches a single statement
+func (d *ddlSchema) parseStatement(src string, tokens []ddlToken) error {
+	s := &ddlStatement{src: src, tokens: tokens}
+
+	switch {
+	case s.acceptKeyword("CREATE"):
+		s.acceptKeyword("OR", "REPLACE")
+		for s.acceptKeyword("TEMPORARY") || s.acceptKeyword("TEMP") || s.acceptKeyword("UNLOGGED") {
+		}
+		if !s.acceptKeyword("TABLE") {
+			return nil
+		}
+		s.acceptKeyword("IF", "NOT", "EXISTS")
+		return d.parseCreateTable(s)
+
+	case s.acceptKeyword("ALTER", "TABLE"):
+		s.acceptKeyword("IF", "EXISTS")
+		s.acceptKeyword("ONLY")
+		return d.parseAlterTable(s)
+
+	case s.acceptKeyword("COMMENT", "ON"):
+		return d.parseComment(s)
+	}
+	return nil
+}
+
+// parseCreateTable handles CREATE TABLE name (...) [options]
+func (d *ddlSchema) parseCreateTable(s *ddlStatement) error {
+	schemaName, tableName, ok := s.name()
+	if !ok {
+		return fmt.Errorf("CREATE TABLE without a table name")
+	}
+	body, ok := s.group()
+	if !ok {
+		// CREATE TABLE ... AS SELECT / LIKE / PARTITION OF are not supported
+		return nil
+	}
+
+	table := &ddlTable{schema: schemaName, name: tableName}
+	for _, element := range splitTopLevel(body) {
+		if err := d.parseTableElement(table, &ddlStatement{src: s.src, tokens: element}); err != nil {
+			return fmt.Errorf("table %s: %w", tableName, err)
+		}
+	}
+
+	// MySQL table options, e.g. ENGINE=InnoDB COMMENT='Users'
+	for !s.done() {
+		if s.acceptKeyword("COMMENT") {
+			if s.peekPunct("=") {
+				s.pos++
+			}
+			if !s.done() && s.tokens[s.pos].kind == ddlString {
+				table.comment = s.tokens[s.pos].text
+			}
+		}
+		s.pos++
+	}
+
+	for i := range table.columns {
+		table.columns[i].OrdinalPosition = i + 1
+	}
+	d.tables[tableName] = table
+	return nil
+}
+
+// parseTableElement handles a column definition or table constraint
+func (d *ddlSchema) parseTableElement(table *ddlTable, s *ddlStatement) error {
+	if s.acceptKeyword("CONSTRAINT") {
+		s.name()
+	}
+
+	switch {
+	case s.acceptKeyword("PRIMARY", "KEY"):
+		table.primaryKey = s.nameList()
+		return nil
+	case s.acceptKeyword("FOREIGN", "KEY"):
+		d.parseForeignKey(table.name, s)
+		return nil
+	case s.peekKeyword("UNIQUE"), s.peekKeyword("KEY"), s.peekKeyword("INDEX"), s.peekKeyword("FULLTEXT"),
+		s.peekKeyword("SPATIAL"), s.peekKeyword("CHECK"), s.peekKeyword("EXCLUDE"), s.peekKeyword("LIKE"):
+		return nil
+	}
+
+	col, ok, err := d.parseColumn(table.name, s)
+	if err != nil || !ok {
+		return err
+	}
+	if col.IsPrimaryKey {
+		table.primaryKey = append(table.primaryKey, col.Name)
+	}
+	table.columns = append(table.columns, col)
+	return nil
+}
+
+// parseForeignKey handles (cols) REFERENCES table (cols); only single-column
+// keys are recorded, matching the live introspectors
+func (d *ddlSchema) parseForeignKey(tableName string, s *ddlStatement) {
+	columns := s.nameList()
+	if s.acceptKeyword("REFERENCES") {
+		d.parseReferences(tableName, columns, s)
+	}
+}
+
+// parseReferences handles the table (cols) following REFERENCES
+func (d *ddlSchema) parseReferences(tableName string, columns []string, s *ddlStatement) {
+	_, refTable, ok := s.name()
+	if !ok {
+		return
+	}
+	refColumns := s.nameList()
+	if len(columns) != 1 || len(refColumns) != 1 {
+		return
+	}
+	d.foreignKeys = append(d.foreignKeys, ForeignKey{
+		Name:             fmt.Sprintf("%s_%s_fkey", tableName, columns[0]),
+		Table:            tableName,
+		Column:           columns[0],
+		ReferencedTable:  refTable,
+		ReferencedColumn: refColumns[0],
+	})
+}
+
+// columnConstraintKeywords end a column's type
+var columnConstraintKeywords = map[string]bool{
+	"NOT": true, "NULL": true, "DEFAULT": true, "PRIMARY": true, "AUTO_INCREMENT": true,
+	"UNIQUE": true, "COMMENT": true, "REFERENCES": true, "GENERATED": true, "COLLATE": true,
+	"CONSTRAINT": true, "CHECK": true, "ON": true, "AS": true, "KEY": true, "STORED": true,
+	"VIRTUAL": true, "INVISIBLE": true, "VISIBLE": true, "COLUMN_FORMAT": true, "STORAGE": true,
+	"SRID": true, "CHARSET": true,
+}
+
+// isConstraintStart reports whether the token at i starts a column constraint
+func (s *ddlStatement) isConstraintStart(i int) bool {
+	tok := s.tokens[i]
+	if tok.kind != ddlIdent {
+		return false
+	}
+	upper := strings.ToUpper(tok.text)
+	if upper == "CHARACTER" {
+		// "character varying" is a type, "CHARACTER SET" a constraint
+		return i+1 < len(s.tokens) && strings.EqualFold(s.tokens[i+1].text, "SET")
+	}
+	return columnConstraintKeywords[upper]
+}
+
+// ddlType is a column type as written in the dump
+type ddlType struct {
+	name     string   // Lowercase type name without arguments, e.g. "character varying"
+	args     []string // Arguments, e.g. ["10", "2"] or ["'a'", "'b'"]
+	unsigned bool
+	zerofill bool
+	array    bool
+}
+
+// parseType reads the column type up to the first constraint keyword
+func (s *ddlStatement) parseType() (ddlType, error) {
+	var t ddlType
+	var words []string
+	for !s.done() && !s.isConstraintStart(s.pos) {
+		tok := s.tokens[s.pos]
+		switch {
+		case tok.kind == ddlPunct && tok.text == "(":
+			inner, ok := s.group()
+			if !ok {
+				return t, errUnbalanced
+			}
+			if t.args == nil {
+				for _, part := range splitTopLevel(inner) {
+					t.args = append(t.args, tokensText(part))
+				}
+			}
+			continue
+		case tok.kind == ddlPunct && tok.text == "[":
+			t.array = true
+		case tok.kind == ddlPunct && tok.text == ".":
+			// Schema-qualified type: keep only the type name
+			words = words[:0]
+		case tok.kind == ddlIdent && strings.EqualFold(tok.text, "unsigned"):
+			t.unsigned = true
+		case tok.kind == ddlIdent && strings.EqualFold(tok.text, "zerofill"):
+			t.zerofill = true
+		case tok.kind == ddlIdent && strings.EqualFold(tok.text, "signed"):
+		case tok.kind == ddlIdent || tok.kind == ddlQuotedIdent:
+			words = append(words, strings.ToLower(tok.text))
+		}
+		s.pos++
+	}
+	t.name = strings.Join(words, " ")
+	return t, nil
+}
+
+// tokensText renders tokens compactly, re-quoting string literals
+func tokensText(tokens []ddlToken) string {
+	var b strings.Builder
+	for i, tok := range tokens {
+		if i > 0 && tok.kind != ddlPunct && tokens[i-1].kind != ddlPunct {
+			b.WriteByte(' ')
+		}
+		if tok.kind == ddlString {
+			b.WriteString("'" + strings.ReplaceAll(tok.text, "'", "''") + "'")
+		} else {
+			b.WriteString(tok.text)
+		}
+	}
+	return b.String()
+}
+
+// parseColumn handles name type [constraints...]
+func (d *ddlSchema) parseColumn(tableName string, s *ddlStatement) (ColumnMetadata, bool, error) {
+	if s.done() || (s.tokens[0].kind != ddlIdent && s.tokens[0].kind != ddlQuotedIdent) {
+		return ColumnMetadata{}, false, nil
+	}
+	col := ColumnMetadata{Name: s.tokens[0].text, IsNullable: true}
+	s.pos = 1
+
+	typ, err := s.parseType()
+	if err != nil {
+		return col, false, fmt.Errorf("column %s: %w", col.Name, err)
+	}
+	serial := d.applyType(&col, typ)
+
+	for !s.done() {
+		switch {
+		case s.acceptKeyword("NOT", "NULL"):
+			col.IsNullable = false
+		case s.acceptKeyword("NULL"):
+		case s.acceptKeyword("PRIMARY", "KEY"):
+			col.IsPrimaryKey = true
+		case s.acceptKeyword("AUTO_INCREMENT"):
+			col.IsAutoIncrement = true
+		case s.acceptKeyword("GENERATED"):
+			if s.skipUntil("IDENTITY") {
+				col.IsAutoIncrement = true
+			}
+		case s.acceptKeyword("COMMENT"):
+			if !s.done() && s.tokens[s.pos].kind == ddlString {
+				col.Comment = s.tokens[s.pos].text
+				s.pos++
+			}
+		case s.acceptKeyword("DEFAULT"):
+			if col.DefaultValue, err = s.parseDefault(d.dialect); err != nil {
+				return col, false, fmt.Errorf("column %s: %w", col.Name, err)
+			}
+		case s.acceptKeyword("REFERENCES"):
+			d.parseReferences(tableName, []string{col.Name}, s)
+		case s.acceptKeyword("SRID"):
+			if !s.done() {
+				if srid, err := strconv.Atoi(s.tokens[s.pos].text); err == nil {
+					col.SRID = &srid
+					s.pos++
+				}
+			}
+		default:
+			s.pos++
+		}
+	}
+
+	if serial {
+		col.IsAutoIncrement = true
+		col.IsNullable = false
+		if col.DefaultValue == nil {
+			def := fmt.Sprintf("nextval('%s_%s_seq'::regclass)", tableName, col.Name)
+			col.DefaultValue = &def
+		}
+	}
+	if col.DefaultValue != nil && strings.Contains(*col.DefaultValue, "nextval") {
+		col.IsAutoIncrement = true
+	}
+	if col.IsPrimaryKey {
+		col.IsNullable = false
+	}
+	return col, true, nil
+}
+
+// skipUntil advances past the given keyword, reporting whether it was found
+func (s *ddlStatement) skipUntil(keyword string) bool {
+	for !s.done() {
+		if s.acceptKeyword(keyword) {
+			return true
+		}
+		s.pos++
+	}
+	return false
+}
+
+// parseDefault reads a DEFAULT expression up to the next column constraint.
+// MySQL reports string defaults unquoted and NULL as no default; PostgreSQL
+// reports the expression as written.
+func (s *ddlStatement) parseDefault(dialect string) (*string, error) {
+	start := s.pos
+	if s.peekPunct("(") {
+		if _, ok := s.group(); !ok {
+			return nil, errUnbalanced
+		}
+	}
+	for !s.done() && !s.isConstraintStart(s.pos) {
+		if s.peekPunct("(") {
+			if _, ok := s.group(); !ok {
+				return nil, errUnbalanced
+			}
+			continue
+		}
+		s.pos++
+	}
+	if start == s.pos {
+		return nil, nil
+	}
+
+	expr := s.tokens[start:s.pos]
+	if dialect == "mysql" {
+		if len(expr) == 1 && expr[0].kind == ddlString {
+			value := expr[0].text
+			return &value, nil
+		}
+		if len(expr) == 1 && strings.EqualFold(expr[0].text, "NULL") {
+			return nil, nil
+		}
+	}
+
+	value := s.src[expr[0].start:expr[len(expr)-1].end]
+	return &value, nil
+}
+
+// applyType fills the type fields of a column. It reports whether the type is
+// a PostgreSQL serial pseudo-type.
+func (d *ddlSchema) applyType(col *ColumnMetadata, t ddlType) bool {
+	if d.dialect == "mysql" {
+		applyMySQLType(col, t)
+		return false
+	}
+	return applyPostgresType(col, t)
+}
+
+// applyMySQLType mirrors information_schema DATA_TYPE and COLUMN_TYPE
+func applyMySQLType(col *ColumnMetadata, t ddlType) {
+	col.DataType = t.name
+	col.RawType = t.name
+	if len(t.args) > 0 {
+		col.RawType += "(" + strings.Join(t.args, ",") + ")"
+	}
+	if t.unsigned || t.zerofill {
+		// ZEROFILL implies UNSIGNED, which MySQL reports explicitly
+		col.RawType += " unsigned"
+		col.IsUnsigned = true
+	}
+	if t.zerofill {
+		col.RawType += " zerofill"
+	}
+
+	switch col.DataType {
+	case "char", "varchar", "binary", "varbinary":
+		if len(t.args) > 0 {
+			if length, err := strconv.Atoi(t.args[0]); err == nil {
+				col.CharMaxLength = &length
+			}
+		}
+	case "decimal", "numeric":
+		setPrecision(col, t.args)
+	case "enum":
+		col.EnumValues = parseEnumValues(col.RawType)
+	}
+}
+
+// postgresTypes maps type names as written by pg_dump to the
+// information_schema data_type and udt_name reported by a live database
+var postgresTypes = map[string][2]string{
+	"integer": {"integer", "int4"}, "int": {"integer", "int4"}, "int4": {"integer", "int4"},
+	"bigint": {"bigint", "int8"}, "int8": {"bigint", "int8"},
+	"smallint": {"smallint", "int2"}, "int2": {"smallint", "int2"},
+	"serial": {"integer", "int4"}, "serial4": {"integer", "int4"},
+	"bigserial": {"bigint", "int8"}, "serial8": {"bigint", "int8"},
+	"smallserial": {"smallint", "int2"}, "serial2": {"smallint", "int2"},
+	"real": {"real", "float4"}, "float4": {"real", "float4"},
+	"double precision": {"double precision", "float8"}, "float8": {"double precision", "float8"},
+	"numeric": {"numeric", "numeric"}, "decimal": {"numeric", "numeric"},
+	"character varying": {"character varying", "varchar"}, "varchar": {"character varying", "varchar"},
+	"character": {"character", "bpchar"}, "char": {"character", "bpchar"}, "bpchar": {"character", "bpchar"},
+	"text":    {"text", "text"},
+	"boolean": {"boolean", "bool"}, "bool": {"boolean", "bool"},
+	"timestamp":                   {"timestamp without time zone", "timestamp"},
+	"timestamp without time zone": {"timestamp without time zone", "timestamp"},
+	"timestamp with time zone":    {"timestamp with time zone", "timestamptz"},
+	"timestamptz":                 {"timestamp with time zone", "timestamptz"},
+	"date":                        {"date", "date"},
+	"time":                        {"time without time zone", "time"},
+	"time without time zone":      {"time without time zone", "time"},
+	"time with time zone":         {"time with time zone", "timetz"},
+	"timetz":                      {"time with time zone", "timetz"},
+	"interval":                    {"interval", "interval"},
+	"json":                        {"json", "json"},
+	"jsonb":                       {"jsonb", "jsonb"},
+	"uuid":                        {"uuid", "uuid"},
+	"bytea":                       {"bytea", "bytea"},
+	"inet":                        {"inet", "inet"},
+	"cidr":                        {"cidr", "cidr"},
+	"macaddr":                     {"macaddr", "macaddr"},
+	"money":                       {"money", "money"},
+	"xml":                         {"xml", "xml"},
+	"tsvector":                    {"tsvector", "tsvector"},
+	"bit":                         {"bit", "bit"},
+	"bit varying":                 {"bit varying", "varbit"},
+}
+
+// applyPostgresType mirrors the live PostgreSQL introspector's type normalization
+func applyPostgresType(col *ColumnMetadata, t ddlType) bool {
+	name := t.name
+	if strings.HasSuffix(name, "[]") {
+		name = strings.TrimSuffix(name, "[]")
+	}
+
+	dataType, udtName := "USER-DEFINED", name
+	if mapped, ok := postgresTypes[name]; ok {
+		dataType, udtName = mapped[0], mapped[1]
+	}
+	if t.array {
+		dataType, udtName = "ARRAY", "_"+udtName
+	}
+
+	var charMaxLength, precision, scale sql.NullInt64
+	if (udtName == "varchar" || udtName == "bpchar") && len(t.args) > 0 {
+		charMaxLength = parseNullInt(t.args[0])
+	}
+	if udtName == "numeric" && len(t.args) > 0 {
+		precision = parseNullInt(t.args[0])
+		if len(t.args) > 1 {
+			scale = parseNullInt(t.args[1])
+		}
+		setPrecision(col, t.args)
+	}
+	if charMaxLength.Valid {
+		length := int(charMaxLength.Int64)
+		col.CharMaxLength = &length
+	}
+
+	col.DataType = normalizePostgresType(dataType, udtName)
+	col.RawType = buildPostgresRawType(dataType, udtName, charMaxLength, precision, scale)
+	col.RawType = userDefinedRawType(col.RawType, dataType, strings.Join(t.args, ","))
+
+	return strings.HasPrefix(name, "serial") || strings.HasSuffix(name, "serial")
+}
+
+// parseNullInt converts a type argument to a sql.NullInt64
+func parseNullInt(s string) sql.NullInt64 {
+	n, err := strconv.ParseInt(s, 10, 64)
+	return sql.NullInt64{Int64: n, Valid: err == nil}
+}
+
+// setPrecision records numeric precision and scale from type arguments
+func setPrecision(col *ColumnMetadata, args []string) {
+	if len(args) > 0 {
+		if precision, err := strconv.Atoi(args[0]); err == nil {
+			col.NumericPrecision = &precision
+		}
+	}
+	if len(args) > 1 {
+		if scale, err := strconv.Atoi(args[1]); err == nil {
+			col.NumericScale = &scale
+		}
+	}
+}
+
+// parseAlterTable handles the ALTER TABLE forms pg_dump uses for keys,
+// serial defaults and identity columns
+func (d *ddlSchema) parseAlterTable(s *ddlStatement) error {
+	_, tableName, ok := s.name()
+	if !ok {
+		return nil
+	}
+	table := d.tables[tableName]
+
+	for _, action := range splitTopLevel(s.tokens[s.pos:]) {
+		a := &ddlStatement{src: s.src, tokens: action}
+		switch {
+		case a.acceptKeyword("ADD"):
+			if a.acceptKeyword("CONSTRAINT") {
+				a.name()
+			}
+			switch {
+			case a.acceptKeyword("PRIMARY", "KEY"):
+				if table != nil {
+					table.primaryKey = a.nameList()
+				}
+			case a.acceptKeyword("FOREIGN", "KEY"):
+				d.parseForeignKey(tableName, a)
+			}
+
+		case a.acceptKeyword("ALTER"):
+			a.acceptKeyword("COLUMN")
+			_, columnName, ok := a.name()
+			if !ok || table == nil {
+				continue
+			}
+			col := table.column(columnName)
+			if col == nil {
+				continue
+			}
+			switch {
+			case a.acceptKeyword("SET", "DEFAULT"):
+				def, err := a.parseDefault(d.dialect)
+				if err != nil {
+					return fmt.Errorf("table %s: column %s: %w", tableName, columnName, err)
+				}
+				col.DefaultValue = def
+				if col.DefaultValue != nil && strings.Contains(*col.DefaultValue, "nextval") {
+					col.IsAutoIncrement = true
+				}
+			case a.acceptKeyword("ADD", "GENERATED"):
+				col.IsAutoIncrement = true
+			case a.acceptKeyword("SET", "NOT", "NULL"):
+				col.IsNullable = false
+			}
+		}
+	}
+	return nil
+}
+
+// parseComment handles COMMENT ON TABLE/COLUMN name IS 'text'
+func (d *ddlSchema) parseComment(s *ddlStatement) error {
+	switch {
+	case s.acceptKeyword("TABLE"):
+		_, tableName, ok := s.name()
+		if !ok || !s.acceptKeyword("IS") || s.done() {
+			return nil
+		}
+		if table := d.tables[tableName]; table != nil && s.tokens[s.pos].kind == ddlString {
+			table.comment = s.tokens[s.pos].text
+		}
+
+	case s.acceptKeyword("COLUMN"):
+		// Name is [schema.]table.column
+		var parts []string
+		for !s.done() && (s.tokens[s.pos].kind == ddlIdent || s.tokens[s.pos].kind == ddlQuotedIdent) {
+			parts = append(parts, s.tokens[s.pos].text)
+			s.pos++
+			if !s.peekPunct(".") {
+				break
+			}
+			s.pos++
+		}
+		if len(parts) < 2 || !s.acceptKeyword("IS") || s.done() {
+			return nil
+		}
+		table := d.tables[parts[len(parts)-2]]
+		if table == nil || s.tokens[s.pos].kind != ddlString {
+			return nil
+		}
+		if col := table.column(parts[len(parts)-1]); col != nil {
+			col.Comment = s.tokens[s.pos].text
+		}
+	}
+	return nil
+}
+
+// column returns the named column of a table, or nil
+func (t *ddlTable) column(name string) *ColumnMetadata {
+	for i := range t.columns {
+		if t.columns[i].Name == name {
+			return &t.columns[i]
+		}
+	}
+	return nil
+}
+
+// applyPrimaryKeys marks primary key columns once all statements are parsed
+func (d *ddlSchema) applyPrimaryKeys() {
+	for _, table := range d.tables {
+		for _, name := range table.primaryKey {
+			if col := table.column(name); col != nil {
+				col.IsPrimaryKey = true
+				col.IsNullable = false
+			}
+		}
+	}
+}
+
+// tableNames returns the parsed table names in sorted order
+func (d *ddlSchema) tableNames() []string {
+	names := make([]string, 0, len(d.tables))
+	for name := range d.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
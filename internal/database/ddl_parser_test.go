@@ -0,0 +1,39 @@
+package database
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDDL_Unbalanced(t *testing.T) {
+	inputs := []string{
+		"CREATE TABLE t (x decimal( ], y int))",
+		"CREATE TABLE t (x int DEFAULT ( ], y int))",
+		"CREATE TABLE t (x int); ALTER TABLE t ALTER COLUMN x SET DEFAULT (1",
+	}
+	for _, src := range inputs {
+		done := make(chan error, 1)
+		go func() {
+			_, err := parseDDL(src, "postgres")
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			if err == nil {
+				t.Errorf("parseDDL(%q) should fail", src)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("parseDDL(%q) did not return", src)
+		}
+	}
+}
+
+func FuzzParseDDL(f *testing.F) {
+	f.Add(mysqlDump, "mysql")
+	f.Add(postgresDump, "postgres")
+	f.Add("CREATE TABLE t (x decimal( ], y int))", "mysql")
+	f.Add("CREATE TABLE t (x int DEFAULT ( ], y int))", "postgres")
+	f.Fuzz(func(t *testing.T, src, dialect string) {
+		parseDDL(src, dialect)
+	})
+}
@@ -0,0 +1,417 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/rowjak/godb-orm/internal/config"
+)
+
+// CheckStatus is the outcome of a diagnostic check
+type CheckStatus int
+
+// Check outcomes
+const (
+	CheckOK CheckStatus = iota
+	CheckWarn
+	CheckFail
+)
+
+// Check is the result of one diagnostic check run by Diagnose
+type Check struct {
+	Name   string      // What was checked, e.g. "network" or "schema usage"
+	Status CheckStatus // Outcome
+	Detail string      // What was found
+	Fix    string      // Statement or action resolving a warning or failure
+	Err    error       // Underlying error of a failed check, if any
+}
+
+// Diagnoser is implemented by introspectors that can check the catalog
+// privileges introspection needs on a connected database
+type Diagnoser interface {
+	// Diagnose runs the privilege checks of the dialect
+	Diagnose() []Check
+}
+
+// maxListedTables bounds the tables named in a check detail
+const maxListedTables = 10
+
+// Diagnose checks that cfg's database is reachable and that the user may
+// read the catalogs introspection queries: the TCP connection and SSL
+// support of the server, the login, and the dialect's privileges in schema
+// (PostgreSQL; "" keeps the default). It stops at the first check the later
+// ones depend on.
+func Diagnose(cfg *config.DBConfig, schema string) []Check {
+	var checks []Check
+	if cfg.Host != "" && cfg.Port > 0 {
+		network := checkNetwork(cfg)
+		checks = append(checks, network)
+		if network.Status == CheckFail {
+			return checks
+		}
+		if ssl, ok := checkSSL(cfg); ok {
+			checks = append(checks, ssl)
+		}
+	}
+
+	introspector, err := NewIntrospector(cfg)
+	if err != nil {
+		return append(checks, Check{Name: "driver", Status: CheckFail, Detail: err.Error(), Err: err})
+	}
+	if s, ok := introspector.(interface{ SetSchema(string) }); ok && schema != "" {
+		s.SetSchema(schema)
+	}
+	if err := introspector.Connect(); err != nil {
+		return append(checks, Check{Name: "login", Status: CheckFail, Detail: err.Error(), Err: err})
+	}
+	defer introspector.Close()
+	checks = append(checks, Check{Name: "login", Status: CheckOK, Detail: fmt.Sprintf("connected to %s as %s", cfg.DBName, cfg.User)})
+
+	if d, ok := introspector.(Diagnoser); ok {
+		return append(checks, d.Diagnose()...)
+	}
+	tables, err := introspector.GetTables()
+	if err != nil {
+		return append(checks, Check{Name: "catalog", Status: CheckFail, Detail: err.Error(), Err: err})
+	}
+	return append(checks, Check{Name: "catalog", Status: CheckOK, Detail: fmt.Sprintf("%d tables visible", len(tables))})
+}
+
+// checkNetwork opens a TCP connection to the configured host and port
+func checkNetwork(cfg *config.DBConfig) Check {
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout(cfg))
+	if err != nil {
+		return Check{
+			Name:   "network",
+			Status: CheckFail,
+			Detail: fmt.Sprintf("%s unreachable: %v", addr, err),
+			Fix:    "check the host and port, that the server listens on this address, and firewalls or VPNs in between",
+			Err:    err,
+		}
+	}
+	conn.Close()
+	return Check{Name: "network", Status: CheckOK, Detail: fmt.Sprintf("%s reachable in %s", addr, time.Since(start).Round(time.Millisecond))}
+}
+
+// dialTimeout bounds the network probes by the query timeout
+func dialTimeout(cfg *config.DBConfig) time.Duration {
+	return (&BaseIntrospector{cfg: cfg}).queryTimeout()
+}
+
+// checkSSL asks a MySQL or PostgreSQL server whether it accepts SSL
+// connections, reporting false for other drivers
+func checkSSL(cfg *config.DBConfig) (Check, bool) {
+	var probe func(conn net.Conn) (bool, error)
+	switch cfg.Driver {
+	case "mysql":
+		probe = mysqlOffersSSL
+	case "postgres", "postgresql":
+		probe = postgresOffersSSL
+	default:
+		return Check{}, false
+	}
+
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout(cfg))
+	if err != nil {
+		return Check{Name: "ssl", Status: CheckWarn, Detail: err.Error(), Err: err}, true
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(dialTimeout(cfg)))
+
+	offered, err := probe(conn)
+	switch {
+	case err != nil:
+		return Check{Name: "ssl", Status: CheckFail, Detail: err.Error(), Err: err}, true
+	case !offered:
+		return Check{
+			Name:   "ssl",
+			Status: CheckWarn,
+			Detail: "server does not offer SSL, credentials and data travel unencrypted",
+			Fix:    "enable SSL on the server if the connection leaves a trusted network",
+		}, true
+	}
+	return Check{Name: "ssl", Status: CheckOK, Detail: "server offers SSL"}, true
+}
+
+// postgresOffersSSL sends an SSLRequest, which the server answers with
+// 'S' if it accepts SSL and 'N' if not
+func postgresOffersSSL(conn net.Conn) (bool, error) {
+	request := make([]byte, 8)
+	binary.BigEndian.PutUint32(request[0:4], 8)
+	binary.BigEndian.PutUint32(request[4:8], 80877103)
+	if _, err := conn.Write(request); err != nil {
+		return false, fmt.Errorf("failed to send SSL request: %w", err)
+	}
+
+	answer := make([]byte, 1)
+	if _, err := io.ReadFull(conn, answer); err != nil {
+		return false, fmt.Errorf("failed to read SSL response: %w", err)
+	}
+	switch answer[0] {
+	case 'S':
+		return true, nil
+	case 'N':
+		return false, nil
+	}
+	return false, fmt.Errorf("unexpected SSL response %q, is this a PostgreSQL server?", answer[0])
+}
+
+// mysqlClientSSL is the CLIENT_SSL capability flag of the MySQL handshake
+const mysqlClientSSL = 0x0800
+
+// mysqlOffersSSL reads the initial handshake packet of a MySQL server and
+// reports whether it has the CLIENT_SSL capability. Servers refusing the
+// client's host send an error packet instead, which is returned.
+func mysqlOffersSSL(conn net.Conn) (bool, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return false, fmt.Errorf("failed to read handshake: %w", err)
+	}
+	length := int(header[0]) | int(header[1])<<8 | int(header[2])<<16
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(conn, payload); err != nil {
+		return false, fmt.Errorf("failed to read handshake: %w", err)
+	}
+
+	if len(payload) > 3 && payload[0] == 0xff {
+		// Error packet: 0xff, error number, message
+		number := binary.LittleEndian.Uint16(payload[1:3])
+		return false, fmt.Errorf("server refused the connection: %d %s", number, payload[3:])
+	}
+	if len(payload) == 0 || payload[0] != 10 {
+		return false, errors.New("unexpected handshake, is this a MySQL server?")
+	}
+
+	// protocol version, server version\0, connection id (4), auth data (8),
+	// filler (1), capability flags (2)
+	end := strings.IndexByte(string(payload[1:]), 0)
+	offset := 1 + end + 1 + 4 + 8 + 1
+	if end < 0 || len(payload) < offset+2 {
+		return false, errors.New("truncated handshake")
+	}
+	capabilities := binary.LittleEndian.Uint16(payload[offset : offset+2])
+	return capabilities&mysqlClientSSL != 0, nil
+}
+
+// Diagnose checks that the user can see the database's tables in
+// information_schema and holds SELECT on them
+func (m *MySQLIntrospector) Diagnose() []Check {
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	var checks []Check
+	var visible int
+	err := m.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = ?
+	`, m.cfg.DBName).Scan(&visible)
+	switch {
+	case err != nil:
+		err = m.wrapQueryError(ctx, err, "failed to query information_schema", "information_schema query")
+		return append(checks, Check{Name: "information_schema", Status: CheckFail, Detail: err.Error(), Err: err})
+	case visible == 0:
+		checks = append(checks, Check{
+			Name:   "information_schema",
+			Status: CheckWarn,
+			Detail: fmt.Sprintf("no tables of %s visible; information_schema only lists tables the user has privileges on", m.cfg.DBName),
+		})
+	default:
+		checks = append(checks, Check{Name: "information_schema", Status: CheckOK, Detail: fmt.Sprintf("%d tables of %s visible", visible, m.cfg.DBName)})
+	}
+
+	var account string
+	if err := m.db.QueryRowContext(ctx, "SELECT CURRENT_USER()").Scan(&account); err != nil {
+		err = m.wrapQueryError(ctx, err, "failed to query current user", "current user query")
+		return append(checks, Check{Name: "grants", Status: CheckWarn, Detail: err.Error(), Err: err})
+	}
+	grants, err := m.showGrants(ctx)
+	if err != nil {
+		return append(checks, Check{Name: "grants", Status: CheckWarn, Detail: fmt.Sprintf("cannot read grants of %s: %v", account, err), Err: err})
+	}
+	return append(checks, mysqlGrantCheck(grants, m.cfg.DBName, account))
+}
+
+// showGrants returns the SHOW GRANTS statements of the current account
+func (m *MySQLIntrospector) showGrants(ctx context.Context) ([]string, error) {
+	rows, err := m.db.QueryContext(ctx, "SHOW GRANTS FOR CURRENT_USER()")
+	if err != nil {
+		return nil, m.wrapQueryError(ctx, err, "failed to show grants", "SHOW GRANTS")
+	}
+	defer rows.Close()
+
+	var grants []string
+	for rows.Next() {
+		var grant string
+		if err := rows.Scan(&grant); err != nil {
+			return nil, fmt.Errorf("failed to scan grant: %w", err)
+		}
+		grants = append(grants, grant)
+	}
+	return grants, rows.Err()
+}
+
+// mysqlGrantCheck reports whether SHOW GRANTS output gives SELECT on every
+// table of dbName, through a global, database or role grant
+func mysqlGrantCheck(grants []string, dbName, account string) Check {
+	var roles, tables []string
+	for _, grant := range grants {
+		privileges, object, ok := parseMySQLGrant(grant)
+		if !ok {
+			continue
+		}
+		if object == "" {
+			// GRANT `role`@`%` TO ...
+			roles = append(roles, privileges)
+			continue
+		}
+		if !strings.Contains(privileges, "SELECT") && !strings.Contains(privileges, "ALL PRIVILEGES") {
+			continue
+		}
+		db, table, _ := strings.Cut(object, ".")
+		db = strings.ReplaceAll(strings.Trim(db, "`"), `\`, "")
+		switch {
+		case db == "*":
+			return Check{Name: "grants", Status: CheckOK, Detail: fmt.Sprintf("%s has SELECT on all databases", account)}
+		case db == dbName && table == "*":
+			return Check{Name: "grants", Status: CheckOK, Detail: fmt.Sprintf("%s has SELECT on %s", account, dbName)}
+		case db == dbName:
+			tables = append(tables, strings.Trim(table, "`"))
+		}
+	}
+
+	check := Check{
+		Name:   "grants",
+		Status: CheckFail,
+		Detail: fmt.Sprintf("%s has no SELECT grant on %s", account, dbName),
+		Fix:    fmt.Sprintf("GRANT SELECT ON `%s`.* TO %s;", dbName, quoteMySQLAccount(account)),
+	}
+	if len(tables) > 0 {
+		check.Status = CheckWarn
+		check.Detail = fmt.Sprintf("%s has SELECT on only some tables of %s (%s); the others are skipped", account, dbName, listTables(tables))
+	}
+	if len(roles) > 0 {
+		check.Status = CheckWarn
+		check.Detail += fmt.Sprintf(", unless granted through role %s", strings.Join(roles, ", "))
+	}
+	return check
+}
+
+// parseMySQLGrant splits a SHOW GRANTS statement into its privileges and
+// object (db.table). Role grants have no ON clause and return the role as
+// privileges and an empty object.
+func parseMySQLGrant(grant string) (privileges, object string, ok bool) {
+	rest, ok := strings.CutPrefix(grant, "GRANT ")
+	if !ok {
+		return "", "", false
+	}
+	privileges, on, found := strings.Cut(rest, " ON ")
+	if !found {
+		role, _, _ := strings.Cut(rest, " TO ")
+		return role, "", true
+	}
+	object, _, _ = strings.Cut(on, " TO ")
+	object = strings.TrimPrefix(object, "TABLE ")
+	return privileges, object, true
+}
+
+// quoteMySQLAccount quotes user@host as returned by CURRENT_USER() for a
+// GRANT statement
+func quoteMySQLAccount(account string) string {
+	user, host, _ := strings.Cut(account, "@")
+	return fmt.Sprintf("'%s'@'%s'", user, host)
+}
+
+// listTables joins table names, shortening long lists
+func listTables(tables []string) string {
+	if len(tables) > maxListedTables {
+		return strings.Join(tables[:maxListedTables], ", ") + fmt.Sprintf(" and %d more", len(tables)-maxListedTables)
+	}
+	return strings.Join(tables, ", ")
+}
+
+// Diagnose checks that the user can read pg_catalog, use the current
+// schema and select from its tables
+func (p *PostgresIntrospector) Diagnose() []Check {
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	var checks []Check
+	var relations int
+	if err := p.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pg_catalog.pg_class").Scan(&relations); err != nil {
+		err = p.wrapQueryError(ctx, err, "failed to query pg_catalog", "pg_catalog query")
+		return append(checks, Check{Name: "pg_catalog", Status: CheckFail, Detail: err.Error(), Fix: "GRANT SELECT ON ALL TABLES IN SCHEMA pg_catalog TO " + pq.QuoteIdentifier(p.cfg.User) + ";", Err: err})
+	}
+	checks = append(checks, Check{Name: "pg_catalog", Status: CheckOK, Detail: "readable"})
+
+	schema := pq.QuoteIdentifier(p.currentSchema)
+	user := pq.QuoteIdentifier(p.cfg.User)
+	var exists, usage bool
+	err := p.db.QueryRowContext(ctx, `
+		SELECT TRUE, has_schema_privilege(oid, 'USAGE')
+		FROM pg_catalog.pg_namespace
+		WHERE nspname = $1
+	`, p.currentSchema).Scan(&exists, &usage)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return append(checks, Check{Name: "schema usage", Status: CheckFail, Detail: fmt.Sprintf("schema %s does not exist", p.currentSchema), Fix: "select an existing schema with --schema"})
+	case err != nil:
+		err = p.wrapQueryError(ctx, err, "failed to query schema privileges", "schema privilege query")
+		return append(checks, Check{Name: "schema usage", Status: CheckFail, Detail: err.Error(), Err: err})
+	case !usage:
+		return append(checks, Check{
+			Name:   "schema usage",
+			Status: CheckFail,
+			Detail: fmt.Sprintf("%s lacks USAGE on schema %s", p.cfg.User, p.currentSchema),
+			Fix:    fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO %s;", schema, user),
+		})
+	}
+	checks = append(checks, Check{Name: "schema usage", Status: CheckOK, Detail: fmt.Sprintf("USAGE on schema %s", p.currentSchema)})
+
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT c.relname
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relkind IN ('r', 'p')
+			AND NOT has_table_privilege(c.oid, 'SELECT')
+		ORDER BY c.relname
+	`, p.currentSchema)
+	if err != nil {
+		err = p.wrapQueryError(ctx, err, "failed to query table privileges", "table privilege query")
+		return append(checks, Check{Name: "table privileges", Status: CheckFail, Detail: err.Error(), Err: err})
+	}
+	defer rows.Close()
+
+	var missing []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return append(checks, Check{Name: "table privileges", Status: CheckFail, Detail: fmt.Sprintf("failed to scan table name: %v", err), Err: err})
+		}
+		missing = append(missing, table)
+	}
+	if err := rows.Err(); err != nil {
+		return append(checks, Check{Name: "table privileges", Status: CheckFail, Detail: err.Error(), Err: err})
+	}
+	if len(missing) > 0 {
+		return append(checks, Check{
+			Name:   "table privileges",
+			Status: CheckFail,
+			Detail: fmt.Sprintf("%s lacks SELECT on %d tables of %s, which information_schema hides: %s", p.cfg.User, len(missing), p.currentSchema, listTables(missing)),
+			Fix:    fmt.Sprintf("GRANT SELECT ON ALL TABLES IN SCHEMA %s TO %s;", schema, user),
+		})
+	}
+	return append(checks, Check{Name: "table privileges", Status: CheckOK, Detail: fmt.Sprintf("SELECT on all tables of %s", p.currentSchema)})
+}
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+)
+
+// serve accepts one connection on a local port and hands it to handle,
+// returning the config pointing at it
+func serve(t *testing.T, driver string, handle func(conn net.Conn)) *config.DBConfig {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			handle(conn)
+			conn.Close()
+		}
+	}()
+	addr := ln.Addr().(*net.TCPAddr)
+	return &config.DBConfig{Driver: driver, Host: "127.0.0.1", Port: addr.Port, QueryTimeout: 2}
+}
+
+// mysqlHandshake builds a handshake v10 packet with the given capabilities
+func mysqlHandshake(capabilities uint16) []byte {
+	payload := []byte{10}
+	payload = append(payload, "8.0.36\x00"...)
+	payload = append(payload, 1, 0, 0, 0)    // connection id
+	payload = append(payload, "abcdefgh"...) // auth data part 1
+	payload = append(payload, 0)             // filler
+	payload = binary.LittleEndian.AppendUint16(payload, capabilities)
+	return append([]byte{byte(len(payload)), 0, 0, 0}, payload...)
+}
+
+func TestCheckSSL(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver string
+		handle func(conn net.Conn)
+		want   CheckStatus
+		detail string
+	}{
+		{"postgres ssl", "postgres", func(conn net.Conn) {
+			conn.Read(make([]byte, 8))
+			conn.Write([]byte("S"))
+		}, CheckOK, "offers SSL"},
+		{"postgres plain", "postgres", func(conn net.Conn) {
+			conn.Read(make([]byte, 8))
+			conn.Write([]byte("N"))
+		}, CheckWarn, "does not offer SSL"},
+		{"mysql ssl", "mysql", func(conn net.Conn) {
+			conn.Write(mysqlHandshake(0xffff))
+		}, CheckOK, "offers SSL"},
+		{"mysql plain", "mysql", func(conn net.Conn) {
+			conn.Write(mysqlHandshake(0xffff &^ mysqlClientSSL))
+		}, CheckWarn, "does not offer SSL"},
+		{"mysql host refused", "mysql", func(conn net.Conn) {
+			msg := "Host '10.0.0.9' is not allowed to connect to this MySQL server"
+			payload := append([]byte{0xff, 0x6a, 0x04}, msg...)
+			conn.Write(append([]byte{byte(len(payload)), 0, 0, 0}, payload...))
+		}, CheckFail, "1130 Host '10.0.0.9' is not allowed"},
+	}
+	for _, tt := range tests {
+		cfg := serve(t, tt.driver, tt.handle)
+		check, ok := checkSSL(cfg)
+		if !ok {
+			t.Fatalf("%s: checkSSL() not run", tt.name)
+		}
+		if check.Status != tt.want || !strings.Contains(check.Detail, tt.detail) {
+			t.Errorf("%s: checkSSL() = %+v, want status %d with %q", tt.name, check, tt.want, tt.detail)
+		}
+	}
+
+	if _, ok := checkSSL(&config.DBConfig{Driver: "trino"}); ok {
+		t.Errorf("checkSSL() should skip drivers without a probe")
+	}
+}
+
+func TestDiagnoseUnreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	checks := Diagnose(&config.DBConfig{Driver: "postgres", Host: "127.0.0.1", Port: port, QueryTimeout: 2}, "")
+	if len(checks) != 1 || checks[0].Name != "network" || checks[0].Status != CheckFail || checks[0].Fix == "" {
+		t.Errorf("Diagnose() = %+v, want a single failed network check", checks)
+	}
+}
+
+func TestMySQLGrantCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		grants []string
+		want   CheckStatus
+		detail string
+	}{
+		{"global", []string{"GRANT SELECT, PROCESS ON *.* TO `app`@`%`"}, CheckOK, "all databases"},
+		{"all privileges", []string{"GRANT USAGE ON *.* TO `app`@`%`", "GRANT ALL PRIVILEGES ON `shop`.* TO `app`@`%`"}, CheckOK, "SELECT on shop"},
+		{"escaped name", []string{"GRANT SELECT ON `sh\\op`.* TO `app`@`%`"}, CheckOK, "SELECT on shop"},
+		{"usage only", []string{"GRANT USAGE ON *.* TO `app`@`%`"}, CheckFail, "no SELECT grant"},
+		{"other database", []string{"GRANT SELECT ON `crm`.* TO `app`@`%`"}, CheckFail, "no SELECT grant"},
+		{"some tables", []string{"GRANT SELECT ON `shop`.`users` TO `app`@`%`", "GRANT SELECT ON TABLE `shop`.`orders` TO `app`@`%`"}, CheckWarn, "only some tables of shop (users, orders)"},
+		{"role", []string{"GRANT USAGE ON *.* TO `app`@`%`", "GRANT `reader`@`%` TO `app`@`%`"}, CheckWarn, "role `reader`@`%`"},
+	}
+	for _, tt := range tests {
+		check := mysqlGrantCheck(tt.grants, "shop", "app@%")
+		if check.Status != tt.want || !strings.Contains(check.Detail, tt.detail) {
+			t.Errorf("%s: mysqlGrantCheck() = %+v, want status %d with %q", tt.name, check, tt.want, tt.detail)
+		}
+		if check.Status != CheckOK && check.Fix != "GRANT SELECT ON `shop`.* TO 'app'@'%';" {
+			t.Errorf("%s: Fix = %q", tt.name, check.Fix)
+		}
+	}
+}
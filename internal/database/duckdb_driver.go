@@ -0,0 +1,6 @@
+//go:build duckdb
+
+package database
+
+// go-duckdb uses cgo and bundles the DuckDB library
+import _ "github.com/marcboeker/go-duckdb"
@@ -0,0 +1,325 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/config"
+)
+
+// duckDBDriver is the database/sql driver name registered by
+// github.com/marcboeker/go-duckdb (compiled in with -tags duckdb)
+const duckDBDriver = "duckdb"
+
+// DuckDBIntrospector implements database introspection for DuckDB database
+// files through DuckDB's information_schema. DBName is the file path; the
+// file is opened read-only.
+type DuckDBIntrospector struct {
+	BaseIntrospector
+	currentSchema string
+}
+
+// NewDuckDBIntrospector creates a new DuckDB introspector
+func NewDuckDBIntrospector(cfg *config.DBConfig) *DuckDBIntrospector {
+	return &DuckDBIntrospector{
+		BaseIntrospector: BaseIntrospector{cfg: cfg},
+		currentSchema:    "main", // Default schema
+	}
+}
+
+// SetSchema sets the current schema to use for table queries
+func (d *DuckDBIntrospector) SetSchema(schema string) {
+	d.currentSchema = schema
+}
+
+// GetCurrentSchema returns the current schema
+func (d *DuckDBIntrospector) GetCurrentSchema() string {
+	return d.currentSchema
+}
+
+// Connect opens the DuckDB database file
+func (d *DuckDBIntrospector) Connect() error {
+	if err := requireDriver(duckDBDriver, "duckdb"); err != nil {
+		return err
+	}
+
+	db, err := sql.Open(duckDBDriver, d.cfg.DBName+"?access_mode=read_only")
+	if err != nil {
+		return fmt.Errorf("failed to open DuckDB database: %w", err)
+	}
+
+	ctx, cancel := d.queryContext()
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return d.wrapQueryError(ctx, err, "failed to open DuckDB database "+d.cfg.DBName, "opening DuckDB database")
+	}
+
+	d.db = db
+	return nil
+}
+
+// Dialect returns the SQL dialect of the schema
+func (d *DuckDBIntrospector) Dialect() string {
+	return "duckdb"
+}
+
+// GetTables returns a list of table names in the current schema
+func (d *DuckDBIntrospector) GetTables() ([]string, error) {
+	query := `
+		SELECT table_name
+		FROM information_schema.tables
+		WHERE table_schema = ? AND table_type = 'BASE TABLE'
+		ORDER BY table_name
+	`
+
+	ctx, cancel := d.queryContext()
+	defer cancel()
+
+	rows, err := d.db.QueryContext(ctx, query, d.currentSchema)
+	if err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to query tables", "table list query")
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var tableName string
+		if err := rows.Scan(&tableName); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+		tables = append(tables, tableName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to read tables", "table list query")
+	}
+
+	return tables, nil
+}
+
+// GetColumns returns column metadata for a specific table
+func (d *DuckDBIntrospector) GetColumns(tableName string) ([]ColumnMetadata, error) {
+	query := `
+		SELECT
+			column_name,
+			data_type,
+			is_nullable,
+			column_default,
+			character_maximum_length,
+			numeric_precision,
+			numeric_scale,
+			ordinal_position
+		FROM information_schema.columns
+		WHERE table_schema = ? AND table_name = ?
+		ORDER BY ordinal_position
+	`
+
+	primaryKeys, err := d.getPrimaryKeyColumns(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := d.queryContext()
+	defer cancel()
+
+	rows, err := d.db.QueryContext(ctx, query, d.currentSchema, tableName)
+	if err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to query columns", fmt.Sprintf("table %s metadata query", tableName))
+	}
+	defer rows.Close()
+
+	var columns []ColumnMetadata
+	for rows.Next() {
+		var (
+			columnName       string
+			dataType         string
+			isNullable       string
+			columnDefault    sql.NullString
+			charMaxLength    sql.NullInt64
+			numericPrecision sql.NullInt64
+			numericScale     sql.NullInt64
+			ordinalPosition  int
+		)
+		if err := rows.Scan(&columnName, &dataType, &isNullable, &columnDefault,
+			&charMaxLength, &numericPrecision, &numericScale, &ordinalPosition); err != nil {
+			return nil, fmt.Errorf("failed to scan column: %w", err)
+		}
+
+		rawType, unsigned := duckDBRawType(dataType)
+		col := ColumnMetadata{
+			Name:            columnName,
+			DataType:        baseTypeName(rawType),
+			RawType:         rawType,
+			IsNullable:      isNullable == "YES",
+			IsPrimaryKey:    primaryKeys[columnName],
+			IsUnsigned:      unsigned,
+			OrdinalPosition: ordinalPosition,
+		}
+		if columnDefault.Valid {
+			col.DefaultValue = &columnDefault.String
+			// Sequence-backed keys: DEFAULT nextval('users_id_seq')
+			if strings.Contains(columnDefault.String, "nextval") {
+				col.IsAutoIncrement = true
+			}
+		}
+		if charMaxLength.Valid {
+			length := int(charMaxLength.Int64)
+			col.CharMaxLength = &length
+		}
+		if col.DataType == "decimal" && numericPrecision.Valid {
+			precision := int(numericPrecision.Int64)
+			col.NumericPrecision = &precision
+			if numericScale.Valid {
+				scale := int(numericScale.Int64)
+				col.NumericScale = &scale
+			}
+		}
+		columns = append(columns, col)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to read columns", fmt.Sprintf("table %s metadata query", tableName))
+	}
+
+	return columns, nil
+}
+
+// getPrimaryKeyColumns returns a set of column names that are primary keys
+func (d *DuckDBIntrospector) getPrimaryKeyColumns(tableName string) (map[string]bool, error) {
+	query := `
+		SELECT kcu.column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_schema = tc.constraint_schema
+			AND kcu.constraint_name = tc.constraint_name
+			AND kcu.table_name = tc.table_name
+		WHERE tc.table_schema = ? AND tc.table_name = ? AND tc.constraint_type = 'PRIMARY KEY'
+	`
+
+	ctx, cancel := d.queryContext()
+	defer cancel()
+
+	rows, err := d.db.QueryContext(ctx, query, d.currentSchema, tableName)
+	if err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to query primary keys", fmt.Sprintf("table %s primary key query", tableName))
+	}
+	defer rows.Close()
+
+	pkColumns := make(map[string]bool)
+	for rows.Next() {
+		var columnName string
+		if err := rows.Scan(&columnName); err != nil {
+			return nil, fmt.Errorf("failed to scan primary key column: %w", err)
+		}
+		pkColumns[columnName] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to read primary keys", fmt.Sprintf("table %s primary key query", tableName))
+	}
+
+	return pkColumns, nil
+}
+
+// GetTableMetadata returns full metadata for a specific table
+func (d *DuckDBIntrospector) GetTableMetadata(tableName string) (*TableMetadata, error) {
+	columns, err := d.GetColumns(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &TableMetadata{
+		Schema:  d.currentSchema,
+		Name:    tableName,
+		Columns: columns,
+	}
+
+	foreignKeys, err := d.getForeignKeys(tableName)
+	if err != nil {
+		return nil, err
+	}
+	splitForeignKeys(meta, foreignKeys)
+
+	return meta, nil
+}
+
+// getForeignKeys returns single-column foreign keys declared on or referencing a table
+func (d *DuckDBIntrospector) getForeignKeys(tableName string) ([]ForeignKey, error) {
+	query := `
+		SELECT
+			rc.constraint_name,
+			kcu.table_name,
+			kcu.column_name,
+			ukcu.table_name,
+			ukcu.column_name
+		FROM information_schema.referential_constraints rc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_schema = rc.constraint_schema
+			AND kcu.constraint_name = rc.constraint_name
+		JOIN information_schema.key_column_usage ukcu
+			ON ukcu.constraint_schema = rc.unique_constraint_schema
+			AND ukcu.constraint_name = rc.unique_constraint_name
+			AND ukcu.ordinal_position = kcu.position_in_unique_constraint
+		WHERE rc.constraint_schema = ?
+			AND (kcu.table_name = ? OR ukcu.table_name = ?)
+			AND rc.constraint_name IN (
+				SELECT constraint_name
+				FROM information_schema.key_column_usage
+				WHERE constraint_schema = ?
+				GROUP BY constraint_name
+				HAVING COUNT(*) = 1
+			)
+		ORDER BY kcu.table_name, rc.constraint_name
+	`
+
+	ctx, cancel := d.queryContext()
+	defer cancel()
+
+	rows, err := d.db.QueryContext(ctx, query, d.currentSchema, tableName, tableName, d.currentSchema)
+	if err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to query foreign keys", fmt.Sprintf("table %s foreign key query", tableName))
+	}
+	defer rows.Close()
+
+	var foreignKeys []ForeignKey
+	for rows.Next() {
+		var fk ForeignKey
+		if err := rows.Scan(&fk.Name, &fk.Table, &fk.Column, &fk.ReferencedTable, &fk.ReferencedColumn); err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key: %w", err)
+		}
+		foreignKeys = append(foreignKeys, fk)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, d.wrapQueryError(ctx, err, "failed to read foreign keys", fmt.Sprintf("table %s foreign key query", tableName))
+	}
+
+	return foreignKeys, nil
+}
+
+// duckDBUnsignedTypes maps DuckDB's unsigned integer types to the spelling
+// the type mapper knows from MySQL
+var duckDBUnsignedTypes = map[string]string{
+	"utinyint":  "tinyint unsigned",
+	"usmallint": "smallint unsigned",
+	"uinteger":  "integer unsigned",
+	"ubigint":   "bigint unsigned",
+}
+
+// duckDBRawType lowercases a DuckDB data type and rewrites the types the
+// type mapper knows under another name: unsigned integers and timestamps
+// with a unit suffix. It reports whether the type is unsigned.
+func duckDBRawType(dataType string) (rawType string, unsigned bool) {
+	rawType = strings.ToLower(strings.TrimSpace(dataType))
+	if mapped, ok := duckDBUnsignedTypes[rawType]; ok {
+		return mapped, true
+	}
+	switch rawType {
+	case "timestamp_s", "timestamp_ms", "timestamp_ns":
+		return "timestamp", false
+	}
+	return rawType, false
+}
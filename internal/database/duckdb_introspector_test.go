@@ -0,0 +1,42 @@
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+)
+
+func TestDuckDBRawType(t *testing.T) {
+	tests := []struct {
+		dataType string
+		rawType  string
+		unsigned bool
+	}{
+		{"INTEGER", "integer", false},
+		{"UBIGINT", "bigint unsigned", true},
+		{"DECIMAL(18,3)", "decimal(18,3)", false},
+		{"TIMESTAMP_NS", "timestamp", false},
+		{"TIMESTAMP WITH TIME ZONE", "timestamp with time zone", false},
+		{"VARCHAR", "varchar", false},
+	}
+	for _, tt := range tests {
+		rawType, unsigned := duckDBRawType(tt.dataType)
+		if rawType != tt.rawType || unsigned != tt.unsigned {
+			t.Errorf("duckDBRawType(%q) = %q, %v; want %q, %v", tt.dataType, rawType, unsigned, tt.rawType, tt.unsigned)
+		}
+	}
+}
+
+func TestDuckDBIntrospector_RequiresBuildTag(t *testing.T) {
+	introspector, err := NewIntrospector(&config.DBConfig{Driver: "duckdb", DBName: "analytics.duckdb"})
+	if err != nil {
+		t.Fatalf("NewIntrospector() error = %v", err)
+	}
+	if requireDriver(duckDBDriver, "duckdb") == nil {
+		t.Skip("built with -tags duckdb")
+	}
+	if err := introspector.Connect(); err == nil || !strings.Contains(err.Error(), "-tags duckdb") {
+		t.Errorf("Connect() error = %v; want a hint to build with -tags duckdb", err)
+	}
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+// Errors detected from driver error codes. They are wrapped together with
+// the driver error, so errors.Is matches them and the message keeps the
+// driver's details.
+var (
+	// ErrAuthFailed means the server rejected the user or password
+	ErrAuthFailed = errors.New("authentication failed")
+	// ErrUnknownDatabase means the database to connect to doesn't exist
+	ErrUnknownDatabase = errors.New("unknown database")
+	// ErrTableNotFound means the table doesn't exist or isn't visible to the user
+	ErrTableNotFound = errors.New("table not found")
+	// ErrPermissionDenied means the user lacks privileges on the schema
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// MySQL server error numbers
+const (
+	mysqlAccessDenied         = 1045 // ER_ACCESS_DENIED_ERROR
+	mysqlDBAccessDenied       = 1044 // ER_DBACCESS_DENIED_ERROR
+	mysqlUnknownDatabase      = 1049 // ER_BAD_DB_ERROR
+	mysqlTableAccessDenied    = 1142 // ER_TABLEACCESS_DENIED_ERROR
+	mysqlColumnAccessDenied   = 1143 // ER_COLUMNACCESS_DENIED_ERROR
+	mysqlNoSuchTable          = 1146 // ER_NO_SUCH_TABLE
+	mysqlSpecificAccessDenied = 1227 // ER_SPECIFIC_ACCESS_DENIED_ERROR
+)
+
+// classifyError returns the error matching a driver error code, or nil if
+// the error is not one of the classified kinds
+func classifyError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case mysqlAccessDenied:
+			return ErrAuthFailed
+		case mysqlUnknownDatabase:
+			return ErrUnknownDatabase
+		case mysqlNoSuchTable:
+			return ErrTableNotFound
+		case mysqlDBAccessDenied, mysqlTableAccessDenied, mysqlColumnAccessDenied, mysqlSpecificAccessDenied:
+			return ErrPermissionDenied
+		}
+		return nil
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "28P01", "28000": // invalid_password, invalid_authorization_specification
+			return ErrAuthFailed
+		case "3D000": // invalid_catalog_name
+			return ErrUnknownDatabase
+		case "42P01": // undefined_table
+			return ErrTableNotFound
+		case "42501": // insufficient_privilege
+			return ErrPermissionDenied
+		}
+	}
+	return nil
+}
+
+// tableNotFound returns ErrTableNotFound for a table with no visible columns.
+// Catalog views only list tables the user has privileges on, so a missing
+// grant looks the same as a missing table.
+func (b *BaseIntrospector) tableNotFound(tableName, schema string) error {
+	return fmt.Errorf("table %s not found in %s or not visible to user %s: %w", tableName, schema, b.cfg.User, ErrTableNotFound)
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/rowjak/godb-orm/internal/config"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"mysql access denied", &mysql.MySQLError{Number: 1045, Message: "Access denied for user 'app'@'10.0.0.1'"}, ErrAuthFailed},
+		{"mysql unknown database", &mysql.MySQLError{Number: 1049, Message: "Unknown database 'shop'"}, ErrUnknownDatabase},
+		{"mysql no such table", &mysql.MySQLError{Number: 1146}, ErrTableNotFound},
+		{"mysql db access denied", &mysql.MySQLError{Number: 1044}, ErrPermissionDenied},
+		{"mysql table access denied", &mysql.MySQLError{Number: 1142}, ErrPermissionDenied},
+		{"mysql other", &mysql.MySQLError{Number: 1064}, nil},
+		{"pg invalid password", &pq.Error{Code: "28P01"}, ErrAuthFailed},
+		{"pg hba rejected", &pq.Error{Code: "28000"}, ErrAuthFailed},
+		{"pg unknown database", &pq.Error{Code: "3D000"}, ErrUnknownDatabase},
+		{"pg undefined table", &pq.Error{Code: "42P01"}, ErrTableNotFound},
+		{"pg insufficient privilege", &pq.Error{Code: "42501"}, ErrPermissionDenied},
+		{"pg syntax error", &pq.Error{Code: "42601"}, nil},
+		{"plain error", errors.New("connection refused"), nil},
+	}
+	for _, tt := range tests {
+		if got := classifyError(tt.err); got != tt.want {
+			t.Errorf("%s: classifyError() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWrapQueryErrorClassifies(t *testing.T) {
+	b := &BaseIntrospector{cfg: &config.DBConfig{}}
+	driverErr := &pq.Error{Code: "28P01", Message: `password authentication failed for user "app"`}
+
+	err := b.wrapQueryError(context.Background(), driverErr, "failed to ping PostgreSQL", "connection to PostgreSQL")
+	if !errors.Is(err, ErrAuthFailed) {
+		t.Errorf("errors.Is(%v, ErrAuthFailed) = false", err)
+	}
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		t.Errorf("driver error not wrapped: %v", err)
+	}
+	if want := `failed to ping PostgreSQL: pq: password authentication failed for user "app" (authentication failed)`; err.Error() != want {
+		t.Errorf("message = %q, want %q", err.Error(), want)
+	}
+
+	plain := b.wrapQueryError(context.Background(), errors.New("EOF"), "failed to query tables", "table list query")
+	if plain.Error() != "failed to query tables: EOF" {
+		t.Errorf("unclassified message = %q", plain.Error())
+	}
+}
+
+func TestTableNotFound(t *testing.T) {
+	b := &BaseIntrospector{cfg: &config.DBConfig{User: "app"}}
+	err := b.tableNotFound("orders", "shop")
+	if !errors.Is(err, ErrTableNotFound) || !strings.Contains(err.Error(), "not visible to user app") {
+		t.Errorf("tableNotFound() = %v", err)
+	}
+
+	ddl := NewDDLIntrospectorFromSource("CREATE TABLE users (id int);", "mysql")
+	if err := ddl.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ddl.GetTableMetadata("orders"); !errors.Is(err, ErrTableNotFound) {
+		t.Errorf("DDL GetTableMetadata(orders) error = %v, want ErrTableNotFound", err)
+	}
+}
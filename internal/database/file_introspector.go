@@ -0,0 +1,279 @@
+package database
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/strcase"
+	"github.com/rowjak/godb-orm/internal/config"
+)
+
+// FileIntrospector implements read-only introspection of a single data file,
+// a CSV, TSV or Parquet file, as one table. CSV column types are inferred
+// from a sample of the rows; Parquet files carry their own schema.
+type FileIntrospector struct {
+	path       string
+	table      string
+	dialect    string
+	sampleSize int
+	meta       *TableMetadata
+}
+
+// NewFileIntrospector creates an introspector for the data file at path.
+// The table is named after the file unless table is set. dialect ("mysql"
+// or "postgres") selects the spelling of types that differ between the two;
+// sampleSize is the number of CSV rows inspected (0 uses DefaultSampleSize).
+func NewFileIntrospector(path, table, dialect string, sampleSize int) *FileIntrospector {
+	if table == "" {
+		table = strcase.ToSnake(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	}
+	if sampleSize <= 0 {
+		sampleSize = config.DefaultSampleSize
+	}
+	return &FileIntrospector{path: path, table: table, dialect: dialect, sampleSize: sampleSize}
+}
+
+// Connect reads the file and infers its columns
+func (f *FileIntrospector) Connect() error {
+	if f.dialect == "postgresql" {
+		f.dialect = "postgres"
+	}
+	if f.dialect != "mysql" && f.dialect != "postgres" {
+		return fmt.Errorf("unsupported dialect for data files: %q (use mysql or postgres)", f.dialect)
+	}
+
+	var columns []ColumnMetadata
+	var err error
+	switch strings.ToLower(filepath.Ext(f.path)) {
+	case ".csv":
+		columns, err = f.readCSV(',')
+	case ".tsv":
+		columns, err = f.readCSV('\t')
+	case ".parquet":
+		columns, err = readParquetColumns(f.path, f.dialect)
+	default:
+		return fmt.Errorf("unsupported data file %s (expected .csv, .tsv or .parquet)", f.path)
+	}
+	if err != nil {
+		return err
+	}
+
+	f.meta = &TableMetadata{Name: f.table, Columns: columns}
+	return nil
+}
+
+// Close releases the inferred metadata
+func (f *FileIntrospector) Close() error {
+	f.meta = nil
+	return nil
+}
+
+// Ping reports whether the file has been read
+func (f *FileIntrospector) Ping() error {
+	if f.meta == nil {
+		return fmt.Errorf("%s not loaded", f.path)
+	}
+	return nil
+}
+
+// Dialect returns the SQL dialect the column types are spelled in
+func (f *FileIntrospector) Dialect() string {
+	return f.dialect
+}
+
+// GetTables returns the single table of the file
+func (f *FileIntrospector) GetTables() ([]string, error) {
+	if err := f.Ping(); err != nil {
+		return nil, err
+	}
+	return []string{f.table}, nil
+}
+
+// GetColumns returns the inferred columns of the file
+func (f *FileIntrospector) GetColumns(tableName string) ([]ColumnMetadata, error) {
+	meta, err := f.GetTableMetadata(tableName)
+	if err != nil {
+		return nil, err
+	}
+	return meta.Columns, nil
+}
+
+// GetTableMetadata returns the metadata of the file's table
+func (f *FileIntrospector) GetTableMetadata(tableName string) (*TableMetadata, error) {
+	if err := f.Ping(); err != nil {
+		return nil, err
+	}
+	if tableName != f.table {
+		return nil, fmt.Errorf("table %s not found in %s: %w", tableName, f.path, ErrTableNotFound)
+	}
+	return f.meta, nil
+}
+
+// readCSV infers the columns of a delimited file from its header and the
+// first sampleSize rows
+func (f *FileIntrospector) readCSV(delimiter rune) ([]ColumnMetadata, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open data file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s is empty", f.path)
+		}
+		return nil, fmt.Errorf("failed to read header of %s: %w", f.path, err)
+	}
+	names := csvColumnNames(header)
+
+	inferrers := make([]csvColumn, len(names))
+	for rows := 0; rows < f.sampleSize; rows++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", f.path, err)
+		}
+		for i := range inferrers {
+			value := ""
+			if i < len(record) {
+				value = record[i]
+			}
+			inferrers[i].add(value)
+		}
+	}
+
+	columns := make([]ColumnMetadata, len(names))
+	for i, name := range names {
+		rawType := inferrers[i].sqlType()
+		columns[i] = ColumnMetadata{
+			Name:            name,
+			DataType:        baseTypeName(rawType),
+			RawType:         rawType,
+			IsNullable:      inferrers[i].empty > 0 || inferrers[i].seen == 0,
+			OrdinalPosition: i + 1,
+		}
+	}
+	return columns, nil
+}
+
+// csvColumnNames turns CSV headers into snake_case column names, naming
+// blank headers after their position and numbering duplicates
+func csvColumnNames(header []string) []string {
+	names := make([]string, len(header))
+	used := make(map[string]bool, len(header))
+	for i, title := range header {
+		name := strcase.ToSnake(strings.TrimSpace(strings.TrimPrefix(title, "\ufeff")))
+		if name == "" {
+			name = fmt.Sprintf("column_%d", i+1)
+		}
+		unique := name
+		for n := 2; used[unique]; n++ {
+			unique = fmt.Sprintf("%s_%d", name, n)
+		}
+		used[unique] = true
+		names[i] = unique
+	}
+	return names
+}
+
+// CSV value kinds, from the most to the least specific
+const (
+	csvBoolean = 1 << iota
+	csvInteger
+	csvFloat
+	csvDate
+	csvTimestamp
+)
+
+// csvTimestampLayouts are the date-time layouts recognized in CSV values
+var csvTimestampLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05"}
+
+// csvColumn accumulates the kinds of the values seen in a CSV column
+type csvColumn struct {
+	kinds int // Kinds every non-empty value so far matches
+	seen  int // Non-empty values
+	empty int // Empty values
+}
+
+// add records a CSV value
+func (c *csvColumn) add(value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		c.empty++
+		return
+	}
+	kinds := csvKinds(value)
+	if c.seen == 0 {
+		c.kinds = kinds
+	} else {
+		c.kinds &= kinds
+	}
+	c.seen++
+}
+
+// csvKinds returns every kind a non-empty CSV value can be read as.
+// Integers are also floats and dates also timestamps, so columns mixing
+// them settle on the wider type. Numbers with leading zeros (zip codes,
+// account numbers) are kept as text.
+func csvKinds(value string) int {
+	switch strings.ToLower(value) {
+	case "true", "false":
+		return csvBoolean
+	}
+
+	kinds := 0
+	digits := strings.TrimLeft(value, "+-")
+	leadingZero := len(digits) > 1 && digits[0] == '0' && digits[1] != '.'
+	if !leadingZero {
+		if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+			kinds |= csvInteger | csvFloat
+		} else if _, err := strconv.ParseFloat(value, 64); err == nil {
+			kinds |= csvFloat
+		}
+	}
+	if _, err := time.Parse("2006-01-02", value); err == nil {
+		kinds |= csvDate | csvTimestamp
+	}
+	for _, layout := range csvTimestampLayouts {
+		if _, err := time.Parse(layout, value); err == nil {
+			kinds |= csvTimestamp
+			break
+		}
+	}
+	return kinds
+}
+
+// sqlType returns the SQL type of the column: the most specific kind all
+// values agree on, or text
+func (c *csvColumn) sqlType() string {
+	switch {
+	case c.seen == 0:
+		return "text"
+	case c.kinds&csvBoolean != 0:
+		return "boolean"
+	case c.kinds&csvInteger != 0:
+		return "bigint"
+	case c.kinds&csvFloat != 0:
+		return "double precision"
+	case c.kinds&csvDate != 0:
+		return "date"
+	case c.kinds&csvTimestamp != 0:
+		return "timestamp"
+	}
+	return "text"
+}
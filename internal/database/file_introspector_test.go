@@ -0,0 +1,137 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeDataFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFileIntrospector_CSV(t *testing.T) {
+	path := writeDataFile(t, "Order Items.csv", "\ufeffID,Name,Price,Active,Zip,Shipped On,Created At,Note\n"+
+		"1,Widget,9.99,true,02134,2024-01-02,2024-01-02 10:00:00,\n"+
+		"2,Gadget,12,FALSE,90210,2024-02-03,2024-02-03T11:30:00Z,fragile\n")
+
+	f := NewFileIntrospector(path, "", "postgresql", 0)
+	if err := f.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if f.Dialect() != "postgres" {
+		t.Errorf("Dialect() = %q, want postgres", f.Dialect())
+	}
+
+	tables, _ := f.GetTables()
+	if !reflect.DeepEqual(tables, []string{"order_items"}) {
+		t.Fatalf("GetTables() = %v", tables)
+	}
+
+	cols, err := f.GetColumns("order_items")
+	if err != nil {
+		t.Fatalf("GetColumns() error = %v", err)
+	}
+	tests := []struct {
+		name     string
+		rawType  string
+		nullable bool
+	}{
+		{"id", "bigint", false},
+		{"name", "text", false},
+		{"price", "double precision", false},
+		{"active", "boolean", false},
+		{"zip", "text", false},
+		{"shipped_on", "date", false},
+		{"created_at", "timestamp", false},
+		{"note", "text", true},
+	}
+	if len(cols) != len(tests) {
+		t.Fatalf("got %d columns, want %d", len(cols), len(tests))
+	}
+	for i, tt := range tests {
+		col := cols[i]
+		if col.Name != tt.name || col.RawType != tt.rawType || col.IsNullable != tt.nullable || col.OrdinalPosition != i+1 {
+			t.Errorf("column %d = %s %s nullable=%v, want %s %s nullable=%v", i, col.Name, col.RawType, col.IsNullable, tt.name, tt.rawType, tt.nullable)
+		}
+	}
+
+	if _, err := f.GetColumns("orders"); err == nil {
+		t.Error("GetColumns(orders) succeeded, want an error")
+	}
+}
+
+func TestFileIntrospector_SampleSize(t *testing.T) {
+	path := writeDataFile(t, "codes.tsv", "code\tlabel\n1\ta\n2\tb\nX-3\tc\n")
+
+	f := NewFileIntrospector(path, "code", "mysql", 2)
+	if err := f.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	cols, _ := f.GetColumns("code")
+	if cols[0].RawType != "bigint" {
+		t.Errorf("code = %s, want bigint from the first 2 rows", cols[0].RawType)
+	}
+
+	f = NewFileIntrospector(path, "code", "mysql", 0)
+	if err := f.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	cols, _ = f.GetColumns("code")
+	if cols[0].RawType != "text" {
+		t.Errorf("code = %s, want text", cols[0].RawType)
+	}
+}
+
+func TestFileIntrospector_Errors(t *testing.T) {
+	csvPath := writeDataFile(t, "data.csv", "a\n1\n")
+	if err := NewFileIntrospector(csvPath, "", "firebird", 0).Connect(); err == nil {
+		t.Error("Connect() with firebird dialect succeeded, want an error")
+	}
+	if err := NewFileIntrospector(writeDataFile(t, "data.json", "{}"), "", "mysql", 0).Connect(); err == nil {
+		t.Error("Connect() on .json succeeded, want an error")
+	}
+	if err := NewFileIntrospector(writeDataFile(t, "empty.csv", ""), "", "mysql", 0).Connect(); err == nil {
+		t.Error("Connect() on an empty file succeeded, want an error")
+	}
+}
+
+func TestCSVColumnNames(t *testing.T) {
+	got := csvColumnNames([]string{"First Name", "", "userID", "first_name", " Email "})
+	want := []string{"first_name", "column_2", "user_id", "first_name_2", "email"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("csvColumnNames() = %v, want %v", got, want)
+	}
+}
+
+func TestCSVColumnType(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   string
+	}{
+		{[]string{"1", "-2", "+3"}, "bigint"},
+		{[]string{"1", "2.5"}, "double precision"},
+		{[]string{"0.5", "1e3"}, "double precision"},
+		{[]string{"007", "8"}, "text"},
+		{[]string{"true", "False"}, "boolean"},
+		{[]string{"1", "true"}, "text"},
+		{[]string{"2024-01-02", "2024-01-03 04:05:06"}, "timestamp"},
+		{[]string{"2024-01-02", "soon"}, "text"},
+		{[]string{"", ""}, "text"},
+	}
+	for _, tt := range tests {
+		var c csvColumn
+		for _, v := range tt.values {
+			c.add(v)
+		}
+		if got := c.sqlType(); got != tt.want {
+			t.Errorf("sqlType(%q) = %s, want %s", tt.values, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,5 @@
+//go:build firebird
+
+package database
+
+import _ "github.com/nakagami/firebirdsql"
@@ -0,0 +1,357 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/config"
+)
+
+// firebirdDriver is the database/sql driver name registered by
+// github.com/nakagami/firebirdsql (compiled in with -tags firebird)
+const firebirdDriver = "firebirdsql"
+
+// FirebirdIntrospector implements database introspection for Firebird 3.0
+// and later. Identifiers are returned as stored, usually upper case.
+type FirebirdIntrospector struct {
+	BaseIntrospector
+}
+
+// NewFirebirdIntrospector creates a new Firebird introspector. DBName is the
+// database file path or alias on the server.
+func NewFirebirdIntrospector(cfg *config.DBConfig) *FirebirdIntrospector {
+	return &FirebirdIntrospector{
+		BaseIntrospector: BaseIntrospector{cfg: cfg},
+	}
+}
+
+// Connect establishes a connection to the Firebird database
+func (f *FirebirdIntrospector) Connect() error {
+	if err := requireDriver(firebirdDriver, "firebird"); err != nil {
+		return err
+	}
+
+	dsn := fmt.Sprintf("%s:%s@%s:%d/%s",
+		f.cfg.User,
+		f.cfg.Password,
+		f.cfg.Host,
+		f.cfg.Port,
+		strings.TrimPrefix(f.cfg.DBName, "/"),
+	)
+
+	db, err := sql.Open(firebirdDriver, dsn)
+	if err != nil {
+		return fmt.Errorf("failed to open Firebird connection: %w", err)
+	}
+
+	ctx, cancel := f.queryContext()
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return f.wrapQueryError(ctx, err, "failed to ping Firebird", "connection to Firebird")
+	}
+
+	f.db = db
+	return nil
+}
+
+// Dialect returns the SQL dialect of the schema
+func (f *FirebirdIntrospector) Dialect() string {
+	return "firebird"
+}
+
+// GetTables returns a list of user table names in the database
+func (f *FirebirdIntrospector) GetTables() ([]string, error) {
+	query := `
+		SELECT TRIM(RDB$RELATION_NAME)
+		FROM RDB$RELATIONS
+		WHERE COALESCE(RDB$SYSTEM_FLAG, 0) = 0 AND RDB$VIEW_BLR IS NULL
+		ORDER BY RDB$RELATION_NAME
+	`
+
+	ctx, cancel := f.queryContext()
+	defer cancel()
+
+	rows, err := f.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, f.wrapQueryError(ctx, err, "failed to query tables", "table list query")
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var tableName string
+		if err := rows.Scan(&tableName); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+		tables = append(tables, tableName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, f.wrapQueryError(ctx, err, "failed to read tables", "table list query")
+	}
+
+	return tables, nil
+}
+
+// GetColumns returns column metadata for a specific table
+func (f *FirebirdIntrospector) GetColumns(tableName string) ([]ColumnMetadata, error) {
+	query := `
+		SELECT
+			TRIM(rf.RDB$FIELD_NAME),
+			fld.RDB$FIELD_TYPE,
+			COALESCE(fld.RDB$FIELD_SUB_TYPE, 0),
+			COALESCE(fld.RDB$CHARACTER_LENGTH, 0),
+			COALESCE(fld.RDB$FIELD_PRECISION, 0),
+			COALESCE(fld.RDB$FIELD_SCALE, 0),
+			COALESCE(rf.RDB$NULL_FLAG, fld.RDB$NULL_FLAG, 0),
+			CAST(COALESCE(rf.RDB$DEFAULT_SOURCE, fld.RDB$DEFAULT_SOURCE) AS VARCHAR(1024)),
+			CAST(rf.RDB$DESCRIPTION AS VARCHAR(8191)),
+			rf.RDB$IDENTITY_TYPE
+		FROM RDB$RELATION_FIELDS rf
+		JOIN RDB$FIELDS fld ON fld.RDB$FIELD_NAME = rf.RDB$FIELD_SOURCE
+		WHERE rf.RDB$RELATION_NAME = ?
+		ORDER BY rf.RDB$FIELD_POSITION
+	`
+
+	primaryKeys, err := f.getPrimaryKeyColumns(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := f.queryContext()
+	defer cancel()
+
+	rows, err := f.db.QueryContext(ctx, query, tableName)
+	if err != nil {
+		return nil, f.wrapQueryError(ctx, err, "failed to query columns", fmt.Sprintf("table %s metadata query", tableName))
+	}
+	defer rows.Close()
+
+	var columns []ColumnMetadata
+	for rows.Next() {
+		var (
+			columnName   string
+			fieldType    int
+			subType      int
+			charLength   int
+			precision    int
+			scale        int
+			notNull      int
+			defaultValue sql.NullString
+			description  sql.NullString
+			identityType sql.NullInt64
+		)
+		if err := rows.Scan(&columnName, &fieldType, &subType, &charLength, &precision, &scale,
+			&notNull, &defaultValue, &description, &identityType); err != nil {
+			return nil, fmt.Errorf("failed to scan column: %w", err)
+		}
+
+		dataType, rawType := firebirdType(fieldType, subType, charLength, precision, scale)
+		col := ColumnMetadata{
+			Name:            columnName,
+			DataType:        dataType,
+			RawType:         rawType,
+			IsNullable:      notNull == 0,
+			IsPrimaryKey:    primaryKeys[columnName],
+			IsAutoIncrement: identityType.Valid,
+			Comment:         description.String,
+			OrdinalPosition: len(columns) + 1,
+		}
+		if defaultValue.Valid {
+			value := firebirdDefault(defaultValue.String)
+			col.DefaultValue = &value
+		}
+		switch dataType {
+		case "char", "varchar":
+			col.CharMaxLength = &charLength
+		case "numeric", "decimal":
+			scale = -scale
+			col.NumericPrecision = &precision
+			col.NumericScale = &scale
+		}
+		columns = append(columns, col)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, f.wrapQueryError(ctx, err, "failed to read columns", fmt.Sprintf("table %s metadata query", tableName))
+	}
+
+	return columns, nil
+}
+
+// getPrimaryKeyColumns returns the primary key columns of a table
+func (f *FirebirdIntrospector) getPrimaryKeyColumns(tableName string) (map[string]bool, error) {
+	query := `
+		SELECT TRIM(seg.RDB$FIELD_NAME)
+		FROM RDB$RELATION_CONSTRAINTS rc
+		JOIN RDB$INDEX_SEGMENTS seg ON seg.RDB$INDEX_NAME = rc.RDB$INDEX_NAME
+		WHERE rc.RDB$RELATION_NAME = ? AND rc.RDB$CONSTRAINT_TYPE = 'PRIMARY KEY'
+	`
+
+	ctx, cancel := f.queryContext()
+	defer cancel()
+
+	rows, err := f.db.QueryContext(ctx, query, tableName)
+	if err != nil {
+		return nil, f.wrapQueryError(ctx, err, "failed to query primary keys", fmt.Sprintf("table %s primary key query", tableName))
+	}
+	defer rows.Close()
+
+	primaryKeys := make(map[string]bool)
+	for rows.Next() {
+		var columnName string
+		if err := rows.Scan(&columnName); err != nil {
+			return nil, fmt.Errorf("failed to scan primary key column: %w", err)
+		}
+		primaryKeys[columnName] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, f.wrapQueryError(ctx, err, "failed to read primary keys", fmt.Sprintf("table %s primary key query", tableName))
+	}
+
+	return primaryKeys, nil
+}
+
+// GetTableMetadata returns full metadata for a specific table
+func (f *FirebirdIntrospector) GetTableMetadata(tableName string) (*TableMetadata, error) {
+	columns, err := f.GetColumns(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	var tableComment sql.NullString
+	query := `
+		SELECT CAST(RDB$DESCRIPTION AS VARCHAR(8191))
+		FROM RDB$RELATIONS
+		WHERE RDB$RELATION_NAME = ?
+	`
+	ctx, cancel := f.queryContext()
+	defer cancel()
+
+	err = f.db.QueryRowContext(ctx, query, tableName).Scan(&tableComment)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, f.wrapQueryError(ctx, err, "failed to get table comment", fmt.Sprintf("table %s comment query", tableName))
+	}
+
+	meta := &TableMetadata{
+		Name:    tableName,
+		Columns: columns,
+		Comment: tableComment.String,
+	}
+
+	foreignKeys, err := f.getForeignKeys(tableName)
+	if err != nil {
+		return nil, err
+	}
+	splitForeignKeys(meta, foreignKeys)
+
+	return meta, nil
+}
+
+// getForeignKeys returns single-column foreign keys declared on or referencing a table
+func (f *FirebirdIntrospector) getForeignKeys(tableName string) ([]ForeignKey, error) {
+	query := `
+		SELECT
+			TRIM(rc.RDB$CONSTRAINT_NAME),
+			TRIM(rc.RDB$RELATION_NAME),
+			TRIM(seg.RDB$FIELD_NAME),
+			TRIM(ref.RDB$RELATION_NAME),
+			TRIM(refseg.RDB$FIELD_NAME)
+		FROM RDB$RELATION_CONSTRAINTS rc
+		JOIN RDB$REF_CONSTRAINTS refc ON refc.RDB$CONSTRAINT_NAME = rc.RDB$CONSTRAINT_NAME
+		JOIN RDB$RELATION_CONSTRAINTS ref ON ref.RDB$CONSTRAINT_NAME = refc.RDB$CONST_NAME_UQ
+		JOIN RDB$INDEX_SEGMENTS seg ON seg.RDB$INDEX_NAME = rc.RDB$INDEX_NAME
+		JOIN RDB$INDEX_SEGMENTS refseg ON refseg.RDB$INDEX_NAME = ref.RDB$INDEX_NAME
+		JOIN RDB$INDICES idx ON idx.RDB$INDEX_NAME = rc.RDB$INDEX_NAME
+		WHERE rc.RDB$CONSTRAINT_TYPE = 'FOREIGN KEY'
+			AND idx.RDB$SEGMENT_COUNT = 1
+			AND (rc.RDB$RELATION_NAME = ? OR ref.RDB$RELATION_NAME = ?)
+		ORDER BY rc.RDB$RELATION_NAME, rc.RDB$CONSTRAINT_NAME
+	`
+
+	ctx, cancel := f.queryContext()
+	defer cancel()
+
+	rows, err := f.db.QueryContext(ctx, query, tableName, tableName)
+	if err != nil {
+		return nil, f.wrapQueryError(ctx, err, "failed to query foreign keys", fmt.Sprintf("table %s foreign key query", tableName))
+	}
+	defer rows.Close()
+
+	var foreignKeys []ForeignKey
+	for rows.Next() {
+		var fk ForeignKey
+		if err := rows.Scan(&fk.Name, &fk.Table, &fk.Column, &fk.ReferencedTable, &fk.ReferencedColumn); err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key: %w", err)
+		}
+		foreignKeys = append(foreignKeys, fk)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, f.wrapQueryError(ctx, err, "failed to read foreign keys", fmt.Sprintf("table %s foreign key query", tableName))
+	}
+
+	return foreignKeys, nil
+}
+
+// firebirdType returns the normalized data type and the raw type of a
+// Firebird column from its RDB$FIELDS entry. Exact numerics are stored as
+// integers with a sub type of 1 (numeric) or 2 (decimal) and a negative scale.
+func firebirdType(fieldType, subType, charLength, precision, scale int) (dataType, rawType string) {
+	switch fieldType {
+	case 7, 8, 16, 26:
+		if subType == 1 || subType == 2 || scale < 0 {
+			dataType = "numeric"
+			if subType == 2 {
+				dataType = "decimal"
+			}
+			return dataType, fmt.Sprintf("%s(%d,%d)", dataType, precision, -scale)
+		}
+		dataType = map[int]string{7: "smallint", 8: "integer", 16: "bigint", 26: "int128"}[fieldType]
+		return dataType, dataType
+	case 10:
+		return "float", "float"
+	case 27:
+		return "double precision", "double precision"
+	case 12:
+		return "date", "date"
+	case 13:
+		return "time", "time"
+	case 28:
+		return "time with time zone", "time with time zone"
+	case 35:
+		return "timestamp", "timestamp"
+	case 29:
+		return "timestamp with time zone", "timestamp with time zone"
+	case 14:
+		return "char", fmt.Sprintf("char(%d)", charLength)
+	case 37:
+		return "varchar", fmt.Sprintf("varchar(%d)", charLength)
+	case 23:
+		return "boolean", "boolean"
+	case 24:
+		return "decfloat", "decfloat(16)"
+	case 25:
+		return "decfloat", "decfloat(34)"
+	case 261:
+		if subType == 1 {
+			return "blob sub_type text", "blob sub_type text"
+		}
+		return "blob", "blob"
+	}
+	return "unknown", fmt.Sprintf("unknown(%d)", fieldType)
+}
+
+// firebirdDefault strips the DEFAULT keyword from an RDB$DEFAULT_SOURCE
+// value, e.g. "DEFAULT 'active'" -> "'active'"
+func firebirdDefault(source string) string {
+	source = strings.TrimSpace(source)
+	if len(source) >= 7 && strings.EqualFold(source[:7], "default") {
+		source = strings.TrimSpace(source[7:])
+	}
+	return source
+}
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+)
+
+func TestFirebirdType(t *testing.T) {
+	tests := []struct {
+		fieldType, subType, charLength, precision, scale int
+		dataType, rawType                                string
+	}{
+		{8, 0, 0, 10, 0, "integer", "integer"},
+		{16, 0, 0, 19, 0, "bigint", "bigint"},
+		{16, 1, 0, 18, -4, "numeric", "numeric(18,4)"},
+		{8, 2, 0, 9, -2, "decimal", "decimal(9,2)"},
+		{37, 0, 120, 0, 0, "varchar", "varchar(120)"},
+		{14, 0, 2, 0, 0, "char", "char(2)"},
+		{261, 1, 0, 0, 0, "blob sub_type text", "blob sub_type text"},
+		{261, 0, 0, 0, 0, "blob", "blob"},
+		{35, 0, 0, 0, 0, "timestamp", "timestamp"},
+		{23, 0, 0, 0, 0, "boolean", "boolean"},
+	}
+	for _, tt := range tests {
+		dataType, rawType := firebirdType(tt.fieldType, tt.subType, tt.charLength, tt.precision, tt.scale)
+		if dataType != tt.dataType || rawType != tt.rawType {
+			t.Errorf("firebirdType(%d, %d, ...) = %q, %q; want %q, %q", tt.fieldType, tt.subType, dataType, rawType, tt.dataType, tt.rawType)
+		}
+	}
+
+	if got := firebirdDefault("DEFAULT 'active'"); got != "'active'" {
+		t.Errorf("firebirdDefault() = %q; want 'active'", got)
+	}
+}
+
+func TestFirebirdIntrospector_RequiresBuildTag(t *testing.T) {
+	introspector, err := NewIntrospector(&config.DBConfig{Driver: "firebird", Host: "localhost", Port: 3050})
+	if err != nil {
+		t.Fatalf("NewIntrospector() error = %v", err)
+	}
+	if requireDriver(firebirdDriver, "firebird") == nil {
+		t.Skip("built with -tags firebird")
+	}
+	if err := introspector.Connect(); err == nil || !strings.Contains(err.Error(), "-tags firebird") {
+		t.Errorf("Connect() error = %v; want a hint to build with -tags firebird", err)
+	}
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	"sort"
+	"strings"
+)
+
+// InferForeignKeys adds the foreign keys on or referencing meta's table
+// that are implied by naming conventions, skipping columns that already have
+// a declared key. tables maps each table of the schema to its key columns
+// (see KeyColumns).
+func InferForeignKeys(meta *TableMetadata, tables map[string][]string) {
+	declared := append(append([]ForeignKey(nil), meta.ForeignKeys...), meta.ReferencedBy...)
+	splitForeignKeys(meta, inferForeignKeys(meta.Name, tables, declared))
+}
+
+// KeyColumns returns the names of the id and *_id columns, the columns
+// foreign key inference looks at
+func KeyColumns(columns []ColumnMetadata) []string {
+	var names []string
+	for _, col := range columns {
+		if col.Name == "id" || strings.HasSuffix(col.Name, "_id") {
+			names = append(names, col.Name)
+		}
+	}
+	return names
+}
+
+// inferForeignKeys derives single-column foreign keys on or referencing
+// tableName from naming conventions: a column <name>_id references the id
+// column of the table named <name> or its plural (user_id -> users.id).
+// tables maps each table of the schema to its id and *_id columns. Columns
+// that already have a declared foreign key are skipped.
+func inferForeignKeys(tableName string, tables map[string][]string, declared []ForeignKey) []ForeignKey {
+	hasDeclared := make(map[string]bool, len(declared))
+	for _, fk := range declared {
+		hasDeclared[fk.Table+"."+fk.Column] = true
+	}
+
+	var inferred []ForeignKey
+	for table, columns := range tables {
+		for _, column := range columns {
+			base, ok := strings.CutSuffix(column, "_id")
+			if !ok || base == "" || hasDeclared[table+"."+column] {
+				continue
+			}
+			target := referencedTable(base, tables)
+			if target == "" || (table != tableName && target != tableName) {
+				continue
+			}
+			inferred = append(inferred, ForeignKey{
+				Name:             "inferred_" + table + "_" + column,
+				Table:            table,
+				Column:           column,
+				ReferencedTable:  target,
+				ReferencedColumn: "id",
+				Inferred:         true,
+			})
+		}
+	}
+	sortForeignKeys(inferred)
+	return inferred
+}
+
+// referencedTable returns the table with an id column named base or one of
+// its plural forms, or an empty string if there is none
+func referencedTable(base string, tables map[string][]string) string {
+	candidates := []string{base, base + "s", base + "es"}
+	if stem, ok := strings.CutSuffix(base, "y"); ok {
+		candidates = append(candidates, stem+"ies")
+	}
+	for _, candidate := range candidates {
+		for _, column := range tables[candidate] {
+			if column == "id" {
+				return candidate
+			}
+		}
+	}
+	return ""
+}
+
+// sortForeignKeys orders foreign keys by table and name, like the
+// introspection queries do
+func sortForeignKeys(foreignKeys []ForeignKey) {
+	sort.Slice(foreignKeys, func(i, j int) bool {
+		if foreignKeys[i].Table != foreignKeys[j].Table {
+			return foreignKeys[i].Table < foreignKeys[j].Table
+		}
+		return foreignKeys[i].Name < foreignKeys[j].Name
+	})
+}
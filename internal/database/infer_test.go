@@ -0,0 +1,77 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInferForeignKeys(t *testing.T) {
+	tables := map[string][]string{
+		"users":      {"id", "company_id"},
+		"companies":  {"id"},
+		"orders":     {"id", "user_id", "coupon_id"},
+		"categories": {"id", "parent_id"},
+		"products":   {"id", "category_id"},
+		"boxes":      {"id"},
+		"items":      {"id", "box_id", "order_id"},
+	}
+	declared := []ForeignKey{{Name: "fk_items_order", Table: "items", Column: "order_id", ReferencedTable: "orders", ReferencedColumn: "id"}}
+
+	got := inferForeignKeys("orders", tables, declared)
+	want := []ForeignKey{
+		{Name: "inferred_orders_user_id", Table: "orders", Column: "user_id", ReferencedTable: "users", ReferencedColumn: "id", Inferred: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("inferForeignKeys(orders) = %+v; want %+v", got, want)
+	}
+
+	got = inferForeignKeys("companies", tables, nil)
+	want = []ForeignKey{
+		{Name: "inferred_users_company_id", Table: "users", Column: "company_id", ReferencedTable: "companies", ReferencedColumn: "id", Inferred: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("inferForeignKeys(companies) = %+v; want %+v", got, want)
+	}
+
+	got = inferForeignKeys("items", tables, declared)
+	if len(got) != 1 || got[0].ReferencedTable != "boxes" {
+		t.Errorf("inferForeignKeys(items) = %+v; want only items.box_id -> boxes", got)
+	}
+}
+
+func TestInferForeignKeysMetadata(t *testing.T) {
+	tables := map[string][]string{
+		"users":  {"id"},
+		"orders": {"id", "user_id", "coupon_id"},
+	}
+	meta := &TableMetadata{
+		Name: "users",
+		Columns: []ColumnMetadata{
+			{Name: "id", IsPrimaryKey: true},
+			{Name: "email"},
+		},
+	}
+
+	InferForeignKeys(meta, tables)
+	if len(meta.ForeignKeys) != 0 {
+		t.Errorf("ForeignKeys = %+v; want none", meta.ForeignKeys)
+	}
+	if len(meta.ReferencedBy) != 1 || meta.ReferencedBy[0].Table != "orders" || !meta.ReferencedBy[0].Inferred {
+		t.Errorf("ReferencedBy = %+v; want inferred orders.user_id", meta.ReferencedBy)
+	}
+
+	// A second pass sees the keys added by the first as declared
+	InferForeignKeys(meta, tables)
+	if len(meta.ReferencedBy) != 1 {
+		t.Errorf("ReferencedBy after second pass = %+v; want one key", meta.ReferencedBy)
+	}
+}
+
+func TestKeyColumns(t *testing.T) {
+	columns := []ColumnMetadata{{Name: "id"}, {Name: "user_id"}, {Name: "paid"}, {Name: "idempotency_key"}}
+	got := KeyColumns(columns)
+	want := []string{"id", "user_id"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("KeyColumns() = %v; want %v", got, want)
+	}
+}
@@ -16,14 +16,27 @@ type ColumnMetadata struct {
 	NumericScale     *int     // Scale for numeric types
 	Comment          string   // Column comment if any
 	OrdinalPosition  int      // Position of the column in the table
+	SRID             *int     // Spatial reference system of a spatial column, if restricted
+}
+
+// ForeignKey represents a single-column foreign key constraint
+type ForeignKey struct {
+	Name             string // Constraint name
+	Table            string // Table owning the foreign key column
+	Column           string // Foreign key column
+	ReferencedTable  string // Referenced table
+	ReferencedColumn string // Referenced column (usually the primary key)
+	Inferred         bool   // Derived from naming conventions rather than declared
 }
 
 // TableMetadata represents metadata for a database table
 type TableMetadata struct {
-	Schema  string           // Schema/Database name
-	Name    string           // Table name
-	Columns []ColumnMetadata // List of columns
-	Comment string           // Table comment if any
+	Schema       string           // Schema/Database name
+	Name         string           // Table name
+	Columns      []ColumnMetadata // List of columns
+	Comment      string           // Table comment if any
+	ForeignKeys  []ForeignKey     // Foreign keys declared on this table (belongs-to)
+	ReferencedBy []ForeignKey     // Foreign keys in other tables referencing this table (has-many)
 }
 
 // DBIntrospector defines the interface for database introspection
@@ -43,3 +56,30 @@ type DBIntrospector interface {
 	// GetTableMetadata returns full metadata for a specific table
 	GetTableMetadata(tableName string) (*TableMetadata, error)
 }
+
+// TableStats holds the approximate size of a table, as estimated by the
+// database's statistics rather than counted
+type TableStats struct {
+	Rows  int64 `json:"rows"`  // Estimated number of rows
+	Bytes int64 `json:"bytes"` // Size on disk of the data and indexes
+}
+
+// StatsProvider is implemented by introspectors that can estimate the size
+// of their tables cheaply
+type StatsProvider interface {
+	// TableStats returns the estimated size of every table, by table name
+	TableStats() (map[string]TableStats, error)
+}
+
+// Dialecter is implemented by introspectors that know the SQL dialect of the schema
+type Dialecter interface {
+	// Dialect returns the driver name of the schema dialect (mysql or postgres)
+	Dialect() string
+}
+
+// Pinger is implemented by introspectors backed by a live connection,
+// allowing callers to detect dropped connections
+type Pinger interface {
+	// Ping checks that the database connection is still alive
+	Ping() error
+}
@@ -0,0 +1,295 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Document field types reported by MongoIntrospector, named after the BSON
+// type aliases used by $type
+const (
+	bsonObjectID  = "objectid"
+	bsonString    = "string"
+	bsonInt       = "int"
+	bsonLong      = "long"
+	bsonDouble    = "double"
+	bsonDecimal   = "decimal"
+	bsonBool      = "bool"
+	bsonDate      = "date"
+	bsonTimestamp = "timestamp"
+	bsonObject    = "object"
+	bsonArray     = "array"
+	bsonBinData   = "bindata"
+	bsonMixed     = "mixed" // Several incompatible types, or only nulls
+)
+
+// MongoIntrospector implements document-database introspection for MongoDB.
+// Collections have no schema, so the fields of a collection are inferred
+// from a random sample of its documents.
+type MongoIntrospector struct {
+	BaseIntrospector
+	client *mongo.Client
+}
+
+// NewMongoIntrospector creates a new MongoDB introspector
+func NewMongoIntrospector(cfg *config.DBConfig) *MongoIntrospector {
+	return &MongoIntrospector{
+		BaseIntrospector: BaseIntrospector{cfg: cfg},
+	}
+}
+
+// Connect establishes a connection to the MongoDB deployment
+func (m *MongoIntrospector) Connect() error {
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoURI(m.cfg)))
+	if err != nil {
+		return fmt.Errorf("failed to open MongoDB connection: %w", err)
+	}
+
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(ctx)
+		return m.wrapQueryError(ctx, err, "failed to ping MongoDB", "connection to MongoDB")
+	}
+
+	m.client = client
+	return nil
+}
+
+// mongoURI builds the connection string. A host that already is a
+// mongodb:// or mongodb+srv:// URI is used as-is.
+func mongoURI(cfg *config.DBConfig) string {
+	if strings.HasPrefix(cfg.Host, "mongodb://") || strings.HasPrefix(cfg.Host, "mongodb+srv://") {
+		return cfg.Host
+	}
+	u := url.URL{
+		Scheme: "mongodb",
+		Host:   fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		Path:   "/",
+	}
+	if cfg.User != "" {
+		u.User = url.UserPassword(cfg.User, cfg.Password)
+	}
+	return u.String()
+}
+
+// Close closes the MongoDB connection
+func (m *MongoIntrospector) Close() error {
+	if m.client == nil {
+		return nil
+	}
+	ctx, cancel := m.queryContext()
+	defer cancel()
+	return m.client.Disconnect(ctx)
+}
+
+// Ping checks that the MongoDB connection is still alive
+func (m *MongoIntrospector) Ping() error {
+	if m.client == nil {
+		return errors.New("not connected")
+	}
+
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	if err := m.client.Ping(ctx, nil); err != nil {
+		return m.wrapQueryError(ctx, err, "failed to ping MongoDB", "connection check")
+	}
+	return nil
+}
+
+// Dialect returns the dialect of the schema
+func (m *MongoIntrospector) Dialect() string {
+	return "mongodb"
+}
+
+// GetTables returns the names of the collections in the database
+func (m *MongoIntrospector) GetTables() ([]string, error) {
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	names, err := m.client.Database(m.cfg.DBName).ListCollectionNames(ctx, bson.D{{Key: "type", Value: "collection"}})
+	if err != nil {
+		return nil, m.wrapQueryError(ctx, err, "failed to list collections", "collection list query")
+	}
+
+	var collections []string
+	for _, name := range names {
+		if !strings.HasPrefix(name, "system.") {
+			collections = append(collections, name)
+		}
+	}
+	sort.Strings(collections)
+	return collections, nil
+}
+
+// sampleSize returns the number of documents sampled per collection
+func (m *MongoIntrospector) sampleSize() int {
+	if m.cfg.SampleSize > 0 {
+		return m.cfg.SampleSize
+	}
+	return config.DefaultSampleSize
+}
+
+// GetColumns returns the fields of a collection, inferred from a sample of
+// its documents
+func (m *MongoIntrospector) GetColumns(tableName string) ([]ColumnMetadata, error) {
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	pipeline := mongo.Pipeline{{{Key: "$sample", Value: bson.D{{Key: "size", Value: m.sampleSize()}}}}}
+	cursor, err := m.client.Database(m.cfg.DBName).Collection(tableName).Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, m.wrapQueryError(ctx, err, "failed to sample documents", fmt.Sprintf("collection %s sample query", tableName))
+	}
+	defer cursor.Close(ctx)
+
+	var docs []bson.D
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, m.wrapQueryError(ctx, err, "failed to read documents", fmt.Sprintf("collection %s sample query", tableName))
+	}
+
+	return documentColumns(docs), nil
+}
+
+// GetTableMetadata returns full metadata for a collection. Documents have no
+// foreign keys, so the metadata never contains any.
+func (m *MongoIntrospector) GetTableMetadata(tableName string) (*TableMetadata, error) {
+	columns, err := m.GetColumns(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TableMetadata{
+		Schema:  m.cfg.DBName,
+		Name:    tableName,
+		Columns: columns,
+	}, nil
+}
+
+// documentField accumulates what the sample shows about one field
+type documentField struct {
+	name     string
+	types    map[string]bool // Types of the non-null values
+	elements map[string]bool // Types of the non-null array elements
+	present  int             // Documents with a non-null value
+}
+
+// documentColumns merges the top-level fields of sampled documents into
+// columns, in order of first appearance. A field is nullable unless every
+// document has a non-null value for it. _id is the primary key.
+func documentColumns(docs []bson.D) []ColumnMetadata {
+	var fields []*documentField
+	byName := make(map[string]*documentField)
+
+	for _, doc := range docs {
+		for _, elem := range doc {
+			field, ok := byName[elem.Key]
+			if !ok {
+				field = &documentField{name: elem.Key, types: make(map[string]bool), elements: make(map[string]bool)}
+				byName[elem.Key] = field
+				fields = append(fields, field)
+			}
+			if elem.Value == nil {
+				continue
+			}
+			field.present++
+			valueType := bsonTypeName(elem.Value)
+			field.types[valueType] = true
+			if array, ok := elem.Value.(bson.A); ok {
+				for _, item := range array {
+					if item != nil {
+						field.elements[bsonTypeName(item)] = true
+					}
+				}
+			}
+		}
+	}
+
+	columns := make([]ColumnMetadata, 0, len(fields))
+	for i, field := range fields {
+		dataType := mergeBSONTypes(field.types)
+		rawType := dataType
+		if dataType == bsonArray && len(field.elements) > 0 {
+			rawType = fmt.Sprintf("array(%s)", mergeBSONTypes(field.elements))
+		}
+		columns = append(columns, ColumnMetadata{
+			Name:            field.name,
+			DataType:        dataType,
+			RawType:         rawType,
+			IsNullable:      field.present < len(docs),
+			IsPrimaryKey:    field.name == "_id",
+			OrdinalPosition: i + 1,
+		})
+	}
+	return columns
+}
+
+// bsonTypeName returns the field type of a decoded BSON value
+func bsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case primitive.ObjectID:
+		return bsonObjectID
+	case string:
+		return bsonString
+	case int32:
+		return bsonInt
+	case int64:
+		return bsonLong
+	case float64:
+		return bsonDouble
+	case primitive.Decimal128:
+		return bsonDecimal
+	case bool:
+		return bsonBool
+	case primitive.DateTime:
+		return bsonDate
+	case primitive.Timestamp:
+		return bsonTimestamp
+	case bson.D, bson.M:
+		return bsonObject
+	case bson.A:
+		return bsonArray
+	case primitive.Binary:
+		return bsonBinData
+	}
+	return bsonMixed
+}
+
+// mergeBSONTypes returns the single type that holds all of types: integers
+// widen to long, and integers mixed with doubles to double. Anything else
+// that disagrees is mixed.
+func mergeBSONTypes(types map[string]bool) string {
+	switch len(types) {
+	case 0:
+		return bsonMixed
+	case 1:
+		for t := range types {
+			return t
+		}
+	}
+
+	numeric := types[bsonInt] || types[bsonLong] || types[bsonDouble]
+	for t := range types {
+		if t != bsonInt && t != bsonLong && t != bsonDouble {
+			numeric = false
+		}
+	}
+	switch {
+	case numeric && types[bsonDouble]:
+		return bsonDouble
+	case numeric:
+		return bsonLong
+	}
+	return bsonMixed
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDocumentColumns(t *testing.T) {
+	now := primitive.NewDateTimeFromTime(time.Now())
+	docs := []bson.D{
+		{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "email", Value: "a@example.com"},
+			{Key: "visits", Value: int32(3)},
+			{Key: "tags", Value: bson.A{"new", "vip"}},
+			{Key: "createdAt", Value: now},
+		},
+		{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "email", Value: "b@example.com"},
+			{Key: "visits", Value: int64(1) << 40},
+			{Key: "tags", Value: bson.A{}},
+			{Key: "createdAt", Value: now},
+			{Key: "nickname", Value: nil},
+			{Key: "profile", Value: bson.D{{Key: "bio", Value: "hi"}}},
+		},
+	}
+
+	want := []struct {
+		name     string
+		dataType string
+		rawType  string
+		nullable bool
+	}{
+		{"_id", "objectid", "objectid", false},
+		{"email", "string", "string", false},
+		{"visits", "long", "long", false},
+		{"tags", "array", "array(string)", false},
+		{"createdAt", "date", "date", false},
+		{"nickname", "mixed", "mixed", true},
+		{"profile", "object", "object", true},
+	}
+
+	columns := documentColumns(docs)
+	if len(columns) != len(want) {
+		t.Fatalf("documentColumns() returned %d columns; want %d: %+v", len(columns), len(want), columns)
+	}
+	for i, w := range want {
+		col := columns[i]
+		if col.Name != w.name || col.DataType != w.dataType || col.RawType != w.rawType || col.IsNullable != w.nullable {
+			t.Errorf("column %d = %s %s (%s) nullable=%v; want %s %s (%s) nullable=%v",
+				i, col.Name, col.DataType, col.RawType, col.IsNullable, w.name, w.dataType, w.rawType, w.nullable)
+		}
+		if col.IsPrimaryKey != (w.name == "_id") {
+			t.Errorf("column %s IsPrimaryKey = %v", col.Name, col.IsPrimaryKey)
+		}
+	}
+}
+
+func TestMergeBSONTypes(t *testing.T) {
+	tests := []struct {
+		types []string
+		want  string
+	}{
+		{nil, "mixed"},
+		{[]string{"string"}, "string"},
+		{[]string{"int", "long"}, "long"},
+		{[]string{"int", "double"}, "double"},
+		{[]string{"int", "long", "double"}, "double"},
+		{[]string{"int", "string"}, "mixed"},
+	}
+
+	for _, tt := range tests {
+		types := make(map[string]bool)
+		for _, name := range tt.types {
+			types[name] = true
+		}
+		if got := mergeBSONTypes(types); got != tt.want {
+			t.Errorf("mergeBSONTypes(%v) = %q; want %q", tt.types, got, tt.want)
+		}
+	}
+}
+
+func TestMongoURI(t *testing.T) {
+	tests := []struct {
+		cfg  config.DBConfig
+		want string
+	}{
+		{config.DBConfig{Host: "localhost", Port: 27017}, "mongodb://localhost:27017/"},
+		{config.DBConfig{Host: "db", Port: 27017, User: "app", Password: "p@ss"}, "mongodb://app:p%40ss@db:27017/"},
+		{config.DBConfig{Host: "mongodb+srv://cluster0.example.net/?retryWrites=true"}, "mongodb+srv://cluster0.example.net/?retryWrites=true"},
+	}
+
+	for _, tt := range tests {
+		if got := mongoURI(&tt.cfg); got != tt.want {
+			t.Errorf("mongoURI(%+v) = %q; want %q", tt.cfg, got, tt.want)
+		}
+	}
+}
@@ -31,14 +31,24 @@ func (m *MySQLIntrospector) Connect() error {
 		m.cfg.Port,
 		m.cfg.DBName,
 	)
+	if m.cfg.Vitess {
+		// vtgate routes information_schema queries by the literal schema
+		// name, which server-side prepared statements hide; PlanetScale
+		// also requires TLS
+		dsn += "&interpolateParams=true&tls=preferred"
+	}
 
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
 		return fmt.Errorf("failed to open MySQL connection: %w", err)
 	}
 
-	if err := db.Ping(); err != nil {
-		return fmt.Errorf("failed to ping MySQL: %w", err)
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return m.wrapQueryError(ctx, err, "failed to ping MySQL", "connection to MySQL")
 	}
 
 	m.db = db
@@ -54,9 +64,12 @@ func (m *MySQLIntrospector) GetTables() ([]string, error) {
 		ORDER BY TABLE_NAME
 	`
 
-	rows, err := m.db.Query(query, m.cfg.DBName)
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	rows, err := m.db.QueryContext(ctx, query, m.cfg.DBName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query tables: %w", err)
+		return nil, m.wrapQueryError(ctx, err, "failed to query tables", "table list query")
 	}
 	defer rows.Close()
 
@@ -69,9 +82,53 @@ func (m *MySQLIntrospector) GetTables() ([]string, error) {
 		tables = append(tables, tableName)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, m.wrapQueryError(ctx, err, "failed to read tables", "table list query")
+	}
+
 	return tables, nil
 }
 
+// TableStats returns the estimated row count and size of every table from
+// information_schema.TABLES; InnoDB's row counts are estimates
+func (m *MySQLIntrospector) TableStats() (map[string]TableStats, error) {
+	query := `
+		SELECT TABLE_NAME, COALESCE(TABLE_ROWS, 0), COALESCE(DATA_LENGTH, 0) + COALESCE(INDEX_LENGTH, 0)
+		FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = ? AND TABLE_TYPE = 'BASE TABLE'
+	`
+
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	rows, err := m.db.QueryContext(ctx, query, m.cfg.DBName)
+	if err != nil {
+		return nil, m.wrapQueryError(ctx, err, "failed to query table statistics", "table statistics query")
+	}
+	defer rows.Close()
+
+	stats := make(map[string]TableStats)
+	for rows.Next() {
+		var tableName string
+		var stat TableStats
+		if err := rows.Scan(&tableName, &stat.Rows, &stat.Bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan table statistics: %w", err)
+		}
+		stats[tableName] = stat
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, m.wrapQueryError(ctx, err, "failed to read table statistics", "table statistics query")
+	}
+
+	return stats, nil
+}
+
+// Dialect returns the SQL dialect of the schema
+func (m *MySQLIntrospector) Dialect() string {
+	return "mysql"
+}
+
 // GetColumns returns column metadata for a specific table
 func (m *MySQLIntrospector) GetColumns(tableName string) ([]ColumnMetadata, error) {
 	query := `
@@ -93,9 +150,12 @@ func (m *MySQLIntrospector) GetColumns(tableName string) ([]ColumnMetadata, erro
 		ORDER BY ORDINAL_POSITION
 	`
 
-	rows, err := m.db.Query(query, m.cfg.DBName, tableName)
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	rows, err := m.db.QueryContext(ctx, query, m.cfg.DBName, tableName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query columns: %w", err)
+		return nil, m.wrapQueryError(ctx, err, "failed to query columns", fmt.Sprintf("table %s metadata query", tableName))
 	}
 	defer rows.Close()
 
@@ -183,15 +243,75 @@ func (m *MySQLIntrospector) GetColumns(tableName string) ([]ColumnMetadata, erro
 		columns = append(columns, col)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, m.wrapQueryError(ctx, err, "failed to read columns", fmt.Sprintf("table %s metadata query", tableName))
+	}
+
+	// Vitess doesn't expose SRS_ID through vtgate
+	if !m.cfg.Vitess {
+		m.applySRIDs(tableName, columns)
+	}
+
 	return columns, nil
 }
 
+// mysqlSpatialTypes are the DATA_TYPE values of MySQL spatial columns
+var mysqlSpatialTypes = map[string]bool{
+	"geometry": true, "point": true, "linestring": true, "polygon": true,
+	"multipoint": true, "multilinestring": true, "multipolygon": true,
+	"geometrycollection": true, "geomcollection": true,
+}
+
+// applySRIDs sets the SRID of spatial columns. SRS_ID only exists on
+// MySQL 8+, so older servers and MariaDB leave the SRID unset.
+func (m *MySQLIntrospector) applySRIDs(tableName string, columns []ColumnMetadata) {
+	spatial := false
+	for _, col := range columns {
+		spatial = spatial || mysqlSpatialTypes[strings.ToLower(col.DataType)]
+	}
+	if !spatial {
+		return
+	}
+
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	rows, err := m.db.QueryContext(ctx, `
+		SELECT COLUMN_NAME, SRS_ID
+		FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND SRS_ID IS NOT NULL
+	`, m.cfg.DBName, tableName)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	srids := make(map[string]int)
+	for rows.Next() {
+		var name string
+		var srid int
+		if err := rows.Scan(&name, &srid); err != nil {
+			return
+		}
+		srids[name] = srid
+	}
+
+	for i := range columns {
+		if srid, ok := srids[columns[i].Name]; ok {
+			columns[i].SRID = &srid
+		}
+	}
+}
+
 // GetTableMetadata returns full metadata for a specific table
 func (m *MySQLIntrospector) GetTableMetadata(tableName string) (*TableMetadata, error) {
 	columns, err := m.GetColumns(tableName)
 	if err != nil {
 		return nil, err
 	}
+	if len(columns) == 0 {
+		return nil, m.tableNotFound(tableName, m.cfg.DBName)
+	}
 
 	// Get table comment
 	var tableComment sql.NullString
@@ -200,9 +320,12 @@ func (m *MySQLIntrospector) GetTableMetadata(tableName string) (*TableMetadata,
 		FROM information_schema.TABLES 
 		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
 	`
-	err = m.db.QueryRow(query, m.cfg.DBName, tableName).Scan(&tableComment)
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	err = m.db.QueryRowContext(ctx, query, m.cfg.DBName, tableName).Scan(&tableComment)
 	if err != nil && err != sql.ErrNoRows {
-		return nil, fmt.Errorf("failed to get table comment: %w", err)
+		return nil, m.wrapQueryError(ctx, err, "failed to get table comment", fmt.Sprintf("table %s comment query", tableName))
 	}
 
 	meta := &TableMetadata{
@@ -215,9 +338,163 @@ func (m *MySQLIntrospector) GetTableMetadata(tableName string) (*TableMetadata,
 		meta.Comment = tableComment.String
 	}
 
+	// Get foreign keys in both directions
+	var foreignKeys []ForeignKey
+	if m.cfg.Vitess {
+		foreignKeys = m.getVitessForeignKeys(tableName)
+	} else {
+		foreignKeys, err = m.getForeignKeys(tableName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if m.cfg.InferForeignKeys {
+		inferred, err := m.inferForeignKeys(tableName, foreignKeys)
+		if err != nil {
+			return nil, err
+		}
+		foreignKeys = append(foreignKeys, inferred...)
+	}
+	splitForeignKeys(meta, foreignKeys)
+
 	return meta, nil
 }
 
+// getForeignKeys returns single-column foreign keys declared on or referencing a table
+func (m *MySQLIntrospector) getForeignKeys(tableName string) ([]ForeignKey, error) {
+	query := `
+		SELECT
+			CONSTRAINT_NAME,
+			TABLE_NAME,
+			COLUMN_NAME,
+			REFERENCED_TABLE_NAME,
+			REFERENCED_COLUMN_NAME
+		FROM information_schema.KEY_COLUMN_USAGE
+		WHERE TABLE_SCHEMA = ?
+			AND REFERENCED_TABLE_SCHEMA = ?
+			AND REFERENCED_TABLE_NAME IS NOT NULL
+			AND (TABLE_NAME = ? OR REFERENCED_TABLE_NAME = ?)
+			AND CONSTRAINT_NAME IN (
+				SELECT CONSTRAINT_NAME
+				FROM information_schema.KEY_COLUMN_USAGE
+				WHERE TABLE_SCHEMA = ? AND REFERENCED_TABLE_NAME IS NOT NULL
+				GROUP BY TABLE_NAME, CONSTRAINT_NAME
+				HAVING COUNT(*) = 1
+			)
+		ORDER BY TABLE_NAME, CONSTRAINT_NAME
+	`
+
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	rows, err := m.db.QueryContext(ctx, query, m.cfg.DBName, m.cfg.DBName, tableName, tableName, m.cfg.DBName)
+	if err != nil {
+		return nil, m.wrapQueryError(ctx, err, "failed to query foreign keys", fmt.Sprintf("table %s foreign key query", tableName))
+	}
+	defer rows.Close()
+
+	var foreignKeys []ForeignKey
+	for rows.Next() {
+		var fk ForeignKey
+		if err := rows.Scan(&fk.Name, &fk.Table, &fk.Column, &fk.ReferencedTable, &fk.ReferencedColumn); err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key: %w", err)
+		}
+		foreignKeys = append(foreignKeys, fk)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, m.wrapQueryError(ctx, err, "failed to read foreign keys", fmt.Sprintf("table %s foreign key query", tableName))
+	}
+
+	return foreignKeys, nil
+}
+
+// getVitessForeignKeys returns single-column foreign keys declared on or
+// referencing a table in a Vitess keyspace. vtgate doesn't plan the
+// subquery getForeignKeys uses, so multi-column keys are dropped here, and
+// keyspaces without foreign key support simply report none.
+func (m *MySQLIntrospector) getVitessForeignKeys(tableName string) []ForeignKey {
+	query := `
+		SELECT
+			CONSTRAINT_NAME,
+			TABLE_NAME,
+			COLUMN_NAME,
+			REFERENCED_TABLE_NAME,
+			REFERENCED_COLUMN_NAME
+		FROM information_schema.KEY_COLUMN_USAGE
+		WHERE TABLE_SCHEMA = ?
+			AND REFERENCED_TABLE_NAME IS NOT NULL
+			AND (TABLE_NAME = ? OR REFERENCED_TABLE_NAME = ?)
+		ORDER BY TABLE_NAME, CONSTRAINT_NAME
+	`
+
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	rows, err := m.db.QueryContext(ctx, query, m.cfg.DBName, tableName, tableName)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var foreignKeys []ForeignKey
+	columns := make(map[string]int)
+	for rows.Next() {
+		var fk ForeignKey
+		if err := rows.Scan(&fk.Name, &fk.Table, &fk.Column, &fk.ReferencedTable, &fk.ReferencedColumn); err != nil {
+			return nil
+		}
+		columns[fk.Table+"."+fk.Name]++
+		foreignKeys = append(foreignKeys, fk)
+	}
+	if rows.Err() != nil {
+		return nil
+	}
+
+	singleColumn := foreignKeys[:0]
+	for _, fk := range foreignKeys {
+		if columns[fk.Table+"."+fk.Name] == 1 {
+			singleColumn = append(singleColumn, fk)
+		}
+	}
+	return singleColumn
+}
+
+// inferForeignKeys derives foreign keys on or referencing a table from the
+// <table>_id naming convention, skipping columns with a declared key
+func (m *MySQLIntrospector) inferForeignKeys(tableName string, declared []ForeignKey) ([]ForeignKey, error) {
+	query := `
+		SELECT TABLE_NAME, COLUMN_NAME
+		FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = ? AND (COLUMN_NAME = 'id' OR COLUMN_NAME LIKE '%\_id')
+		ORDER BY TABLE_NAME, ORDINAL_POSITION
+	`
+
+	ctx, cancel := m.queryContext()
+	defer cancel()
+
+	rows, err := m.db.QueryContext(ctx, query, m.cfg.DBName)
+	if err != nil {
+		return nil, m.wrapQueryError(ctx, err, "failed to query key columns", "key column query")
+	}
+	defer rows.Close()
+
+	tables := make(map[string][]string)
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return nil, fmt.Errorf("failed to scan key column: %w", err)
+		}
+		tables[table] = append(tables[table], column)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, m.wrapQueryError(ctx, err, "failed to read key columns", "key column query")
+	}
+
+	return inferForeignKeys(tableName, tables, declared), nil
+}
+
 // parseEnumValues extracts enum values from a MySQL COLUMN_TYPE
 // e.g., "enum('active','inactive','pending')" -> ["active", "inactive", "pending"]
 func parseEnumValues(columnType string) []string {
@@ -0,0 +1,603 @@
+package database
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// parquetMagic starts and ends every Parquet file
+const parquetMagic = "PAR1"
+
+// Parquet physical types
+const (
+	parquetBoolean = iota
+	parquetInt32
+	parquetInt64
+	parquetInt96
+	parquetFloat
+	parquetDouble
+	parquetByteArray
+	parquetFixedLenByteArray
+)
+
+// Parquet converted types (the legacy logical type annotations)
+const (
+	convertedUTF8 = iota
+	convertedMap
+	convertedMapKeyValue
+	convertedList
+	convertedEnum
+	convertedDecimal
+	convertedDate
+	convertedTimeMillis
+	convertedTimeMicros
+	convertedTimestampMillis
+	convertedTimestampMicros
+	convertedUint8
+	convertedUint16
+	convertedUint32
+	convertedUint64
+	convertedInt8
+	convertedInt16
+	convertedInt32
+	convertedInt64
+	convertedJSON
+	convertedBSON
+	convertedInterval
+)
+
+// Parquet logical types, identified by their field in the LogicalType union
+const (
+	logicalString    = 1
+	logicalEnum      = 4
+	logicalDecimal   = 5
+	logicalDate      = 6
+	logicalTime      = 7
+	logicalTimestamp = 8
+	logicalInteger   = 10
+	logicalJSON      = 12
+	logicalUUID      = 14
+)
+
+// Parquet repetition types of optional (nullable) and repeated fields;
+// repeated fields are lists in files written without the LIST annotation
+const (
+	parquetOptional = 1
+	parquetRepeated = 2
+)
+
+// parquetSchemaElement is the subset of a Parquet SchemaElement needed to
+// type a column. Optional fields are -1 when absent.
+type parquetSchemaElement struct {
+	name          string
+	physicalType  int
+	typeLength    int
+	repetition    int
+	numChildren   int
+	convertedType int
+	scale         int
+	precision     int
+	logicalType   int  // Field id of the LogicalType union member
+	bitWidth      int  // Integer logical type bit width
+	signed        bool // Integer logical type signedness
+	adjustedToUTC bool // Timestamp logical type is an instant rather than a local time
+}
+
+// readParquetColumns reads the top-level columns of a Parquet file from the
+// schema in its footer. Nested groups (lists, maps, structs) become json
+// columns.
+func readParquetColumns(path, dialect string) ([]ColumnMetadata, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open data file: %w", err)
+	}
+	defer file.Close()
+
+	footer, err := readParquetFooter(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	schema, err := parseParquetSchema(footer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema of %s: %w", path, err)
+	}
+	return parquetColumns(schema, dialect), nil
+}
+
+// readParquetFooter returns the Thrift-encoded FileMetaData at the end of a
+// Parquet file: ... <metadata> <4-byte little-endian length> PAR1
+func readParquetFooter(file io.ReadSeeker) ([]byte, error) {
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	if size < 12 {
+		return nil, errors.New("not a Parquet file")
+	}
+
+	trailer := make([]byte, 8)
+	if _, err := file.Seek(size-8, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(file, trailer); err != nil {
+		return nil, err
+	}
+	if string(trailer[4:]) != parquetMagic {
+		return nil, errors.New("not a Parquet file")
+	}
+
+	length := int64(binary.LittleEndian.Uint32(trailer[:4]))
+	if length > size-12 {
+		return nil, errors.New("corrupt Parquet footer")
+	}
+	footer := make([]byte, length)
+	if _, err := file.Seek(size-8-length, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(file, footer); err != nil {
+		return nil, err
+	}
+	return footer, nil
+}
+
+// parseParquetSchema decodes the schema list (field 2) of a FileMetaData
+func parseParquetSchema(footer []byte) ([]parquetSchemaElement, error) {
+	r := &thriftReader{data: footer}
+	var schema []parquetSchemaElement
+	err := r.readStruct(func(id int16, typ byte) error {
+		if id != 2 || typ != thriftList {
+			return r.skip(typ)
+		}
+		elemType, count, err := r.readListHeader()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < count; i++ {
+			if elemType != thriftStruct {
+				return errors.New("unexpected schema element type")
+			}
+			element, err := r.readSchemaElement()
+			if err != nil {
+				return err
+			}
+			schema = append(schema, element)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(schema) == 0 {
+		return nil, errors.New("no schema in Parquet footer")
+	}
+	return schema, nil
+}
+
+// readSchemaElement decodes a SchemaElement struct
+func (r *thriftReader) readSchemaElement() (parquetSchemaElement, error) {
+	el := parquetSchemaElement{physicalType: -1, repetition: -1, convertedType: -1, logicalType: -1}
+	err := r.readStruct(func(id int16, typ byte) error {
+		var err error
+		switch {
+		case id == 1 && typ == thriftI32:
+			el.physicalType, err = r.readInt()
+		case id == 2 && typ == thriftI32:
+			el.typeLength, err = r.readInt()
+		case id == 3 && typ == thriftI32:
+			el.repetition, err = r.readInt()
+		case id == 4 && typ == thriftBinary:
+			var name []byte
+			name, err = r.readBinary()
+			el.name = string(name)
+		case id == 5 && typ == thriftI32:
+			el.numChildren, err = r.readInt()
+		case id == 6 && typ == thriftI32:
+			el.convertedType, err = r.readInt()
+		case id == 7 && typ == thriftI32:
+			el.scale, err = r.readInt()
+		case id == 8 && typ == thriftI32:
+			el.precision, err = r.readInt()
+		case id == 10 && typ == thriftStruct:
+			err = r.readLogicalType(&el)
+		default:
+			err = r.skip(typ)
+		}
+		return err
+	})
+	return el, err
+}
+
+// readLogicalType decodes the LogicalType union, keeping the member's field
+// id, for integers the bit width and signedness, and for timestamps whether
+// they are adjusted to UTC
+func (r *thriftReader) readLogicalType(el *parquetSchemaElement) error {
+	return r.readStruct(func(id int16, typ byte) error {
+		el.logicalType = int(id)
+		if id == logicalTimestamp && typ == thriftStruct {
+			return r.readStruct(func(id int16, typ byte) error {
+				if id == 1 && (typ == thriftTrue || typ == thriftFalse) {
+					el.adjustedToUTC = typ == thriftTrue
+					return nil
+				}
+				return r.skip(typ)
+			})
+		}
+		if id != logicalInteger || typ != thriftStruct {
+			return r.skip(typ)
+		}
+		return r.readStruct(func(id int16, typ byte) error {
+			switch {
+			case id == 1 && typ == thriftByte:
+				b, err := r.readByte()
+				el.bitWidth = int(int8(b))
+				return err
+			case id == 2 && (typ == thriftTrue || typ == thriftFalse):
+				el.signed = typ == thriftTrue
+				return nil
+			}
+			return r.skip(typ)
+		})
+	})
+}
+
+// parquetColumns converts the flattened schema tree to the columns of its
+// top-level fields; schema[0] is the root
+func parquetColumns(schema []parquetSchemaElement, dialect string) []ColumnMetadata {
+	var columns []ColumnMetadata
+	for i := 1; i < len(schema); i = nextParquetSibling(schema, i) {
+		el := schema[i]
+		rawType := parquetColumnType(el, dialect)
+		columns = append(columns, ColumnMetadata{
+			Name:            el.name,
+			DataType:        baseTypeName(rawType),
+			RawType:         rawType,
+			IsNullable:      el.repetition == parquetOptional,
+			IsUnsigned:      strings.HasSuffix(rawType, " unsigned"),
+			OrdinalPosition: len(columns) + 1,
+		})
+	}
+	return columns
+}
+
+// nextParquetSibling returns the index of the element following the
+// subtree rooted at schema[i]
+func nextParquetSibling(schema []parquetSchemaElement, i int) int {
+	pending := 1
+	for pending > 0 && i < len(schema) {
+		pending += schema[i].numChildren - 1
+		i++
+	}
+	return i
+}
+
+// parquetColumnType returns the SQL type of a top-level Parquet field,
+// preferring its logical annotation over the physical type
+func parquetColumnType(el parquetSchemaElement, dialect string) string {
+	isMySQL := dialect == "mysql"
+	if el.numChildren > 0 || el.repetition == parquetRepeated {
+		return "json"
+	}
+	if el.logicalType == logicalTimestamp {
+		return parquetTimestampType(el.adjustedToUTC, isMySQL)
+	}
+
+	switch el.convertedType {
+	case convertedUTF8, convertedEnum:
+		return "text"
+	case convertedJSON:
+		return "json"
+	case convertedDecimal:
+		return fmt.Sprintf("decimal(%d,%d)", el.precision, el.scale)
+	case convertedDate:
+		return "date"
+	case convertedTimeMillis, convertedTimeMicros:
+		return "time"
+	case convertedTimestampMillis, convertedTimestampMicros:
+		// The legacy annotations are instants, like TIMESTAMP(isAdjustedToUTC=true)
+		return parquetTimestampType(true, isMySQL)
+	case convertedInt8, convertedInt16, convertedUint8:
+		return "smallint"
+	case convertedInt32, convertedUint16:
+		return "integer"
+	case convertedInt64, convertedUint32:
+		return "bigint"
+	case convertedUint64:
+		return unsignedBigint(isMySQL)
+	}
+
+	switch el.logicalType {
+	case logicalString, logicalEnum:
+		return "text"
+	case logicalJSON:
+		return "json"
+	case logicalDecimal:
+		return fmt.Sprintf("decimal(%d,%d)", el.precision, el.scale)
+	case logicalDate:
+		return "date"
+	case logicalTime:
+		return "time"
+	case logicalUUID:
+		if isMySQL {
+			return "char(36)"
+		}
+		return "uuid"
+	case logicalInteger:
+		switch {
+		case el.bitWidth == 64 && !el.signed:
+			return unsignedBigint(isMySQL)
+		case el.bitWidth == 64 || (el.bitWidth == 32 && !el.signed):
+			return "bigint"
+		case el.bitWidth == 32 || (el.bitWidth == 16 && !el.signed):
+			return "integer"
+		default:
+			return "smallint"
+		}
+	}
+
+	switch el.physicalType {
+	case parquetBoolean:
+		return "boolean"
+	case parquetInt32:
+		return "integer"
+	case parquetInt64:
+		return "bigint"
+	case parquetInt96:
+		return "timestamp" // Legacy Impala/Spark timestamps
+	case parquetFloat:
+		return "real"
+	case parquetDouble:
+		return "double precision"
+	case parquetFixedLenByteArray:
+		if isMySQL {
+			return fmt.Sprintf("binary(%d)", el.typeLength)
+		}
+		return "bytea"
+	}
+	if isMySQL {
+		return "blob"
+	}
+	return "bytea"
+}
+
+// parquetTimestampType returns the type of a timestamp column: instants
+// become timestamptz on PostgreSQL. MySQL's TIMESTAMP stores instants too.
+func parquetTimestampType(adjustedToUTC, isMySQL bool) string {
+	if adjustedToUTC && !isMySQL {
+		return "timestamptz"
+	}
+	return "timestamp"
+}
+
+// unsignedBigint returns the type holding a 64-bit unsigned integer
+func unsignedBigint(isMySQL bool) string {
+	if isMySQL {
+		return "bigint unsigned"
+	}
+	return "numeric(20,0)"
+}
+
+// Thrift compact protocol field types
+const (
+	thriftTrue   = 1
+	thriftFalse  = 2
+	thriftByte   = 3
+	thriftI16    = 4
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftDouble = 7
+	thriftBinary = 8
+	thriftList   = 9
+	thriftSet    = 10
+	thriftMap    = 11
+	thriftStruct = 12
+)
+
+// maxThriftDepth caps the nesting of structs and collections. Parquet
+// metadata nests a handful of levels; a crafted footer nesting thousands
+// would otherwise exhaust the stack.
+const maxThriftDepth = 64
+
+// errThriftTruncated is returned when the Thrift data ends unexpectedly
+var errThriftTruncated = errors.New("truncated Parquet metadata")
+
+// errThriftTooDeep is returned when values nest deeper than maxThriftDepth
+var errThriftTooDeep = errors.New("too deeply nested Parquet metadata")
+
+// thriftReader decodes the Thrift compact protocol Parquet metadata is
+// written in, just far enough to read the schema
+type thriftReader struct {
+	data  []byte
+	pos   int
+	depth int // Structs and collections being read
+}
+
+// readByte reads a single byte
+func (r *thriftReader) readByte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, errThriftTruncated
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+// readVarint reads an unsigned LEB128 varint
+func (r *thriftReader) readVarint() (uint64, error) {
+	value, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, errThriftTruncated
+	}
+	r.pos += n
+	return value, nil
+}
+
+// readZigzag reads a zigzag-encoded signed varint (i16, i32 and i64)
+func (r *thriftReader) readZigzag() (int64, error) {
+	value, err := r.readVarint()
+	return int64(value>>1) ^ -int64(value&1), err
+}
+
+// readInt reads an i32 as an int
+func (r *thriftReader) readInt() (int, error) {
+	value, err := r.readZigzag()
+	return int(value), err
+}
+
+// readBinary reads a length-prefixed byte string
+func (r *thriftReader) readBinary() ([]byte, error) {
+	length, err := r.readVarint()
+	if err != nil {
+		return nil, err
+	}
+	if length > uint64(len(r.data)-r.pos) {
+		return nil, errThriftTruncated
+	}
+	value := r.data[r.pos : r.pos+int(length)]
+	r.pos += int(length)
+	return value, nil
+}
+
+// readListHeader reads the element type and size of a list or set
+func (r *thriftReader) readListHeader() (byte, int, error) {
+	header, err := r.readByte()
+	if err != nil {
+		return 0, 0, err
+	}
+	size := int(header >> 4)
+	if size == 15 {
+		long, err := r.readVarint()
+		if err != nil {
+			return 0, 0, err
+		}
+		if long > uint64(len(r.data)) {
+			return 0, 0, errThriftTruncated
+		}
+		size = int(long)
+	}
+	return header & 0x0f, size, nil
+}
+
+// nest enters a struct or collection, failing beyond maxThriftDepth. The
+// caller must call unnest when done with it.
+func (r *thriftReader) nest() error {
+	r.depth++
+	if r.depth > maxThriftDepth {
+		return errThriftTooDeep
+	}
+	return nil
+}
+
+// unnest leaves a struct or collection entered with nest
+func (r *thriftReader) unnest() {
+	r.depth--
+}
+
+// readStruct calls field for every field of a struct until its stop byte.
+// field must consume (or skip) the field's value.
+func (r *thriftReader) readStruct(field func(id int16, typ byte) error) error {
+	defer r.unnest()
+	if err := r.nest(); err != nil {
+		return err
+	}
+
+	var lastID int16
+	for {
+		header, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		if header == 0 {
+			return nil
+		}
+		typ := header & 0x0f
+		id := lastID + int16(header>>4)
+		if header>>4 == 0 {
+			long, err := r.readZigzag()
+			if err != nil {
+				return err
+			}
+			id = int16(long)
+		}
+		if err := field(id, typ); err != nil {
+			return err
+		}
+		lastID = id
+	}
+}
+
+// skip skips a value of the given type
+func (r *thriftReader) skip(typ byte) error {
+	switch typ {
+	case thriftTrue, thriftFalse:
+		return nil
+	case thriftByte:
+		_, err := r.readByte()
+		return err
+	case thriftI16, thriftI32, thriftI64:
+		_, err := r.readVarint()
+		return err
+	case thriftDouble:
+		if len(r.data)-r.pos < 8 {
+			return errThriftTruncated
+		}
+		r.pos += 8
+		return nil
+	case thriftBinary:
+		_, err := r.readBinary()
+		return err
+	case thriftList, thriftSet:
+		defer r.unnest()
+		if err := r.nest(); err != nil {
+			return err
+		}
+		elemType, size, err := r.readListHeader()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < size; i++ {
+			if err := r.skipElement(elemType); err != nil {
+				return err
+			}
+		}
+		return nil
+	case thriftMap:
+		defer r.unnest()
+		if err := r.nest(); err != nil {
+			return err
+		}
+		size, err := r.readVarint()
+		if err != nil || size == 0 {
+			return err
+		}
+		types, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		for i := uint64(0); i < size; i++ {
+			if err := r.skipElement(types >> 4); err != nil {
+				return err
+			}
+			if err := r.skipElement(types & 0x0f); err != nil {
+				return err
+			}
+		}
+		return nil
+	case thriftStruct:
+		return r.readStruct(func(_ int16, typ byte) error { return r.skip(typ) })
+	}
+	return fmt.Errorf("unknown Thrift type %d in Parquet metadata", typ)
+}
+
+// skipElement skips a list, set or map element. Unlike struct fields,
+// booleans in collections take a byte of their own.
+func (r *thriftReader) skipElement(typ byte) error {
+	if typ == thriftTrue || typ == thriftFalse {
+		_, err := r.readByte()
+		return err
+	}
+	return r.skip(typ)
+}
@@ -0,0 +1,242 @@
+package database
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// thriftWriter encodes the Thrift compact protocol for building test footers
+type thriftWriter struct {
+	buf    []byte
+	lastID []int16
+}
+
+func (w *thriftWriter) field(id int16, typ byte) {
+	last := w.lastID[len(w.lastID)-1]
+	if delta := id - last; delta > 0 && delta <= 15 {
+		w.buf = append(w.buf, byte(delta)<<4|typ)
+	} else {
+		w.buf = append(w.buf, typ)
+		w.buf = binary.AppendUvarint(w.buf, uint64(id)<<1^uint64(id>>15))
+	}
+	w.lastID[len(w.lastID)-1] = id
+}
+
+func (w *thriftWriter) begin()      { w.lastID = append(w.lastID, 0) }
+func (w *thriftWriter) end()        { w.buf = append(w.buf, 0); w.lastID = w.lastID[:len(w.lastID)-1] }
+func (w *thriftWriter) i32(v int32) { w.buf = binary.AppendUvarint(w.buf, uint64(uint32(v<<1^v>>31))) }
+
+func (w *thriftWriter) i32Field(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.i32(v)
+}
+
+// testParquetElement is a SchemaElement to encode; zero fields are omitted
+type testParquetElement struct {
+	name         string
+	physicalType int32
+	typeLength   int32
+	repetition   int32
+	numChildren  int32
+	converted    int32 // -1 when absent
+	precision    int32
+	scale        int32
+	logical      int16 // LogicalType union member, 0 when absent
+	bitWidth     int8
+	signed       bool
+}
+
+func (w *thriftWriter) schemaElement(el testParquetElement) {
+	w.begin()
+	if el.numChildren == 0 {
+		w.i32Field(1, el.physicalType)
+	}
+	if el.typeLength > 0 {
+		w.i32Field(2, el.typeLength)
+	}
+	w.i32Field(3, el.repetition)
+	w.field(4, thriftBinary)
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(el.name)))
+	w.buf = append(w.buf, el.name...)
+	if el.numChildren > 0 {
+		w.i32Field(5, el.numChildren)
+	}
+	if el.converted >= 0 {
+		w.i32Field(6, el.converted)
+	}
+	if el.precision > 0 {
+		w.i32Field(7, el.scale)
+		w.i32Field(8, el.precision)
+	}
+	if el.logical > 0 {
+		w.field(10, thriftStruct)
+		w.begin()
+		w.field(el.logical, thriftStruct)
+		w.begin()
+		if el.logical == logicalInteger {
+			w.field(1, thriftByte)
+			w.buf = append(w.buf, byte(el.bitWidth))
+			if el.signed {
+				w.field(2, thriftTrue)
+			} else {
+				w.field(2, thriftFalse)
+			}
+		}
+		w.end()
+		w.end()
+	}
+	w.end()
+}
+
+// writeParquet writes a Parquet file with no row groups and the given schema
+func writeParquet(t *testing.T, elements []testParquetElement) string {
+	t.Helper()
+	w := &thriftWriter{}
+	w.begin()
+	w.i32Field(1, 1) // version
+	w.field(2, thriftList)
+	w.buf = append(w.buf, 0xf0|thriftStruct)
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(elements)))
+	for _, el := range elements {
+		w.schemaElement(el)
+	}
+	w.field(3, thriftI64) // num_rows
+	w.buf = append(w.buf, 0)
+	w.field(5, thriftBinary) // key_value_metadata stand-in, skipped
+	w.buf = append(w.buf, 2, 'o', 'k')
+	w.end()
+
+	data := append([]byte(parquetMagic), w.buf...)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(w.buf)))
+	data = append(data, parquetMagic...)
+
+	path := filepath.Join(t.TempDir(), "events.parquet")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFileIntrospector_Parquet(t *testing.T) {
+	path := writeParquet(t, []testParquetElement{
+		{name: "schema", numChildren: 9, converted: -1},
+		{name: "id", physicalType: parquetInt64, converted: -1},
+		{name: "name", physicalType: parquetByteArray, repetition: parquetOptional, converted: convertedUTF8},
+		{name: "amount", physicalType: parquetFixedLenByteArray, typeLength: 16, repetition: parquetOptional, converted: convertedDecimal, precision: 18, scale: 2},
+		{name: "clicks", physicalType: parquetInt64, converted: -1, logical: logicalInteger, bitWidth: 64},
+		{name: "tags", repetition: parquetOptional, numChildren: 1, converted: convertedList},
+		{name: "list", repetition: parquetRepeated, numChildren: 1, converted: -1},
+		{name: "element", physicalType: parquetByteArray, converted: convertedUTF8},
+		{name: "ts", physicalType: parquetInt96, repetition: parquetOptional, converted: -1},
+		{name: "ref", physicalType: parquetFixedLenByteArray, typeLength: 16, converted: -1, logical: logicalUUID},
+	})
+
+	tests := []struct {
+		dialect string
+		want    []string
+	}{
+		{"postgres", []string{"bigint", "text", "decimal(18,2)", "numeric(20,0)", "json", "timestamp", "uuid"}},
+		{"mysql", []string{"bigint", "text", "decimal(18,2)", "bigint unsigned", "json", "timestamp", "char(36)"}},
+	}
+	names := []string{"id", "name", "amount", "clicks", "tags", "ts", "ref"}
+	for _, tt := range tests {
+		f := NewFileIntrospector(path, "", tt.dialect, 0)
+		if err := f.Connect(); err != nil {
+			t.Fatalf("Connect() error = %v", err)
+		}
+		cols, err := f.GetColumns("events")
+		if err != nil {
+			t.Fatalf("GetColumns() error = %v", err)
+		}
+		if len(cols) != len(names) {
+			t.Fatalf("%s: got %d columns, want %d", tt.dialect, len(cols), len(names))
+		}
+		for i, col := range cols {
+			if col.Name != names[i] || col.RawType != tt.want[i] {
+				t.Errorf("%s: column %d = %s %s, want %s %s", tt.dialect, i, col.Name, col.RawType, names[i], tt.want[i])
+			}
+		}
+		if cols[0].IsNullable || !cols[1].IsNullable {
+			t.Errorf("%s: nullability id=%v name=%v", tt.dialect, cols[0].IsNullable, cols[1].IsNullable)
+		}
+		if cols[3].IsUnsigned != (tt.dialect == "mysql") {
+			t.Errorf("%s: clicks IsUnsigned = %v", tt.dialect, cols[3].IsUnsigned)
+		}
+	}
+}
+
+func TestReadParquetFooter_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"short":     "PAR1",
+		"magic":     "PAR1 not a parquet file",
+		"length":    "PAR1\x00\x00\xff\x7fPAR1",
+		"truncated": "PAR1\x19\x1c\x00\x00\x00\x04\x00\x00\x00PAR1",
+	} {
+		path := filepath.Join(t.TempDir(), name+".parquet")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readParquetColumns(path, "postgres"); err == nil {
+			t.Errorf("%s: readParquetColumns() succeeded, want an error", name)
+		}
+	}
+}
+
+func TestParseParquetSchema_TooDeep(t *testing.T) {
+	for name, footer := range map[string]string{
+		// Structs nested in field 9 of FileMetaData
+		"structs": strings.Repeat("\x9c", 100000) + strings.Repeat("\x00", 100001),
+		// Lists of lists in field 9
+		"lists": "\x99" + strings.Repeat("\x19", 100000),
+	} {
+		if _, err := parseParquetSchema([]byte(footer)); !errors.Is(err, errThriftTooDeep) {
+			t.Errorf("%s: parseParquetSchema() error = %v, want %v", name, err, errThriftTooDeep)
+		}
+	}
+}
+
+// TestFileIntrospector_ParquetArrow reads a file written by Apache Arrow
+// (see testdata/parquet/generate)
+func TestFileIntrospector_ParquetArrow(t *testing.T) {
+	path := filepath.Join("testdata", "parquet", "events.parquet")
+	f := NewFileIntrospector(path, "", "postgres", 0)
+	if err := f.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	cols, err := f.GetColumns("events")
+	if err != nil {
+		t.Fatalf("GetColumns() error = %v", err)
+	}
+
+	want := []struct{ name, rawType string }{
+		{"id", "bigint"},
+		{"name", "text"},
+		{"amount", "decimal(18,2)"},
+		{"clicks", "numeric(20,0)"},
+		{"tags", "json"},
+		{"address", "json"},
+		{"attrs", "json"},
+		{"created_at", "timestamptz"},
+		{"local_at", "timestamp"},
+		{"day", "date"},
+		{"at", "time"},
+		{"active", "boolean"},
+		{"score", "double precision"},
+		{"payload", "bytea"},
+	}
+	if len(cols) != len(want) {
+		t.Fatalf("got %d columns, want %d: %+v", len(cols), len(want), cols)
+	}
+	for i, col := range cols {
+		if col.Name != want[i].name || col.RawType != want[i].rawType {
+			t.Errorf("column %d = %s %s, want %s %s", i, col.Name, col.RawType, want[i].name, want[i].rawType)
+		}
+	}
+	if cols[0].IsNullable || !cols[1].IsNullable {
+		t.Errorf("nullability id=%v name=%v", cols[0].IsNullable, cols[1].IsNullable)
+	}
+}
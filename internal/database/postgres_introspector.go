@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"strings"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/rowjak/godb-orm/internal/config"
 )
 
@@ -32,9 +32,12 @@ func (p *PostgresIntrospector) GetSchemas() ([]string, error) {
 		ORDER BY schema_name
 	`
 
-	rows, err := p.db.Query(query)
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	rows, err := p.db.QueryContext(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query schemas: %w", err)
+		return nil, p.wrapQueryError(ctx, err, "failed to query schemas", "schema list query")
 	}
 	defer rows.Close()
 
@@ -47,6 +50,10 @@ func (p *PostgresIntrospector) GetSchemas() ([]string, error) {
 		schemas = append(schemas, schemaName)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, p.wrapQueryError(ctx, err, "failed to read schemas", "schema list query")
+	}
+
 	return schemas, nil
 }
 
@@ -75,8 +82,12 @@ func (p *PostgresIntrospector) Connect() error {
 		return fmt.Errorf("failed to open PostgreSQL connection: %w", err)
 	}
 
-	if err := db.Ping(); err != nil {
-		return fmt.Errorf("failed to ping PostgreSQL: %w", err)
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return p.wrapQueryError(ctx, err, "failed to ping PostgreSQL", "connection to PostgreSQL")
 	}
 
 	p.db = db
@@ -92,9 +103,12 @@ func (p *PostgresIntrospector) GetTables() ([]string, error) {
 		ORDER BY table_name
 	`
 
-	rows, err := p.db.Query(query, p.currentSchema)
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	rows, err := p.db.QueryContext(ctx, query, p.currentSchema)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query tables: %w", err)
+		return nil, p.wrapQueryError(ctx, err, "failed to query tables", "table list query")
 	}
 	defer rows.Close()
 
@@ -107,9 +121,55 @@ func (p *PostgresIntrospector) GetTables() ([]string, error) {
 		tables = append(tables, tableName)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, p.wrapQueryError(ctx, err, "failed to read tables", "table list query")
+	}
+
 	return tables, nil
 }
 
+// TableStats returns the estimated row count (pg_class.reltuples) and total
+// size of every table of the current schema. Tables that were never
+// vacuumed or analyzed have no estimate and report 0 rows.
+func (p *PostgresIntrospector) TableStats() (map[string]TableStats, error) {
+	query := `
+		SELECT c.relname, GREATEST(c.reltuples, 0)::bigint, pg_total_relation_size(c.oid)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = $1 AND c.relkind IN ('r', 'p')
+	`
+
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	rows, err := p.db.QueryContext(ctx, query, p.currentSchema)
+	if err != nil {
+		return nil, p.wrapQueryError(ctx, err, "failed to query table statistics", "table statistics query")
+	}
+	defer rows.Close()
+
+	stats := make(map[string]TableStats)
+	for rows.Next() {
+		var tableName string
+		var stat TableStats
+		if err := rows.Scan(&tableName, &stat.Rows, &stat.Bytes); err != nil {
+			return nil, fmt.Errorf("failed to scan table statistics: %w", err)
+		}
+		stats[tableName] = stat
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, p.wrapQueryError(ctx, err, "failed to read table statistics", "table statistics query")
+	}
+
+	return stats, nil
+}
+
+// Dialect returns the SQL dialect of the schema
+func (p *PostgresIntrospector) Dialect() string {
+	return "postgres"
+}
+
 // GetColumns returns column metadata for a specific table
 func (p *PostgresIntrospector) GetColumns(tableName string) ([]ColumnMetadata, error) {
 	// Main query for column information with udt_name for custom types
@@ -124,19 +184,25 @@ func (p *PostgresIntrospector) GetColumns(tableName string) ([]ColumnMetadata, e
 			c.numeric_precision,
 			c.numeric_scale,
 			c.ordinal_position,
-			COALESCE(pgd.description, '') as column_comment
+			COALESCE(pgd.description, '') as column_comment,
+			COALESCE(pg_catalog.format_type(att.atttypid, att.atttypmod), '') as formatted_type
 		FROM information_schema.columns c
 		LEFT JOIN pg_catalog.pg_statio_all_tables st 
 			ON c.table_schema = st.schemaname AND c.table_name = st.relname
 		LEFT JOIN pg_catalog.pg_description pgd 
 			ON pgd.objoid = st.relid AND pgd.objsubid = c.ordinal_position
+		LEFT JOIN pg_catalog.pg_attribute att
+			ON att.attrelid = st.relid AND att.attname = c.column_name
 		WHERE c.table_schema = $1 AND c.table_name = $2
 		ORDER BY c.ordinal_position
 	`
 
-	rows, err := p.db.Query(query, p.currentSchema, tableName)
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	rows, err := p.db.QueryContext(ctx, query, p.currentSchema, tableName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query columns: %w", err)
+		return nil, p.wrapQueryError(ctx, err, "failed to query columns", fmt.Sprintf("table %s metadata query", tableName))
 	}
 	defer rows.Close()
 
@@ -153,6 +219,7 @@ func (p *PostgresIntrospector) GetColumns(tableName string) ([]ColumnMetadata, e
 			numericScale     sql.NullInt64
 			ordinalPosition  int
 			columnComment    string
+			formattedType    string
 		)
 
 		err := rows.Scan(
@@ -166,6 +233,7 @@ func (p *PostgresIntrospector) GetColumns(tableName string) ([]ColumnMetadata, e
 			&numericScale,
 			&ordinalPosition,
 			&columnComment,
+			&formattedType,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan column: %w", err)
@@ -173,11 +241,12 @@ func (p *PostgresIntrospector) GetColumns(tableName string) ([]ColumnMetadata, e
 
 		// Use udt_name for more specific type information
 		// PostgreSQL udt_name gives us internal types like int4, int8, varchar, etc.
-		rawType := p.buildRawType(dataType, udtName, charMaxLength, numericPrecision, numericScale)
+		rawType := buildPostgresRawType(dataType, udtName, charMaxLength, numericPrecision, numericScale)
+		rawType = userDefinedRawType(rawType, dataType, typeModifier(formattedType))
 
 		col := ColumnMetadata{
 			Name:            columnName,
-			DataType:        p.normalizeDataType(dataType, udtName),
+			DataType:        normalizePostgresType(dataType, udtName),
 			RawType:         rawType,
 			IsNullable:      isNullable == "YES",
 			OrdinalPosition: ordinalPosition,
@@ -214,6 +283,10 @@ func (p *PostgresIntrospector) GetColumns(tableName string) ([]ColumnMetadata, e
 		columns = append(columns, col)
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, p.wrapQueryError(ctx, err, "failed to read columns", fmt.Sprintf("table %s metadata query", tableName))
+	}
+
 	// Get primary key information
 	pkColumns, err := p.getPrimaryKeyColumns(tableName)
 	if err != nil {
@@ -230,10 +303,15 @@ func (p *PostgresIntrospector) GetColumns(tableName string) ([]ColumnMetadata, e
 	return columns, nil
 }
 
+// regclass returns the schema-qualified, quoted name of a table for a
+// ::regclass cast. Unquoted, the cast folds MyTable to mytable and fails on
+// names with spaces or dots.
+func (p *PostgresIntrospector) regclass(tableName string) string {
+	return pq.QuoteIdentifier(p.currentSchema) + "." + pq.QuoteIdentifier(tableName)
+}
+
 // getPrimaryKeyColumns returns a set of column names that are primary keys
 func (p *PostgresIntrospector) getPrimaryKeyColumns(tableName string) (map[string]bool, error) {
-	// Use schema-qualified name for regclass
-	qualifiedName := fmt.Sprintf("%s.%s", p.currentSchema, tableName)
 	query := `
 		SELECT a.attname
 		FROM pg_index i
@@ -241,9 +319,12 @@ func (p *PostgresIntrospector) getPrimaryKeyColumns(tableName string) (map[strin
 		WHERE i.indrelid = $1::regclass AND i.indisprimary
 	`
 
-	rows, err := p.db.Query(query, qualifiedName)
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	rows, err := p.db.QueryContext(ctx, query, p.regclass(tableName))
 	if err != nil {
-		return nil, fmt.Errorf("failed to query primary keys: %w", err)
+		return nil, p.wrapQueryError(ctx, err, "failed to query primary keys", fmt.Sprintf("table %s primary key query", tableName))
 	}
 	defer rows.Close()
 
@@ -256,11 +337,15 @@ func (p *PostgresIntrospector) getPrimaryKeyColumns(tableName string) (map[strin
 		pkColumns[columnName] = true
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, p.wrapQueryError(ctx, err, "failed to read primary keys", fmt.Sprintf("table %s primary key query", tableName))
+	}
+
 	return pkColumns, nil
 }
 
-// normalizeDataType normalizes PostgreSQL data types to common names
-func (p *PostgresIntrospector) normalizeDataType(dataType, udtName string) string {
+// normalizePostgresType normalizes PostgreSQL data types to common names
+func normalizePostgresType(dataType, udtName string) string {
 	// Map udt_name to standard types
 	switch udtName {
 	case "int2":
@@ -294,13 +379,18 @@ func (p *PostgresIntrospector) normalizeDataType(dataType, udtName string) strin
 		if dataType == "ARRAY" && strings.HasPrefix(udtName, "_") {
 			return "[]" + udtName[1:] // e.g., "_int4" -> "[]int4"
 		}
+		// Extension and user-defined types (hstore, enums, ...) are only
+		// identifiable by udt_name
+		if dataType == "USER-DEFINED" {
+			return udtName
+		}
 		return dataType
 	}
 }
 
-// buildRawType constructs the full type string with size information
-func (p *PostgresIntrospector) buildRawType(dataType, udtName string, charMaxLength, numericPrecision, numericScale sql.NullInt64) string {
-	normalizedType := p.normalizeDataType(dataType, udtName)
+// buildPostgresRawType constructs the full type string with size information
+func buildPostgresRawType(dataType, udtName string, charMaxLength, numericPrecision, numericScale sql.NullInt64) string {
+	normalizedType := normalizePostgresType(dataType, udtName)
 
 	// Add size information for varchar/char
 	if (normalizedType == "varchar" || normalizedType == "character varying") && charMaxLength.Valid {
@@ -318,22 +408,45 @@ func (p *PostgresIntrospector) buildRawType(dataType, udtName string, charMaxLen
 	return normalizedType
 }
 
+// userDefinedRawType appends the type modifier of an extension type, such as
+// the dimension of vector(1536), which information_schema doesn't report
+func userDefinedRawType(rawType, dataType, modifier string) string {
+	if dataType != "USER-DEFINED" || modifier == "" {
+		return rawType
+	}
+	return rawType + "(" + modifier + ")"
+}
+
+// typeModifier extracts the modifier from a format_type() result,
+// e.g. "1536" from "vector(1536)"
+func typeModifier(formattedType string) string {
+	start := strings.Index(formattedType, "(")
+	if start == -1 || !strings.HasSuffix(formattedType, ")") {
+		return ""
+	}
+	return formattedType[start+1 : len(formattedType)-1]
+}
+
 // GetTableMetadata returns full metadata for a specific table
 func (p *PostgresIntrospector) GetTableMetadata(tableName string) (*TableMetadata, error) {
 	columns, err := p.GetColumns(tableName)
 	if err != nil {
 		return nil, err
 	}
+	if len(columns) == 0 {
+		return nil, p.tableNotFound(tableName, p.currentSchema)
+	}
 
-	// Get table comment using schema-qualified name
-	qualifiedName := fmt.Sprintf("%s.%s", p.currentSchema, tableName)
 	var tableComment sql.NullString
 	query := `
 		SELECT obj_description($1::regclass, 'pg_class')
 	`
-	err = p.db.QueryRow(query, qualifiedName).Scan(&tableComment)
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	err = p.db.QueryRowContext(ctx, query, p.regclass(tableName)).Scan(&tableComment)
 	if err != nil && err != sql.ErrNoRows {
-		return nil, fmt.Errorf("failed to get table comment: %w", err)
+		return nil, p.wrapQueryError(ctx, err, "failed to get table comment", fmt.Sprintf("table %s comment query", tableName))
 	}
 
 	meta := &TableMetadata{
@@ -346,5 +459,61 @@ func (p *PostgresIntrospector) GetTableMetadata(tableName string) (*TableMetadat
 		meta.Comment = tableComment.String
 	}
 
+	// Get foreign keys in both directions
+	foreignKeys, err := p.getForeignKeys(tableName)
+	if err != nil {
+		return nil, err
+	}
+	splitForeignKeys(meta, foreignKeys)
+
 	return meta, nil
 }
+
+// getForeignKeys returns single-column foreign keys declared on or referencing a table
+func (p *PostgresIntrospector) getForeignKeys(tableName string) ([]ForeignKey, error) {
+	query := `
+		SELECT
+			con.conname,
+			cl.relname,
+			att.attname,
+			fcl.relname,
+			fatt.attname
+		FROM pg_constraint con
+		JOIN pg_class cl ON cl.oid = con.conrelid
+		JOIN pg_namespace ns ON ns.oid = cl.relnamespace
+		JOIN pg_class fcl ON fcl.oid = con.confrelid
+		JOIN pg_namespace fns ON fns.oid = fcl.relnamespace
+		JOIN pg_attribute att ON att.attrelid = con.conrelid AND att.attnum = con.conkey[1]
+		JOIN pg_attribute fatt ON fatt.attrelid = con.confrelid AND fatt.attnum = con.confkey[1]
+		WHERE con.contype = 'f'
+			AND array_length(con.conkey, 1) = 1
+			AND ns.nspname = $1
+			AND fns.nspname = $1
+			AND (cl.relname = $2 OR fcl.relname = $2)
+		ORDER BY cl.relname, con.conname
+	`
+
+	ctx, cancel := p.queryContext()
+	defer cancel()
+
+	rows, err := p.db.QueryContext(ctx, query, p.currentSchema, tableName)
+	if err != nil {
+		return nil, p.wrapQueryError(ctx, err, "failed to query foreign keys", fmt.Sprintf("table %s foreign key query", tableName))
+	}
+	defer rows.Close()
+
+	var foreignKeys []ForeignKey
+	for rows.Next() {
+		var fk ForeignKey
+		if err := rows.Scan(&fk.Name, &fk.Table, &fk.Column, &fk.ReferencedTable, &fk.ReferencedColumn); err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key: %w", err)
+		}
+		foreignKeys = append(foreignKeys, fk)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, p.wrapQueryError(ctx, err, "failed to read foreign keys", fmt.Sprintf("table %s foreign key query", tableName))
+	}
+
+	return foreignKeys, nil
+}
@@ -0,0 +1,18 @@
+package database
+
+import "testing"
+
+func TestPostgresRegclass(t *testing.T) {
+	p := &PostgresIntrospector{currentSchema: "Sales"}
+	tests := map[string]string{
+		"users":      `"Sales"."users"`,
+		"MyTable":    `"Sales"."MyTable"`,
+		"order.item": `"Sales"."order.item"`,
+		`odd"name`:   `"Sales"."odd""name"`,
+	}
+	for table, want := range tests {
+		if got := p.regclass(table); got != want {
+			t.Errorf("regclass(%q) = %s; want %s", table, got, want)
+		}
+	}
+}
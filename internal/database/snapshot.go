@@ -0,0 +1,88 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// SchemaSnapshot maps every table of a schema to a hash of its columns, so
+// that a later snapshot shows which tables changed in between
+type SchemaSnapshot map[string]string
+
+// SchemaChange lists the tables that differ between two snapshots
+type SchemaChange struct {
+	Added   []string `json:"added"`   // Tables created since the older snapshot
+	Removed []string `json:"removed"` // Tables dropped since the older snapshot
+	Changed []string `json:"changed"` // Tables whose columns changed
+}
+
+// Empty reports whether the schema is unchanged
+func (c SchemaChange) Empty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Changed) == 0
+}
+
+// TakeSnapshot hashes the columns of every table the introspector lists
+func TakeSnapshot(introspector DBIntrospector) (SchemaSnapshot, error) {
+	tables, err := introspector.GetTables()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := make(SchemaSnapshot, len(tables))
+	for _, table := range tables {
+		columns, err := introspector.GetColumns(table)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(columns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash columns of %s: %w", table, err)
+		}
+		sum := sha256.Sum256(data)
+		snapshot[table] = hex.EncodeToString(sum[:])
+	}
+	return snapshot, nil
+}
+
+// Diff returns the tables added, removed or changed in newer compared to s,
+// each list sorted by name
+func (s SchemaSnapshot) Diff(newer SchemaSnapshot) SchemaChange {
+	var change SchemaChange
+	for table, hash := range newer {
+		old, ok := s[table]
+		switch {
+		case !ok:
+			change.Added = append(change.Added, table)
+		case old != hash:
+			change.Changed = append(change.Changed, table)
+		}
+	}
+	for table := range s {
+		if _, ok := newer[table]; !ok {
+			change.Removed = append(change.Removed, table)
+		}
+	}
+	sort.Strings(change.Added)
+	sort.Strings(change.Removed)
+	sort.Strings(change.Changed)
+	return change
+}
+
+// Hash fingerprints the whole snapshot, for recording which schema a set
+// of generated files came from
+func (s SchemaSnapshot) Hash() string {
+	tables := make([]string, 0, len(s))
+	for table := range s {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	h := sha256.New()
+	for _, table := range tables {
+		fmt.Fprintf(h, "%s\x00%s\n", table, s[table])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+// schemaFake is an in-memory DBIntrospector listing tables by name
+type schemaFake map[string][]ColumnMetadata
+
+func (schemaFake) Connect() error { return nil }
+func (schemaFake) Close() error   { return nil }
+
+func (f schemaFake) GetTables() ([]string, error) {
+	var tables []string
+	for table := range f {
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+func (f schemaFake) GetColumns(tableName string) ([]ColumnMetadata, error) {
+	return f[tableName], nil
+}
+
+func (f schemaFake) GetTableMetadata(tableName string) (*TableMetadata, error) {
+	return &TableMetadata{Name: tableName, Columns: f[tableName]}, nil
+}
+
+func TestSchemaSnapshotDiff(t *testing.T) {
+	schema := schemaFake{
+		"users":  {{Name: "id", DataType: "int"}, {Name: "email", DataType: "varchar"}},
+		"orders": {{Name: "id", DataType: "int"}},
+		"logs":   {{Name: "id", DataType: "int"}},
+	}
+	before, err := TakeSnapshot(schema)
+	if err != nil {
+		t.Fatalf("TakeSnapshot() error = %v", err)
+	}
+
+	unchanged, _ := TakeSnapshot(schema)
+	if change := before.Diff(unchanged); !change.Empty() {
+		t.Errorf("Diff() of an unchanged schema = %+v", change)
+	}
+
+	schema["users"] = append(schema["users"], ColumnMetadata{Name: "name", DataType: "varchar"})
+	delete(schema, "logs")
+	schema["payments"] = []ColumnMetadata{{Name: "id", DataType: "int"}}
+	after, err := TakeSnapshot(schema)
+	if err != nil {
+		t.Fatalf("TakeSnapshot() error = %v", err)
+	}
+
+	want := SchemaChange{Added: []string{"payments"}, Removed: []string{"logs"}, Changed: []string{"users"}}
+	if got := before.Diff(after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v; want %+v", got, want)
+	}
+	if before.Hash() != unchanged.Hash() || before.Hash() == after.Hash() {
+		t.Errorf("Hash() should change with the schema alone")
+	}
+}
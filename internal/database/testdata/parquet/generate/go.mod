@@ -0,0 +1,5 @@
+module github.com/rowjak/godb-orm/internal/database/testdata/parquet/generate
+
+go 1.23.0
+
+require github.com/apache/arrow-go/v18 v18.0.0
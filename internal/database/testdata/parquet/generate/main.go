@@ -0,0 +1,90 @@
+// Command generate writes ../events.parquet with the Parquet writer of Apache
+// Arrow, so the Parquet schema reader is tested against a file of an
+// independent writer rather than its own test encoder.
+//
+//	cd internal/database/testdata/parquet/generate
+//	go mod tidy && go run .
+//
+// TestFileIntrospector_ParquetArrow expects the columns below; update both
+// together.
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+func main() {
+	address := arrow.StructOf(
+		arrow.Field{Name: "city", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "zip", Type: arrow.BinaryTypes.String, Nullable: true},
+	)
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "amount", Type: &arrow.Decimal128Type{Precision: 18, Scale: 2}, Nullable: true},
+		{Name: "clicks", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+		{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+		{Name: "address", Type: address, Nullable: true},
+		{Name: "attrs", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.BinaryTypes.String), Nullable: true},
+		{Name: "created_at", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}, Nullable: true},
+		{Name: "local_at", Type: &arrow.TimestampType{Unit: arrow.Microsecond}, Nullable: true},
+		{Name: "day", Type: arrow.FixedWidthTypes.Date32, Nullable: true},
+		{Name: "at", Type: arrow.FixedWidthTypes.Time64us, Nullable: true},
+		{Name: "active", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "payload", Type: arrow.BinaryTypes.Binary, Nullable: true},
+	}, nil)
+
+	b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer b.Release()
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	b.Field(0).(*array.Int64Builder).Append(1)
+	b.Field(1).(*array.StringBuilder).Append("Ada")
+	b.Field(2).(*array.Decimal128Builder).Append(decimal128.FromI64(1250))
+	b.Field(3).(*array.Uint64Builder).Append(1 << 63)
+	tags := b.Field(4).(*array.ListBuilder)
+	tags.Append(true)
+	tags.ValueBuilder().(*array.StringBuilder).AppendValues([]string{"new", "vip"}, nil)
+	addr := b.Field(5).(*array.StructBuilder)
+	addr.Append(true)
+	addr.FieldBuilder(0).(*array.StringBuilder).Append("Bandung")
+	addr.FieldBuilder(1).(*array.StringBuilder).Append("40115")
+	attrs := b.Field(6).(*array.MapBuilder)
+	attrs.Append(true)
+	attrs.KeyBuilder().(*array.StringBuilder).Append("plan")
+	attrs.ItemBuilder().(*array.StringBuilder).Append("pro")
+	b.Field(7).(*array.TimestampBuilder).Append(arrow.Timestamp(created.UnixMilli()))
+	b.Field(8).(*array.TimestampBuilder).Append(arrow.Timestamp(created.UnixMicro()))
+	b.Field(9).(*array.Date32Builder).Append(arrow.Date32FromTime(created))
+	b.Field(10).(*array.Time64Builder).Append(arrow.Time64((3*3600 + 4*60 + 5) * 1e6))
+	b.Field(11).(*array.BooleanBuilder).Append(true)
+	b.Field(12).(*array.Float64Builder).Append(0.5)
+	b.Field(13).(*array.BinaryBuilder).Append([]byte{0, 1})
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	f, err := os.Create(filepath.Join("..", "events.parquet"))
+	if err != nil {
+		panic(err)
+	}
+	w, err := pqarrow.NewFileWriter(schema, f, parquet.NewWriterProperties(), pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()))
+	if err != nil {
+		panic(err)
+	}
+	if err := w.Write(rec); err != nil {
+		panic(err)
+	}
+	if err := w.Close(); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,5 @@
+//go:build trino
+
+package database
+
+import _ "github.com/trinodb/trino-go-client/trino"
@@ -0,0 +1,235 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/config"
+)
+
+// trinoDriver is the database/sql driver name registered by
+// github.com/trinodb/trino-go-client/trino (compiled in with -tags trino)
+const trinoDriver = "trino"
+
+// TrinoIntrospector implements read-only introspection of a Trino (or
+// Presto) catalog through its information_schema. DBName selects the
+// catalog, optionally followed by the schema, as in hive.sales.
+type TrinoIntrospector struct {
+	BaseIntrospector
+	catalog       string
+	currentSchema string
+}
+
+// NewTrinoIntrospector creates a new Trino introspector. The schema defaults
+// to "default".
+func NewTrinoIntrospector(cfg *config.DBConfig) *TrinoIntrospector {
+	catalog, schema, _ := strings.Cut(cfg.DBName, ".")
+	if schema == "" {
+		schema = "default"
+	}
+	return &TrinoIntrospector{
+		BaseIntrospector: BaseIntrospector{cfg: cfg},
+		catalog:          catalog,
+		currentSchema:    schema,
+	}
+}
+
+// SetSchema sets the current schema to use for table queries
+func (t *TrinoIntrospector) SetSchema(schema string) {
+	t.currentSchema = schema
+}
+
+// GetCurrentSchema returns the current schema
+func (t *TrinoIntrospector) GetCurrentSchema() string {
+	return t.currentSchema
+}
+
+// Connect establishes a connection to the Trino coordinator
+func (t *TrinoIntrospector) Connect() error {
+	if err := requireDriver(trinoDriver, "trino"); err != nil {
+		return err
+	}
+
+	db, err := sql.Open(trinoDriver, trinoDSN(t.cfg, t.catalog, t.currentSchema))
+	if err != nil {
+		return fmt.Errorf("failed to open Trino connection: %w", err)
+	}
+
+	ctx, cancel := t.queryContext()
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return t.wrapQueryError(ctx, err, "failed to ping Trino", "connection to Trino")
+	}
+
+	t.db = db
+	return nil
+}
+
+// trinoDSN builds the trino-go-client DSN. Trino only accepts passwords over
+// TLS, so https is used when a password is set.
+func trinoDSN(cfg *config.DBConfig, catalog, schema string) string {
+	u := url.URL{
+		Scheme: "http",
+		User:   url.User(cfg.User),
+		Host:   fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+	}
+	if cfg.Password != "" {
+		u.Scheme = "https"
+		u.User = url.UserPassword(cfg.User, cfg.Password)
+	}
+	query := url.Values{}
+	query.Set("catalog", catalog)
+	query.Set("schema", schema)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// Dialect returns the SQL dialect of the schema
+func (t *TrinoIntrospector) Dialect() string {
+	return "trino"
+}
+
+// GetCatalogs returns the catalogs configured on the Trino cluster
+func (t *TrinoIntrospector) GetCatalogs() ([]string, error) {
+	return t.queryNames("SHOW CATALOGS", "catalog list query")
+}
+
+// GetSchemas returns the schemas of the current catalog
+func (t *TrinoIntrospector) GetSchemas() ([]string, error) {
+	query := fmt.Sprintf(`
+		SELECT schema_name
+		FROM %s.information_schema.schemata
+		WHERE schema_name <> 'information_schema'
+		ORDER BY schema_name
+	`, quoteTrinoIdent(t.catalog))
+	return t.queryNames(query, "schema list query")
+}
+
+// GetTables returns a list of table names in the current schema
+func (t *TrinoIntrospector) GetTables() ([]string, error) {
+	query := fmt.Sprintf(`
+		SELECT table_name
+		FROM %s.information_schema.tables
+		WHERE table_schema = ? AND table_type = 'BASE TABLE'
+		ORDER BY table_name
+	`, quoteTrinoIdent(t.catalog))
+	return t.queryNames(query, "table list query", t.currentSchema)
+}
+
+// queryNames runs a query returning a single column of names
+func (t *TrinoIntrospector) queryNames(query, what string, args ...any) ([]string, error) {
+	ctx, cancel := t.queryContext()
+	defer cancel()
+
+	rows, err := t.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, t.wrapQueryError(ctx, err, "failed to run "+what, what)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan name: %w", err)
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, t.wrapQueryError(ctx, err, "failed to read "+what, what)
+	}
+
+	return names, nil
+}
+
+// GetColumns returns column metadata for a specific table
+func (t *TrinoIntrospector) GetColumns(tableName string) ([]ColumnMetadata, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			column_name,
+			data_type,
+			is_nullable,
+			comment
+		FROM %s.information_schema.columns
+		WHERE table_schema = ? AND table_name = ?
+		ORDER BY ordinal_position
+	`, quoteTrinoIdent(t.catalog))
+
+	ctx, cancel := t.queryContext()
+	defer cancel()
+
+	rows, err := t.db.QueryContext(ctx, query, t.currentSchema, tableName)
+	if err != nil {
+		return nil, t.wrapQueryError(ctx, err, "failed to query columns", fmt.Sprintf("table %s metadata query", tableName))
+	}
+	defer rows.Close()
+
+	var columns []ColumnMetadata
+	for rows.Next() {
+		var (
+			columnName string
+			dataType   string
+			isNullable string
+			comment    sql.NullString
+		)
+		if err := rows.Scan(&columnName, &dataType, &isNullable, &comment); err != nil {
+			return nil, fmt.Errorf("failed to scan column: %w", err)
+		}
+
+		col := ColumnMetadata{
+			Name:            columnName,
+			DataType:        baseTypeName(dataType),
+			RawType:         dataType,
+			IsNullable:      isNullable == "YES",
+			Comment:         comment.String,
+			OrdinalPosition: len(columns) + 1,
+		}
+		columns = append(columns, col)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, t.wrapQueryError(ctx, err, "failed to read columns", fmt.Sprintf("table %s metadata query", tableName))
+	}
+
+	return columns, nil
+}
+
+// GetTableMetadata returns full metadata for a specific table. Trino has no
+// keys, so the metadata never contains primary or foreign keys.
+func (t *TrinoIntrospector) GetTableMetadata(tableName string) (*TableMetadata, error) {
+	columns, err := t.GetColumns(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	var tableComment sql.NullString
+	query := `
+		SELECT comment
+		FROM system.metadata.table_comments
+		WHERE catalog_name = ? AND schema_name = ? AND table_name = ?
+	`
+	ctx, cancel := t.queryContext()
+	defer cancel()
+
+	err = t.db.QueryRowContext(ctx, query, t.catalog, t.currentSchema, tableName).Scan(&tableComment)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, t.wrapQueryError(ctx, err, "failed to get table comment", fmt.Sprintf("table %s comment query", tableName))
+	}
+
+	return &TableMetadata{
+		Schema:  t.currentSchema,
+		Name:    tableName,
+		Columns: columns,
+		Comment: tableComment.String,
+	}, nil
+}
+
+// quoteTrinoIdent quotes a catalog or schema name for use in a query
+func quoteTrinoIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,41 @@
+// Package fixtures provides the reference schemas used by the golden-file
+// tests. The same schemas back `godb-orm fixtures`, so custom templates and
+// overrides can be tried out without a database.
+package fixtures
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+//go:embed mysql.sql postgres.sql
+var files embed.FS
+
+// Dialects lists the dialects with a fixture schema
+var Dialects = []string{"mysql", "postgres"}
+
+// DDL returns the fixture schema for a dialect as CREATE TABLE statements
+func DDL(dialect string) (string, error) {
+	data, err := files.ReadFile(dialect + ".sql")
+	if err != nil {
+		return "", fmt.Errorf("no fixture schema for dialect %q", dialect)
+	}
+	return string(data), nil
+}
+
+// Introspector returns a connected introspector over the fixture schema
+// for a dialect
+func Introspector(dialect string) (*database.DDLIntrospector, error) {
+	ddl, err := DDL(dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	introspector := database.NewDDLIntrospectorFromSource(ddl, dialect)
+	if err := introspector.Connect(); err != nil {
+		return nil, fmt.Errorf("failed to load %s fixture: %w", dialect, err)
+	}
+	return introspector, nil
+}
@@ -0,0 +1,107 @@
+package fixtures
+
+import (
+	"bytes"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+	"github.com/rowjak/godb-orm/internal/generator"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestGolden(t *testing.T) {
+	for _, dialect := range Dialects {
+		t.Run(dialect, func(t *testing.T) {
+			introspector, err := Introspector(dialect)
+			if err != nil {
+				t.Fatal(err)
+			}
+			compareGolden(t, introspector, filepath.Join("testdata", dialect))
+		})
+	}
+}
+
+// compareGolden generates every table of a schema and compares the output
+// directory with the golden directory, or rewrites it with -update
+func compareGolden(t *testing.T, introspector database.DBIntrospector, goldenDir string) {
+	t.Helper()
+
+	outputDir := t.TempDir()
+	gen := generator.NewGeneratorWithConfig(introspector, generator.GeneratorConfig{PackageName: "models"})
+	if _, err := gen.GenerateAll(outputDir); err != nil {
+		t.Fatalf("GenerateAll() error = %v", err)
+	}
+	got := readTree(t, outputDir)
+
+	if *update {
+		if err := os.RemoveAll(goldenDir); err != nil {
+			t.Fatal(err)
+		}
+		for name, content := range got {
+			path := filepath.Join(goldenDir, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, content, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return
+	}
+
+	want := readTree(t, goldenDir)
+	for _, name := range sortedKeys(want) {
+		content, ok := got[name]
+		if !ok {
+			t.Errorf("%s: not generated", name)
+			continue
+		}
+		if !bytes.Equal(content, want[name]) {
+			t.Errorf("%s differs from the golden file (run go test ./internal/fixtures -update to accept):\n%s", name, content)
+		}
+	}
+	for _, name := range sortedKeys(got) {
+		if _, ok := want[name]; !ok {
+			t.Errorf("%s: generated but has no golden file", name)
+		}
+	}
+}
+
+// readTree reads every file below dir, keyed by slash-separated relative path
+func readTree(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", dir, err)
+	}
+	return files
+}
+
+// sortedKeys returns the keys of a file map in sorted order
+func sortedKeys(files map[string][]byte) []string {
+	keys := make([]string, 0, len(files))
+	for key := range files {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
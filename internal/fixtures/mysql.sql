@@ -0,0 +1,45 @@
+-- godb-orm fixture schema (MySQL 8)
+DROP TABLE IF EXISTS `order_items`;
+DROP TABLE IF EXISTS `orders`;
+DROP TABLE IF EXISTS `users`;
+
+CREATE TABLE `users` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `email` varchar(255) NOT NULL COMMENT 'Login, unique',
+  `name` varchar(100) DEFAULT NULL,
+  `status` enum('active','banned') NOT NULL,
+  `is_admin` tinyint(1) NOT NULL,
+  `balance` decimal(10,2) NOT NULL,
+  `settings` json DEFAULT NULL,
+  `avatar` blob,
+  `last_login_ip` varchar(45) DEFAULT NULL,
+  `birth_date` date DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `users_email` (`email`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='Registered users';
+
+CREATE TABLE `orders` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `total` decimal(12,2) NOT NULL,
+  `quantity` smallint NOT NULL,
+  `weight` double DEFAULT NULL,
+  `notes` text,
+  `placed_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `orders_user` (`user_id`),
+  CONSTRAINT `orders_user` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE `order_items` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `order_id` int NOT NULL,
+  `sku` char(12) NOT NULL,
+  `price` decimal(10,2) NOT NULL,
+  `discount` float DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `order_items_order` (`order_id`),
+  CONSTRAINT `order_items_order` FOREIGN KEY (`order_id`) REFERENCES `orders` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
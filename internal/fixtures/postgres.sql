@@ -0,0 +1,42 @@
+-- godb-orm fixture schema (PostgreSQL)
+SET search_path = public;
+
+DROP TABLE IF EXISTS public.order_items;
+DROP TABLE IF EXISTS public.orders;
+DROP TABLE IF EXISTS public.users;
+
+CREATE TABLE public.users (
+    id bigserial PRIMARY KEY,
+    email character varying(255) NOT NULL UNIQUE,
+    name character varying(100),
+    is_admin boolean NOT NULL,
+    balance numeric(10,2) NOT NULL,
+    tags text[],
+    settings jsonb,
+    avatar bytea,
+    external_id uuid,
+    birth_date date,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp without time zone
+);
+
+COMMENT ON TABLE public.users IS 'Registered users';
+COMMENT ON COLUMN public.users.email IS 'Login, unique';
+
+CREATE TABLE public.orders (
+    id serial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES public.users(id) ON DELETE CASCADE,
+    total numeric(12,2) NOT NULL,
+    quantity smallint NOT NULL,
+    weight double precision,
+    notes text,
+    placed_at timestamp with time zone
+);
+
+CREATE TABLE public.order_items (
+    id serial PRIMARY KEY,
+    order_id integer NOT NULL REFERENCES public.orders(id),
+    sku character(12) NOT NULL,
+    price numeric(10,2) NOT NULL,
+    discount real
+);
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// User represents the users table
+//
+// Registered users
+type User struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement;column:id;type:bigint unsigned" json:"id"`
+	// Login, unique
+	Email       string         `gorm:"column:email;type:varchar(255);not null" json:"email"`
+	Name        string         `gorm:"column:name;type:varchar(100)" json:"name"`
+	Status      string         `gorm:"column:status;type:enum('active','banned');not null" json:"status"` // enum('active','banned')
+	IsAdmin     bool           `gorm:"column:is_admin;type:tinyint(1);not null" json:"is_admin"`
+	Balance     float64        `gorm:"column:balance;type:decimal(10,2);not null" json:"balance"`
+	Settings    datatypes.JSON `gorm:"column:settings;type:json" json:"settings"`
+	Avatar      []byte         `gorm:"column:avatar;type:blob" json:"avatar"`
+	LastLoginIP string         `gorm:"column:last_login_ip;type:varchar(45)" json:"last_login_ip"`
+	BirthDate   time.Time      `gorm:"column:birth_date;type:date" json:"birth_date"`
+	CreatedAt   time.Time      `gorm:"column:created_at;type:datetime;autoCreateTime;not null" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"column:updated_at;type:datetime;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM
+func (User) TableName() string {
+	return "users"
+}
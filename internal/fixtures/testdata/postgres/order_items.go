@@ -0,0 +1,15 @@
+package models
+
+// OrderItem represents the order_items table
+type OrderItem struct {
+	ID       int32   `gorm:"primaryKey;autoIncrement;column:id;type:integer" json:"id"`
+	OrderID  int32   `gorm:"column:order_id;type:integer;not null" json:"order_id"`
+	Sku      string  `gorm:"column:sku;type:varchar(12);not null" json:"sku"`
+	Price    float64 `gorm:"column:price;type:numeric(10,2);not null" json:"price"`
+	Discount float32 `gorm:"column:discount;type:real" json:"discount"`
+}
+
+// TableName returns the table name for GORM
+func (OrderItem) TableName() string {
+	return "order_items"
+}
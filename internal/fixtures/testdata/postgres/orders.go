@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// Order represents the orders table
+type Order struct {
+	ID       int32     `gorm:"primaryKey;autoIncrement;column:id;type:integer" json:"id"`
+	UserID   int64     `gorm:"column:user_id;type:bigint;not null" json:"user_id"`
+	Total    float64   `gorm:"column:total;type:numeric(12,2);not null" json:"total"`
+	Quantity int16     `gorm:"column:quantity;type:smallint;not null" json:"quantity"`
+	Weight   float64   `gorm:"column:weight;type:double precision" json:"weight"`
+	Notes    string    `gorm:"column:notes;type:text" json:"notes"`
+	PlacedAt time.Time `gorm:"column:placed_at;type:timestamptz" json:"placed_at"`
+}
+
+// TableName returns the table name for GORM
+func (Order) TableName() string {
+	return "orders"
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// User represents the users table
+//
+// Registered users
+type User struct {
+	ID int64 `gorm:"primaryKey;autoIncrement;column:id;type:bigint" json:"id"`
+	// Login, unique
+	Email      string         `gorm:"column:email;type:varchar(255);not null" json:"email"`
+	Name       string         `gorm:"column:name;type:varchar(100)" json:"name"`
+	IsAdmin    bool           `gorm:"column:is_admin;type:boolean;not null" json:"is_admin"`
+	Balance    float64        `gorm:"column:balance;type:numeric(10,2);not null" json:"balance"`
+	Tags       interface{}    `gorm:"column:tags;type:[]text" json:"tags"` // unknown type: []text
+	Settings   datatypes.JSON `gorm:"column:settings;type:jsonb" json:"settings"`
+	Avatar     []byte         `gorm:"column:avatar;type:bytea" json:"avatar"`
+	ExternalID uuid.UUID      `gorm:"column:external_id;type:uuid" json:"external_id"`
+	BirthDate  time.Time      `gorm:"column:birth_date;type:date" json:"birth_date"`
+	CreatedAt  time.Time      `gorm:"column:created_at;type:timestamptz;autoCreateTime;not null" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"column:updated_at;type:timestamp;autoUpdateTime" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM
+func (User) TableName() string {
+	return "users"
+}
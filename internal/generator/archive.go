@@ -0,0 +1,145 @@
+package generator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ArchiveFormat is the container format of an archive of generated models
+type ArchiveFormat string
+
+const (
+	// ArchiveZip writes a .zip archive
+	ArchiveZip ArchiveFormat = "zip"
+	// ArchiveTarGz writes a gzip-compressed tar archive (.tar.gz)
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+)
+
+// Extension returns the file name extension of the format, e.g. ".tar.gz"
+func (f ArchiveFormat) Extension() string {
+	return "." + string(f)
+}
+
+// ParseArchiveFormat parses an archive format name (zip, tar.gz or tgz).
+// An empty name selects zip.
+func ParseArchiveFormat(name string) (ArchiveFormat, error) {
+	switch name {
+	case "", "zip":
+		return ArchiveZip, nil
+	case "tar.gz", "tgz":
+		return ArchiveTarGz, nil
+	default:
+		return "", fmt.Errorf("unknown archive format %q (expected zip or tar.gz)", name)
+	}
+}
+
+// archiveWriter adds files to an archive
+type archiveWriter interface {
+	add(name string, content []byte) error
+	Close() error
+}
+
+type zipArchive struct {
+	zw *zip.Writer
+}
+
+func (z *zipArchive) add(name string, content []byte) error {
+	f, err := z.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(content)
+	return err
+}
+
+func (z *zipArchive) Close() error {
+	return z.zw.Close()
+}
+
+type tarGzArchive struct {
+	gw *gzip.Writer
+	tw *tar.Writer
+}
+
+func (t *tarGzArchive) add(name string, content []byte) error {
+	err := t.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = t.tw.Write(content)
+	return err
+}
+
+func (t *tarGzArchive) Close() error {
+	if err := t.tw.Close(); err != nil {
+		return err
+	}
+	return t.gw.Close()
+}
+
+// WriteArchive generates the model files (and the helper files they need)
+// for the given tables, or every table if none are given, and writes them
+// to w as an archive laid out like the output directory
+func (g *Generator) WriteArchive(w io.Writer, tables []string, format ArchiveFormat) error {
+	var archive archiveWriter
+	switch format {
+	case ArchiveZip:
+		archive = &zipArchive{zw: zip.NewWriter(w)}
+	case ArchiveTarGz:
+		gw := gzip.NewWriter(w)
+		archive = &tarGzArchive{gw: gw, tw: tar.NewWriter(gw)}
+	default:
+		return fmt.Errorf("unknown archive format %q (expected zip or tar.gz)", format)
+	}
+
+	dir, err := os.MkdirTemp("", "godb-orm-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if len(tables) == 0 {
+		if tables, err = g.introspector.GetTables(); err != nil {
+			return fmt.Errorf("failed to get tables: %w", err)
+		}
+	}
+	for _, table := range tables {
+		if _, err := g.GenerateToFile(table, dir); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", table, err)
+		}
+	}
+
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return archive.add(filepath.ToSlash(rel), content)
+	})
+	if err == nil {
+		err = archive.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteArchive(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{PackageName: "models"})
+	want, err := gen.Generate("users")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := gen.WriteArchive(&buf, nil, ArchiveZip); err != nil {
+		t.Fatalf("WriteArchive(zip) error = %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	if len(zr.File) != 1 || zr.File[0].Name != "users.go" {
+		t.Fatalf("zip files = %v; want [users.go]", zr.File)
+	}
+	f, err := zr.File[0].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := io.ReadAll(f)
+	f.Close()
+	if !bytes.Equal(got, want) {
+		t.Errorf("zip users.go differs from Generate():\n%s", got)
+	}
+
+	buf.Reset()
+	if err := gen.WriteArchive(&buf, []string{"users"}, ArchiveTarGz); err != nil {
+		t.Fatalf("WriteArchive(tar.gz) error = %v", err)
+	}
+	gr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	tr := tar.NewReader(gr)
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != "users.go" {
+		t.Fatalf("tar entry = %v, %v; want users.go", hdr, err)
+	}
+	if got, _ := io.ReadAll(tr); !bytes.Equal(got, want) {
+		t.Errorf("tar users.go differs from Generate():\n%s", got)
+	}
+
+	if err := gen.WriteArchive(io.Discard, nil, "rar"); err == nil || !strings.Contains(err.Error(), "unknown archive format") {
+		t.Errorf("WriteArchive(rar) error = %v; want unknown archive format", err)
+	}
+}
+
+func TestParseArchiveFormat(t *testing.T) {
+	for name, want := range map[string]ArchiveFormat{"": ArchiveZip, "zip": ArchiveZip, "tar.gz": ArchiveTarGz, "tgz": ArchiveTarGz} {
+		if got, err := ParseArchiveFormat(name); err != nil || got != want {
+			t.Errorf("ParseArchiveFormat(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseArchiveFormat("7z"); err == nil {
+		t.Error("ParseArchiveFormat(7z): expected error")
+	}
+}
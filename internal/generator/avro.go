@@ -0,0 +1,231 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// AvroFileExt is the file extension of exported Avro schemas
+const AvroFileExt = ".avsc"
+
+// avroRecord is an Avro record schema; field order matches the JSON output
+type avroRecord struct {
+	Type      string      `json:"type"`
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace,omitempty"`
+	Doc       string      `json:"doc,omitempty"`
+	Fields    []avroField `json:"fields"`
+}
+
+// avroField is a field of an Avro record
+type avroField struct {
+	Name    string          `json:"name"`
+	Type    interface{}     `json:"type"`
+	Doc     string          `json:"doc,omitempty"`
+	Default json.RawMessage `json:"default,omitempty"`
+}
+
+// avroLogical is a primitive Avro type annotated with a logical type
+type avroLogical struct {
+	Type        string `json:"type"`
+	LogicalType string `json:"logicalType"`
+	Precision   int    `json:"precision,omitempty"`
+	Scale       int    `json:"scale,omitempty"`
+}
+
+// avroEnum is an Avro enum schema
+type avroEnum struct {
+	Type    string   `json:"type"`
+	Name    string   `json:"name"`
+	Symbols []string `json:"symbols"`
+}
+
+// avroArray is an Avro array schema
+type avroArray struct {
+	Type  string      `json:"type"`
+	Items interface{} `json:"items"`
+}
+
+// avroNamePattern matches valid Avro names
+var avroNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// GenerateAvroSchema converts a table to an Avro record schema (.avsc) for
+// streaming its rows, e.g. CDC events into Kafka. Timestamps, decimals and
+// UUIDs use Avro logical types; nullable columns are unions with null.
+func (g *Generator) GenerateAvroSchema(tableName string) ([]byte, error) {
+	meta, err := g.introspector.GetTableMetadata(tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table metadata for %s: %w", tableName, err)
+	}
+
+	record := buildAvroRecord(meta, g.structName(tableName))
+	content, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Avro schema: %w", err)
+	}
+	return append(content, '\n'), nil
+}
+
+// GenerateAvroSchemasToFiles writes <table>.avsc for each of the given
+// tables to outputDir and returns their paths
+func (g *Generator) GenerateAvroSchemasToFiles(tableNames []string, outputDir string) ([]string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	var files []string
+	for _, tableName := range tableNames {
+		content, err := g.GenerateAvroSchema(tableName)
+		if err != nil {
+			return files, err
+		}
+		filePath := filepath.Join(outputDir, tableName+AvroFileExt)
+		if err := os.WriteFile(filePath, content, 0644); err != nil {
+			return files, fmt.Errorf("failed to write file: %w", err)
+		}
+		files = append(files, filePath)
+	}
+	return files, nil
+}
+
+// buildAvroRecord converts table metadata to an Avro record named name. The
+// table's schema becomes the record namespace.
+func buildAvroRecord(meta *database.TableMetadata, name string) avroRecord {
+	record := avroRecord{
+		Type:      "record",
+		Name:      avroName(name),
+		Namespace: avroNamespace(meta.Schema),
+		Doc:       meta.Comment,
+		Fields:    []avroField{},
+	}
+	for _, col := range meta.Columns {
+		field := avroField{
+			Name: avroName(col.Name),
+			Type: avroColumnType(col, record.Name),
+			Doc:  col.Comment,
+		}
+		if col.IsNullable {
+			field.Type = []interface{}{"null", field.Type}
+			field.Default = json.RawMessage("null")
+		}
+		record.Fields = append(record.Fields, field)
+	}
+	return record
+}
+
+// avroColumnType returns the Avro type of a column. Types without an Avro
+// counterpart (JSON, spatial, intervals) are strings.
+func avroColumnType(col database.ColumnMetadata, recordName string) interface{} {
+	dataType := strings.ToLower(col.DataType)
+	if strings.HasPrefix(dataType, "[]") {
+		element := col
+		element.DataType = dataType[2:]
+		element.RawType = strings.TrimPrefix(col.RawType, "[]")
+		return avroArray{Type: "array", Items: avroColumnType(element, recordName)}
+	}
+
+	switch dataType {
+	case "bool", "boolean":
+		return "boolean"
+	case "tinyint", "smallint", "mediumint", "smallserial", "year", "int2":
+		return "int"
+	case "int", "integer", "serial", "int4":
+		if col.IsUnsigned {
+			return "long"
+		}
+		return "int"
+	case "bigint", "bigserial", "int8":
+		if col.IsUnsigned {
+			return avroLogical{Type: "bytes", LogicalType: "decimal", Precision: 20}
+		}
+		return "long"
+	case "float", "real", "float4":
+		return "float"
+	case "double", "double precision", "money", "decfloat", "float8":
+		return "double"
+	case "decimal", "numeric":
+		if col.NumericPrecision == nil {
+			return "string" // Unbounded numerics have no Avro decimal precision
+		}
+		scale := 0
+		if col.NumericScale != nil {
+			scale = *col.NumericScale
+		}
+		return avroLogical{Type: "bytes", LogicalType: "decimal", Precision: *col.NumericPrecision, Scale: scale}
+	case "date":
+		return avroLogical{Type: "int", LogicalType: "date"}
+	case "time", "time without time zone", "time with time zone", "timetz":
+		return avroLogical{Type: "long", LogicalType: "time-micros"}
+	case "timestamp", "timestamptz", "timestamp with time zone", "timestamp without time zone", "datetime":
+		return avroLogical{Type: "long", LogicalType: "timestamp-micros"}
+	case "uuid", "uniqueidentifier":
+		return avroLogical{Type: "string", LogicalType: "uuid"}
+	case "binary", "varbinary", "blob", "tinyblob", "mediumblob", "longblob", "bytea", "bit varying", "varbit":
+		return "bytes"
+	case "enum":
+		if enum, ok := avroEnumType(col, recordName); ok {
+			return enum
+		}
+	}
+	return "string"
+}
+
+// avroEnumType returns an Avro enum for an enum column, or false if a value
+// is not a valid Avro symbol
+func avroEnumType(col database.ColumnMetadata, recordName string) (avroEnum, bool) {
+	if len(col.EnumValues) == 0 {
+		return avroEnum{}, false
+	}
+	for _, value := range col.EnumValues {
+		if !avroNamePattern.MatchString(value) {
+			return avroEnum{}, false
+		}
+	}
+	name := recordName + strcase.ToCamel(avroName(col.Name))
+	return avroEnum{Type: "enum", Name: name, Symbols: col.EnumValues}, true
+}
+
+// avroName makes a table or column name a valid Avro name by replacing
+// invalid characters with underscores
+func avroName(name string) string {
+	if avroNamePattern.MatchString(name) {
+		return name
+	}
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "_"
+	}
+	return b.String()
+}
+
+// avroNamespace makes a schema name a valid dotted Avro namespace
+func avroNamespace(schema string) string {
+	if schema == "" {
+		return ""
+	}
+	parts := strings.Split(schema, ".")
+	for i, part := range parts {
+		parts[i] = avroName(part)
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestGenerateAvroSchema(t *testing.T) {
+	precision, scale := 10, 2
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"order_items": {
+			Schema:  "shop",
+			Name:    "order_items",
+			Comment: "Line items",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "bigint", RawType: "bigint", IsPrimaryKey: true},
+				{Name: "qty", DataType: "int", RawType: "int unsigned", IsUnsigned: true},
+				{Name: "price", DataType: "decimal", RawType: "decimal(10,2)", NumericPrecision: &precision, NumericScale: &scale},
+				{Name: "ref", DataType: "uuid", RawType: "uuid", Comment: "External id"},
+				{Name: "status", DataType: "enum", RawType: "enum('open','shipped')", EnumValues: []string{"open", "shipped"}},
+				{Name: "shipped_at", DataType: "timestamp", RawType: "timestamp", IsNullable: true},
+				{Name: "tags", DataType: "[]text", RawType: "[]text", IsNullable: true},
+				{Name: "meta", DataType: "jsonb", RawType: "jsonb"},
+			},
+		},
+	}}
+
+	content, err := NewGenerator(fake).GenerateAvroSchema("order_items")
+	if err != nil {
+		t.Fatalf("GenerateAvroSchema() error = %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, content)
+	}
+	var want map[string]interface{}
+	if err := json.Unmarshal([]byte(`{
+		"type": "record",
+		"name": "OrderItem",
+		"namespace": "shop",
+		"doc": "Line items",
+		"fields": [
+			{"name": "id", "type": "long"},
+			{"name": "qty", "type": "long"},
+			{"name": "price", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}},
+			{"name": "ref", "type": {"type": "string", "logicalType": "uuid"}, "doc": "External id"},
+			{"name": "status", "type": {"type": "enum", "name": "OrderItemStatus", "symbols": ["open", "shipped"]}},
+			{"name": "shipped_at", "type": ["null", {"type": "long", "logicalType": "timestamp-micros"}], "default": null},
+			{"name": "tags", "type": ["null", {"type": "array", "items": "string"}], "default": null},
+			{"name": "meta", "type": "string"}
+		]
+	}`), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GenerateAvroSchema() =\n%s", content)
+	}
+}
+
+func TestAvroColumnType_Fallbacks(t *testing.T) {
+	tests := []struct {
+		col  database.ColumnMetadata
+		want interface{}
+	}{
+		{database.ColumnMetadata{DataType: "numeric", RawType: "numeric"}, "string"},
+		{database.ColumnMetadata{DataType: "enum", EnumValues: []string{"in progress"}}, "string"},
+		{database.ColumnMetadata{DataType: "bigint", IsUnsigned: true}, avroLogical{Type: "bytes", LogicalType: "decimal", Precision: 20}},
+		{database.ColumnMetadata{DataType: "date"}, avroLogical{Type: "int", LogicalType: "date"}},
+		{database.ColumnMetadata{DataType: "bytea"}, "bytes"},
+		{database.ColumnMetadata{DataType: "point"}, "string"},
+		{database.ColumnMetadata{DataType: "[]int4"}, avroArray{Type: "array", Items: "int"}},
+	}
+	for _, tt := range tests {
+		if got := avroColumnType(tt.col, "T"); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("avroColumnType(%s) = %v, want %v", tt.col.DataType, got, tt.want)
+		}
+	}
+}
+
+func TestAvroName(t *testing.T) {
+	tests := map[string]string{
+		"user_id":    "user_id",
+		"Order Date": "Order_Date",
+		"2fa":        "_2fa",
+		"ünit":       "_nit",
+		"":           "_",
+	}
+	for in, want := range tests {
+		if got := avroName(in); got != want {
+			t.Errorf("avroName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerateAvroSchemasToFiles(t *testing.T) {
+	dir := t.TempDir()
+	files, err := NewGenerator(newFakeUsers()).GenerateAvroSchemasToFiles([]string{"users"}, dir)
+	if err != nil {
+		t.Fatalf("GenerateAvroSchemasToFiles() error = %v", err)
+	}
+	want := filepath.Join(dir, "users.avsc")
+	if !reflect.DeepEqual(files, []string{want}) {
+		t.Fatalf("files = %v", files)
+	}
+	if _, err := os.Stat(want); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// BannerTemplates defines the "header" and "footer" partials used by
+// StructTemplate. Custom templates include them with {{template "header" .}}
+// and {{template "footer" .}}, or replace them with {{define "header"}}.
+const BannerTemplates = `{{define "header"}}{{if .Header}}{{.Header}}
+
+{{end}}{{end}}{{define "footer"}}{{if .Footer}}
+
+{{.Footer}}{{end}}{{end}}`
+
+// GoGenerateDirective returns the //go:generate line that regenerates a
+// table's model in place. The connection settings come from the config.
+func GoGenerateDirective(tableName string) string {
+	return fmt.Sprintf("//go:generate godb-orm --ci --table %s --out .", tableName)
+}
+
+// applyBanners renders the configured header and footer into the template data
+func (g *Generator) applyBanners(data *TemplateData) error {
+	header, err := renderBanner("header", g.header, data)
+	if err != nil {
+		return err
+	}
+	if g.goGenerate && data.TableName != "" {
+		if header != "" {
+			header += "\n"
+		}
+		header += GoGenerateDirective(data.TableName)
+	}
+	if g.buildTag != "" {
+		// The constraint must come first and be followed by a blank line
+		directive := "//go:build " + g.buildTag
+		if header != "" {
+			directive += "\n\n" + header
+		}
+		header = directive
+	}
+
+	footer, err := renderBanner("footer", g.footer, data)
+	if err != nil {
+		return err
+	}
+
+	data.Header = header
+	data.Footer = footer
+	return nil
+}
+
+// withBanners wraps a package-level generated file (not tied to a table)
+// in the configured header and footer
+func (g *Generator) withBanners(packageName string, src []byte) ([]byte, error) {
+	banners := &TemplateData{PackageName: packageName, naming: g.namingConv}
+	if err := g.applyBanners(banners); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if banners.Header != "" {
+		buf.WriteString(banners.Header + "\n\n")
+	}
+	buf.Write(src)
+	if banners.Footer != "" {
+		buf.WriteString("\n" + banners.Footer + "\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// renderBanner executes a header or footer template and turns it into comments
+func renderBanner(name, text string, data *TemplateData) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return "", nil
+	}
+
+	tmpl, err := template.New(name).Funcs(TemplateFuncs(data)).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute %s: %w", name, err)
+	}
+
+	return commentLines(strings.TrimSpace(buf.String())), nil
+}
+
+// commentLines prefixes every non-empty line that is not already a comment
+// (including //go:build and //go:generate directives) with "// "
+func commentLines(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t\r")
+		if line != "" && !strings.HasPrefix(line, "//") && !strings.HasPrefix(line, "/*") {
+			line = "// " + line
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate_HeaderFooter(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{
+		Header:     "Copyright 2026 Acme Corp.\nSPDX-License-Identifier: MIT\n\n//go:build !nomodels",
+		Footer:     "End of {{.StructName}}",
+		GoGenerate: true,
+	})
+
+	code, err := gen.GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+
+	wantPrefix := "// Copyright 2026 Acme Corp.\n// SPDX-License-Identifier: MIT\n\n//go:build !nomodels\n\n" +
+		"//go:generate godb-orm --ci --table users --out .\n\npackage models\n"
+	if !strings.HasPrefix(code, wantPrefix) {
+		t.Errorf("GenerateString() header mismatch:\n%s", code)
+	}
+	if !strings.HasSuffix(code, "}\n\n// End of User\n") {
+		t.Errorf("GenerateString() footer mismatch:\n%s", code)
+	}
+}
+
+func TestGenerate_HeaderPartialInCustomTemplate(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{Header: "Licensed under MIT"})
+	gen.templateFile = writeTemplate(t, `{{define "header"}}// Custom: {{.Header}}
+{{end}}{{template "header" .}}package {{.PackageName}}
+`)
+
+	code, err := gen.GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if !strings.HasPrefix(code, "// Custom: // Licensed under MIT\npackage models") {
+		t.Errorf("GenerateString() should use the redefined partial:\n%s", code)
+	}
+}
+
+func TestGenerateConstants_Header(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{Header: "Licensed under MIT", GoGenerate: true})
+
+	code, err := gen.GenerateConstants([]string{"users"})
+	if err != nil {
+		t.Fatalf("GenerateConstants() error = %v", err)
+	}
+	if !strings.HasPrefix(string(code), "// Licensed under MIT\n\n// Code generated by godb-orm") {
+		t.Errorf("GenerateConstants() header mismatch:\n%s", code)
+	}
+	if strings.Contains(string(code), "go:generate") {
+		t.Errorf("GenerateConstants() should not add a per-table go:generate line:\n%s", code)
+	}
+}
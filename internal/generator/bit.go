@@ -0,0 +1,96 @@
+package generator
+
+// BitMode controls how MySQL BIT(n) columns wider than one bit are represented in Go
+type BitMode string
+
+const (
+	// BitBytes maps BIT(n>1) to []byte holding the big-endian bits
+	BitBytes BitMode = "bytes"
+	// BitUint64 maps BIT(n>1) to the generated BitUint64 type
+	BitUint64 BitMode = "uint64"
+)
+
+// BitFileName is the file name for the generated BIT helper types
+const BitFileName = "bit_gen.go"
+
+// BitTemplate is the template for the BIT helper file. The MySQL driver
+// returns BIT values as big-endian bytes, which database/sql can't convert
+// to bool or uint64, so both types implement sql.Scanner.
+const BitTemplate = `// Code generated by godb-orm. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// BitBool is a BIT(1) flag
+type BitBool bool
+
+// Scan implements sql.Scanner
+func (b *BitBool) Scan(src interface{}) error {
+	var n BitUint64
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*b = n != 0
+	return nil
+}
+
+// Value implements driver.Valuer
+func (b BitBool) Value() (driver.Value, error) {
+	if b {
+		return int64(1), nil
+	}
+	return int64(0), nil
+}
+
+// BitUint64 is a BIT(n) value of up to 64 bits
+type BitUint64 uint64
+
+// Scan implements sql.Scanner
+func (n *BitUint64) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*n = 0
+	case []byte:
+		if len(v) > 8 {
+			return fmt.Errorf("cannot scan %d bytes into BitUint64", len(v))
+		}
+		var buf [8]byte
+		copy(buf[8-len(v):], v)
+		*n = BitUint64(binary.BigEndian.Uint64(buf[:]))
+	case int64:
+		*n = BitUint64(v)
+	default:
+		return fmt.Errorf("cannot scan %T into BitUint64", src)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer
+func (n BitUint64) Value() (driver.Value, error) {
+	if n <= math.MaxInt64 {
+		return int64(n), nil
+	}
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(n))
+	return buf[:], nil
+}
+`
+
+// SetBitMode sets how BIT columns are mapped (empty keeps the default).
+// BIT(1) always maps to BitBool. PostgreSQL returns bit strings as text,
+// so the generator only applies this for MySQL schemas.
+func (tm *TypeMapper) SetBitMode(mode BitMode) {
+	tm.typeMap["bit(1)"] = TypeMapping{GoType: "BitBool"}
+	switch mode {
+	case BitBytes:
+		tm.typeMap["bit"] = TypeMapping{GoType: "[]byte", IsSlice: true}
+	case BitUint64:
+		tm.typeMap["bit"] = TypeMapping{GoType: "BitUint64"}
+	}
+}
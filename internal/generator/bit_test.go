@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func newFakeFlags() *fakeIntrospector {
+	return &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"flags": {
+			Name: "flags",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+				{Name: "active", DataType: "bit", RawType: "bit(1)"},
+				{Name: "mask", DataType: "bit", RawType: "bit(16)"},
+			},
+		},
+	}}
+}
+
+func TestGenerate_Bit(t *testing.T) {
+	tests := []struct {
+		name         string
+		introspector database.DBIntrospector
+		mode         BitMode
+		wantActive   string
+		wantMask     string
+		wantHelper   bool
+	}{
+		{"mysql default", fakeMySQL{newFakeFlags()}, "", "BitBool", "[]byte", true},
+		{"mysql uint64", fakeMySQL{newFakeFlags()}, BitUint64, "BitBool", "BitUint64", true},
+		{"postgres", newFakeFlags(), BitUint64, "[]byte", "[]byte", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			gen := NewGeneratorWithConfig(tt.introspector, GeneratorConfig{Bit: tt.mode})
+
+			filePath, err := gen.GenerateToFile("flags", outputDir)
+			if err != nil {
+				t.Fatalf("GenerateToFile() error = %v", err)
+			}
+			code, _ := os.ReadFile(filePath)
+			for _, want := range []string{"Active " + tt.wantActive, "Mask   " + tt.wantMask} {
+				if !strings.Contains(string(code), want) {
+					t.Errorf("generated code missing %q:\n%s", want, code)
+				}
+			}
+
+			_, err = os.Stat(filepath.Join(outputDir, BitFileName))
+			if got := err == nil; got != tt.wantHelper {
+				t.Errorf("helper written = %v, want %v", got, tt.wantHelper)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// CacheFileName is the name of the cache file stored in the output directory
+const CacheFileName = ".godb-orm.cache"
+
+// cacheVersion is bumped whenever generated output changes for the same schema,
+// invalidating all existing cache entries
+const cacheVersion = 1
+
+// Cache stores per-table metadata hashes so unchanged tables can be skipped
+type Cache struct {
+	path    string
+	Version int               `json:"version"`
+	Tables  map[string]string `json:"tables"`
+}
+
+// LoadCache reads the cache file from outputDir. A missing, unreadable or
+// outdated cache yields an empty cache rather than an error.
+func LoadCache(outputDir string) (*Cache, error) {
+	cache := &Cache{
+		path:    filepath.Join(outputDir, CacheFileName),
+		Version: cacheVersion,
+		Tables:  make(map[string]string),
+	}
+
+	data, err := os.ReadFile(cache.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	var stored Cache
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Version != cacheVersion || stored.Tables == nil {
+		return cache, nil
+	}
+	cache.Tables = stored.Tables
+	return cache, nil
+}
+
+// Matches reports whether the cached hash for a table equals hash
+func (c *Cache) Matches(tableName, hash string) bool {
+	if c == nil {
+		return false
+	}
+	cached, ok := c.Tables[tableName]
+	return ok && cached == hash
+}
+
+// Set records the hash for a table
+func (c *Cache) Set(tableName, hash string) {
+	if c != nil {
+		c.Tables[tableName] = hash
+	}
+}
+
+// Save writes the cache file to the output directory
+func (c *Cache) Save() error {
+	if c == nil {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	return nil
+}
+
+// metadataHash fingerprints table metadata together with the generator
+// settings that influence the output
+func (g *Generator) metadataHash(meta *database.TableMetadata) (string, error) {
+	tmpl, err := g.structTemplate()
+	if err != nil {
+		return "", err
+	}
+
+	payload := struct {
+		Meta         *database.TableMetadata
+		PackageName  string
+		NullStrategy NullStrategy
+		TagStyle     TagStyle
+		ScanHelpers  bool
+		QueryBuilder QueryBuilder
+		Relations    RelationMode
+		Infer        bool
+		Rules        map[string]config.RelationRule
+		Overrides    map[string]config.TableOverride
+		Template     string
+		Header       string
+		Footer       string
+		GoGenerate   bool
+		TypeRules    []config.TypeRule
+		Hstore       HstoreMode
+		Vector       VectorMode
+		Spatial      SpatialMode
+		Bit          BitMode
+		DateTime     DateTimeMode
+		AutoCreate   []string
+		AutoUpdate   []string
+		TablePrefix  string
+		Acronyms     map[string]string
+		Subpackages  []config.SubpackageRule
+		FieldOrder   FieldOrder
+		MaxLineWidth int
+		FilePattern  string
+		BuildTag     string
+		Hooks        bool
+		Scopes       bool
+		TenantColumn string
+		WithTx       bool
+		Factories    bool
+		Private      bool
+		Filters      bool
+		Pagination   bool
+		Swagger      bool
+		Style        Style
+		Repositories bool
+		MockTool     MockTool
+		NoPrimaryKey NoPrimaryKeyMode
+		GormOptions  map[string]bool
+		ExtraTags    []string
+		Sensitive    []string
+		WriteOnly    bool
+	}{
+		Meta:         meta,
+		PackageName:  g.packageName,
+		NullStrategy: g.typeMapper.nullStrategy,
+		TagStyle:     g.tagBuilder.tagStyle,
+		ScanHelpers:  g.scanHelpers,
+		QueryBuilder: g.queryBuilder,
+		Relations:    g.relationMode,
+		Infer:        g.inferRelations,
+		Rules:        g.relationRules,
+		Overrides:    g.overrides,
+		Template:     tmpl,
+		Header:       g.header,
+		Footer:       g.footer,
+		GoGenerate:   g.goGenerate,
+		TypeRules:    g.typeRules,
+		Hstore:       g.hstoreMode,
+		Vector:       g.vectorMode,
+		Spatial:      g.spatialMode,
+		Bit:          g.bitMode,
+		DateTime:     g.dateTimeMode,
+		AutoCreate:   g.tagBuilder.autoCreateTime,
+		AutoUpdate:   g.tagBuilder.autoUpdateTime,
+		TablePrefix:  g.tablePrefix,
+		Acronyms:     g.namingConv.acronyms,
+		Subpackages:  g.subpackages,
+		FieldOrder:   g.fieldOrder,
+		MaxLineWidth: g.maxLineWidth,
+		FilePattern:  g.filePatternSrc,
+		BuildTag:     g.buildTag,
+		Hooks:        g.hooks,
+		Scopes:       g.scopes,
+		TenantColumn: g.tenantCol,
+		WithTx:       g.withTx,
+		Factories:    g.factories,
+		Private:      g.privateFields,
+		Filters:      g.filters,
+		Pagination:   g.pagination,
+		Swagger:      g.swagger,
+		Style:        g.style,
+		Repositories: g.repositories,
+		MockTool:     g.mockTool,
+		NoPrimaryKey: g.noPrimaryKey,
+		GormOptions:  g.tagBuilder.gormOptions,
+		ExtraTags:    g.tagBuilder.extraTagSpecs(),
+		Sensitive:    g.sensitive,
+		WriteOnly:    g.writeOnly,
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash metadata: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
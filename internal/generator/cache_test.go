@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// fakeIntrospector serves table metadata from memory
+type fakeIntrospector struct {
+	tables map[string]*database.TableMetadata
+	calls  atomic.Int32
+}
+
+func (f *fakeIntrospector) Connect() error { return nil }
+func (f *fakeIntrospector) Close() error   { return nil }
+
+func (f *fakeIntrospector) GetTables() ([]string, error) {
+	var names []string
+	for name := range f.tables {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (f *fakeIntrospector) GetColumns(tableName string) ([]database.ColumnMetadata, error) {
+	meta, err := f.GetTableMetadata(tableName)
+	if err != nil {
+		return nil, err
+	}
+	return meta.Columns, nil
+}
+
+func (f *fakeIntrospector) GetTableMetadata(tableName string) (*database.TableMetadata, error) {
+	f.calls.Add(1)
+	meta, ok := f.tables[tableName]
+	if !ok {
+		return nil, fmt.Errorf("table %s not found", tableName)
+	}
+	return meta, nil
+}
+
+func newFakeUsers() *fakeIntrospector {
+	return &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"users": {
+			Name: "users",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+				{Name: "email", DataType: "varchar", RawType: "varchar(255)"},
+			},
+		},
+	}}
+}
+
+func TestGenerateToFileIncremental(t *testing.T) {
+	outputDir := t.TempDir()
+	fake := newFakeUsers()
+	gen := NewGenerator(fake)
+
+	cache, err := LoadCache(outputDir)
+	if err != nil {
+		t.Fatalf("LoadCache() error = %v", err)
+	}
+
+	// First run writes the file
+	filePath, written, err := gen.GenerateToFileIncremental("users", outputDir, cache)
+	if err != nil || !written {
+		t.Fatalf("first run: written = %v, err = %v; want written", written, err)
+	}
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Second run with a reloaded cache is skipped
+	cache, _ = LoadCache(outputDir)
+	if _, written, _ := gen.GenerateToFileIncremental("users", outputDir, cache); written {
+		t.Error("second run: unchanged table should be skipped")
+	}
+
+	// Schema change invalidates the entry
+	fake.tables["users"].Columns = append(fake.tables["users"].Columns,
+		database.ColumnMetadata{Name: "name", DataType: "varchar", RawType: "varchar(100)"})
+	if _, written, _ := gen.GenerateToFileIncremental("users", outputDir, cache); !written {
+		t.Error("schema change: table should be regenerated")
+	}
+
+	// Deleted file is regenerated even if the hash matches
+	os.Remove(filePath)
+	if _, written, _ := gen.GenerateToFileIncremental("users", outputDir, cache); !written {
+		t.Error("missing file: table should be regenerated")
+	}
+
+	// A nil cache always regenerates
+	if _, written, _ := gen.GenerateToFileIncremental("users", outputDir, nil); !written {
+		t.Error("nil cache: table should be regenerated")
+	}
+
+	if _, err := os.Stat(filepath.Join(outputDir, CacheFileName)); err != nil {
+		t.Errorf("cache file not written: %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrConflict is wrapped by the *ConflictError CheckConflicts returns
+var ErrConflict = errors.New("generated code conflicts with existing declarations")
+
+// Conflict is a declaration of a generated file that its package already
+// has, which would not compile
+type Conflict struct {
+	Name     string // Declared name, e.g. User or User.TableName for a method
+	File     string // Generated file declaring it
+	Existing string // Where it is already declared, as file:line
+}
+
+// ConflictError lists the conflicts found by CheckConflicts
+type ConflictError struct {
+	Conflicts []Conflict
+}
+
+func (e *ConflictError) Error() string {
+	var b strings.Builder
+	b.WriteString(ErrConflict.Error())
+	b.WriteString(":")
+	for _, c := range e.Conflicts {
+		kind := ""
+		if strings.Contains(c.Name, ".") {
+			kind = "method "
+		}
+		fmt.Fprintf(&b, "\n  %s: %s%s is already declared in %s", c.File, kind, c.Name, c.Existing)
+	}
+	b.WriteString("\nrename the hand-written declaration, or the generated one with a struct_name override")
+	return b.String()
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrConflict
+}
+
+// declaration is a top-level name declared by a Go file
+type declaration struct {
+	name string
+	pos  string // file:line
+}
+
+// declCache remembers the declarations of the files in output directories,
+// so checking the tables of a run one by one doesn't re-parse every file
+type declCache struct {
+	mu    sync.Mutex
+	files map[string]cachedDecls
+}
+
+type cachedDecls struct {
+	modTime time.Time
+	size    int64
+	pkg     string
+	decls   []declaration
+}
+
+// CheckConflicts reports the top-level declarations of generated Go files
+// that other files of the same package in their directory already declare
+// (e.g. a hand-written User struct or NewUser function), or that two of the
+// generated files both declare. Files being replaced don't count.
+func (g *Generator) CheckConflicts(files []OutputFile) error {
+	replaced := make(map[string]bool, len(files))
+	for _, file := range files {
+		replaced[filepath.Clean(file.Path)] = true
+	}
+
+	var conflicts []Conflict
+	declared := make(map[string]map[string]string) // dir+package -> name -> file:line
+	checkedDirs := make(map[string]bool)
+	for _, file := range files {
+		if filepath.Ext(file.Path) != ".go" {
+			continue
+		}
+		pkg, decls, err := parseDecls(file.Path, file.Content)
+		if err != nil {
+			// Unparseable code is reported when it is formatted
+			continue
+		}
+
+		dir := filepath.Dir(filepath.Clean(file.Path))
+		key := dir + "\x00" + pkg
+		if !checkedDirs[key] {
+			checkedDirs[key] = true
+			declared[key] = g.decls.dir(dir, pkg, replaced)
+		}
+		for _, d := range decls {
+			if existing, ok := declared[key][d.name]; ok {
+				conflicts = append(conflicts, Conflict{Name: d.name, File: file.Path, Existing: existing})
+				continue
+			}
+			declared[key][d.name] = d.pos
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return &ConflictError{Conflicts: conflicts}
+	}
+	return nil
+}
+
+// dir returns the declarations of the package pkg in dir, by name, leaving
+// out the files in skip
+func (c *declCache) dir(dir, pkg string, skip map[string]bool) map[string]string {
+	declared := make(map[string]string)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return declared
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if entry.IsDir() || filepath.Ext(path) != ".go" || skip[path] {
+			continue
+		}
+		filePkg, decls := c.file(path)
+		if filePkg != pkg {
+			continue
+		}
+		for _, d := range decls {
+			if _, ok := declared[d.name]; !ok {
+				declared[d.name] = d.pos
+			}
+		}
+	}
+	return declared
+}
+
+// file returns the package name and declarations of a Go file, parsing it
+// only if it changed since it was last parsed
+func (c *declCache) file(path string) (string, []declaration) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.files[path]; ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.pkg, cached.decls
+	}
+
+	pkg, decls, err := parseDecls(path, nil)
+	if err != nil {
+		return "", nil
+	}
+	if c.files == nil {
+		c.files = make(map[string]cachedDecls)
+	}
+	c.files[path] = cachedDecls{modTime: info.ModTime(), size: info.Size(), pkg: pkg, decls: decls}
+	return pkg, decls
+}
+
+// parseDecls parses the package name and top-level declarations of a Go
+// file; src nil reads the file. Methods are named Type.Method.
+func parseDecls(path string, src []byte) (string, []declaration, error) {
+	fset := token.NewFileSet()
+	var source any
+	if src != nil {
+		source = src
+	}
+	f, err := parser.ParseFile(fset, path, source, parser.SkipObjectResolution)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var decls []declaration
+	add := func(name string, pos token.Pos) {
+		if name == "_" || name == "init" {
+			return
+		}
+		p := fset.Position(pos)
+		decls = append(decls, declaration{name: name, pos: fmt.Sprintf("%s:%d", path, p.Line)})
+	}
+	for _, decl := range f.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				add(receiverType(d.Recv.List[0].Type)+"."+d.Name.Name, d.Pos())
+				continue
+			}
+			add(d.Name.Name, d.Pos())
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					add(s.Name.Name, s.Pos())
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						add(name.Name, name.Pos())
+					}
+				}
+			}
+		}
+	}
+	return f.Name.Name, decls, nil
+}
+
+// receiverType returns the name of a method's receiver type, without
+// pointer and type parameters
+func receiverType(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverType(t.X)
+	case *ast.IndexExpr:
+		return receiverType(t.X)
+	case *ast.IndexListExpr:
+		return receiverType(t.X)
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
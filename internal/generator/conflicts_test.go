@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckConflicts(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, src string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("account.go", "package models\n\ntype Account struct{}\n\nfunc NewUser() *Account { return nil }\n\nfunc (*Account) TableName() string { return \"accounts\" }\n")
+	write("user_ext.go", "package models\n\nfunc (User) TableName() string { return \"people\" }\n")
+	write("other.go", "package other\n\ntype Order struct{}\n")
+	// Replaced by the run, so its declarations don't count
+	write("user.go", "package models\n\ntype User struct{}\n")
+
+	g := NewGenerator(nil)
+	files := []OutputFile{
+		{Path: filepath.Join(dir, "user.go"), Content: []byte("package models\n\ntype User struct{}\n\nfunc (User) TableName() string { return \"users\" }\n\nfunc NewUser() *User { return &User{} }\n")},
+		{Path: filepath.Join(dir, "order.go"), Content: []byte("package models\n\ntype Order struct{}\n")},
+		{Path: filepath.Join(dir, "orders.go"), Content: []byte("package models\n\ntype Order struct{}\n")},
+	}
+
+	err := g.CheckConflicts(files)
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("CheckConflicts() error = %v; want ErrConflict", err)
+	}
+	var conflictErr *ConflictError
+	errors.As(err, &conflictErr)
+
+	var got []string
+	for _, c := range conflictErr.Conflicts {
+		got = append(got, filepath.Base(c.File)+" "+c.Name+" "+filepath.Base(c.Existing))
+	}
+	want := []string{
+		"user.go User.TableName user_ext.go:3",
+		"user.go NewUser account.go:5",
+		"orders.go Order order.go:3",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("conflicts =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if !strings.Contains(err.Error(), "method User.TableName is already declared in") {
+		t.Errorf("error = %q; want the conflicting method named", err)
+	}
+
+	// Once the hand-written declarations are gone, the run is fine
+	os.Remove(filepath.Join(dir, "user_ext.go"))
+	os.Remove(filepath.Join(dir, "account.go"))
+	if err := g.CheckConflicts(files[:2]); err != nil {
+		t.Errorf("CheckConflicts() error = %v; want none", err)
+	}
+}
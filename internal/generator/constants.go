@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+// GenerateConstants generates a file with typed constants for table and column
+// names (e.g., TableUsers = "users", UserColumns.Email = "email") so query
+// code can avoid magic strings and picks up renames on regeneration
+func (g *Generator) GenerateConstants(tableNames []string) ([]byte, error) {
+	data := &ConstantsTemplateData{PackageName: g.packageName}
+
+	for _, tableName := range tableNames {
+		columns, err := g.introspector.GetColumns(tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get columns for %s: %w", tableName, err)
+		}
+
+		table := ConstantsTable{
+			TableName:  tableName,
+			ConstName:  g.tableConst(tableName),
+			StructName: g.structName(tableName),
+		}
+		names := g.fieldNames(columns)
+		for i, col := range columns {
+			table.Columns = append(table.Columns, ConstantsColumn{
+				FieldName:  names[i].name,
+				ColumnName: col.Name,
+			})
+		}
+		data.Tables = append(data.Tables, table)
+	}
+
+	tmpl, err := template.New("constants").Parse(ConstantsTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	src, err := g.withBanners(g.packageName, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	formatted, err := FormatSource(ConstantsFileName, src)
+	if err != nil {
+		return src, err
+	}
+	return formatted, nil
+}
+
+// GenerateConstantsToFile writes the constants file for the given tables to outputDir
+func (g *Generator) GenerateConstantsToFile(tableNames []string, outputDir string) (string, error) {
+	content, err := g.GenerateConstants(tableNames)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filePath := filepath.Join(outputDir, ConstantsFileName)
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return filePath, nil
+}
+
+// tableConst returns the name of the table name constant of a table
+func (g *Generator) tableConst(tableName string) string {
+	return "Table" + g.namingConv.HandleAcronyms(pascalIdentifier(g.baseName(tableName)))
+}
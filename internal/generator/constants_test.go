@@ -0,0 +1,29 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateConstants(t *testing.T) {
+	gen := NewGenerator(newFakeUsers())
+
+	code, err := gen.GenerateConstants([]string{"users"})
+	if err != nil {
+		t.Fatalf("GenerateConstants() error = %v", err)
+	}
+
+	expected := []string{
+		"// Code generated by godb-orm. DO NOT EDIT.",
+		"package models",
+		`TableUsers = "users"`,
+		"var UserColumns = struct {",
+		`ID:    "id",`,
+		`Email: "email",`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(string(code), want) {
+			t.Errorf("GenerateConstants() missing %q:\n%s", want, code)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// DebeziumFileExt is the file extension of exported Debezium schema descriptors
+const DebeziumFileExt = ".debezium.json"
+
+// connectSchema is a Kafka Connect schema in its JSON converter form, as
+// found in the "schema" part of Debezium change events
+type connectSchema struct {
+	Type       string            `json:"type"`
+	Optional   bool              `json:"optional"`
+	Name       string            `json:"name,omitempty"`
+	Version    int               `json:"version,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	Default    interface{}       `json:"default,omitempty"`
+	Items      *connectSchema    `json:"items,omitempty"`
+	Fields     []connectSchema   `json:"fields,omitempty"`
+	Field      string            `json:"field,omitempty"`
+}
+
+// debeziumDescriptor holds the key and value schemas of a table's CDC topic
+type debeziumDescriptor struct {
+	Topic string         `json:"topic"`
+	Key   *connectSchema `json:"key"`
+	Value connectSchema  `json:"value"`
+}
+
+// debeziumSourceFields are the source block fields Debezium connectors add
+// to every change event, by dialect
+var debeziumSourceFields = map[string][]connectSchema{
+	"mysql": {
+		{Type: "string", Field: "version"},
+		{Type: "string", Field: "connector"},
+		{Type: "string", Field: "name"},
+		{Type: "int64", Field: "ts_ms"},
+		{Type: "string", Optional: true, Name: "io.debezium.data.Enum", Version: 1, Parameters: map[string]string{"allowed": "true,last,false,incremental"}, Default: "false", Field: "snapshot"},
+		{Type: "string", Field: "db"},
+		{Type: "string", Optional: true, Field: "sequence"},
+		{Type: "string", Optional: true, Field: "table"},
+		{Type: "int64", Field: "server_id"},
+		{Type: "string", Optional: true, Field: "gtid"},
+		{Type: "string", Field: "file"},
+		{Type: "int64", Field: "pos"},
+		{Type: "int32", Field: "row"},
+		{Type: "int64", Optional: true, Field: "thread"},
+		{Type: "string", Optional: true, Field: "query"},
+	},
+	"postgres": {
+		{Type: "string", Field: "version"},
+		{Type: "string", Field: "connector"},
+		{Type: "string", Field: "name"},
+		{Type: "int64", Field: "ts_ms"},
+		{Type: "string", Optional: true, Name: "io.debezium.data.Enum", Version: 1, Parameters: map[string]string{"allowed": "true,last,false,incremental"}, Default: "false", Field: "snapshot"},
+		{Type: "string", Field: "db"},
+		{Type: "string", Optional: true, Field: "sequence"},
+		{Type: "string", Field: "schema"},
+		{Type: "string", Field: "table"},
+		{Type: "int64", Optional: true, Field: "txId"},
+		{Type: "int64", Optional: true, Field: "lsn"},
+		{Type: "int64", Optional: true, Field: "xmin"},
+	},
+}
+
+// debeziumConnectors are the connector names used in source schema names
+var debeziumConnectors = map[string]string{
+	"mysql":    "mysql",
+	"postgres": "postgresql",
+}
+
+// GenerateDebeziumSchema returns a descriptor with the key and value schemas
+// Debezium produces for a table's change events, so consumers of the CDC
+// topic can validate payloads against the introspected schema. topicPrefix
+// is the connector's topic.prefix; dialect is the database driver.
+func (g *Generator) GenerateDebeziumSchema(tableName, topicPrefix, dialect string) ([]byte, error) {
+	if dialect == "postgresql" {
+		dialect = "postgres"
+	}
+	if _, ok := debeziumConnectors[dialect]; !ok {
+		return nil, fmt.Errorf("debezium schemas are only supported for mysql and postgres, not %s", dialect)
+	}
+
+	meta, err := g.introspector.GetTableMetadata(tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table metadata for %s: %w", tableName, err)
+	}
+
+	content, err := json.MarshalIndent(buildDebeziumDescriptor(meta, topicPrefix, dialect), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode Debezium schema: %w", err)
+	}
+	return append(content, '\n'), nil
+}
+
+// GenerateDebeziumSchemasToFiles writes <table>.debezium.json for each of the
+// given tables to outputDir and returns their paths
+func (g *Generator) GenerateDebeziumSchemasToFiles(tableNames []string, outputDir, topicPrefix, dialect string) ([]string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	var files []string
+	for _, tableName := range tableNames {
+		content, err := g.GenerateDebeziumSchema(tableName, topicPrefix, dialect)
+		if err != nil {
+			return files, err
+		}
+		filePath := filepath.Join(outputDir, tableName+DebeziumFileExt)
+		if err := os.WriteFile(filePath, content, 0644); err != nil {
+			return files, fmt.Errorf("failed to write file: %w", err)
+		}
+		files = append(files, filePath)
+	}
+	return files, nil
+}
+
+// buildDebeziumDescriptor builds the key schema (nil without a primary key)
+// and the change event envelope schema of a table
+func buildDebeziumDescriptor(meta *database.TableMetadata, topicPrefix, dialect string) debeziumDescriptor {
+	topic := meta.Name
+	if meta.Schema != "" {
+		topic = meta.Schema + "." + topic
+	}
+	if topicPrefix != "" {
+		topic = topicPrefix + "." + topic
+	}
+
+	var keyFields, valueFields []connectSchema
+	for _, col := range meta.Columns {
+		field := debeziumColumnSchema(col, dialect)
+		field.Optional = col.IsNullable
+		field.Field = col.Name
+		valueFields = append(valueFields, field)
+		if col.IsPrimaryKey {
+			keyFields = append(keyFields, field)
+		}
+	}
+
+	descriptor := debeziumDescriptor{Topic: topic}
+	if len(keyFields) > 0 {
+		descriptor.Key = &connectSchema{Type: "struct", Fields: keyFields, Name: topic + ".Key"}
+	}
+
+	row := func(field string) connectSchema {
+		return connectSchema{Type: "struct", Fields: valueFields, Optional: true, Name: topic + ".Value", Field: field}
+	}
+	descriptor.Value = connectSchema{
+		Type: "struct",
+		Fields: []connectSchema{
+			row("before"),
+			row("after"),
+			{Type: "struct", Fields: debeziumSourceFields[dialect], Name: "io.debezium.connector." + debeziumConnectors[dialect] + ".Source", Field: "source"},
+			{Type: "string", Field: "op"},
+			{Type: "int64", Optional: true, Field: "ts_ms"},
+			{Type: "struct", Optional: true, Name: "event.block", Version: 1, Field: "transaction", Fields: []connectSchema{
+				{Type: "string", Field: "id"},
+				{Type: "int64", Field: "total_order"},
+				{Type: "int64", Field: "data_collection_order"},
+			}},
+		},
+		Name:    topic + ".Envelope",
+		Version: 1,
+	}
+	return descriptor
+}
+
+// debeziumColumnSchema returns the Kafka Connect schema Debezium uses for a
+// column with its default converters (precise decimals, adaptive temporal
+// precision). Types Debezium has no specific mapping for are strings.
+func debeziumColumnSchema(col database.ColumnMetadata, dialect string) connectSchema {
+	isMySQL := dialect == "mysql"
+	dataType := strings.ToLower(col.DataType)
+
+	if strings.HasPrefix(dataType, "[]") {
+		element := col
+		element.DataType = dataType[2:]
+		items := debeziumColumnSchema(element, dialect)
+		items.Optional = true
+		return connectSchema{Type: "array", Items: &items}
+	}
+
+	switch dataType {
+	case "bool", "boolean":
+		return connectSchema{Type: "boolean"}
+	case "bit":
+		length := bitLength(col.RawType)
+		if length == "1" {
+			return connectSchema{Type: "boolean"}
+		}
+		return connectSchema{Type: "bytes", Name: "io.debezium.data.Bits", Version: 1, Parameters: map[string]string{"length": length}}
+	case "tinyint", "smallint", "smallserial", "int2":
+		if col.IsUnsigned && dataType == "smallint" {
+			return connectSchema{Type: "int32"}
+		}
+		return connectSchema{Type: "int16"}
+	case "mediumint", "int", "integer", "serial", "int4":
+		if col.IsUnsigned && dataType != "mediumint" {
+			return connectSchema{Type: "int64"}
+		}
+		return connectSchema{Type: "int32"}
+	case "bigint", "bigserial", "int8":
+		return connectSchema{Type: "int64"}
+	case "year":
+		return connectSchema{Type: "int32", Name: "io.debezium.time.Year", Version: 1}
+	case "float", "real", "float4":
+		return connectSchema{Type: "float32"}
+	case "double", "double precision", "float8":
+		return connectSchema{Type: "float64"}
+	case "decimal", "numeric", "money":
+		if col.NumericPrecision == nil {
+			return connectSchema{Type: "struct", Name: "io.debezium.data.VariableScaleDecimal", Version: 1, Fields: []connectSchema{
+				{Type: "int32", Field: "scale"},
+				{Type: "bytes", Field: "value"},
+			}}
+		}
+		scale := 0
+		if col.NumericScale != nil {
+			scale = *col.NumericScale
+		}
+		return connectSchema{Type: "bytes", Name: "org.apache.kafka.connect.data.Decimal", Version: 1, Parameters: map[string]string{
+			"scale":                     strconv.Itoa(scale),
+			"connect.decimal.precision": strconv.Itoa(*col.NumericPrecision),
+		}}
+	case "date":
+		return connectSchema{Type: "int32", Name: "io.debezium.time.Date", Version: 1}
+	case "time", "time without time zone":
+		return connectSchema{Type: "int64", Name: "io.debezium.time.MicroTime", Version: 1}
+	case "timetz", "time with time zone":
+		return connectSchema{Type: "string", Name: "io.debezium.time.ZonedTime", Version: 1}
+	case "datetime":
+		return connectSchema{Type: "int64", Name: "io.debezium.time.Timestamp", Version: 1}
+	case "timestamp", "timestamp without time zone":
+		// MySQL TIMESTAMP columns are stored in UTC and emitted zoned
+		if isMySQL {
+			return connectSchema{Type: "string", Name: "io.debezium.time.ZonedTimestamp", Version: 1}
+		}
+		return connectSchema{Type: "int64", Name: "io.debezium.time.MicroTimestamp", Version: 1}
+	case "timestamptz", "timestamp with time zone":
+		return connectSchema{Type: "string", Name: "io.debezium.time.ZonedTimestamp", Version: 1}
+	case "uuid":
+		return connectSchema{Type: "string", Name: "io.debezium.data.Uuid", Version: 1}
+	case "json", "jsonb":
+		return connectSchema{Type: "string", Name: "io.debezium.data.Json", Version: 1}
+	case "xml":
+		return connectSchema{Type: "string", Name: "io.debezium.data.Xml", Version: 1}
+	case "enum":
+		return connectSchema{Type: "string", Name: "io.debezium.data.Enum", Version: 1, Parameters: map[string]string{"allowed": strings.Join(col.EnumValues, ",")}}
+	case "set":
+		return connectSchema{Type: "string", Name: "io.debezium.data.EnumSet", Version: 1, Parameters: map[string]string{"allowed": strings.Join(col.EnumValues, ",")}}
+	case "binary", "varbinary", "blob", "tinyblob", "mediumblob", "longblob", "bytea":
+		return connectSchema{Type: "bytes"}
+	}
+	return connectSchema{Type: "string"}
+}
+
+// bitLength returns the length of a bit(n) type, defaulting to 1
+func bitLength(rawType string) string {
+	if _, rest, ok := strings.Cut(rawType, "("); ok {
+		if n, _, ok := strings.Cut(rest, ")"); ok {
+			return strings.TrimSpace(n)
+		}
+	}
+	return "1"
+}
@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestGenerateDebeziumSchema(t *testing.T) {
+	precision, scale := 10, 2
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"orders": {
+			Schema: "shop",
+			Name:   "orders",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int unsigned", IsPrimaryKey: true, IsUnsigned: true},
+				{Name: "total", DataType: "decimal", RawType: "decimal(10,2)", NumericPrecision: &precision, NumericScale: &scale},
+				{Name: "status", DataType: "enum", RawType: "enum('open','paid')", EnumValues: []string{"open", "paid"}},
+				{Name: "placed_at", DataType: "datetime", RawType: "datetime", IsNullable: true},
+			},
+		},
+	}}
+
+	content, err := NewGenerator(fake).GenerateDebeziumSchema("orders", "dbserver1", "mysql")
+	if err != nil {
+		t.Fatalf("GenerateDebeziumSchema() error = %v", err)
+	}
+
+	var descriptor debeziumDescriptor
+	if err := json.Unmarshal(content, &descriptor); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, content)
+	}
+	if descriptor.Topic != "dbserver1.shop.orders" {
+		t.Errorf("Topic = %q", descriptor.Topic)
+	}
+
+	id := connectSchema{Type: "int64", Field: "id"}
+	if descriptor.Key == nil || descriptor.Key.Name != "dbserver1.shop.orders.Key" || !reflect.DeepEqual(descriptor.Key.Fields, []connectSchema{id}) {
+		t.Errorf("Key = %+v", descriptor.Key)
+	}
+
+	value := descriptor.Value
+	if value.Name != "dbserver1.shop.orders.Envelope" || len(value.Fields) != 6 {
+		t.Fatalf("Value = %+v", value)
+	}
+	var names []string
+	for _, f := range value.Fields {
+		names = append(names, f.Field)
+	}
+	if strings.Join(names, ",") != "before,after,source,op,ts_ms,transaction" {
+		t.Errorf("envelope fields = %v", names)
+	}
+	if source := value.Fields[2]; source.Name != "io.debezium.connector.mysql.Source" {
+		t.Errorf("source = %s", source.Name)
+	}
+
+	after := value.Fields[1]
+	if !after.Optional || after.Name != "dbserver1.shop.orders.Value" {
+		t.Errorf("after = %+v", after)
+	}
+	want := []connectSchema{
+		id,
+		{Type: "bytes", Name: "org.apache.kafka.connect.data.Decimal", Version: 1, Parameters: map[string]string{"scale": "2", "connect.decimal.precision": "10"}, Field: "total"},
+		{Type: "string", Name: "io.debezium.data.Enum", Version: 1, Parameters: map[string]string{"allowed": "open,paid"}, Field: "status"},
+		{Type: "int64", Optional: true, Name: "io.debezium.time.Timestamp", Version: 1, Field: "placed_at"},
+	}
+	if !reflect.DeepEqual(after.Fields, want) {
+		t.Errorf("after.Fields = %+v", after.Fields)
+	}
+}
+
+func TestGenerateDebeziumSchema_UnsupportedDialect(t *testing.T) {
+	if _, err := NewGenerator(newFakeUsers()).GenerateDebeziumSchema("users", "db", "firebird"); err == nil {
+		t.Error("GenerateDebeziumSchema() succeeded for firebird, want an error")
+	}
+}
+
+func TestDebeziumColumnSchema(t *testing.T) {
+	tests := []struct {
+		col     database.ColumnMetadata
+		dialect string
+		want    connectSchema
+	}{
+		{database.ColumnMetadata{DataType: "timestamp"}, "mysql", connectSchema{Type: "string", Name: "io.debezium.time.ZonedTimestamp", Version: 1}},
+		{database.ColumnMetadata{DataType: "timestamp"}, "postgres", connectSchema{Type: "int64", Name: "io.debezium.time.MicroTimestamp", Version: 1}},
+		{database.ColumnMetadata{DataType: "uuid"}, "postgres", connectSchema{Type: "string", Name: "io.debezium.data.Uuid", Version: 1}},
+		{database.ColumnMetadata{DataType: "jsonb"}, "postgres", connectSchema{Type: "string", Name: "io.debezium.data.Json", Version: 1}},
+		{database.ColumnMetadata{DataType: "bit", RawType: "bit(1)"}, "mysql", connectSchema{Type: "boolean"}},
+		{database.ColumnMetadata{DataType: "bit", RawType: "bit(8)"}, "mysql", connectSchema{Type: "bytes", Name: "io.debezium.data.Bits", Version: 1, Parameters: map[string]string{"length": "8"}}},
+		{database.ColumnMetadata{DataType: "smallint", IsUnsigned: true}, "mysql", connectSchema{Type: "int32"}},
+		{database.ColumnMetadata{DataType: "[]int4"}, "postgres", connectSchema{Type: "array", Items: &connectSchema{Type: "int32", Optional: true}}},
+		{database.ColumnMetadata{DataType: "[]text"}, "postgres", connectSchema{Type: "array", Items: &connectSchema{Type: "string", Optional: true}}},
+	}
+	for _, tt := range tests {
+		if got := debeziumColumnSchema(tt.col, tt.dialect); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("debeziumColumnSchema(%s, %s) = %+v, want %+v", tt.col.DataType, tt.dialect, got, tt.want)
+		}
+	}
+}
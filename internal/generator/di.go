@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+// DIDir is the directory of the generated dependency-injection providers,
+// next to the output directory
+const DIDir = "di"
+
+// DIFileName is the file holding the providers
+const DIFileName = "providers_gen.go"
+
+// ErrDINoModule is returned by GenerateDIToFile when the output directory is
+// not inside a Go module, so the providers can't import the models
+var ErrDINoModule = errors.New("dependency injection needs the output directory inside a Go module (go.mod not found)")
+
+// diFrameworks maps the supported dependency-injection frameworks to their
+// import path
+var diFrameworks = map[string]string{
+	"wire": "github.com/google/wire",
+	"fx":   "go.uber.org/fx",
+}
+
+// diTemplate is the template of the providers file
+const diTemplate = `{{template "header" .}}
+{{- if eq .Framework "wire"}}
+// ProviderSet provides a *gorm.DB opened from a gorm.Dialector, and the
+// repositories{{if .Handlers}} and REST handlers{{end}} of the tables using it
+var ProviderSet = wire.NewSet(
+	NewDB,
+{{- range .Providers}}
+	{{.}},
+{{- end}}
+)
+{{- else}}
+// Module provides a *gorm.DB opened from a gorm.Dialector, and the
+// repositories{{if .Handlers}} and REST handlers{{end}} of the tables using it
+var Module = fx.Module({{printf "%q" .Package}},
+	fx.Provide(
+		NewDB,
+{{- range .Providers}}
+		{{.}},
+{{- end}}
+	),
+)
+{{- end}}
+
+// NewDB opens the database of dialector with GORM
+func NewDB(dialector gorm.Dialector) (*gorm.DB, error) {
+	return gorm.Open(dialector, &gorm.Config{})
+}
+`
+
+// DIData holds the data for diTemplate
+type DIData struct {
+	Package   string
+	Imports   []string
+	Framework string
+	Handlers  bool
+	Providers []string // Qualified constructors, e.g. models.NewUserRepository
+}
+
+// GenerateDIToFile writes the dependency-injection providers of the given
+// tables to the DIDir directory next to outputDir: a google/wire provider
+// set (framework wire) or an uber/fx module (framework fx) with the
+// repositories of the models (see GeneratorConfig.Repositories) and, with
+// handlers, the REST handlers of GenerateHandlersToFiles. The *gorm.DB they
+// take is opened from a gorm.Dialector the application provides.
+func (g *Generator) GenerateDIToFile(tableNames []string, outputDir, framework string, handlers bool) (string, error) {
+	frameworkImport, ok := diFrameworks[framework]
+	if !ok {
+		return "", fmt.Errorf("unknown dependency-injection framework %q (want wire or fx)", framework)
+	}
+	if g.importPath == "" {
+		return "", ErrDINoModule
+	}
+	parent := filepath.Dir(filepath.Clean(outputDir))
+
+	data := &DIData{Package: DIDir, Framework: framework, Handlers: handlers}
+	data.Imports = []string{fmt.Sprintf("%q", frameworkImport), `"gorm.io/gorm"`}
+	packages, qualifiers := g.modelPackages(tableNames)
+	for _, pkg := range packages {
+		data.Imports = append(data.Imports, fmt.Sprintf("%s %q", pkg.Alias, pkg.ImportPath))
+	}
+	if handlers {
+		module, err := DetectModule(outputDir)
+		if err != nil {
+			return "", err
+		}
+		if module == nil {
+			return "", ErrDINoModule
+		}
+		handlersImport, err := module.ImportPath(filepath.Join(parent, HandlersDir))
+		if err != nil {
+			return "", err
+		}
+		data.Imports = append(data.Imports, fmt.Sprintf("%q", handlersImport))
+	}
+	data.Imports = groupImports(data.Imports)
+
+	for _, tableName := range tableNames {
+		structName := g.structName(tableName)
+		data.Providers = append(data.Providers, qualifiers[tableName]+".New"+structName+"Repository")
+		if handlers {
+			data.Providers = append(data.Providers, HandlersDir+".New"+structName+"Handler")
+		}
+	}
+
+	content, err := renderWithImports(diTemplate, data)
+	if err != nil {
+		return "", err
+	}
+	filePath := filepath.Join(parent, DIDir, DIFileName)
+	if err := WriteFiles([]OutputFile{{Path: filePath, Content: content}}); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
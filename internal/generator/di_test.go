@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateDIToFile(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gen := NewGeneratorWithConfig(newFakeShop(), GeneratorConfig{PackageName: "models", ImportPath: "example.com/app/models"})
+
+	tests := []struct {
+		framework string
+		handlers  bool
+		want      []string
+	}{
+		{
+			framework: "wire",
+			want: []string{
+				`"github.com/google/wire"`,
+				"var ProviderSet = wire.NewSet( NewDB, models.NewUserRepository, models.NewOrderRepository, )",
+				"func NewDB(dialector gorm.Dialector) (*gorm.DB, error) {",
+			},
+		},
+		{
+			framework: "fx",
+			handlers:  true,
+			want: []string{
+				`"example.com/app/handlers"`,
+				`var Module = fx.Module("di", fx.Provide( NewDB, models.NewUserRepository, handlers.NewUserHandler, models.NewOrderRepository, handlers.NewOrderHandler, ), )`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.framework, func(t *testing.T) {
+			filePath, err := gen.GenerateDIToFile([]string{"users", "orders"}, filepath.Join(root, "models"), tt.framework, tt.handlers)
+			if err != nil {
+				t.Fatalf("GenerateDIToFile() error = %v", err)
+			}
+			if want := filepath.Join(root, DIDir, DIFileName); filePath != want {
+				t.Errorf("GenerateDIToFile() = %s; want %s", filePath, want)
+			}
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			code := strings.Join(strings.Fields(string(content)), " ")
+			for _, want := range tt.want {
+				if !strings.Contains(code, want) {
+					t.Errorf("providers missing %q:\n%s", want, content)
+				}
+			}
+		})
+	}
+
+	if _, err := gen.GenerateDIToFile([]string{"users"}, filepath.Join(root, "models"), "dig", false); err == nil {
+		t.Error("GenerateDIToFile() error = nil for an unknown framework")
+	}
+}
@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// DictionaryFormat is the file format of a data dictionary
+type DictionaryFormat string
+
+const (
+	// DictionaryCSV writes a comma-separated file with a header row
+	DictionaryCSV DictionaryFormat = "csv"
+	// DictionaryXLSX writes an Excel workbook with a single sheet
+	DictionaryXLSX DictionaryFormat = "xlsx"
+)
+
+// Extension returns the file name extension of the format, e.g. ".xlsx"
+func (f DictionaryFormat) Extension() string {
+	return "." + string(f)
+}
+
+// ParseDictionaryFormat parses a data dictionary format name (csv or xlsx).
+// An empty name selects csv.
+func ParseDictionaryFormat(name string) (DictionaryFormat, error) {
+	switch name {
+	case "", "csv":
+		return DictionaryCSV, nil
+	case "xlsx", "excel":
+		return DictionaryXLSX, nil
+	default:
+		return "", fmt.Errorf("unknown data dictionary format %q (expected csv or xlsx)", name)
+	}
+}
+
+// dictionaryHeader names the columns of the data dictionary
+var dictionaryHeader = []string{"Table", "Table Comment", "Column", "Position", "Type", "Nullable", "Primary Key", "Default", "Comment"}
+
+// WriteDictionary writes the data dictionary of the given tables, or every
+// table if none are given, to w: a row per column with its type,
+// nullability, default and comment, for analysts and auditors
+func (g *Generator) WriteDictionary(w io.Writer, tables []string, format DictionaryFormat) error {
+	rows, err := g.dictionaryRows(tables)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case DictionaryCSV:
+		cw := csv.NewWriter(w)
+		cw.WriteAll(rows)
+		err = cw.Error()
+	case DictionaryXLSX:
+		err = writeXLSX(w, "Data Dictionary", rows)
+	default:
+		return fmt.Errorf("unknown data dictionary format %q (expected csv or xlsx)", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write data dictionary: %w", err)
+	}
+	return nil
+}
+
+// dictionaryRows returns the header and a row per column of the tables
+func (g *Generator) dictionaryRows(tables []string) ([][]string, error) {
+	var err error
+	if len(tables) == 0 {
+		if tables, err = g.introspector.GetTables(); err != nil {
+			return nil, fmt.Errorf("failed to get tables: %w", err)
+		}
+	}
+
+	rows := [][]string{dictionaryHeader}
+	for _, table := range tables {
+		meta, err := g.introspector.GetTableMetadata(table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get table metadata for %s: %w", table, err)
+		}
+		for i, col := range meta.Columns {
+			position := col.OrdinalPosition
+			if position == 0 {
+				position = i + 1
+			}
+			rows = append(rows, []string{
+				meta.Name,
+				meta.Comment,
+				col.Name,
+				strconv.Itoa(position),
+				col.RawType,
+				yesNo(col.IsNullable),
+				yesNo(col.IsPrimaryKey),
+				dictionaryDefault(col),
+				col.Comment,
+			})
+		}
+	}
+	return rows, nil
+}
+
+// dictionaryDefault describes a column's default value
+func dictionaryDefault(col database.ColumnMetadata) string {
+	if col.IsAutoIncrement {
+		return "auto increment"
+	}
+	if col.DefaultValue == nil {
+		return ""
+	}
+	return *col.DefaultValue
+}
+
+// yesNo spells out a flag for spreadsheet readers
+func yesNo(b bool) string {
+	if b {
+		return "YES"
+	}
+	return "NO"
+}
+
+// xlsxParts are the fixed parts of a single-sheet workbook; the sheet
+// itself is written by writeXLSX. Style 1 is the bold header font.
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`},
+	{"xl/styles.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs></styleSheet>`},
+}
+
+// writeXLSX writes rows as a workbook with one sheet. The first row is the
+// bold, frozen header; cells are inline strings, except whole numbers.
+func writeXLSX(w io.Writer, sheet string, rows [][]string) error {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+
+	var workbook bytes.Buffer
+	workbook.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	workbook.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="`)
+	xml.EscapeText(&workbook, []byte(sheet))
+	workbook.WriteString(`" sheetId="1" r:id="rId1"/></sheets></workbook>`)
+
+	var data bytes.Buffer
+	data.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	data.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	data.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews><sheetData>`)
+	for r, row := range rows {
+		fmt.Fprintf(&data, `<row r="%d">`, r+1)
+		for c, value := range row {
+			ref := xlsxColumn(c) + strconv.Itoa(r+1)
+			style := ""
+			if r == 0 {
+				style = ` s="1"`
+			}
+			if _, err := strconv.Atoi(value); err == nil && r > 0 {
+				fmt.Fprintf(&data, `<c r="%s"%s><v>%s</v></c>`, ref, style, value)
+				continue
+			}
+			fmt.Fprintf(&data, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">`, ref, style)
+			xml.EscapeText(&data, []byte(value))
+			data.WriteString(`</t></is></c>`)
+		}
+		data.WriteString(`</row>`)
+	}
+	data.WriteString(`</sheetData></worksheet>`)
+
+	for _, part := range []struct {
+		name    string
+		content []byte
+	}{{"xl/workbook.xml", workbook.Bytes()}, {"xl/worksheets/sheet1.xml", data.Bytes()}} {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(part.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// xlsxColumn returns the letters of a zero-based column index: A, B, ...,
+// Z, AA, AB, ...
+func xlsxColumn(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func dictionaryGenerator(t *testing.T) *Generator {
+	introspector := database.NewDDLIntrospectorFromSource("CREATE TABLE `users` (\n"+
+		"  `id` int NOT NULL AUTO_INCREMENT,\n"+
+		"  `email` varchar(255) NOT NULL COMMENT 'Login, unique',\n"+
+		"  `status` varchar(20) DEFAULT 'active',\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB COMMENT='Registered users';\n", "mysql")
+	if err := introspector.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	return NewGenerator(introspector)
+}
+
+func TestWriteDictionaryCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := dictionaryGenerator(t).WriteDictionary(&buf, nil, DictionaryCSV); err != nil {
+		t.Fatalf("WriteDictionary() error = %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	want := [][]string{
+		dictionaryHeader,
+		{"users", "Registered users", "id", "1", "int", "NO", "YES", "auto increment", ""},
+		{"users", "Registered users", "email", "2", "varchar(255)", "NO", "NO", "", "Login, unique"},
+		{"users", "Registered users", "status", "3", "varchar(20)", "YES", "NO", "active", ""},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("WriteDictionary() rows =\n%q\nwant\n%q", rows, want)
+	}
+}
+
+func TestWriteDictionaryXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := dictionaryGenerator(t).WriteDictionary(&buf, []string{"users"}, DictionaryXLSX); err != nil {
+		t.Fatalf("WriteDictionary() error = %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("workbook is not a zip archive: %v", err)
+	}
+
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("opening %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(content)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("workbook missing part %s", name)
+		}
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Table</t></is></c>`,
+		`<c r="D2"><v>1</v></c>`,
+		`<t xml:space="preserve">Login, unique</t>`,
+		`<row r="4">`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %q:\n%s", want, sheet)
+		}
+	}
+}
+
+func TestParseDictionaryFormat(t *testing.T) {
+	for name, want := range map[string]DictionaryFormat{"": DictionaryCSV, "csv": DictionaryCSV, "xlsx": DictionaryXLSX, "excel": DictionaryXLSX} {
+		if got, err := ParseDictionaryFormat(name); err != nil || got != want {
+			t.Errorf("ParseDictionaryFormat(%q) = %q, %v, want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseDictionaryFormat("pdf"); err == nil {
+		t.Error("ParseDictionaryFormat(pdf) succeeded, want an error")
+	}
+	if got := xlsxColumn(27); got != "AB" {
+		t.Errorf("xlsxColumn(27) = %q, want AB", got)
+	}
+}
@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Preview holds freshly generated code together with how it differs from the
+// file currently at the target path
+type Preview struct {
+	Code   string // Generated code
+	Path   string // Target file path
+	Exists bool   // Whether a file already exists at Path
+	Diff   string // Unified diff from the existing file to Code (empty if identical)
+}
+
+// Preview generates the code for a table and diffs it against the existing
+// file in outputDir, showing what regeneration would change before saving
+func (g *Generator) Preview(tableName, outputDir string) (*Preview, error) {
+	content, err := g.Generate(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &Preview{
+		Code: string(content),
+		Path: g.FilePath(tableName, outputDir),
+	}
+
+	existing, err := os.ReadFile(preview.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	preview.Exists = err == nil
+	if preview.Exists {
+		// Show the file as saving would write it, hand edits included
+		content, err = MergePreserved(filepath.Base(preview.Path), content, existing)
+		if err != nil {
+			return nil, err
+		}
+		preview.Code = string(content)
+	}
+
+	preview.Diff, err = UnifiedDiff(preview.Path, existing, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// UnifiedDiff returns a unified diff between two versions of a file
+func UnifiedDiff(path string, before, after []byte) (string, error) {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(before)),
+		B:        difflib.SplitLines(string(after)),
+		FromFile: "a/" + path,
+		ToFile:   "b/" + path,
+		Context:  3,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to diff %s: %w", path, err)
+	}
+	return diff, nil
+}
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestPreview(t *testing.T) {
+	outputDir := t.TempDir()
+	gen := NewGenerator(newFakeUsers())
+
+	// No file yet: everything is an addition
+	preview, err := gen.Preview("users", outputDir)
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+	if preview.Exists {
+		t.Error("Exists = true; want false before the file is written")
+	}
+	if !strings.Contains(preview.Diff, "+type User struct {") {
+		t.Errorf("Diff should add the struct:\n%s", preview.Diff)
+	}
+
+	// Identical file: no diff
+	if err := os.WriteFile(preview.Path, []byte(preview.Code), 0644); err != nil {
+		t.Fatal(err)
+	}
+	preview, err = gen.Preview("users", outputDir)
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+	if !preview.Exists || preview.Diff != "" {
+		t.Errorf("Exists = %v, Diff = %q; want existing file without diff", preview.Exists, preview.Diff)
+	}
+
+	// Edited file: the edit shows up as a removal
+	edited := strings.Replace(preview.Code, "type User struct {", "type User struct {\n\tLegacy string", 1)
+	if err := os.WriteFile(preview.Path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	preview, err = gen.Preview("users", outputDir)
+	if err != nil {
+		t.Fatalf("Preview() error = %v", err)
+	}
+	if !strings.Contains(preview.Diff, "-\tLegacy string") {
+		t.Errorf("Diff should remove the hand-written field:\n%s", preview.Diff)
+	}
+}
@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// Import paths of the MongoDB driver's BSON packages
+const (
+	bsonImport          = "go.mongodb.org/mongo-driver/bson"
+	bsonPrimitiveImport = "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// documentTypes maps the field types inferred from sampled MongoDB documents
+// to Go types
+var documentTypes = map[string]TypeMapping{
+	"objectid":  {GoType: "primitive.ObjectID", ImportPath: bsonPrimitiveImport},
+	"string":    {GoType: "string"},
+	"int":       {GoType: "int32"},
+	"long":      {GoType: "int64"},
+	"double":    {GoType: "float64"},
+	"decimal":   {GoType: "primitive.Decimal128", ImportPath: bsonPrimitiveImport},
+	"bool":      {GoType: "bool"},
+	"date":      {GoType: "time.Time", ImportPath: "time"},
+	"timestamp": {GoType: "primitive.Timestamp", ImportPath: bsonPrimitiveImport},
+	"object":    {GoType: "bson.M", ImportPath: bsonImport, IsSlice: true},
+	"array":     {GoType: "bson.A", ImportPath: bsonImport, IsSlice: true},
+	"bindata":   {GoType: "[]byte", IsSlice: true},
+	"mixed":     {GoType: "interface{}", IsSlice: true, Comment: "// mixed types in sampled documents"},
+}
+
+// SetDocumentTypes maps MongoDB field types. Some share names with SQL types
+// (decimal, timestamp), so the generator only applies this for MongoDB.
+// Arrays whose elements agree on a type, reported as array(string), become
+// typed slices.
+func (tm *TypeMapper) SetDocumentTypes() {
+	for fieldType, mapping := range documentTypes {
+		tm.typeMap[fieldType] = mapping
+		element := TypeMapping{GoType: "[]" + mapping.GoType, ImportPath: mapping.ImportPath, IsSlice: true}
+		switch fieldType {
+		case "array", "mixed":
+			element = documentTypes["array"]
+		}
+		tm.typeMap[fmt.Sprintf("array(%s)", fieldType)] = element
+	}
+}
+
+// buildBSONTag builds the bson tag of a document field. Fields missing from
+// some sampled documents are omitted when empty, so they stay missing on write.
+func (tb *TagBuilder) buildBSONTag(col database.ColumnMetadata, _ gormTagOptions) string {
+	if col.IsNullable {
+		return fmt.Sprintf(`bson:"%s,omitempty"`, tagLiteral(col.Name))
+	}
+	return fmt.Sprintf(`bson:"%s"`, tagLiteral(col.Name))
+}
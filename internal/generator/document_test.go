@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// fakeMongo is a fakeIntrospector reporting the mongodb dialect
+type fakeMongo struct {
+	*fakeIntrospector
+}
+
+func (fakeMongo) Dialect() string { return "mongodb" }
+
+func newFakeCustomers() fakeMongo {
+	return fakeMongo{&fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"customers": {
+			Name: "customers",
+			Columns: []database.ColumnMetadata{
+				{Name: "_id", DataType: "objectid", RawType: "objectid", IsPrimaryKey: true},
+				{Name: "email", DataType: "string", RawType: "string"},
+				{Name: "balance", DataType: "decimal", RawType: "decimal"},
+				{Name: "tags", DataType: "array", RawType: "array(string)"},
+				{Name: "createdAt", DataType: "date", RawType: "date"},
+				{Name: "profile", DataType: "object", RawType: "object", IsNullable: true},
+				{Name: "nickname", DataType: "string", RawType: "string", IsNullable: true},
+			},
+		},
+	}}}
+}
+
+func TestGenerate_MongoDocuments(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeCustomers(), GeneratorConfig{
+		NullStrategy: NullStrategyPointer,
+		DateTime:     DateTimeLocal,
+		ExtraTags:    []string{"bson:camel", "yaml"},
+	})
+	code, err := gen.GenerateString("customers")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	code = strings.Join(strings.Fields(code), " ")
+
+	for _, want := range []string{
+		`"go.mongodb.org/mongo-driver/bson"`,
+		`"go.mongodb.org/mongo-driver/bson/primitive"`,
+		"ID primitive.ObjectID `bson:\"_id\" json:\"_id\" yaml:\"_id\"`",
+		"Balance primitive.Decimal128 `bson:\"balance\"",
+		"Tags []string `bson:\"tags\"",
+		"CreatedAt time.Time `bson:\"createdAt\" json:\"createdAt\"",
+		"Profile bson.M `bson:\"profile,omitempty\"",
+		"Nickname *string `bson:\"nickname,omitempty\"",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+	if strings.Contains(code, "gorm:") {
+		t.Errorf("document models should have no gorm tags:\n%s", code)
+	}
+}
+
+func TestSetDocumentTypes(t *testing.T) {
+	tm := NewTypeMapper()
+	tm.SetDocumentTypes()
+
+	tests := []struct {
+		dbType string
+		want   string
+	}{
+		{"array(objectid)", "[]primitive.ObjectID"},
+		{"array(object)", "[]bson.M"},
+		{"array(mixed)", "bson.A"},
+		{"array", "bson.A"},
+		{"timestamp", "primitive.Timestamp"},
+		{"mixed", "interface{}"},
+	}
+	for _, tt := range tests {
+		if got := tm.GetGoTypeSimple(tt.dbType, true); got != tt.want {
+			t.Errorf("GetGoTypeSimple(%q) = %q; want %q", tt.dbType, got, tt.want)
+		}
+	}
+}
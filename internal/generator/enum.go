@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// maxSuggestedEnumValues is the largest number of distinct values of a
+// column SuggestEnums proposes an enum for
+const maxSuggestedEnumValues = 10
+
+// minEnumSampleRows is the smallest sample SuggestEnums draws conclusions
+// from; a handful of rows has few distinct values by chance
+const minEnumSampleRows = 20
+
+// EnumSuggestion proposes generating a string column as an enum type, based
+// on the distinct values found in a sample of its rows
+type EnumSuggestion struct {
+	Column      string   `json:"column"`
+	TypeName    string   `json:"typeName"`    // Name of the generated type, e.g. OrderStatus
+	Values      []string `json:"values"`      // Distinct values of the sample, sorted
+	SampledRows int64    `json:"sampledRows"` // Rows the values were taken from
+	Complete    bool     `json:"complete"`    // The sample covered the whole table
+}
+
+// EnumType is a named string type generated for a column with a configured
+// enum (generator.overrides.<table>.columns.<column>.enum)
+type EnumType struct {
+	Name      string         // Type name, e.g. OrderStatus
+	Column    string         // Column the type is generated for
+	Constants []EnumConstant // One constant per value, in configured order
+}
+
+// EnumConstant is a constant of an EnumType
+type EnumConstant struct {
+	Name  string // Constant name, e.g. OrderStatusPending
+	Value string // Column value
+}
+
+// SuggestEnums samples the varchar and char columns of a table and proposes
+// an enum for each with between 2 and 10 distinct values. Columns that
+// already have an enum, a type override or a rejected suggestion, native
+// enum columns and primary keys are skipped.
+func (g *Generator) SuggestEnums(tableName string, analyzer database.ColumnAnalyzer) ([]EnumSuggestion, error) {
+	meta, err := g.tableMetadata(tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table metadata: %w", err)
+	}
+
+	override := g.TableOverride(meta.Name)
+	structName := g.structName(meta.Name)
+	cols := g.columns(meta)
+	names := g.fieldNames(cols)
+
+	var suggestions []EnumSuggestion
+	for i, col := range cols {
+		if !enumCandidate(col) {
+			continue
+		}
+		if colOverride := override.Column(col.Name); colOverride.Type != "" || len(colOverride.Enum) > 0 || colOverride.NoEnum {
+			continue
+		}
+
+		stats, err := analyzer.AnalyzeColumn(meta.Name, col.Name, database.DefaultAnalyzeSample)
+		if err != nil {
+			return nil, err
+		}
+		if stats.SampledRows < minEnumSampleRows || stats.DistinctCount < 2 || stats.DistinctCount > maxSuggestedEnumValues || int64(len(stats.TopValues)) != stats.DistinctCount {
+			continue
+		}
+
+		values := make([]string, len(stats.TopValues))
+		for j, value := range stats.TopValues {
+			values[j] = value.Value
+		}
+		sort.Strings(values)
+		suggestions = append(suggestions, EnumSuggestion{
+			Column:      col.Name,
+			TypeName:    structName + names[i].name,
+			Values:      values,
+			SampledRows: stats.SampledRows,
+			Complete:    stats.Complete,
+		})
+	}
+	return suggestions, nil
+}
+
+// enumCandidate reports whether a column is a plain string column an enum
+// could be suggested for
+func enumCandidate(col database.ColumnMetadata) bool {
+	if col.IsPrimaryKey || len(col.EnumValues) > 0 {
+		return false
+	}
+	switch strings.ToLower(col.DataType) {
+	case "varchar", "char", "character varying", "character", "nvarchar", "nchar", "bpchar":
+		return true
+	}
+	return false
+}
+
+// applyEnums gives the string fields of columns with a configured enum
+// their own named type, returning the types to declare
+func (g *Generator) applyEnums(meta *database.TableMetadata, fields []StructField) []EnumType {
+	override := g.TableOverride(meta.Name)
+	if len(override.Columns) == 0 {
+		return nil
+	}
+
+	var enums []EnumType
+	structName := g.structName(meta.Name)
+	for i := range fields {
+		colOverride := override.Column(fields[i].Column)
+		if fields[i].Column == "" || len(colOverride.Enum) == 0 || colOverride.Type != "" {
+			continue
+		}
+		if fields[i].Type != "string" && fields[i].Type != "*string" {
+			continue
+		}
+
+		enum := g.enumType(structName+fields[i].Name, fields[i].Column, colOverride.Enum)
+		fields[i].Type = strings.TrimSuffix(fields[i].Type, "string") + enum.Name
+		enums = append(enums, enum)
+	}
+	return enums
+}
+
+// enumType names the constants of an enum type after its values, e.g.
+// OrderStatusPending for pending. Values that yield no name, or a name
+// already taken, are numbered instead.
+func (g *Generator) enumType(name, column string, values []string) EnumType {
+	enum := EnumType{Name: name, Column: column}
+	used := make(map[string]bool, len(values))
+	for i, value := range values {
+		constName := name + g.namingConv.HandleAcronyms(pascalIdentifier(value))
+		if constName == name || used[constName] {
+			constName = name + "Value" + strconv.Itoa(i+1)
+		}
+		used[constName] = true
+		enum.Constants = append(enum.Constants, EnumConstant{Name: constName, Value: value})
+	}
+	return enum
+}
@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// fakeAnalyzer serves column statistics from memory
+type fakeAnalyzer map[string]*database.ColumnStats
+
+func (f fakeAnalyzer) AnalyzeColumn(tableName, columnName string, sampleRows int) (*database.ColumnStats, error) {
+	if stats, ok := f[columnName]; ok {
+		return stats, nil
+	}
+	return &database.ColumnStats{}, nil
+}
+
+func ordersIntrospector() *fakeIntrospector {
+	return &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"orders": {
+			Name: "orders",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+				{Name: "status", DataType: "varchar", RawType: "varchar(20)"},
+				{Name: "channel", DataType: "varchar", RawType: "varchar(20)", IsNullable: true},
+				{Name: "email", DataType: "varchar", RawType: "varchar(255)"},
+				{Name: "kind", DataType: "enum", RawType: "enum('a','b')", EnumValues: []string{"a", "b"}},
+			},
+		},
+	}}
+}
+
+func TestSuggestEnums(t *testing.T) {
+	analyzer := fakeAnalyzer{
+		"status": {SampledRows: 500, Complete: true, DistinctCount: 3, TopValues: []database.ValueCount{
+			{Value: "shipped", Count: 300}, {Value: "pending", Count: 150}, {Value: "cancelled", Count: 50},
+		}},
+		"channel": {SampledRows: 10, DistinctCount: 2, TopValues: []database.ValueCount{{Value: "web"}, {Value: "app"}}},
+		"email":   {SampledRows: 500, DistinctCount: 500},
+	}
+	gen := NewGenerator(ordersIntrospector())
+
+	suggestions, err := gen.SuggestEnums("orders", analyzer)
+	if err != nil {
+		t.Fatalf("SuggestEnums() error = %v", err)
+	}
+	want := []EnumSuggestion{{
+		Column:      "status",
+		TypeName:    "OrderStatus",
+		Values:      []string{"cancelled", "pending", "shipped"},
+		SampledRows: 500,
+		Complete:    true,
+	}}
+	if !reflect.DeepEqual(suggestions, want) {
+		t.Errorf("SuggestEnums() = %+v, want %+v", suggestions, want)
+	}
+
+	gen.SetTableOverride("orders", config.TableOverride{Columns: map[string]config.ColumnOverride{"status": {NoEnum: true}}})
+	if suggestions, _ := gen.SuggestEnums("orders", analyzer); len(suggestions) != 0 {
+		t.Errorf("SuggestEnums() = %+v, want rejected suggestion skipped", suggestions)
+	}
+}
+
+func TestGenerateEnum(t *testing.T) {
+	gen := NewGeneratorWithConfig(ordersIntrospector(), GeneratorConfig{
+		NullStrategy: "pointer",
+		Overrides: map[string]config.TableOverride{
+			"orders": {Columns: map[string]config.ColumnOverride{
+				"status":  {Enum: []string{"pending", "in-progress", "", "In Progress"}},
+				"channel": {Enum: []string{"web", "app"}},
+			}},
+		},
+	})
+
+	code, err := gen.GenerateString("orders")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	for _, want := range []string{
+		"Status  OrderStatus ",
+		"Channel *OrderChannel ",
+		"// OrderStatus is a value of the status column\ntype OrderStatus string",
+		`OrderStatusPending    OrderStatus = "pending"`,
+		`OrderStatusInProgress OrderStatus = "in-progress"`,
+		`OrderStatusValue3     OrderStatus = ""`,
+		`OrderStatusValue4     OrderStatus = "In Progress"`,
+		`OrderChannelWeb OrderChannel = "web"`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+}
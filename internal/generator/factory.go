@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// FactoryField is a column field settable through a functional option of
+// the model constructor (factories)
+type FactoryField struct {
+	Name    string // Go field name
+	Option  string // Name in the option function: the field name, or its accessor for private fields
+	Type    string // Go type
+	Default string // Go literal of the column default, empty for the zero value
+}
+
+// applyFactories fills in the constructor and functional options of a model
+// when factories are enabled
+func (g *Generator) applyFactories(data *TemplateData) {
+	if !g.factories {
+		return
+	}
+
+	columns := make(map[string]database.ColumnMetadata, len(data.Table.Columns))
+	for _, col := range data.Table.Columns {
+		columns[col.Name] = col
+	}
+
+	for _, field := range data.Fields {
+		col, ok := columns[field.Column]
+		if !ok {
+			continue
+		}
+		option := field.Name
+		if field.Accessor != "" {
+			option = field.Accessor
+		}
+		data.FactoryFields = append(data.FactoryFields, FactoryField{
+			Name:    field.Name,
+			Option:  option,
+			Type:    field.Type,
+			Default: factoryDefault(col, field.Type),
+		})
+	}
+}
+
+// factoryDefault returns the Go literal of a column's default for a field
+// of the given type, or "" if the default is absent, an expression
+// (CURRENT_TIMESTAMP, nextval(...)) or doesn't fit the type
+func factoryDefault(col database.ColumnMetadata, goType string) string {
+	if col.DefaultValue == nil || col.IsAutoIncrement {
+		return ""
+	}
+	value := normalizeDefault(*col.DefaultValue)
+	quoted := len(value) >= 2 && strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'")
+	if quoted {
+		value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+	}
+
+	switch goType {
+	case "string":
+		if !quoted && (strings.EqualFold(value, "NULL") || strings.Contains(value, "(")) {
+			return ""
+		}
+		if !quoted && !isStringType(col.DataType) && col.DataType != "enum" {
+			return ""
+		}
+		if value == "" {
+			return ""
+		}
+		return strconv.Quote(value)
+	case "bool":
+		switch strings.ToLower(value) {
+		case "1", "true", "b'1'", "t":
+			return "true"
+		}
+		return ""
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n == 0 || (n < 0 && strings.HasPrefix(goType, "uint")) {
+			return ""
+		}
+		return strconv.FormatInt(n, 10)
+	case "float32", "float64":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || f == 0 {
+			return ""
+		}
+		return strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestFactories(t *testing.T) {
+	status, retries, active, created := "'active'::character varying", "3", "1", "CURRENT_TIMESTAMP"
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"jobs": {
+			Name: "jobs",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+				{Name: "status", DataType: "varchar", RawType: "varchar(20)", DefaultValue: &status},
+				{Name: "retries", DataType: "int", RawType: "int", DefaultValue: &retries},
+				{Name: "active", DataType: "boolean", RawType: "boolean", DefaultValue: &active},
+				{Name: "created_at", DataType: "timestamp", RawType: "timestamp", DefaultValue: &created},
+			},
+		},
+	}}
+
+	code, err := NewGeneratorWithConfig(fake, GeneratorConfig{Factories: true}).GenerateString("jobs")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	code = strings.Join(strings.Fields(code), " ")
+
+	for _, want := range []string{
+		"type JobOption func(*Job)",
+		`func NewJob(opts ...JobOption) *Job { m := &Job{ Status: "active", Retries: 3, Active: true, } for _, opt := range opts { opt(m) } return m }`,
+		"func WithJobID(v int32) JobOption { return func(m *Job) { m.ID = v } }",
+		"func WithJobCreatedAt(v time.Time) JobOption {",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+
+	plain, err := NewGenerator(fake).GenerateString("jobs")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if strings.Contains(plain, "JobOption") {
+		t.Errorf("factories generated without the option:\n%s", plain)
+	}
+}
+
+func TestFactoryDefault(t *testing.T) {
+	tests := []struct {
+		value  string
+		col    database.ColumnMetadata
+		goType string
+		want   string
+	}{
+		{"'it''s'", database.ColumnMetadata{DataType: "varchar"}, "string", `"it's"`},
+		{"pending", database.ColumnMetadata{DataType: "enum"}, "string", `"pending"`},
+		{"uuid()", database.ColumnMetadata{DataType: "char"}, "string", ""},
+		{"NULL", database.ColumnMetadata{DataType: "varchar"}, "string", ""},
+		{"(0)", database.ColumnMetadata{DataType: "int"}, "int32", ""},
+		{"-1", database.ColumnMetadata{DataType: "int"}, "int32", "-1"},
+		{"-1", database.ColumnMetadata{DataType: "int"}, "uint32", ""},
+		{"1.50", database.ColumnMetadata{DataType: "decimal"}, "float64", "1.5"},
+		{"false", database.ColumnMetadata{DataType: "boolean"}, "bool", ""},
+		{"b'1'", database.ColumnMetadata{DataType: "bit"}, "bool", "true"},
+		{"42", database.ColumnMetadata{DataType: "int"}, "*int32", ""},
+	}
+	for _, tt := range tests {
+		value := tt.value
+		tt.col.DefaultValue = &value
+		if got := factoryDefault(tt.col, tt.goType); got != tt.want {
+			t.Errorf("factoryDefault(%q, %s) = %q, want %q", tt.value, tt.goType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// FieldOrder controls the order of the column fields in generated structs.
+// Association fields always follow the column fields.
+type FieldOrder string
+
+const (
+	// FieldOrderOrdinal keeps the column order of the table (default)
+	FieldOrderOrdinal FieldOrder = "ordinal"
+	// FieldOrderPKFirst moves the primary key columns to the top
+	FieldOrderPKFirst FieldOrder = "pk_first"
+	// FieldOrderAlphabetical sorts fields by Go field name
+	FieldOrderAlphabetical FieldOrder = "alphabetical"
+	// FieldOrderGrouped groups keys (primary and foreign), data columns and
+	// timestamps, each in column order
+	FieldOrderGrouped FieldOrder = "grouped"
+)
+
+// Field groups used by FieldOrderGrouped, in output order
+const (
+	groupKey = iota
+	groupData
+	groupTimestamp
+)
+
+// orderFields reorders the fields built from cols (fields[i] belongs to
+// cols[i]) according to the configured field order
+func (g *Generator) orderFields(meta *database.TableMetadata, cols []database.ColumnMetadata, fields []StructField) []StructField {
+	rank := make([]int, len(fields))
+	switch g.fieldOrder {
+	case FieldOrderPKFirst:
+		for i, col := range cols {
+			if !col.IsPrimaryKey {
+				rank[i] = 1
+			}
+		}
+	case FieldOrderGrouped:
+		foreignKeys := make(map[string]bool)
+		for _, fk := range meta.ForeignKeys {
+			foreignKeys[fk.Column] = true
+		}
+		for i, col := range cols {
+			rank[i] = g.fieldGroup(col, fields[i], foreignKeys[col.Name])
+		}
+	case FieldOrderAlphabetical:
+	default:
+		return fields
+	}
+
+	order := make([]int, len(fields))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		i, j := order[a], order[b]
+		if g.fieldOrder == FieldOrderAlphabetical {
+			return fields[i].Name < fields[j].Name
+		}
+		return rank[i] < rank[j]
+	})
+
+	ordered := make([]StructField, len(fields))
+	for i, index := range order {
+		ordered[i] = fields[index]
+	}
+	return ordered
+}
+
+// fieldGroup returns the FieldOrderGrouped group of a column field
+func (g *Generator) fieldGroup(col database.ColumnMetadata, field StructField, foreignKey bool) int {
+	if col.IsPrimaryKey || foreignKey {
+		return groupKey
+	}
+	if strings.Contains(field.Tags, "autoCreateTime") || strings.Contains(field.Tags, "autoUpdateTime") {
+		return groupTimestamp
+	}
+	goType := strings.TrimPrefix(field.Type, "*")
+	if strings.HasSuffix(strings.ToLower(col.Name), "_at") &&
+		(goType == "time.Time" || goType == "LocalTime" || goType == "gorm.DeletedAt" || goType == "sql.NullTime") {
+		return groupTimestamp
+	}
+	return groupData
+}
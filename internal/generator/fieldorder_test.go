@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestFieldOrder(t *testing.T) {
+	meta := &database.TableMetadata{
+		Name: "orders",
+		Columns: []database.ColumnMetadata{
+			{Name: "status", DataType: "varchar", RawType: "varchar(20)"},
+			{Name: "created_at", DataType: "timestamp", RawType: "timestamp"},
+			{Name: "user_id", DataType: "int", RawType: "int"},
+			{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "shipped_at", DataType: "timestamp", RawType: "timestamp", IsNullable: true},
+			{Name: "amount", DataType: "int", RawType: "int"},
+		},
+		ForeignKeys: []database.ForeignKey{{Table: "orders", Column: "user_id", ReferencedTable: "users", ReferencedColumn: "id"}},
+	}
+
+	tests := []struct {
+		order FieldOrder
+		want  []string
+	}{
+		{"", []string{"Status", "CreatedAt", "UserID", "ID", "ShippedAt", "Amount"}},
+		{FieldOrderOrdinal, []string{"Status", "CreatedAt", "UserID", "ID", "ShippedAt", "Amount"}},
+		{FieldOrderPKFirst, []string{"ID", "Status", "CreatedAt", "UserID", "ShippedAt", "Amount"}},
+		{FieldOrderAlphabetical, []string{"Amount", "CreatedAt", "ID", "ShippedAt", "Status", "UserID"}},
+		{FieldOrderGrouped, []string{"UserID", "ID", "Status", "Amount", "CreatedAt", "ShippedAt"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			gen := NewGeneratorWithConfig(&fakeIntrospector{}, GeneratorConfig{FieldOrder: tt.order})
+			var got []string
+			for _, field := range gen.columnFields(meta) {
+				got = append(got, field.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("field order %q = %v; want %v", tt.order, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/build/constraint"
+	"strings"
+	"text/template"
+)
+
+// DefaultFilePattern is the file name template used when none is configured
+const DefaultFilePattern = "{{.Table}}.go"
+
+// FileNameData is the data available to the file name pattern
+type FileNameData struct {
+	Table     string // snake_case table name without the table prefix, e.g. blog_posts
+	TableName string // Full table name as in the database, e.g. wp_blog_posts
+	Struct    string // Struct name, e.g. BlogPost
+}
+
+// parseFilePattern parses a file name pattern such as {{.Table}}.gen.go.
+// The pattern must produce a .go file name without directories.
+func parseFilePattern(pattern string) (*template.Template, error) {
+	tmpl, err := template.New("file_pattern").Funcs(TemplateFuncs(&TemplateData{})).Parse(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file pattern %q: %w", pattern, err)
+	}
+
+	name, err := executeFilePattern(tmpl, FileNameData{Table: "users", TableName: "users", Struct: "User"})
+	if err != nil {
+		return nil, fmt.Errorf("invalid file pattern %q: %w", pattern, err)
+	}
+	if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("invalid file pattern %q: must produce a non-test .go file name without directories", pattern)
+	}
+	return tmpl, nil
+}
+
+// executeFilePattern renders a parsed file name pattern
+func executeFilePattern(tmpl *template.Template, data FileNameData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// modelFileName returns the file name for a table's model from the file
+// name pattern, falling back to DefaultFilePattern
+func (g *Generator) modelFileName(table string) string {
+	base := g.baseName(table)
+	data := FileNameData{
+		Table:     strings.TrimSuffix(g.namingConv.ToFileName(base), ".go"),
+		TableName: table,
+		Struct:    g.structName(table),
+	}
+	if g.filePattern != nil {
+		if name, err := executeFilePattern(g.filePattern, data); err == nil {
+			return name
+		}
+	}
+	return data.Table + ".go"
+}
+
+// validateBuildTag checks that a build constraint expression (e.g.,
+// "!nomodels" or "linux && cgo") is valid
+func validateBuildTag(expr string) error {
+	if _, err := constraint.Parse("//go:build " + expr); err != nil {
+		return fmt.Errorf("invalid build tag %q: %w", expr, err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFilePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		prefix  string
+		want    string
+	}{
+		{"", "", "users.go"},
+		{"{{.Table}}.gen.go", "", "users.gen.go"},
+		{"{{.Table}}_model.go", "", "users_model.go"},
+		{"{{snake .Struct}}.go", "", "user.go"},
+		{"{{.TableName}}.go", "us", "users.go"},
+		{"{{.Table}}.go", "us", "ers.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			gen := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{FilePattern: tt.pattern, TablePrefix: tt.prefix})
+			if got := filepath.Base(gen.FilePath("users", "models")); got != tt.want {
+				t.Errorf("FilePath() = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilePattern_Invalid(t *testing.T) {
+	for _, pattern := range []string{"{{.Table}", "{{.Table}}.txt", "{{.Table}}_test.go", "models/{{.Table}}.go", "{{.Missing}}.go"} {
+		gen := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{FilePattern: pattern})
+		if _, err := gen.Generate("users"); err == nil {
+			t.Errorf("Generate() with pattern %q: expected error", pattern)
+		}
+	}
+}
+
+func TestBuildTag(t *testing.T) {
+	outputDir := t.TempDir()
+	gen := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{BuildTag: "!nomodels", Header: "Copyright Example"})
+
+	filePath, err := gen.GenerateToFile("users", outputDir)
+	if err != nil {
+		t.Fatalf("GenerateToFile() error = %v", err)
+	}
+	code, _ := os.ReadFile(filePath)
+	if !strings.HasPrefix(string(code), "//go:build !nomodels\n\n// Copyright Example\n") {
+		t.Errorf("generated code does not start with the build constraint:\n%s", code)
+	}
+
+	constants, err := gen.GenerateConstants([]string{"users"})
+	if err != nil {
+		t.Fatalf("GenerateConstants() error = %v", err)
+	}
+	if !strings.HasPrefix(string(constants), "//go:build !nomodels\n\n") {
+		t.Errorf("constants file does not start with the build constraint:\n%s", constants)
+	}
+
+	invalid := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{BuildTag: "linux &&"})
+	if _, err := invalid.Generate("users"); err == nil {
+		t.Error("Generate() with invalid build tag: expected error")
+	}
+}
@@ -0,0 +1,55 @@
+package generator
+
+import "strings"
+
+// FilterField is an optional condition of a model's filter struct (filters)
+type FilterField struct {
+	Name   string // Field name in the filter struct
+	Column string // Filtered column
+	Type   string // Go type the pointer field points to
+}
+
+// applyFilters fills in the fields of the <Model>Filter struct when filters
+// or pagination, which lists through the filter, are enabled. Apply needs
+// gorm, which is added to importMgr.
+func (g *Generator) applyFilters(data *TemplateData, importMgr *ImportManager) {
+	if !g.filters && !g.pagination {
+		return
+	}
+	data.Filters = true
+
+	for _, field := range data.Fields {
+		if field.Column == "" || !filterable(field.Type) {
+			continue
+		}
+		name := field.Name
+		if field.Accessor != "" {
+			name = field.Accessor
+		}
+		data.FilterFields = append(data.FilterFields, FilterField{
+			Name:   name,
+			Column: field.Column,
+			Type:   strings.TrimPrefix(field.Type, "*"),
+		})
+	}
+
+	importMgr.Add(WellKnownImports.GormDriver)
+	data.Imports = importMgr.GenerateImportBlock()
+}
+
+// filterable reports whether an equality condition on a field of the given
+// type makes sense; byte slices, arrays, JSON documents, geometries and
+// vectors are skipped
+func filterable(goType string) bool {
+	goType = strings.TrimPrefix(goType, "*")
+	for _, prefix := range []string{"[]", "map[", "orb.", "pgvector."} {
+		if strings.HasPrefix(goType, prefix) {
+			return false
+		}
+	}
+	switch goType {
+	case "datatypes.JSON", "pgtype.Hstore", "Hstore", "interface{}":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestFilters(t *testing.T) {
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"posts": {
+			Name: "posts",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+				{Name: "title", DataType: "varchar", RawType: "varchar(200)", IsNullable: true},
+				{Name: "published_at", DataType: "timestamp", RawType: "timestamp"},
+				{Name: "body", DataType: "blob", RawType: "blob"},
+				{Name: "meta", DataType: "json", RawType: "json"},
+			},
+		},
+	}}
+
+	code, err := NewGeneratorWithConfig(fake, GeneratorConfig{Filters: true, NullStrategy: NullStrategyPointer}).GenerateString("posts")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if !strings.Contains(code, `"gorm.io/gorm"`) {
+		t.Errorf("gorm import missing:\n%s", code)
+	}
+	code = strings.Join(strings.Fields(code), " ")
+
+	for _, want := range []string{
+		"type PostFilter struct { ID *int32 Title *string PublishedAt *time.Time }",
+		"// db.Scopes(PostFilter{ID: &v}.Apply).Find(&rows)",
+		"func (f PostFilter) Apply(db *gorm.DB) *gorm.DB {",
+		`if f.Title != nil { db = db.Where("posts.title = ?", *f.Title) }`,
+		"return db }",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+
+	plain, err := NewGenerator(fake).GenerateString("posts")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if strings.Contains(plain, "PostFilter") {
+		t.Errorf("filter generated without the option:\n%s", plain)
+	}
+}
+
+func TestFiltersPrivateFields(t *testing.T) {
+	code, err := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{Filters: true, PrivateFields: true}).GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	code = strings.Join(strings.Fields(code), " ")
+	if !strings.Contains(code, `if f.ID != nil { db = db.Where("users.id = ?", *f.ID) }`) {
+		t.Errorf("filter fields should use exported names:\n%s", code)
+	}
+}
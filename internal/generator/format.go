@@ -0,0 +1,28 @@
+package generator
+
+import (
+	"fmt"
+
+	"golang.org/x/tools/imports"
+)
+
+// importsOptions mirrors the defaults used by the goimports command
+var importsOptions = &imports.Options{
+	Comments:   true,
+	TabIndent:  true,
+	TabWidth:   8,
+	FormatOnly: false,
+}
+
+// FormatSource formats generated code like goimports: it gofmt-formats the
+// source, adds missing imports and removes unused ones. This keeps files
+// compilable even when custom templates or type overrides change which
+// packages are referenced. fileName is used to resolve imports relative to
+// the destination and may be a bare file name.
+func FormatSource(fileName string, src []byte) ([]byte, error) {
+	formatted, err := imports.Process(fileName, src, importsOptions)
+	if err != nil {
+		return nil, fmt.Errorf("goimports failed (returning unformatted): %w", err)
+	}
+	return formatted, nil
+}
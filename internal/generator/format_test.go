@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatSource_FixesImports(t *testing.T) {
+	src := []byte(`package models
+
+import "fmt"
+
+type User struct {
+	CreatedAt time.Time
+}
+`)
+
+	formatted, err := FormatSource("users.go", src)
+	if err != nil {
+		t.Fatalf("FormatSource() error = %v", err)
+	}
+
+	code := string(formatted)
+	if !strings.Contains(code, `import "time"`) {
+		t.Errorf("FormatSource() should add missing time import:\n%s", code)
+	}
+	if strings.Contains(code, `"fmt"`) {
+		t.Errorf("FormatSource() should remove unused fmt import:\n%s", code)
+	}
+}
+
+func TestFormatSource_InvalidCode(t *testing.T) {
+	if _, err := FormatSource("broken.go", []byte("package models\n\ntype {")); err == nil {
+		t.Error("FormatSource() should fail on invalid code")
+	}
+}
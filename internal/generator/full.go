@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"go/token"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+)
+
+// Style selects how much code is generated per table
+type Style string
+
+const (
+	// StyleModel generates the model structs only, plus the opted-in helpers (default)
+	StyleModel Style = "model"
+	// StyleFull also generates CRUD functions with column lists and
+	// relationship loaders per table, like sqlboiler; it turns on all
+	// relations unless relations are configured
+	StyleFull Style = "full"
+)
+
+// KeyParam is a primary key column taken by the functions finding a row
+// (full style, repositories)
+type KeyParam struct {
+	Name   string // Parameter name
+	Type   string // Go type of the key field
+	Field  string // Key field of the model
+	Column string // Key column
+}
+
+// Loader loads an association of a model (full style)
+type Loader struct {
+	Field  string // Association field of the model
+	Name   string // Exported association name the loader and Rels entry are named after
+	Struct string // Struct of the associated rows
+	Many   bool   // The field is a slice (has-many)
+}
+
+// fullMethods are the model methods emitted in full style
+var fullMethods = []string{"Insert", "Update", "Delete", "Reload"}
+
+// applyFullStyle fills in the CRUD functions and relationship loaders of a
+// model in full style. Finding, updating, deleting and reloading a single
+// row need a primary key and are left out for tables without one. The
+// functions take a *gorm.DB, which is added to importMgr.
+func (g *Generator) applyFullStyle(data *TemplateData, importMgr *ImportManager) {
+	if g.style != StyleFull {
+		return
+	}
+
+	data.FindFunc = "Find" + data.StructName
+	data.AllFunc = "All" + pluralize(data.StructName)
+	data.CountFunc = "Count" + pluralize(data.StructName)
+
+	g.applyKeyParams(data)
+
+	for _, field := range data.Fields {
+		if field.Column != "" {
+			continue
+		}
+		name := field.Name
+		if field.Accessor != "" {
+			name = field.Accessor
+		}
+		data.Loaders = append(data.Loaders, Loader{
+			Field:  field.Name,
+			Name:   name,
+			Struct: strings.TrimPrefix(strings.TrimPrefix(field.Type, "*"), "[]"),
+			Many:   strings.HasPrefix(field.Type, "[]"),
+		})
+	}
+
+	importMgr.Add(WellKnownImports.GormDriver)
+	data.Imports = importMgr.GenerateImportBlock()
+}
+
+// applyKeyParams fills in the primary key parameters of the functions
+// finding a row and the WHERE clause selecting it, unless already done
+func (g *Generator) applyKeyParams(data *TemplateData) {
+	if data.KeyWhere != "" {
+		return
+	}
+
+	names := columnFieldNames(data.Fields)
+	types := make(map[string]string, len(data.Fields))
+	for _, field := range data.Fields {
+		types[field.Name] = field.Type
+	}
+	var where []string
+	for _, col := range data.Table.Columns {
+		if !col.IsPrimaryKey || names[col.Name] == "" {
+			continue
+		}
+		name := strcase.ToLowerCamel(names[col.Name])
+		if token.IsKeyword(name) || name == "db" || name == "m" || name == "ctx" {
+			name += "Key"
+		}
+		data.KeyParams = append(data.KeyParams, KeyParam{
+			Name:   name,
+			Type:   types[names[col.Name]],
+			Field:  names[col.Name],
+			Column: col.Name,
+		})
+		where = append(where, sqlIdent(data.TableName, g.dialect)+"."+sqlIdent(col.Name, g.dialect)+" = ?")
+	}
+	data.KeyWhere = strings.Join(where, " AND ")
+}
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestFullStyle(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeShop(), GeneratorConfig{Style: StyleFull})
+
+	code, err := gen.GenerateString("orders")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	code = strings.Join(strings.Fields(code), " ")
+	for _, want := range []string{
+		"func FindOrder(db *gorm.DB, id int32) (*Order, error) {",
+		`db.Where("orders.id = ?", id).First(&m)`,
+		"func AllOrders(db *gorm.DB, scopes ...func(*gorm.DB) *gorm.DB) ([]Order, error) {",
+		"func CountOrders(db *gorm.DB, scopes ...func(*gorm.DB) *gorm.DB) (int64, error) {",
+		"func (m *Order) Insert(db *gorm.DB, columns ...string) error { if len(columns) > 0 { db = db.Select(columns) }",
+		`db.Model(m).Select(columns).Omit("id").Updates(m)`,
+		"func (m *Order) Delete(db *gorm.DB) (int64, error) {",
+		`return db.Where("orders.id = ?", m.ID).First(m).Error`,
+		`var OrderRels = struct { User string }{ User: "User", }`,
+		"func (m *Order) LoadUser(db *gorm.DB) error { var related []User",
+		"m.User = &related[0]",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+
+	// Full style turns on has-many relations too
+	code, err = gen.GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if !strings.Contains(code, `return db.Model(m).Association("Orders").Find(&m.Orders)`) {
+		t.Errorf("has-many loader missing:\n%s", code)
+	}
+
+	code, err = NewGeneratorWithConfig(newFakeShop(), GeneratorConfig{}).GenerateString("orders")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if strings.Contains(code, "FindOrder") || strings.Contains(code, "Insert") {
+		t.Errorf("CRUD code generated without full style:\n%s", code)
+	}
+}
+
+func TestFullStyleRenamesClashingFields(t *testing.T) {
+	fake := newFakeUsers()
+	fake.tables["users"].Columns = append(fake.tables["users"].Columns, database.ColumnMetadata{Name: "delete", DataType: "tinyint", RawType: "tinyint"})
+
+	code, err := NewGeneratorWithConfig(fake, GeneratorConfig{Style: StyleFull}).GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if !strings.Contains(code, "DeleteField") {
+		t.Errorf("field clashing with the Delete method not renamed:\n%s", code)
+	}
+}
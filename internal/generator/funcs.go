@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"text/template"
+
+	"github.com/iancoleman/strcase"
+)
+
+// TemplateFuncs returns the helper functions available to struct templates,
+// so custom templates can reuse the generator's naming and type logic.
+// Column helpers look up fields of the table being rendered by column name.
+//
+//	camel "user_id"     -> "userId"
+//	pascal "user_id"    -> "UserID" (Go field naming, with acronyms)
+//	snake "UserID"      -> "user_id"
+//	plural "category"   -> "categories"
+//	singular "users"    -> "user"
+//	hasColumn "deleted_at"
+//	goType "created_at" -> "time.Time"
+//	gormTag "id"        -> "column:id;primaryKey;autoIncrement"
+//	sqlIdent "MyTable"  -> "\"MyTable\"" (quoted for the dialect when needed)
+//	structTag `json:"id"` -> the tag as a Go literal, quoted if it contains a backquote
+func TemplateFuncs(data *TemplateData) template.FuncMap {
+	nc := data.naming
+	if nc == nil {
+		nc = NewNamingConverter()
+	}
+	field := func(column string) (StructField, bool) {
+		for _, f := range data.Fields {
+			if f.Column == column {
+				return f, true
+			}
+		}
+		return StructField{}, false
+	}
+
+	return template.FuncMap{
+		"camel":    strcase.ToLowerCamel,
+		"pascal":   nc.ToGoFieldName,
+		"snake":    strcase.ToSnake,
+		"plural":   pluralize,
+		"singular": singularize,
+		"hasColumn": func(column string) bool {
+			_, ok := field(column)
+			return ok
+		},
+		"goType": func(column string) string {
+			f, _ := field(column)
+			return f.Type
+		},
+		"gormTag": func(column string) string {
+			f, _ := field(column)
+			for _, tag := range parseTags(f.Tags) {
+				if tag.key == "gorm" {
+					return tag.value
+				}
+			}
+			return ""
+		},
+		"sqlIdent": func(name string) string {
+			return sqlIdent(name, data.dialect)
+		},
+		"structTag": tagSource,
+	}
+}
@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	data := &TemplateData{Fields: []StructField{
+		{Name: "ID", Column: "id", Type: "int32", Tags: `gorm:"column:id;primaryKey" json:"id"`},
+	}}
+	funcs := TemplateFuncs(data)
+
+	tests := []struct {
+		name string
+		call func() interface{}
+		want interface{}
+	}{
+		{"camel", func() interface{} { return funcs["camel"].(func(string) string)("user_id") }, "userId"},
+		{"pascal", func() interface{} { return funcs["pascal"].(func(string) string)("user_id") }, "UserID"},
+		{"snake", func() interface{} { return funcs["snake"].(func(string) string)("UserID") }, "user_id"},
+		{"plural", func() interface{} { return funcs["plural"].(func(string) string)("category") }, "categories"},
+		{"plural box", func() interface{} { return funcs["plural"].(func(string) string)("box") }, "boxes"},
+		{"singular", func() interface{} { return funcs["singular"].(func(string) string)("users") }, "user"},
+		{"hasColumn", func() interface{} { return funcs["hasColumn"].(func(string) bool)("id") }, true},
+		{"hasColumn missing", func() interface{} { return funcs["hasColumn"].(func(string) bool)("deleted_at") }, false},
+		{"goType", func() interface{} { return funcs["goType"].(func(string) string)("id") }, "int32"},
+		{"gormTag", func() interface{} { return funcs["gormTag"].(func(string) string)("id") }, "column:id;primaryKey"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.call(); got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerate_CustomTemplate(t *testing.T) {
+	tmplPath := writeTemplate(t, `package {{.PackageName}}
+
+// {{plural .StructName}} lists {{.TableName}}
+type {{.StructName}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} `+"`{{.Tags}}`"+`
+{{- end}}
+}
+{{if hasColumn "email"}}
+const {{camel .StructName}}EmailType = "{{goType "email"}}"
+{{end}}`)
+
+	gen := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{TemplateFile: tmplPath})
+	code, err := gen.GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+
+	for _, want := range []string{"// Users lists users", `const userEmailType = "string"`} {
+		if !strings.Contains(code, want) {
+			t.Errorf("GenerateString() missing %q:\n%s", want, code)
+		}
+	}
+	if strings.Contains(code, "TableName()") {
+		t.Errorf("GenerateString() should not use the built-in template:\n%s", code)
+	}
+}
+
+// writeTemplate writes a custom template to a temporary file and returns its path
+func writeTemplate(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "struct.tmpl")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
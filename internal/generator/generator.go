@@ -2,38 +2,150 @@ package generator
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
-	"go/format"
 	"os"
 	"path/filepath"
+	"sync"
 	"text/template"
 
+	"github.com/rowjak/godb-orm/internal/config"
 	"github.com/rowjak/godb-orm/internal/database"
 )
 
 // Generator handles the generation of Go struct files from database tables
 type Generator struct {
-	introspector database.DBIntrospector
-	typeMapper   *TypeMapper
-	tagBuilder   *TagBuilder
-	namingConv   *NamingConverter
-	packageName  string
+	introspector   database.DBIntrospector
+	typeMapper     *TypeMapper
+	tagBuilder     *TagBuilder
+	namingConv     *NamingConverter
+	packageName    string
+	importPath     string
+	useCache       bool
+	scanHelpers    bool
+	queryBuilder   QueryBuilder
+	relationMode   RelationMode
+	relationRules  map[string]config.RelationRule
+	inferRelations bool
+	keyColumns     map[string][]string // Table -> id and *_id columns, built on first use by keyColumnIndex
+	keyColumnsMu   sync.Mutex          // Guards keyColumns, as tables are generated concurrently
+	overrides      map[string]config.TableOverride
+	templateFile   string
+	header         string
+	footer         string
+	goGenerate     bool
+	typeRules      []config.TypeRule
+	hstoreMode     HstoreMode
+	vectorMode     VectorMode
+	spatialMode    SpatialMode
+	bitMode        BitMode
+	dateTimeMode   DateTimeMode
+	tablePrefix    string
+	subpackages    []config.SubpackageRule
+	fieldOrder     FieldOrder
+	maxLineWidth   int
+	filePattern    *template.Template // Parsed file name pattern (nil uses DefaultFilePattern)
+	filePatternSrc string
+	buildTag       string
+	hooks          bool
+	scopes         bool
+	tenantCol      string
+	withTx         bool
+	factories      bool
+	privateFields  bool
+	filters        bool
+	pagination     bool
+	swagger        bool
+	style          Style
+	repositories   bool
+	mockTool       MockTool
+	noPrimaryKey   NoPrimaryKeyMode
+	sensitive      []string
+	writeOnly      bool
+	dialect        string    // SQL dialect of the schema, if the introspector reports it
+	decls          declCache // Declarations of the files in output directories, for CheckConflicts
+	err            error     // Invalid configuration, reported by every generation
 }
 
 // GeneratorConfig holds configuration for the generator
 type GeneratorConfig struct {
-	PackageName string
+	PackageName    string
+	ImportPath     string       // Fully qualified import path of the models package (optional)
+	UseCache       bool         // Skip tables whose schema is unchanged since the last run
+	ScanHelpers    bool         // Emit Columns() and ScanRow() helpers for database/sql users
+	QueryBuilder   QueryBuilder // Emit SelectColumns(), InsertColumns() and InsertValues() for squirrel or goqu
+	NullStrategy   NullStrategy
+	TagStyle       TagStyle
+	Relations      RelationMode                    // Which association fields to emit (default none)
+	InferRelations bool                            // Infer relations from <singular_table>_id columns (implies belongs_to relations)
+	RelationRules  map[string]config.RelationRule  // Per-table allow/deny lists for association fields
+	Overrides      map[string]config.TableOverride // Per-table customizations such as excluded columns
+	TemplateFile   string                          // Custom struct template replacing StructTemplate (optional)
+	Header         string                          // Template prepended to every generated file, as comments
+	Footer         string                          // Template appended to every generated file, as comments
+	GoGenerate     bool                            // Add a //go:generate line regenerating the table
+	TypeRules      []config.TypeRule               // Regexp-based type mappings taking precedence over the built-in ones
+	Hstore         HstoreMode                      // Go type for hstore columns (default pgtype)
+	Vector         VectorMode                      // Go type for pgvector columns (default pgvector)
+	Spatial        SpatialMode                     // Go type for MySQL spatial columns (default wkb)
+	Bit            BitMode                         // Go type for MySQL BIT(n>1) columns (default bytes)
+	DateTime       DateTimeMode                    // Go type for date-time columns without a time zone (default time)
+	AutoCreate     []string                        // Columns tagged autoCreateTime (nil uses DefaultAutoCreateTimeColumns)
+	AutoUpdate     []string                        // Columns tagged autoUpdateTime (nil uses DefaultAutoUpdateTimeColumns)
+	TablePrefix    string                          // Prefix stripped from struct and file names (e.g., wp_)
+	Acronyms       []string                        // Acronyms upcased in Go names besides the built-in ones (-NAME removes one)
+	NoAcronyms     bool                            // Leave names in plain PascalCase, upcasing no acronyms
+	Subpackages    []config.SubpackageRule         // Route tables matching a pattern into subpackages (first match wins)
+	FieldOrder     FieldOrder                      // Order of column fields (default ordinal)
+	MaxLineWidth   int                             // Width struct field lines should fit in (0 for no limit)
+	FilePattern    string                          // File name template, e.g. {{.Table}}.gen.go (default DefaultFilePattern)
+	BuildTag       string                          // Build constraint added as //go:build to every generated file (optional)
+	Hooks          bool                            // Emit a BeforeCreate hook assigning uuid.New() to UUID primary keys
+	Scopes         bool                            // Emit a TenantScope scope for tables with the tenant column
+	TenantColumn   string                          // Column scoped by TenantScope (default DefaultTenantColumn)
+	WithTx         bool                            // Emit a WithTx transaction helper per model
+	Factories      bool                            // Emit a New<Model> constructor with functional options per column
+	PrivateFields  bool                            // Emit unexported fields with getters, setters and ToMap()
+	Filters        bool                            // Emit a <Model>Filter struct with an Apply(*gorm.DB) method
+	Pagination     bool                            // Emit a List<Models> helper returning a page and the total count
+	Swagger        bool                            // Annotate models for swaggo: model comments, format and example tags
+	Style          Style                           // How much code is generated per table (default model)
+	Repositories   bool                            // Emit a <Model>Repository interface with a GORM implementation
+	MockTool       MockTool                        // Mock generator of the repositories' go:generate line (default mockery)
+	NoPrimaryKey   NoPrimaryKeyMode                // How tables without a primary key are generated (default generate)
+	GormOptions    []string                        // Optional gorm tag options to emit (nil uses DefaultGormOptions)
+	ExtraTags      []string                        // Extra tag sets emitted after the JSON tag, e.g. yaml or xml:camel
+	Sensitive      []string                        // Column patterns (e.g., *password*) tagged json:"-"
+	WriteOnly      bool                            // Also tag sensitive columns gorm:"->:false" so they are never read back
 }
 
 // NewGenerator creates a new Generator instance
 func NewGenerator(introspector database.DBIntrospector) *Generator {
-	return &Generator{
+	g := &Generator{
 		introspector: introspector,
 		typeMapper:   NewTypeMapper(),
 		tagBuilder:   NewTagBuilder(),
 		namingConv:   NewNamingConverter(),
-		packageName:  "models",
+		packageName:  DefaultPackageName,
+	}
+	if d, ok := introspector.(database.Dialecter); ok {
+		g.dialect = d.Dialect()
+	}
+	if g.dialect == "mysql" {
+		g.typeMapper.SetSpatialMode(SpatialWKB)
+		g.typeMapper.SetBitMode(BitBytes)
+	}
+	// Trino is queried, never written through GORM, so models are plain structs
+	if g.dialect == "trino" {
+		g.tagBuilder.noGormTag = true
 	}
+	// MongoDB documents are read and written through the driver's bson codec
+	if g.dialect == "mongodb" {
+		g.tagBuilder.noGormTag = true
+		g.tagBuilder.bsonTag = true
+		g.typeMapper.SetDocumentTypes()
+	}
+	return g
 }
 
 // NewGeneratorWithConfig creates a new Generator with custom configuration
@@ -41,10 +153,128 @@ func NewGeneratorWithConfig(introspector database.DBIntrospector, cfg GeneratorC
 	g := NewGenerator(introspector)
 	if cfg.PackageName != "" {
 		g.packageName = cfg.PackageName
+		if err := validatePackageName(cfg.PackageName); err != nil && g.err == nil {
+			g.err = err
+		}
+	}
+	g.importPath = cfg.ImportPath
+	g.useCache = cfg.UseCache
+	g.scanHelpers = cfg.ScanHelpers
+	g.queryBuilder = cfg.QueryBuilder
+	if err := validateQueryBuilder(cfg.QueryBuilder); err != nil && g.err == nil {
+		g.err = err
+	}
+	g.typeMapper.SetNullStrategy(cfg.NullStrategy)
+	g.tagBuilder.SetTagStyle(cfg.TagStyle)
+	g.tagBuilder.SetAuditColumns(cfg.AutoCreate, cfg.AutoUpdate)
+	g.relationMode = cfg.Relations
+	g.inferRelations = cfg.InferRelations
+	if cfg.InferRelations && (g.relationMode == "" || g.relationMode == RelationsNone) {
+		g.relationMode = RelationsBelongsTo
+	}
+	g.relationRules = cfg.RelationRules
+	g.overrides = cfg.Overrides
+	g.templateFile = cfg.TemplateFile
+	g.header = cfg.Header
+	g.footer = cfg.Footer
+	g.goGenerate = cfg.GoGenerate
+	g.typeRules = cfg.TypeRules
+	g.hstoreMode = cfg.Hstore
+	g.typeMapper.SetHstoreMode(cfg.Hstore)
+	g.vectorMode = cfg.Vector
+	g.typeMapper.SetVectorMode(cfg.Vector)
+	g.spatialMode = cfg.Spatial
+	g.dateTimeMode = cfg.DateTime
+	g.typeMapper.SetDateTimeMode(cfg.DateTime, g.dialect)
+	g.bitMode = cfg.Bit
+	g.fieldOrder = cfg.FieldOrder
+	g.maxLineWidth = cfg.MaxLineWidth
+	g.tablePrefix = cfg.TablePrefix
+	g.namingConv.SetAcronyms(cfg.Acronyms, cfg.NoAcronyms)
+	g.hooks = cfg.Hooks
+	g.scopes = cfg.Scopes
+	g.tenantCol = cfg.TenantColumn
+	g.withTx = cfg.WithTx
+	g.factories = cfg.Factories
+	g.privateFields = cfg.PrivateFields
+	if err := validatePrivateFields(cfg); err != nil && g.err == nil {
+		g.err = err
+	}
+	g.filters = cfg.Filters
+	g.pagination = cfg.Pagination
+	g.swagger = cfg.Swagger
+	g.style = cfg.Style
+	g.repositories = cfg.Repositories
+	g.mockTool = cfg.MockTool
+	if err := validateMockTool(cfg.MockTool); err != nil && g.err == nil {
+		g.err = err
+	}
+	if cfg.Style == StyleFull && (g.relationMode == "" || g.relationMode == RelationsNone) {
+		g.relationMode = RelationsAll
+	}
+	g.noPrimaryKey = cfg.NoPrimaryKey
+	if err := g.tagBuilder.SetGormOptions(cfg.GormOptions); err != nil && g.err == nil {
+		g.err = err
+	}
+	if err := g.tagBuilder.SetExtraTags(cfg.ExtraTags); err != nil && g.err == nil {
+		g.err = err
+	}
+	g.sensitive = cfg.Sensitive
+	g.writeOnly = cfg.WriteOnly
+	for _, pattern := range cfg.Sensitive {
+		if err := validateSensitivePattern(pattern); err != nil && g.err == nil {
+			g.err = err
+		}
+	}
+	if g.dialect == "mysql" {
+		g.typeMapper.SetSpatialMode(cfg.Spatial)
+		g.typeMapper.SetBitMode(cfg.Bit)
+	}
+	g.subpackages = cfg.Subpackages
+	for _, rule := range cfg.Subpackages {
+		if err := validateSubpackage(rule); err != nil && g.err == nil {
+			g.err = err
+		}
+	}
+	if cfg.FilePattern != "" {
+		tmpl, err := parseFilePattern(cfg.FilePattern)
+		if err != nil && g.err == nil {
+			g.err = err
+		}
+		g.filePattern = tmpl
+		g.filePatternSrc = cfg.FilePattern
+	}
+	if cfg.BuildTag != "" {
+		if err := validateBuildTag(cfg.BuildTag); err != nil && g.err == nil {
+			g.err = err
+		}
+		g.buildTag = cfg.BuildTag
+	}
+	for _, rule := range cfg.TypeRules {
+		if err := g.typeMapper.AddTypeRule(rule.Match, rule.Type, rule.Import); err != nil && g.err == nil {
+			g.err = err
+		}
 	}
 	return g
 }
 
+// Err returns the configuration error (e.g., an invalid type rule or file
+// pattern) that every generation reports, or nil
+func (g *Generator) Err() error {
+	return g.err
+}
+
+// PackageName returns the package name used for generated files
+func (g *Generator) PackageName() string {
+	return g.packageName
+}
+
+// ImportPath returns the fully qualified import path of the models package,
+// or an empty string if it is unknown
+func (g *Generator) ImportPath() string {
+	return g.importPath
+}
+
 // GeneratedFile represents a generated Go file
 type GeneratedFile struct {
 	FileName    string
@@ -60,37 +290,98 @@ type GeneratedFile struct {
 // This is the main entry point as specified in Tahap 3 Tugas 3
 func (g *Generator) Generate(tableName string) ([]byte, error) {
 	// Get table metadata
-	meta, err := g.introspector.GetTableMetadata(tableName)
+	meta, err := g.tableMetadata(tableName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get table metadata: %w", err)
 	}
 
+	return g.GenerateFromMetadata(meta)
+}
+
+// GenerateFromMetadata generates Go struct code from already-fetched table metadata
+func (g *Generator) GenerateFromMetadata(meta *database.TableMetadata) ([]byte, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+
+	meta, err := g.applyLogicalKey(meta)
+	if err != nil {
+		return nil, err
+	}
+	noPrimaryKey := g.noPrimaryKeyMode(meta)
+	if noPrimaryKey == NoPrimaryKeySkip {
+		return nil, ErrNoPrimaryKey
+	}
+
+	tableName := meta.Name
+
 	// Build struct fields
-	var fields []StructField
-	for _, col := range meta.Columns {
-		field := g.tagBuilder.BuildStructField(col, g.typeMapper)
-		// Use strcase-based naming for field names
-		field.Name = g.namingConv.ToGoFieldName(col.Name)
-		fields = append(fields, field)
+	fields := g.columnFields(meta)
+	enums := g.applyEnums(meta, fields)
+	if noPrimaryKey == NoPrimaryKeyReadOnly {
+		for i := range fields {
+			fields[i].Tags = readOnlyTags(fields[i].Tags)
+		}
 	}
+	fields = append(fields, g.buildRelationFields(meta, fields)...)
+	g.privatizeFields(fields)
+	g.fitLineWidth(meta, fields)
 
 	// Detect required imports using smart import detection
 	importMgr := DetectRequiredImports(fields)
+	if g.scanHelpers {
+		importMgr.Add(WellKnownImports.SQL)
+	}
 
 	// Build template data
 	templateData := &TemplateData{
-		PackageName: g.packageName,
+		PackageName: g.filePackage(tableName),
 		Imports:     importMgr.GenerateImportBlock(),
-		StructName:  g.namingConv.ToGoStructName(tableName),
+		StructName:  g.structName(tableName),
 		TableName:   tableName,
 		Fields:      fields,
 		HasTime:     importMgr.Has(WellKnownImports.Time),
 		HasJSON:     importMgr.Has(WellKnownImports.Datatypes),
 		HasUUID:     importMgr.Has(WellKnownImports.UUID),
+		ScanHelpers: g.scanHelpers,
+		Table:       meta,
+		Doc:         DocLines(meta.Comment),
+
+		PrivateFields: g.privateFields,
+		Swagger:       g.swagger,
+		Enums:         enums,
+
+		naming:  g.namingConv,
+		dialect: g.dialect,
+	}
+	if noPrimaryKey == NoPrimaryKeyReadOnly {
+		if len(templateData.Doc) > 0 {
+			templateData.Doc = append(templateData.Doc, "")
+		}
+		templateData.Doc = append(templateData.Doc, readOnlyDoc)
 	}
+	g.applyScaffold(templateData, importMgr)
+	g.applyFactories(templateData)
+	g.applyFilters(templateData, importMgr)
+	g.applyPagination(templateData)
+	g.applyFullStyle(templateData, importMgr)
+	g.applyQueryBuilder(templateData, importMgr)
+	g.applyRepository(templateData, importMgr)
 
-	// Render template
-	tmpl, err := template.New("struct").Parse(StructTemplate)
+	if err := g.applyBanners(templateData); err != nil {
+		return nil, err
+	}
+
+	// Render template; the banner partials are parsed first so custom
+	// templates can use or redefine them
+	text, err := g.structTemplate()
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New("struct").Funcs(TemplateFuncs(templateData)).Parse(BannerTemplates)
+	if err == nil {
+		tmpl, err = tmpl.Parse(text)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template: %w", err)
 	}
@@ -100,17 +391,56 @@ func (g *Generator) Generate(tableName string) ([]byte, error) {
 		return nil, fmt.Errorf("failed to execute template: %w", err)
 	}
 
-	// Format with go/format for proper indentation
-	formatted, err := format.Source(buf.Bytes())
+	// Format and fix imports (goimports-equivalent) for proper indentation
+	formatted, err := FormatSource(g.fileName(tableName), buf.Bytes())
 	if err != nil {
 		// If formatting fails, return unformatted with warning in content
 		// This allows debugging of template issues
-		return buf.Bytes(), fmt.Errorf("go/format failed (returning unformatted): %w", err)
+		return buf.Bytes(), err
 	}
 
 	return formatted, nil
 }
 
+// columnFields builds the struct fields for the columns of a table
+func (g *Generator) columnFields(meta *database.TableMetadata) []StructField {
+	override := g.TableOverride(meta.Name)
+
+	cols := g.columns(meta)
+	names := g.fieldNames(cols)
+	var fields []StructField
+	for i, col := range cols {
+		field := g.tagBuilder.BuildStructField(col, g.typeMapper)
+		field.Name = names[i].name
+		if names[i].note != "" {
+			field.Doc = append(field.Doc, names[i].note)
+		}
+		if g.isSensitive(meta.Name, col.Name) {
+			field.Tags = maskTags(field.Tags, g.tagBuilder.extraTagKeys(), g.writeOnly)
+		}
+		applyColumnOverride(&field, override.Column(col.Name))
+		if g.swagger {
+			addSwaggerTags(&field, col)
+		}
+		fields = append(fields, field)
+	}
+	return g.orderFields(meta, cols, fields)
+}
+
+// structTemplate returns the custom struct template if one is configured,
+// otherwise StructTemplate. The file is read on every call so edits are
+// picked up without restarting the GUI.
+func (g *Generator) structTemplate() (string, error) {
+	if g.templateFile == "" {
+		return StructTemplate, nil
+	}
+	data, err := os.ReadFile(g.templateFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read template: %w", err)
+	}
+	return string(data), nil
+}
+
 // GenerateString generates Go struct code and returns as string
 func (g *Generator) GenerateString(tableName string) (string, error) {
 	bytes, err := g.Generate(tableName)
@@ -123,45 +453,132 @@ func (g *Generator) GenerateString(tableName string) (string, error) {
 // GenerateToFile generates and writes the Go struct to a file
 // File name uses snake_case as specified in Tahap 3 Tugas 4
 func (g *Generator) GenerateToFile(tableName, outputDir string) (string, error) {
-	// Generate formatted code
-	content, err := g.Generate(tableName)
+	files, err := g.GenerateFiles(tableName, outputDir)
 	if err != nil {
 		return "", err
 	}
+	if err := g.CheckConflicts(files); err != nil {
+		return "", err
+	}
+
+	// The model and its helper files are replaced together
+	if err := WriteFiles(files); err != nil {
+		return "", err
+	}
+	return files[0].Path, nil
+}
+
+// IsUpToDate reports whether the file for a table in outputDir already matches
+// the freshly generated code. A missing file is reported as out of date.
+func (g *Generator) IsUpToDate(tableName, outputDir string) (bool, error) {
+	content, err := g.Generate(tableName)
+	if err != nil {
+		return false, err
+	}
+
+	filePath := g.FilePath(tableName, outputDir)
+	existing, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read file: %w", err)
+	}
 
-	// Create output directory if it doesn't exist
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return "", fmt.Errorf("failed to create output directory: %w", err)
+	// Hand-added tags and fields don't make a file outdated
+	content, err = MergePreserved(filepath.Base(filePath), content, existing)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(existing, content), nil
+}
+
+// GenerateToFileIncremental writes the Go struct for a table unless the cache
+// shows that its schema (and the generator settings) are unchanged and the
+// file still exists. It reports whether the file was (re)written.
+func (g *Generator) GenerateToFileIncremental(tableName, outputDir string, cache *Cache) (string, bool, error) {
+	filePath := g.FilePath(tableName, outputDir)
+
+	meta, err := g.tableMetadata(tableName)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get table metadata: %w", err)
+	}
+
+	hash, err := g.metadataHash(meta)
+	if err != nil {
+		return "", false, err
 	}
 
-	// Generate file name using snake_case
-	fileName := g.namingConv.ToFileName(tableName)
-	filePath := filepath.Join(outputDir, fileName)
+	if cache.Matches(tableName, hash) {
+		if _, err := os.Stat(filePath); err == nil {
+			return filePath, false, nil
+		}
+	}
+
+	content, err := g.GenerateFromMetadata(meta)
+	if err != nil {
+		return "", false, err
+	}
+	content, err = MergeExisting(content, filePath)
+	if err != nil {
+		return "", false, err
+	}
 
-	// Write file
-	if err := os.WriteFile(filePath, content, 0644); err != nil {
-		return "", fmt.Errorf("failed to write file: %w", err)
+	support, err := g.supportOutputs(meta, filePath)
+	if err != nil {
+		return "", false, err
+	}
+	files := append([]OutputFile{{Path: filePath, Content: content, Table: tableName}}, support...)
+	if err := g.CheckConflicts(files); err != nil {
+		return "", false, err
+	}
+	if err := WriteFiles(files); err != nil {
+		return "", false, err
 	}
 
-	return filePath, nil
+	cache.Set(tableName, hash)
+	return filePath, true, nil
 }
 
-// GenerateAll generates Go structs for all tables
+// GenerateAll generates Go structs for all tables.
+// When caching is enabled, tables whose schema is unchanged are skipped.
 func (g *Generator) GenerateAll(outputDir string) ([]string, error) {
 	tables, err := g.introspector.GetTables()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tables: %w", err)
 	}
 
+	var cache *Cache
+	if g.useCache {
+		cache, err = LoadCache(outputDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var filePaths []string
 	for _, table := range tables {
-		filePath, err := g.GenerateToFile(table, outputDir)
+		var filePath string
+		if cache != nil {
+			filePath, _, err = g.GenerateToFileIncremental(table, outputDir, cache)
+		} else {
+			filePath, err = g.GenerateToFile(table, outputDir)
+		}
+		if errors.Is(err, ErrNoPrimaryKey) {
+			continue
+		}
 		if err != nil {
 			return filePaths, fmt.Errorf("failed to generate %s: %w", table, err)
 		}
 		filePaths = append(filePaths, filePath)
 	}
 
+	if cache != nil {
+		if err := cache.Save(); err != nil {
+			return filePaths, err
+		}
+	}
+
 	return filePaths, nil
 }
 
@@ -0,0 +1,151 @@
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestGenerate_ScanHelpers(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{ScanHelpers: true})
+
+	code, err := gen.GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+
+	expected := []string{
+		`"database/sql"`,
+		"func (User) Columns() []string {",
+		`"id",`,
+		`"email",`,
+		"func (User) ScanRow(rows *sql.Rows) (*User, error) {",
+		"&m.ID,",
+		"&m.Email,",
+	}
+	for _, want := range expected {
+		if !strings.Contains(code, want) {
+			t.Errorf("GenerateString() missing %q:\n%s", want, code)
+		}
+	}
+}
+
+func TestGenerate_NoScanHelpersByDefault(t *testing.T) {
+	gen := NewGenerator(newFakeUsers())
+
+	code, err := gen.GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if strings.Contains(code, "ScanRow") || strings.Contains(code, "database/sql") {
+		t.Errorf("GenerateString() should not emit scan helpers by default:\n%s", code)
+	}
+}
+
+func TestGenerate_ExcludeColumns(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{
+		Overrides: map[string]config.TableOverride{"users": {ExcludeColumns: []string{"email"}}},
+	})
+
+	code, err := gen.GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if strings.Contains(code, "Email") {
+		t.Errorf("GenerateString() should skip excluded columns:\n%s", code)
+	}
+
+	gen.SetTableOverride("users", config.TableOverride{})
+	code, err = gen.GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if !strings.Contains(code, "Email") {
+		t.Errorf("GenerateString() should include columns once the override is cleared:\n%s", code)
+	}
+}
+
+func TestGenerate_DocComments(t *testing.T) {
+	fake := newFakeUsers()
+	fake.tables["users"].Comment = "Registered users\r\nincluding admins"
+	fake.tables["users"].Columns[1].Comment = "Login address.\n\nMust be unique.\x00"
+
+	code, err := NewGenerator(fake).GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+
+	expected := []string{
+		"// User represents the users table\n//\n// Registered users\n// including admins\ntype User struct {",
+		"\t// Login address.\n\t//\n\t// Must be unique.\n\tEmail",
+	}
+	for _, want := range expected {
+		if !strings.Contains(code, want) {
+			t.Errorf("GenerateString() missing %q:\n%s", want, code)
+		}
+	}
+}
+
+func TestDocLines(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    []string
+	}{
+		{"", nil},
+		{"  \n ", nil},
+		{"Primary key", []string{"Primary key"}},
+		{"\nfirst\r\n\tsecond  \n", []string{"first", " second"}},
+	}
+	for _, tt := range tests {
+		got := DocLines(tt.comment)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") || len(got) != len(tt.want) {
+			t.Errorf("DocLines(%q) = %q, want %q", tt.comment, got, tt.want)
+		}
+	}
+}
+
+func TestGenerate_InvalidTypeRule(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{
+		TypeRules: []config.TypeRule{{Match: "[", Type: "string"}},
+	})
+	if _, err := gen.GenerateString("users"); err == nil || !strings.Contains(err.Error(), "invalid type rule") {
+		t.Errorf("GenerateString() error = %v, want invalid type rule", err)
+	}
+}
+
+func TestGenerate_Deterministic(t *testing.T) {
+	def := "('pending'::character varying)"
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"api_requests": {
+			Name: "api_requests",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+				{Name: "http_json_uuid", DataType: "uuid", RawType: "uuid"},
+				{Name: "db_ip_url_id", DataType: "varchar", RawType: "varchar(255)"},
+				{Name: "status", DataType: "varchar", RawType: "varchar(20)", DefaultValue: &def},
+				{Name: "payload", DataType: "json", RawType: "json", IsNullable: true},
+				{Name: "created_at", DataType: "timestamp", RawType: "timestamp"},
+			},
+		},
+	}}
+
+	first, err := NewGenerator(fake).Generate("api_requests")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		code, err := NewGenerator(fake).Generate("api_requests")
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if !bytes.Equal(code, first) {
+			t.Fatalf("regeneration %d differs:\n%s\nwant:\n%s", i, code, first)
+		}
+	}
+	if !strings.Contains(string(first), "default:'pending'") {
+		t.Errorf("default value not normalized:\n%s", first)
+	}
+}
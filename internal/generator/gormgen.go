@@ -0,0 +1,148 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"text/template"
+)
+
+// GormGenDir is the directory, inside the output directory, of the program
+// running gorm.io/gen on the generated models
+const GormGenDir = "gormgen"
+
+// GormGenQueryDir is the directory gorm.io/gen writes the query API to, next
+// to the output directory
+const GormGenQueryDir = "query"
+
+// ErrGormGenNoModule is returned by GenerateGormGen when the output directory
+// is not inside a Go module, so the runner can't import the models
+var ErrGormGenNoModule = errors.New("gorm.io/gen needs the output directory inside a Go module (go.mod not found)")
+
+// GormGenTemplate is the template of the gorm.io/gen runner program
+const GormGenTemplate = `// Code generated by godb-orm. DO NOT EDIT.
+
+// Command gormgen generates the type-safe gorm.io/gen query API (field
+// helpers and DAO interfaces) of the models into {{.OutPath}}.
+package main
+
+//go:generate go run .
+
+import (
+	"gorm.io/gen"
+{{range .Packages}}
+	{{.Alias}} {{printf "%q" .ImportPath}}
+{{- end}}
+)
+
+func main() {
+	g := gen.NewGenerator(gen.Config{
+		OutPath: {{printf "%q" .OutPath}},
+		Mode:    gen.WithDefaultQuery | gen.WithQueryInterface,
+	})
+
+	g.ApplyBasic(
+{{- range .Models}}
+		{{.}}{},
+{{- end}}
+	)
+
+	g.Execute()
+}
+`
+
+// GormGenTemplateData holds the data for GormGenTemplate
+type GormGenTemplateData struct {
+	OutPath  string // Query directory, relative to the runner
+	Packages []ModelPackage
+	Models   []string // Qualified struct names, e.g. models.User
+}
+
+// ModelPackage is a models package imported by code using the models
+type ModelPackage struct {
+	Alias      string
+	ImportPath string
+}
+
+// GenerateGormGen renders the gorm.io/gen runner for the given tables. It
+// applies gen to the same structs the models are generated as, so the query
+// API follows the table selection and naming rules of the models, including
+// subpackages.
+func (g *Generator) GenerateGormGen(tableNames []string) ([]byte, error) {
+	if g.importPath == "" {
+		return nil, ErrGormGenNoModule
+	}
+
+	packages, qualifiers := g.modelPackages(tableNames)
+	data := &GormGenTemplateData{OutPath: path.Join("..", "..", GormGenQueryDir), Packages: packages}
+	for _, tableName := range tableNames {
+		data.Models = append(data.Models, qualifiers[tableName]+"."+g.structName(tableName))
+	}
+
+	tmpl, err := template.New("gormgen").Parse(GormGenTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	src, err := g.withBanners("main", buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	formatted, err := FormatSource("main.go", src)
+	if err != nil {
+		return src, err
+	}
+	return formatted, nil
+}
+
+// GenerateGormGenToFile writes the gorm.io/gen runner for the given tables to
+// the GormGenDir directory of outputDir
+func (g *Generator) GenerateGormGenToFile(tableNames []string, outputDir string) (string, error) {
+	content, err := g.GenerateGormGen(tableNames)
+	if err != nil {
+		return "", err
+	}
+
+	filePath := filepath.Join(outputDir, GormGenDir, "main.go")
+	if err := WriteFiles([]OutputFile{{Path: filePath, Content: content}}); err != nil {
+		return "", err
+	}
+	return filePath, nil
+}
+
+// modelPackages returns the packages holding the models of the given
+// tables, which are in several with subpackages, and the alias qualifying
+// the model of each table. Packages with the same name get numbered aliases.
+func (g *Generator) modelPackages(tableNames []string) ([]ModelPackage, map[string]string) {
+	var packages []ModelPackage
+	qualifiers := make(map[string]string, len(tableNames))
+	aliases := make(map[string]string) // import path -> alias
+	taken := make(map[string]bool)
+	for _, tableName := range tableNames {
+		importPath := g.importPath
+		if dir := filepath.Dir(g.fileName(tableName)); dir != "." {
+			importPath = path.Join(importPath, filepath.ToSlash(dir))
+		}
+
+		alias, ok := aliases[importPath]
+		if !ok {
+			alias = g.filePackage(tableName)
+			for i := 2; taken[alias]; i++ {
+				alias = fmt.Sprintf("%s%d", g.filePackage(tableName), i)
+			}
+			aliases[importPath] = alias
+			taken[alias] = true
+			packages = append(packages, ModelPackage{Alias: alias, ImportPath: importPath})
+		}
+		qualifiers[tableName] = alias
+	}
+	return packages, qualifiers
+}
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+)
+
+func TestGenerateGormGen(t *testing.T) {
+	gen := NewGeneratorWithConfig(nil, GeneratorConfig{
+		PackageName: "models",
+		ImportPath:  "github.com/acme/app/models",
+		Subpackages: []config.SubpackageRule{
+			{Match: "billing_*", Dir: "billing", Package: "models"},
+		},
+		Overrides: map[string]config.TableOverride{
+			"people": {StructName: "Person"},
+		},
+	})
+
+	code, err := gen.GenerateGormGen([]string{"users", "people", "billing_invoices"})
+	if err != nil {
+		t.Fatalf("GenerateGormGen() error = %v", err)
+	}
+
+	expected := []string{
+		"// Code generated by godb-orm. DO NOT EDIT.",
+		"package main",
+		"//go:generate go run .",
+		`"gorm.io/gen"`,
+		`models "github.com/acme/app/models"`,
+		`models2 "github.com/acme/app/models/billing"`,
+		`OutPath: "../../query",`,
+		"models.User{},",
+		"models.Person{},",
+		"models2.BillingInvoice{},",
+	}
+	for _, want := range expected {
+		if !strings.Contains(string(code), want) {
+			t.Errorf("GenerateGormGen() missing %q:\n%s", want, code)
+		}
+	}
+
+	if _, err := NewGenerator(nil).GenerateGormGen([]string{"users"}); !errors.Is(err, ErrGormGenNoModule) {
+		t.Errorf("GenerateGormGen() outside a module error = %v; want ErrGormGenNoModule", err)
+	}
+}
@@ -0,0 +1,120 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// Selectable gorm tag options (generator.gorm_tag). Options GORM needs to
+// map a field correctly (primaryKey, autoIncrement, serializer, audit
+// timestamps) are always emitted.
+const (
+	GormOptionColumn    = "column"    // column:<name>, kept when GORM can't derive it from the field name
+	GormOptionType      = "type"      // type:<raw database type>
+	GormOptionDefault   = "default"   // default:<value>
+	GormOptionNotNull   = "not_null"  // not null
+	GormOptionSize      = "size"      // size:<length> for char and varchar columns
+	GormOptionPrecision = "precision" // precision:<p>;scale:<s> for decimal and numeric columns
+	GormOptionComment   = "comment"   // comment:<column comment>
+)
+
+// DefaultGormOptions are the gorm tag options emitted when none are configured
+var DefaultGormOptions = []string{GormOptionColumn, GormOptionType, GormOptionDefault, GormOptionNotNull}
+
+// knownGormOptions lists every selectable gorm tag option
+var knownGormOptions = []string{GormOptionColumn, GormOptionType, GormOptionDefault, GormOptionNotNull, GormOptionSize, GormOptionPrecision, GormOptionComment}
+
+// SetGormOptions selects the optional gorm tag options to emit. A nil list
+// keeps DefaultGormOptions. It returns an error for unknown options.
+func (tb *TagBuilder) SetGormOptions(options []string) error {
+	if options == nil {
+		return nil
+	}
+
+	selected := make(map[string]bool, len(options))
+	for _, option := range options {
+		option = strings.ToLower(strings.TrimSpace(option))
+		if !isKnownGormOption(option) {
+			return fmt.Errorf("unknown gorm tag option %q (expected one of %s)", option, strings.Join(knownGormOptions, ", "))
+		}
+		selected[option] = true
+	}
+	tb.gormOptions = selected
+	return nil
+}
+
+// emits reports whether an optional gorm tag option is selected
+func (tb *TagBuilder) emits(option string) bool {
+	if tb.gormOptions == nil {
+		for _, def := range DefaultGormOptions {
+			if def == option {
+				return true
+			}
+		}
+		return false
+	}
+	return tb.gormOptions[option]
+}
+
+// needsColumnOption reports whether the column: option must be emitted:
+// either it is selected, or GORM's naming strategy would not arrive at the
+// column name from the generated field name
+func (tb *TagBuilder) needsColumnOption(col database.ColumnMetadata) bool {
+	return tb.emits(GormOptionColumn) || strcase.ToSnake(strcase.ToCamel(col.Name)) != col.Name
+}
+
+// isKnownGormOption reports whether option can be selected in generator.gorm_tag
+func isKnownGormOption(option string) bool {
+	for _, known := range knownGormOptions {
+		if known == option {
+			return true
+		}
+	}
+	return false
+}
+
+// sizeOption returns the size: option for a char or varchar column, derived
+// from its maximum length, or an empty string for other columns
+func sizeOption(col database.ColumnMetadata) string {
+	if col.CharMaxLength == nil || *col.CharMaxLength <= 0 || !strings.Contains(strings.ToLower(col.DataType), "char") {
+		return ""
+	}
+	return fmt.Sprintf("size:%d", *col.CharMaxLength)
+}
+
+// precisionOptions returns the precision: and scale: options for a decimal
+// or numeric column with a declared precision, or nil for other columns
+func precisionOptions(col database.ColumnMetadata) []string {
+	switch strings.ToLower(col.DataType) {
+	case "decimal", "numeric":
+	default:
+		return nil
+	}
+	if col.NumericPrecision == nil || *col.NumericPrecision <= 0 {
+		return nil
+	}
+
+	options := []string{fmt.Sprintf("precision:%d", *col.NumericPrecision)}
+	if col.NumericScale != nil {
+		options = append(options, fmt.Sprintf("scale:%d", *col.NumericScale))
+	}
+	return options
+}
+
+// commentOption returns the comment: option for a column comment, or an
+// empty string if the column has none. The comment is folded onto one line
+// and escaped for both GORM (\; separates options) and the quoted struct
+// tag; backquotes, which would end the tag literal, become single quotes.
+func commentOption(comment string) string {
+	comment = strings.Join(strings.Fields(comment), " ")
+	if comment == "" {
+		return ""
+	}
+	comment = strings.ReplaceAll(comment, "`", "'")
+	comment = strings.ReplaceAll(comment, ";", `\;`)
+	comment = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(comment)
+	return "comment:" + comment
+}
@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestSetGormOptions(t *testing.T) {
+	status, size := "'active'", 20
+	cols := []database.ColumnMetadata{
+		{Name: "id", RawType: "bigint", IsPrimaryKey: true, IsAutoIncrement: true},
+		{Name: "status", DataType: "varchar", RawType: "varchar(20)", CharMaxLength: &size, DefaultValue: &status},
+		{Name: "UserName", RawType: "text", IsNullable: true},
+	}
+
+	tests := []struct {
+		name    string
+		options []string
+		want    []string
+	}{
+		{
+			name: "default",
+			want: []string{
+				`gorm:"primaryKey;autoIncrement;column:id;type:bigint"`,
+				`gorm:"column:status;type:varchar(20);default:'active';not null"`,
+				`gorm:"column:UserName;type:text"`,
+			},
+		},
+		{
+			name:    "minimal",
+			options: []string{},
+			want: []string{
+				`gorm:"primaryKey;autoIncrement"`,
+				``,
+				`gorm:"column:UserName"`,
+			},
+		},
+		{
+			name:    "size instead of type",
+			options: []string{"column", "size", "default", "not_null"},
+			want: []string{
+				`gorm:"primaryKey;autoIncrement;column:id"`,
+				`gorm:"column:status;size:20;default:'active';not null"`,
+				`gorm:"column:UserName"`,
+			},
+		},
+		{
+			name:    "type and not null",
+			options: []string{"type", "NOT_NULL"},
+			want: []string{
+				`gorm:"primaryKey;autoIncrement;type:bigint"`,
+				`gorm:"type:varchar(20);not null"`,
+				`gorm:"column:UserName;type:text"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := NewTagBuilder()
+			if err := tb.SetGormOptions(tt.options); err != nil {
+				t.Fatalf("SetGormOptions() error = %v", err)
+			}
+			for i, col := range cols {
+				if got := tb.BuildGormTag(col); got != tt.want[i] {
+					t.Errorf("BuildGormTag(%s) = %q; want %q", col.Name, got, tt.want[i])
+				}
+			}
+		})
+	}
+
+	if err := NewTagBuilder().SetGormOptions([]string{"index"}); err == nil {
+		t.Error("SetGormOptions(index): expected error")
+	}
+}
+
+func TestGormOptions_OmitsEmptyTag(t *testing.T) {
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"notes": {Name: "notes", Columns: []database.ColumnMetadata{
+			{Name: "body", DataType: "text", RawType: "text", IsNullable: true},
+		}},
+	}}
+	gen := NewGeneratorWithConfig(fake, GeneratorConfig{GormOptions: []string{}})
+	meta, _ := fake.GetTableMetadata("notes")
+	if got := gen.columnFields(meta)[0].Tags; got != `json:"body"` {
+		t.Errorf("Tags = %q; want only the json tag", got)
+	}
+
+	gen = NewGeneratorWithConfig(fake, GeneratorConfig{GormOptions: []string{"index"}})
+	if err := gen.Err(); err == nil {
+		t.Error("Err() = nil; want unknown gorm tag option")
+	}
+}
+
+func TestSizeOption(t *testing.T) {
+	length := func(n int) *int { return &n }
+	tests := []struct {
+		col  database.ColumnMetadata
+		want string
+	}{
+		{database.ColumnMetadata{DataType: "varchar", CharMaxLength: length(255)}, "size:255"},
+		{database.ColumnMetadata{DataType: "character varying", CharMaxLength: length(64)}, "size:64"},
+		{database.ColumnMetadata{DataType: "char", CharMaxLength: length(2)}, "size:2"},
+		{database.ColumnMetadata{DataType: "text", CharMaxLength: length(65535)}, ""},
+		{database.ColumnMetadata{DataType: "varchar"}, ""},
+	}
+	for _, tt := range tests {
+		if got := sizeOption(tt.col); got != tt.want {
+			t.Errorf("sizeOption(%s) = %q; want %q", tt.col.DataType, got, tt.want)
+		}
+	}
+}
+
+func TestPrecisionOptions(t *testing.T) {
+	number := func(n int) *int { return &n }
+	tests := []struct {
+		col  database.ColumnMetadata
+		want string
+	}{
+		{database.ColumnMetadata{DataType: "decimal", NumericPrecision: number(10), NumericScale: number(2)}, "precision:10;scale:2"},
+		{database.ColumnMetadata{DataType: "numeric", NumericPrecision: number(12), NumericScale: number(0)}, "precision:12;scale:0"},
+		{database.ColumnMetadata{DataType: "NUMERIC", NumericPrecision: number(8)}, "precision:8"},
+		{database.ColumnMetadata{DataType: "numeric"}, ""},
+		{database.ColumnMetadata{DataType: "int", NumericPrecision: number(10), NumericScale: number(0)}, ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(precisionOptions(tt.col), ";"); got != tt.want {
+			t.Errorf("precisionOptions(%s) = %q; want %q", tt.col.DataType, got, tt.want)
+		}
+	}
+
+	tb := NewTagBuilder()
+	if err := tb.SetGormOptions([]string{"column", "precision"}); err != nil {
+		t.Fatalf("SetGormOptions() error = %v", err)
+	}
+	col := database.ColumnMetadata{Name: "total", DataType: "decimal", RawType: "decimal(10,2)", NumericPrecision: number(10), NumericScale: number(2)}
+	if got, want := tb.BuildGormTag(col), `gorm:"column:total;precision:10;scale:2"`; got != want {
+		t.Errorf("BuildGormTag() = %q; want %q", got, want)
+	}
+}
+
+func TestCommentOption(t *testing.T) {
+	tests := []struct {
+		comment string
+		want    string
+	}{
+		{"", ""},
+		{"  \n ", ""},
+		{"Login email", "comment:Login email"},
+		{"Login\n  email", "comment:Login email"},
+		{"a;b", `comment:a\\;b`},
+		{`say "hi"`, `comment:say \"hi\"`},
+		{"use `x`", "comment:use 'x'"},
+	}
+	for _, tt := range tests {
+		if got := commentOption(tt.comment); got != tt.want {
+			t.Errorf("commentOption(%q) = %q; want %q", tt.comment, got, tt.want)
+		}
+	}
+
+	tb := NewTagBuilder()
+	if err := tb.SetGormOptions([]string{"column", "comment"}); err != nil {
+		t.Fatalf("SetGormOptions() error = %v", err)
+	}
+	col := database.ColumnMetadata{Name: "email", DataType: "varchar", Comment: `Login; "unique"`}
+	tag := tb.BuildGormTag(col)
+	if want := `gorm:"column:email;comment:Login\\; \"unique\""`; tag != want {
+		t.Errorf("BuildGormTag() = %q; want %q", tag, want)
+	}
+	// The struct tag must unquote to the form GORM parses, with \; escaping the separator
+	if got, want := reflect.StructTag(tag).Get("gorm"), `column:email;comment:Login\; "unique"`; got != want {
+		t.Errorf("StructTag.Get() = %q; want %q", got, want)
+	}
+}
@@ -0,0 +1,669 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/iancoleman/strcase"
+)
+
+// HandlersDir is the directory of the generated REST handlers, next to the
+// output directory
+const HandlersDir = "handlers"
+
+// HandlersRoutesFileName is the file registering the routes of every table
+const HandlersRoutesFileName = "routes_gen.go"
+
+// HandlersMainFile is the API server scaffolded below the module root. It is
+// only written if missing, as it is meant to be edited.
+const HandlersMainFile = "cmd/api/main.go"
+
+// ErrHandlersNoModule is returned by GenerateHandlersToFiles when the output
+// directory is not inside a Go module, so the handlers can't import the models
+var ErrHandlersNoModule = errors.New("handlers need the output directory inside a Go module (go.mod not found)")
+
+// handlerFrameworks maps the supported web frameworks to their import path
+var handlerFrameworks = map[string]string{
+	"gin":  "github.com/gin-gonic/gin",
+	"echo": "github.com/labstack/echo/v4",
+	"chi":  "github.com/go-chi/chi/v5",
+}
+
+// gormDrivers maps drivers to the GORM dialector package of the API server
+var gormDrivers = map[string]string{
+	"mysql":      "gorm.io/driver/mysql",
+	"postgres":   "gorm.io/driver/postgres",
+	"postgresql": "gorm.io/driver/postgres",
+	"sqlite":     "gorm.io/driver/sqlite",
+}
+
+// handlerHeader starts every generated handlers file
+const handlerHeader = `{{define "header"}}
+{{- if ne .Package "main"}}// Code generated by godb-orm. DO NOT EDIT.
+
+{{end -}}
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+{{end}}`
+
+// handlerTemplates are the templates of a table's handlers, by framework
+var handlerTemplates = map[string]string{
+	"gin": `{{template "header" .}}
+{{template "handler" .}}
+
+// Register adds the routes of the {{.Table}} table to r
+func (h *{{.Handler}}) Register(r gin.IRouter) {
+	g := r.Group({{printf "%q" .Path}})
+	g.GET("", h.List)
+	g.POST("", h.Create)
+{{- if .KeyType}}
+	g.GET("/:id", h.Get)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+{{- end}}
+}
+
+// List responds with the {{.Table}} rows
+func (h *{{.Handler}}) List(c *gin.Context) {
+	rows, err := h.Repo.List(c.Request.Context())
+	if err != nil {
+		c.JSON(statusOf(err), errorBody(err))
+		return
+	}
+	c.JSON(http.StatusOK, rows)
+}
+
+// Create inserts the {{.Model}} in the request body
+func (h *{{.Handler}}) Create(c *gin.Context) {
+	var m {{.Model}}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		c.JSON(http.StatusBadRequest, errorBody(err))
+		return
+	}
+	if err := h.Repo.Create(c.Request.Context(), &m); err != nil {
+		c.JSON(statusOf(err), errorBody(err))
+		return
+	}
+	c.JSON(http.StatusCreated, m)
+}
+{{- if .KeyType}}
+
+// Get responds with the {{.Table}} row with the id in the path
+func (h *{{.Handler}}) Get(c *gin.Context) {
+	id, err := {{.ParseKey}}(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorBody(err))
+		return
+	}
+	m, err := h.Repo.Find(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(statusOf(err), errorBody(err))
+		return
+	}
+	c.JSON(http.StatusOK, m)
+}
+
+// Update replaces the {{.Table}} row with the id in the path by the request body
+func (h *{{.Handler}}) Update(c *gin.Context) {
+	id, err := {{.ParseKey}}(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorBody(err))
+		return
+	}
+	var m {{.Model}}
+	if err := c.ShouldBindJSON(&m); err != nil {
+		c.JSON(http.StatusBadRequest, errorBody(err))
+		return
+	}
+	{{.SetKey}}
+	if err := h.Repo.Update(c.Request.Context(), &m); err != nil {
+		c.JSON(statusOf(err), errorBody(err))
+		return
+	}
+	c.JSON(http.StatusOK, m)
+}
+
+// Delete deletes the {{.Table}} row with the id in the path
+func (h *{{.Handler}}) Delete(c *gin.Context) {
+	id, err := {{.ParseKey}}(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorBody(err))
+		return
+	}
+	var m {{.Model}}
+	{{.SetKey}}
+	if err := h.Repo.Delete(c.Request.Context(), &m); err != nil {
+		c.JSON(statusOf(err), errorBody(err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+{{- end}}
+{{template "parse" .}}`,
+
+	"echo": `{{template "header" .}}
+{{template "handler" .}}
+
+// Register adds the routes of the {{.Table}} table to r
+func (h *{{.Handler}}) Register(r *echo.Group) {
+	g := r.Group({{printf "%q" .Path}})
+	g.GET("", h.List)
+	g.POST("", h.Create)
+{{- if .KeyType}}
+	g.GET("/:id", h.Get)
+	g.PUT("/:id", h.Update)
+	g.DELETE("/:id", h.Delete)
+{{- end}}
+}
+
+// List responds with the {{.Table}} rows
+func (h *{{.Handler}}) List(c echo.Context) error {
+	rows, err := h.Repo.List(c.Request().Context())
+	if err != nil {
+		return c.JSON(statusOf(err), errorBody(err))
+	}
+	return c.JSON(http.StatusOK, rows)
+}
+
+// Create inserts the {{.Model}} in the request body
+func (h *{{.Handler}}) Create(c echo.Context) error {
+	var m {{.Model}}
+	if err := c.Bind(&m); err != nil {
+		return c.JSON(http.StatusBadRequest, errorBody(err))
+	}
+	if err := h.Repo.Create(c.Request().Context(), &m); err != nil {
+		return c.JSON(statusOf(err), errorBody(err))
+	}
+	return c.JSON(http.StatusCreated, m)
+}
+{{- if .KeyType}}
+
+// Get responds with the {{.Table}} row with the id in the path
+func (h *{{.Handler}}) Get(c echo.Context) error {
+	id, err := {{.ParseKey}}(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorBody(err))
+	}
+	m, err := h.Repo.Find(c.Request().Context(), id)
+	if err != nil {
+		return c.JSON(statusOf(err), errorBody(err))
+	}
+	return c.JSON(http.StatusOK, m)
+}
+
+// Update replaces the {{.Table}} row with the id in the path by the request body
+func (h *{{.Handler}}) Update(c echo.Context) error {
+	id, err := {{.ParseKey}}(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorBody(err))
+	}
+	var m {{.Model}}
+	if err := c.Bind(&m); err != nil {
+		return c.JSON(http.StatusBadRequest, errorBody(err))
+	}
+	{{.SetKey}}
+	if err := h.Repo.Update(c.Request().Context(), &m); err != nil {
+		return c.JSON(statusOf(err), errorBody(err))
+	}
+	return c.JSON(http.StatusOK, m)
+}
+
+// Delete deletes the {{.Table}} row with the id in the path
+func (h *{{.Handler}}) Delete(c echo.Context) error {
+	id, err := {{.ParseKey}}(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, errorBody(err))
+	}
+	var m {{.Model}}
+	{{.SetKey}}
+	if err := h.Repo.Delete(c.Request().Context(), &m); err != nil {
+		return c.JSON(statusOf(err), errorBody(err))
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+{{- end}}
+{{template "parse" .}}`,
+
+	"chi": `{{template "header" .}}
+{{template "handler" .}}
+
+// Register adds the routes of the {{.Table}} table to r
+func (h *{{.Handler}}) Register(r chi.Router) {
+	r.Route({{printf "%q" .Path}}, func(r chi.Router) {
+		r.Get("/", h.List)
+		r.Post("/", h.Create)
+{{- if .KeyType}}
+		r.Get("/{id}", h.Get)
+		r.Put("/{id}", h.Update)
+		r.Delete("/{id}", h.Delete)
+{{- end}}
+	})
+}
+
+// List responds with the {{.Table}} rows
+func (h *{{.Handler}}) List(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.Repo.List(r.Context())
+	if err != nil {
+		writeJSON(w, statusOf(err), errorBody(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, rows)
+}
+
+// Create inserts the {{.Model}} in the request body
+func (h *{{.Handler}}) Create(w http.ResponseWriter, r *http.Request) {
+	var m {{.Model}}
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody(err))
+		return
+	}
+	if err := h.Repo.Create(r.Context(), &m); err != nil {
+		writeJSON(w, statusOf(err), errorBody(err))
+		return
+	}
+	writeJSON(w, http.StatusCreated, m)
+}
+{{- if .KeyType}}
+
+// Get responds with the {{.Table}} row with the id in the path
+func (h *{{.Handler}}) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := {{.ParseKey}}(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody(err))
+		return
+	}
+	m, err := h.Repo.Find(r.Context(), id)
+	if err != nil {
+		writeJSON(w, statusOf(err), errorBody(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, m)
+}
+
+// Update replaces the {{.Table}} row with the id in the path by the request body
+func (h *{{.Handler}}) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := {{.ParseKey}}(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody(err))
+		return
+	}
+	var m {{.Model}}
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody(err))
+		return
+	}
+	{{.SetKey}}
+	if err := h.Repo.Update(r.Context(), &m); err != nil {
+		writeJSON(w, statusOf(err), errorBody(err))
+		return
+	}
+	writeJSON(w, http.StatusOK, m)
+}
+
+// Delete deletes the {{.Table}} row with the id in the path
+func (h *{{.Handler}}) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := {{.ParseKey}}(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody(err))
+		return
+	}
+	var m {{.Model}}
+	{{.SetKey}}
+	if err := h.Repo.Delete(r.Context(), &m); err != nil {
+		writeJSON(w, statusOf(err), errorBody(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+{{- end}}
+{{template "parse" .}}`,
+}
+
+// handlerShared holds the parts of a table's handlers common to every framework
+const handlerShared = `{{define "handler"}}
+// {{.Handler}} serves the {{.Table}} table under {{.Path}}
+type {{.Handler}} struct {
+	Repo {{.Repository}}
+}
+
+// New{{.Handler}} returns a {{.Handler}} reading and writing through repo
+func New{{.Handler}}(repo {{.Repository}}) *{{.Handler}} {
+	return &{{.Handler}}{Repo: repo}
+}
+{{- end}}
+{{define "parse"}}
+{{- if .KeyType}}
+// {{.ParseKey}} parses the primary key in the path of a {{.Table}} route
+func {{.ParseKey}}(s string) ({{.KeyType}}, error) {
+{{- if eq .KeyKind "int"}}
+	v, err := strconv.ParseInt(s, 10, {{.KeyBits}})
+	return {{.KeyType}}(v), err
+{{- else if eq .KeyKind "uint"}}
+	v, err := strconv.ParseUint(s, 10, {{.KeyBits}})
+	return {{.KeyType}}(v), err
+{{- else if eq .KeyKind "uuid"}}
+	return uuid.Parse(s)
+{{- else}}
+	return s, nil
+{{- end}}
+}
+{{- end}}
+{{- end}}`
+
+// handlerRoutesTemplate is the template of the file registering the routes of
+// every table
+const handlerRoutesTemplate = `{{template "header" .}}
+// Register adds the REST routes of every table to r, reading and writing
+// through the GORM repositories of the models
+func Register(r {{.Router}}, db *gorm.DB) {
+{{- range .Tables}}
+	New{{.Handler}}({{.NewRepository}}(db)).Register(r)
+{{- end}}
+}
+
+// statusOf returns the HTTP status of a repository error
+func statusOf(err error) int {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// errorBody is the response body of a failed request
+func errorBody(err error) map[string]string {
+	return map[string]string{"error": err.Error()}
+}
+{{- if eq .Framework "chi"}}
+
+// writeJSON responds with v encoded as JSON
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+{{- end}}
+`
+
+// handlerMainTemplate is the template of the API server
+const handlerMainTemplate = `{{template "header" .}}
+// Command api serves the REST API of the database tables. The database is
+// given by the DATABASE_DSN environment variable.
+func main() {
+	db, err := gorm.Open({{.Dialector}}.Open(os.Getenv("DATABASE_DSN")), &gorm.Config{})
+	if err != nil {
+		log.Fatal(err)
+	}
+{{if eq .Framework "gin"}}
+	r := gin.Default()
+	handlers.Register(r, db)
+	log.Fatal(r.Run(":8080"))
+{{- else if eq .Framework "echo"}}
+	e := echo.New()
+	handlers.Register(e.Group(""), db)
+	log.Fatal(e.Start(":8080"))
+{{- else}}
+	r := chi.NewRouter()
+	handlers.Register(r, db)
+	log.Fatal(http.ListenAndServe(":8080", r))
+{{- end}}
+}
+`
+
+// HandlerData holds the data for the handler templates
+type HandlerData struct {
+	Package    string
+	Imports    []string // Quoted import paths, optionally with an alias
+	Framework  string
+	Table      string
+	Path       string // Route of the table, e.g. /users
+	Handler    string // Handler type, e.g. UserHandler
+	Model      string // Qualified model struct, e.g. models.User
+	Repository string // Qualified repository interface, e.g. models.UserRepository
+	KeyType    string // Qualified type of the single primary key column, empty without one
+	KeyKind    string // How the key is parsed: int, uint, uuid or string
+	KeyBits    int    // Bit size of an integer key, 0 for int and uint
+	ParseKey   string // Name of the function parsing the key
+	SetKey     string // Statement setting the key of m to id
+}
+
+// HandlerRoutesData holds the data for the routes and main templates
+type HandlerRoutesData struct {
+	Package   string
+	Imports   []string
+	Framework string
+	Router    string // Type of the router Register takes
+	Tables    []HandlerRoute
+	Dialector string // GORM dialector package name (main)
+}
+
+// HandlerRoute registers the handler of a table
+type HandlerRoute struct {
+	Handler       string
+	NewRepository string // Qualified repository constructor
+}
+
+// GenerateHandlersToFiles writes REST handlers (list, get, create, update,
+// delete) for the given tables to the HandlersDir directory next to
+// outputDir, using the web framework gin, echo or chi. The handlers go
+// through the repositories of the models (see GeneratorConfig.Repositories)
+// and take the models as request and response bodies. Get, update and delete
+// need a single-column primary key. A runnable API server is scaffolded as
+// HandlersMainFile below the module root unless it exists, for the drivers
+// GORM has a dialector for. It returns the paths of the files written.
+func (g *Generator) GenerateHandlersToFiles(tableNames []string, outputDir, framework, driver string) ([]string, error) {
+	frameworkImport, ok := handlerFrameworks[framework]
+	if !ok {
+		return nil, fmt.Errorf("unknown web framework %q (want gin, echo or chi)", framework)
+	}
+	module, err := DetectModule(outputDir)
+	if err != nil {
+		return nil, err
+	}
+	if module == nil || g.importPath == "" {
+		return nil, ErrHandlersNoModule
+	}
+	dir := filepath.Join(filepath.Dir(filepath.Clean(outputDir)), HandlersDir)
+	handlersImport, err := module.ImportPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	packages, qualifiers := g.modelPackages(tableNames)
+	importOf := make(map[string]string, len(packages))
+	for _, pkg := range packages {
+		importOf[pkg.Alias] = fmt.Sprintf("%s %q", pkg.Alias, pkg.ImportPath)
+	}
+
+	var files []OutputFile
+	routes := &HandlerRoutesData{Package: HandlersDir, Framework: framework}
+	for _, tableName := range tableNames {
+		data, err := g.handlerData(tableName, framework, qualifiers[tableName])
+		if err != nil {
+			return nil, err
+		}
+		data.Imports = groupImports(append(data.Imports, fmt.Sprintf("%q", frameworkImport), importOf[qualifiers[tableName]]))
+		content, err := renderWithImports(handlerTemplates[framework], data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, OutputFile{
+			Path:    filepath.Join(dir, strcase.ToSnake(g.baseName(tableName))+".go"),
+			Content: content,
+			Table:   tableName,
+		})
+		routes.Tables = append(routes.Tables, HandlerRoute{
+			Handler:       data.Handler,
+			NewRepository: qualifiers[tableName] + ".New" + g.structName(tableName) + "Repository",
+		})
+	}
+
+	routes.Router = map[string]string{"gin": "gin.IRouter", "echo": "*echo.Group", "chi": "chi.Router"}[framework]
+	routes.Imports = []string{`"errors"`, `"net/http"`, fmt.Sprintf("%q", frameworkImport), `"gorm.io/gorm"`}
+	if framework == "chi" {
+		routes.Imports = append(routes.Imports, `"encoding/json"`)
+	}
+	for _, pkg := range packages {
+		routes.Imports = append(routes.Imports, importOf[pkg.Alias])
+	}
+	routes.Imports = groupImports(routes.Imports)
+	content, err := renderWithImports(handlerRoutesTemplate, routes)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, OutputFile{Path: filepath.Join(dir, HandlersRoutesFileName), Content: content})
+
+	mainPath := filepath.Join(module.Root, filepath.FromSlash(HandlersMainFile))
+	if dialector, ok := gormDrivers[strings.ToLower(driver)]; ok {
+		if _, err := os.Stat(mainPath); os.IsNotExist(err) {
+			main := &HandlerRoutesData{
+				Package:   "main",
+				Framework: framework,
+				Dialector: dialector[strings.LastIndex(dialector, "/")+1:],
+				Imports: []string{
+					`"log"`, `"os"`, fmt.Sprintf("%q", frameworkImport), fmt.Sprintf("%q", dialector),
+					`"gorm.io/gorm"`, fmt.Sprintf("%q", handlersImport),
+				},
+			}
+			if framework == "chi" {
+				main.Imports = append(main.Imports, `"net/http"`)
+			}
+			main.Imports = groupImports(main.Imports)
+			content, err := renderWithImports(handlerMainTemplate, main)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, OutputFile{Path: mainPath, Content: content})
+		}
+	}
+
+	if err := WriteFiles(files); err != nil {
+		return nil, err
+	}
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.Path
+	}
+	return paths, nil
+}
+
+// handlerData builds the handler template data of a table whose model is in
+// the package qualified by pkg
+func (g *Generator) handlerData(tableName, framework, pkg string) (*HandlerData, error) {
+	meta, err := g.tableMetadata(tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table metadata for %s: %w", tableName, err)
+	}
+
+	structName := g.structName(tableName)
+	data := &HandlerData{
+		Package:    HandlersDir,
+		Framework:  framework,
+		Table:      tableName,
+		Path:       "/" + strcase.ToKebab(g.baseName(tableName)),
+		Handler:    structName + "Handler",
+		Model:      pkg + "." + structName,
+		Repository: pkg + "." + structName + "Repository",
+		ParseKey:   "parse" + structName + "Key",
+	}
+	if framework == "chi" {
+		data.Imports = append(data.Imports, `"encoding/json"`)
+	}
+	data.Imports = append(data.Imports, `"net/http"`)
+
+	fields := g.columnFields(meta)
+	g.privatizeFields(fields)
+	model := &TemplateData{TableName: tableName, Fields: fields, Table: meta}
+	g.applyKeyParams(model)
+	if len(model.KeyParams) != 1 {
+		return data, nil
+	}
+
+	key := model.KeyParams[0]
+	switch {
+	case strings.HasPrefix(key.Type, "int"):
+		data.KeyKind = "int"
+		data.Imports = append(data.Imports, `"strconv"`)
+	case strings.HasPrefix(key.Type, "uint"):
+		data.KeyKind = "uint"
+		data.Imports = append(data.Imports, `"strconv"`)
+	case key.Type == "uuid.UUID":
+		data.KeyKind = "uuid"
+		data.Imports = append(data.Imports, fmt.Sprintf("%q", WellKnownImports.UUID))
+	case key.Type == "string":
+		data.KeyKind = "string"
+	default:
+		// No path parameter parses into other key types
+		return data, nil
+	}
+	data.KeyType = key.Type
+	if data.KeyKind == "int" || data.KeyKind == "uint" {
+		data.KeyBits, _ = strconv.Atoi(strings.TrimPrefix(key.Type, data.KeyKind))
+	}
+
+	data.SetKey = "m." + key.Field + " = id"
+	for _, field := range fields {
+		if field.Name == key.Field && field.Accessor != "" {
+			data.SetKey = "m.Set" + field.Accessor + "(id)"
+		}
+	}
+	return data, nil
+}
+
+// renderWithImports executes a template using the handlers header and
+// gofmt-formats the result. The imports are listed explicitly (see
+// groupImports), as goimports would resolve the models package through the
+// network.
+func renderWithImports(text string, data interface{}) ([]byte, error) {
+	tmpl, err := template.New("handlers").Parse(handlerHeader + handlerShared + text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return buf.Bytes(), fmt.Errorf("gofmt failed (returning unformatted): %w", err)
+	}
+	return formatted, nil
+}
+
+// groupImports sorts import specs by path, the standard library first and
+// separated from the other packages by an empty spec
+func groupImports(specs []string) []string {
+	pathOf := func(spec string) string {
+		return spec[strings.Index(spec, `"`):]
+	}
+	var std, other []string
+	for _, spec := range specs {
+		if p := pathOf(spec); strings.Contains(strings.SplitN(p, "/", 2)[0], ".") {
+			other = append(other, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+	byPath := func(list []string) func(i, j int) bool {
+		return func(i, j int) bool { return pathOf(list[i]) < pathOf(list[j]) }
+	}
+	sort.Slice(std, byPath(std))
+	sort.Slice(other, byPath(other))
+	if len(std) > 0 && len(other) > 0 {
+		std = append(std, "")
+	}
+	return append(std, other...)
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateHandlersToFiles(t *testing.T) {
+	for _, framework := range []string{"gin", "echo", "chi"} {
+		t.Run(framework, func(t *testing.T) {
+			root := t.TempDir()
+			if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			gen := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{
+				PackageName:  "models",
+				ImportPath:   "example.com/app/models",
+				Repositories: true,
+			})
+
+			paths, err := gen.GenerateHandlersToFiles([]string{"users"}, filepath.Join(root, "models"), framework, "postgres")
+			if err != nil {
+				t.Fatalf("GenerateHandlersToFiles() error = %v", err)
+			}
+			if len(paths) != 3 {
+				t.Fatalf("GenerateHandlersToFiles() wrote %v; want the users handlers, routes and main", paths)
+			}
+
+			read := func(path string) string {
+				content, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return strings.Join(strings.Fields(string(content)), " ")
+			}
+			handler := read(filepath.Join(root, HandlersDir, "users.go"))
+			for _, want := range []string{
+				"package handlers",
+				`models "example.com/app/models"`,
+				"type UserHandler struct { Repo models.UserRepository }",
+				"func parseUserKey(s string) (int32, error) { v, err := strconv.ParseInt(s, 10, 32) return int32(v), err }",
+				"m.ID = id",
+			} {
+				if !strings.Contains(handler, want) {
+					t.Errorf("handler missing %q:\n%s", want, handler)
+				}
+			}
+
+			routes := read(filepath.Join(root, HandlersDir, HandlersRoutesFileName))
+			if want := "NewUserHandler(models.NewUserRepository(db)).Register(r)"; !strings.Contains(routes, want) {
+				t.Errorf("routes missing %q:\n%s", want, routes)
+			}
+
+			main := read(filepath.Join(root, HandlersMainFile))
+			for _, want := range []string{`"example.com/app/handlers"`, `gorm.Open(postgres.Open(os.Getenv("DATABASE_DSN"))`} {
+				if !strings.Contains(main, want) {
+					t.Errorf("main missing %q:\n%s", want, main)
+				}
+			}
+		})
+	}
+
+	if _, err := NewGenerator(newFakeUsers()).GenerateHandlersToFiles([]string{"users"}, t.TempDir(), "gin", "mysql"); err == nil {
+		t.Error("GenerateHandlersToFiles() outside a module error = nil")
+	}
+	if _, err := NewGenerator(newFakeUsers()).GenerateHandlersToFiles([]string{"users"}, t.TempDir(), "fiber", "mysql"); err == nil {
+		t.Error("GenerateHandlersToFiles() error = nil for an unknown framework")
+	}
+}
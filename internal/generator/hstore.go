@@ -0,0 +1,141 @@
+package generator
+
+// HstoreMode controls how PostgreSQL hstore columns are represented in Go
+type HstoreMode string
+
+const (
+	// HstorePgtype maps hstore to pgtype.Hstore (requires pgx v5)
+	HstorePgtype HstoreMode = "pgtype"
+	// HstoreMap maps hstore to a generated Hstore map[string]string type with
+	// Scan/Value methods, written to HstoreFileName next to the models
+	HstoreMap HstoreMode = "map"
+)
+
+// HstoreFileName is the file name for the generated Hstore helper type
+const HstoreFileName = "hstore_gen.go"
+
+// HstoreTemplate is the template for the Hstore helper file
+const HstoreTemplate = `// Code generated by godb-orm. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Hstore is a PostgreSQL hstore value. NULL values are dropped when scanning.
+type Hstore map[string]string
+
+// Scan implements sql.Scanner
+func (h *Hstore) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*h = nil
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Hstore", src)
+	}
+
+	result := Hstore{}
+	for i := skipHstoreSpace(s, 0); i < len(s); {
+		key, next, ok := scanHstoreToken(s, i)
+		if !ok || key == nil {
+			return fmt.Errorf("invalid hstore key at offset %d", i)
+		}
+		i = skipHstoreSpace(s, next)
+		if !strings.HasPrefix(s[i:], "=>") {
+			return fmt.Errorf("invalid hstore separator at offset %d", i)
+		}
+		value, next, ok := scanHstoreToken(s, i+2)
+		if !ok {
+			return fmt.Errorf("invalid hstore value at offset %d", i+2)
+		}
+		if value != nil {
+			result[*key] = *value
+		}
+		i = skipHstoreSpace(s, next)
+		if i < len(s) {
+			if s[i] != ',' {
+				return fmt.Errorf("invalid hstore delimiter at offset %d", i)
+			}
+			i = skipHstoreSpace(s, i+1)
+		}
+	}
+	*h = result
+	return nil
+}
+
+// Value implements driver.Valuer
+func (h Hstore) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = quoteHstore(k) + "=>" + quoteHstore(h[k])
+	}
+	return strings.Join(pairs, ", "), nil
+}
+
+// scanHstoreToken reads a quoted string or NULL starting at i
+func scanHstoreToken(s string, i int) (*string, int, bool) {
+	i = skipHstoreSpace(s, i)
+	if strings.HasPrefix(s[i:], "NULL") {
+		return nil, i + 4, true
+	}
+	if i >= len(s) || s[i] != '"' {
+		return nil, i, false
+	}
+	var b strings.Builder
+	for i++; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+			if i < len(s) {
+				b.WriteByte(s[i])
+			}
+		case '"':
+			value := b.String()
+			return &value, i + 1, true
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return nil, i, false
+}
+
+func skipHstoreSpace(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+func quoteHstore(s string) string {
+	return "\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(s) + "\""
+}
+`
+
+// SetHstoreMode sets how hstore columns are mapped (empty keeps the default)
+func (tm *TypeMapper) SetHstoreMode(mode HstoreMode) {
+	switch mode {
+	case HstorePgtype:
+		tm.typeMap["hstore"] = TypeMapping{GoType: "pgtype.Hstore", ImportPath: "github.com/jackc/pgx/v5/pgtype", IsSlice: true}
+	case HstoreMap:
+		tm.typeMap["hstore"] = TypeMapping{GoType: "Hstore", IsSlice: true}
+	}
+}
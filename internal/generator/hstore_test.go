@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func newFakeProducts() *fakeIntrospector {
+	return &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"products": {
+			Name: "products",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "integer", RawType: "integer", IsPrimaryKey: true},
+				{Name: "attrs", DataType: "hstore", RawType: "hstore", IsNullable: true},
+				{Name: "category", DataType: "ltree", RawType: "ltree"},
+			},
+		},
+	}}
+}
+
+func TestGetGoType_HstoreAndLtree(t *testing.T) {
+	tests := []struct {
+		mode        HstoreMode
+		dbType      string
+		wantType    string
+		wantImport  string
+		wantComment string
+	}{
+		{"", "hstore", "pgtype.Hstore", "github.com/jackc/pgx/v5/pgtype", ""},
+		{HstorePgtype, "hstore", "pgtype.Hstore", "github.com/jackc/pgx/v5/pgtype", ""},
+		{HstoreMap, "hstore", "Hstore", "", ""},
+		{"", "ltree", "*string", "", "// ltree: dot-separated label path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode)+"/"+tt.dbType, func(t *testing.T) {
+			tm := NewTypeMapper()
+			tm.SetNullStrategy(NullStrategyPointer)
+			tm.SetHstoreMode(tt.mode)
+
+			goType, importPath, comment := tm.GetGoType(tt.dbType, true)
+			if goType != tt.wantType || importPath != tt.wantImport || comment != tt.wantComment {
+				t.Errorf("GetGoType(%q) = (%q, %q, %q), want (%q, %q, %q)", tt.dbType,
+					goType, importPath, comment, tt.wantType, tt.wantImport, tt.wantComment)
+			}
+		})
+	}
+}
+
+func TestGenerateToFile_HstoreHelper(t *testing.T) {
+	tests := []struct {
+		mode       HstoreMode
+		wantHelper bool
+	}{
+		{HstorePgtype, false},
+		{HstoreMap, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			outputDir := t.TempDir()
+			gen := NewGeneratorWithConfig(newFakeProducts(), GeneratorConfig{Hstore: tt.mode})
+
+			filePath, err := gen.GenerateToFile("products", outputDir)
+			if err != nil {
+				t.Fatalf("GenerateToFile() error = %v", err)
+			}
+			code, _ := os.ReadFile(filePath)
+			if !strings.Contains(string(code), "Category string") || !strings.Contains(string(code), "// ltree: dot-separated label path") {
+				t.Errorf("ltree column should map to a commented string:\n%s", code)
+			}
+
+			helper, err := os.ReadFile(filepath.Join(outputDir, HstoreFileName))
+			if got := err == nil; got != tt.wantHelper {
+				t.Fatalf("helper written = %v, want %v", got, tt.wantHelper)
+			}
+			if tt.wantHelper && !strings.Contains(string(helper), "func (h *Hstore) Scan(src interface{}) error") {
+				t.Errorf("helper should define Hstore.Scan:\n%s", helper)
+			}
+		})
+	}
+}
+
+func TestGenerateToFile_HstoreHelperSkippedWhenExcluded(t *testing.T) {
+	outputDir := t.TempDir()
+	gen := NewGeneratorWithConfig(newFakeProducts(), GeneratorConfig{Hstore: HstoreMap})
+	gen.overrides = map[string]config.TableOverride{"products": {ExcludeColumns: []string{"attrs"}}}
+
+	if _, err := gen.GenerateToFile("products", outputDir); err != nil {
+		t.Fatalf("GenerateToFile() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, HstoreFileName)); !os.IsNotExist(err) {
+		t.Errorf("helper should not be written when no model uses it (stat err = %v)", err)
+	}
+}
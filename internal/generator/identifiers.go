@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// reservedFieldSuffix is appended to a field named like a generated method
+// (a table_name column would otherwise clash with TableName())
+const reservedFieldSuffix = "Field"
+
+// fieldName is the Go field name of a column, with a note explaining why it
+// differs from the name derived from the column, if it does
+type fieldName struct {
+	name string
+	note string
+}
+
+// fieldNames derives the Go field name of every column. Names clashing with
+// a method the model gets are suffixed with "Field"; a column whose name
+// normalizes to one already taken (userId and user_id both give UserID) gets
+// a number suffix, in column order, so regeneration renames deterministically.
+func (g *Generator) fieldNames(cols []database.ColumnMetadata) []fieldName {
+	methods := g.modelMethods()
+	names := make([]fieldName, len(cols))
+	taken := make(map[string]string, len(cols)) // Field name -> column
+	for i, col := range cols {
+		name := g.namingConv.ToGoFieldName(col.Name)
+		var note string
+		if name == "" {
+			name = "Column" + strconv.Itoa(i+1)
+			note = fmt.Sprintf("Named after its position, column %q has no letters or digits", col.Name)
+		}
+		if methods[name] {
+			note = fmt.Sprintf("Renamed from %s, which is a method of the model", name)
+			name += reservedFieldSuffix
+		}
+		if other, ok := taken[name]; ok {
+			base := name
+			for n := 2; taken[name] != ""; n++ {
+				name = base + strconv.Itoa(n)
+			}
+			note = fmt.Sprintf("Renamed from %s, which column %s already uses", base, other)
+		}
+		taken[name] = col.Name
+		names[i] = fieldName{name: name, note: note}
+	}
+	return names
+}
+
+// modelMethods returns the methods generated models may have with the
+// current options. Private fields never clash with them; their getters are
+// prefixed with Get instead (see privatizeFields).
+func (g *Generator) modelMethods() map[string]bool {
+	if g.privateFields {
+		return nil
+	}
+	methods := map[string]bool{
+		"TableName":    true,
+		"Columns":      g.scanHelpers,
+		"ScanRow":      g.scanHelpers,
+		"WithTx":       g.withTx,
+		"TenantScope":  g.scopes,
+		"BeforeCreate": g.hooks,
+	}
+	for _, name := range fullMethods {
+		methods[name] = g.style == StyleFull
+	}
+	for _, name := range queryBuilderMethods {
+		methods[name] = g.queryBuilder != "" && g.queryBuilder != QueryBuilderNone
+	}
+	return methods
+}
+
+// columnFieldNames maps the columns of fields to their field names
+func columnFieldNames(fields []StructField) map[string]string {
+	names := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if field.Column != "" {
+			names[field.Column] = field.Name
+		}
+	}
+	return names
+}
+
+// tagLiteral escapes backslashes and double quotes in a struct tag value
+func tagLiteral(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+}
+
+// gormTagValue escapes a gorm tag option value: GORM splits options at
+// semicolons not preceded by a backslash
+func gormTagValue(value string) string {
+	return tagLiteral(strings.ReplaceAll(value, ";", `\;`))
+}
+
+// jsonTagName replaces the characters encoding/json doesn't accept in a tag
+// name with underscores; with any of them it would silently fall back to
+// the field name
+func jsonTagName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r):
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// plainSQLName matches the names every dialect accepts unquoted and leaves
+// as they are
+var plainSQLName = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// sqlIdent quotes a table or column name for the SQL fragments of generated
+// code (scopes, filters, ORDER BY) when it needs quoting: Postgres folds
+// unquoted MyTable to mytable, and spaces or dots split the name. Plain
+// names are left bare so the common case reads naturally.
+func sqlIdent(name, dialect string) string {
+	if plainSQLName.MatchString(name) {
+		return name
+	}
+	return quoteIdentifier(name, dialect == "mysql")
+}
@@ -0,0 +1,215 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestFieldNameCollisions(t *testing.T) {
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"accounts": {
+			Name: "accounts",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+				{Name: "userId", DataType: "int", RawType: "int"},
+				{Name: "user_id", DataType: "int", RawType: "int"},
+				{Name: "USER_ID", DataType: "int", RawType: "int"},
+				{Name: "type", DataType: "varchar", RawType: "varchar(20)"},
+				{Name: "table_name", DataType: "varchar", RawType: "varchar(64)"},
+				{Name: "columns", DataType: "int", RawType: "int"},
+			},
+		},
+	}}
+
+	gen := NewGeneratorWithConfig(fake, GeneratorConfig{ScanHelpers: true})
+	code, err := gen.Generate("accounts")
+	if err != nil {
+		t.Fatalf("Generate() error = %v\n%s", err, code)
+	}
+	src := string(code)
+	if _, err := parser.ParseFile(token.NewFileSet(), "accounts.go", code, 0); err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, src)
+	}
+
+	for _, want := range []string{
+		"UserID int32",
+		"UserID2 int32",
+		"UserID3 int32",
+		"// Renamed from UserID, which column userId already uses",
+		"Type ",
+		"TableNameField string",
+		"// Renamed from TableName, which is a method of the model",
+		`gorm:"column:table_name`,
+		"ColumnsField int32",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("missing %q:\n%s", want, src)
+		}
+	}
+
+	// Without scan helpers there is no Columns() method to clash with
+	code, err = NewGenerator(fake).Generate("accounts")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if strings.Contains(string(code), "ColumnsField") {
+		t.Errorf("columns renamed without scan helpers:\n%s", code)
+	}
+}
+
+func TestRelationTagsUseRenamedFields(t *testing.T) {
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"orders": {
+			Name: "orders",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+				{Name: "customerId", DataType: "int", RawType: "int"},
+				{Name: "customer_id", DataType: "int", RawType: "int"},
+			},
+			ForeignKeys: []database.ForeignKey{
+				{Table: "orders", Column: "customer_id", ReferencedTable: "customers", ReferencedColumn: "id"},
+			},
+		},
+	}}
+
+	code, err := NewGeneratorWithConfig(fake, GeneratorConfig{Relations: RelationsBelongsTo}).Generate("orders")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.Contains(string(code), "foreignKey:CustomerID2;references:ID") {
+		t.Errorf("relation does not use the renamed key field:\n%s", code)
+	}
+}
+
+func TestExoticIdentifiers(t *testing.T) {
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"2024 Umsätze": {
+			Name: "2024 Umsätze",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+				{Name: "Nama Pelanggan", DataType: "varchar", RawType: "varchar(50)"},
+				{Name: "1st_place", DataType: "int", RawType: "int"},
+				{Name: "order.total", DataType: "int", RawType: "int"},
+				{Name: "größe", DataType: "int", RawType: "int"},
+				{Name: "価格", DataType: "int", RawType: "int"},
+				{Name: `a;b"c`, DataType: "int", RawType: "int"},
+				{Name: "#", DataType: "int", RawType: "int"},
+				{Name: "a`b", DataType: "int", RawType: "int"},
+			},
+		},
+	}}
+
+	gen := NewGeneratorWithConfig(fake, GeneratorConfig{ScanHelpers: true})
+	code, err := gen.Generate("2024 Umsätze")
+	if err != nil {
+		t.Fatalf("Generate() error = %v\n%s", err, code)
+	}
+	src := string(code)
+	if _, err := parser.ParseFile(token.NewFileSet(), "umsatze.go", code, 0); err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, src)
+	}
+
+	for _, want := range []string{
+		"type X2024Umsatze struct",
+		"NamaPelanggan string",
+		`gorm:"column:Nama Pelanggan;`,
+		"X1StPlace ",
+		"OrderTotal ",
+		"Grosse ",
+		`gorm:"column:größe;`,
+		"X価格 ",
+		`gorm:"column:a\\;b\"c;`,
+		`json:"a;b_c"`,
+		"Column8 ",
+		`"a;b\"c",`,
+		"int32 \"gorm:\\\"column:a`b;",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("missing %q:\n%s", want, src)
+		}
+	}
+	if got := gen.FilePath("2024 Umsätze", "models"); got != "models/2024_umsatze.go" {
+		t.Errorf("FilePath() = %q", got)
+	}
+}
+
+func TestPascalIdentifier(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"user_id", "UserId"},
+		{"Nama Pelanggan", "NamaPelanggan"},
+		{"order.total", "OrderTotal"},
+		{"ça_va", "CaVa"},
+		{"straße", "Strasse"},
+		{"Ærø", "Aero"},
+		{"価格_表", "価格表"},
+		{"prix_€", "Prix"},
+		{"---", ""},
+	}
+	for _, tt := range tests {
+		if got := pascalIdentifier(tt.input); got != tt.expected {
+			t.Errorf("pascalIdentifier(%q) = %q; want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestQuotedTableNames(t *testing.T) {
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"MyTable": {
+			Name: "MyTable",
+			Columns: []database.ColumnMetadata{
+				{Name: "Id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+				{Name: "TenantId", DataType: "int", RawType: "int"},
+				{Name: "status", DataType: "varchar", RawType: "varchar(20)"},
+			},
+		},
+	}}
+	cfg := GeneratorConfig{Scopes: true, TenantColumn: "TenantId", Pagination: true}
+
+	tests := []struct {
+		name  string
+		intro database.DBIntrospector
+		want  []string
+	}{
+		{"postgres", fake, []string{
+			`return "MyTable"`,
+			`db.Where("\"MyTable\".\"TenantId\" = ?", tenantID)`,
+			`db.Where("\"MyTable\".status = ?", *f.Status)`,
+			`.Order("\"MyTable\".\"Id\"")`,
+		}},
+		{"mysql", fakeMySQL{fake}, []string{
+			`return "MyTable"`,
+			"db.Where(\"`MyTable`.`TenantId` = ?\", tenantID)",
+			".Order(\"`MyTable`.`Id`\")",
+		}},
+	}
+	for _, tt := range tests {
+		code, err := NewGeneratorWithConfig(tt.intro, cfg).Generate("MyTable")
+		if err != nil {
+			t.Fatalf("%s: Generate() error = %v\n%s", tt.name, err, code)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(string(code), want) {
+				t.Errorf("%s: missing %s:\n%s", tt.name, want, code)
+			}
+		}
+	}
+
+	fake.tables[`odd"name`] = &database.TableMetadata{
+		Name:    `odd"name`,
+		Columns: []database.ColumnMetadata{{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true}},
+	}
+	code, err := NewGeneratorWithConfig(fake, cfg).Generate(`odd"name`)
+	if err != nil {
+		t.Fatalf("Generate() error = %v\n%s", err, code)
+	}
+	if !strings.Contains(string(code), `return "odd\"name"`) {
+		t.Errorf("TableName() does not escape the quote:\n%s", code)
+	}
+}
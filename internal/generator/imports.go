@@ -100,59 +100,12 @@ func (im *ImportManager) GenerateImportBlock() string {
 	return builder.String()
 }
 
-// isStdLib checks if an import path is from the Go standard library
+// isStdLib checks if an import path is from the Go standard library.
+// Standard library paths have no dot in their first element, which is
+// also how goimports groups them.
 func isStdLib(path string) bool {
-	// Standard library packages don't contain dots in their path
-	// This is a simple heuristic that works for most cases
-	stdLibPrefixes := []string{
-		"archive/",
-		"bufio",
-		"bytes",
-		"compress/",
-		"container/",
-		"context",
-		"crypto/",
-		"database/",
-		"debug/",
-		"embed",
-		"encoding/",
-		"errors",
-		"expvar",
-		"flag",
-		"fmt",
-		"go/",
-		"hash/",
-		"html/",
-		"image/",
-		"index/",
-		"io",
-		"log",
-		"math",
-		"mime/",
-		"net/",
-		"os",
-		"path",
-		"reflect",
-		"regexp",
-		"runtime",
-		"sort",
-		"strconv",
-		"strings",
-		"sync",
-		"syscall",
-		"testing",
-		"text/",
-		"time",
-		"unicode",
-		"unsafe",
-	}
-
-	for _, prefix := range stdLibPrefixes {
-		if path == prefix || strings.HasPrefix(path, prefix+"/") || strings.HasPrefix(path, prefix) {
-			return true
-		}
-	}
-	return false
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
 }
 
 // WellKnownImports contains common import paths used in generated code
@@ -161,9 +114,13 @@ var WellKnownImports = struct {
 	Datatypes  string
 	UUID       string
 	GormDriver string
+	SQL        string
+	Goqu       string
 }{
 	Time:       "time",
 	Datatypes:  "gorm.io/datatypes",
 	UUID:       "github.com/google/uuid",
 	GormDriver: "gorm.io/gorm",
+	SQL:        "database/sql",
+	Goqu:       "github.com/doug-martin/goqu/v9",
 }
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// tableMetadata fetches the metadata of a table. With relation inference
+// enabled it adds the foreign keys implied by <singular_table>_id columns.
+func (g *Generator) tableMetadata(tableName string) (*database.TableMetadata, error) {
+	meta, err := g.introspector.GetTableMetadata(tableName)
+	if err != nil {
+		return nil, err
+	}
+	if meta, err = g.applyLogicalKey(meta); err != nil || !g.inferRelations {
+		return meta, err
+	}
+
+	tables, err := g.keyColumnIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	// Introspectors may hand out shared metadata, so infer on a copy
+	copied := *meta
+	copied.ForeignKeys = append([]database.ForeignKey(nil), meta.ForeignKeys...)
+	copied.ReferencedBy = append([]database.ForeignKey(nil), meta.ReferencedBy...)
+	database.InferForeignKeys(&copied, tables)
+	return &copied, nil
+}
+
+// keyColumnIndex maps every table to its id and *_id columns. The index is
+// built on first use and reused for the lifetime of the generator; concurrent
+// callers wait for it to be built once. A failed build is retried on the next
+// call.
+func (g *Generator) keyColumnIndex() (map[string][]string, error) {
+	g.keyColumnsMu.Lock()
+	defer g.keyColumnsMu.Unlock()
+
+	if g.keyColumns != nil {
+		return g.keyColumns, nil
+	}
+
+	tables, err := g.introspector.GetTables()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tables: %w", err)
+	}
+
+	index := make(map[string][]string, len(tables))
+	for _, table := range tables {
+		columns, err := g.introspector.GetColumns(table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get columns of %s: %w", table, err)
+		}
+		index[table] = database.KeyColumns(columns)
+	}
+	g.keyColumns = index
+	return index, nil
+}
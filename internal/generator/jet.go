@@ -0,0 +1,294 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/iancoleman/strcase"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// JetDir is the directory of the go-jet table package, next to the output
+// directory like jet's own .gen/<schema>/table
+const JetDir = "table"
+
+// JetUseSchemaFileName is the file name of the UseSchema function of the
+// go-jet table package
+const JetUseSchemaFileName = "table_use_schema.go"
+
+// jetDialects maps drivers to the go-jet dialect packages
+var jetDialects = map[string]string{
+	"mysql":      "mysql",
+	"postgres":   "postgres",
+	"postgresql": "postgres",
+	"sqlite":     "sqlite",
+}
+
+// jetReservedFields are the fields of a table type that columns can't use
+var jetReservedFields = map[string]bool{
+	"Table":          true,
+	"AllColumns":     true,
+	"MutableColumns": true,
+}
+
+// JetTableTemplate is the template of a go-jet table descriptor file
+const JetTableTemplate = `// Code generated by godb-orm. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import "github.com/go-jet/jet/v2/{{.Dialect}}"
+
+// {{.Name}} is the {{.TableName}} table of the {{.Dialect}} SQL builder
+var {{.Name}} = new{{.Name}}Table("", {{printf "%q" .TableName}}, "")
+
+type {{.Type}}Table struct {
+	{{.Dialect}}.Table
+
+	// Columns
+{{- range .Columns}}
+	{{.Name}} {{$.Dialect}}.{{.Kind}}
+{{- end}}
+
+	AllColumns     {{.Dialect}}.ColumnList
+	MutableColumns {{.Dialect}}.ColumnList
+}
+
+// {{.Name}}Table is the type of {{.Name}}
+type {{.Name}}Table struct {
+	{{.Type}}Table
+}
+
+// AS creates a new {{.Name}}Table with the given alias
+func (a {{.Name}}Table) AS(alias string) *{{.Name}}Table {
+	return new{{.Name}}Table(a.SchemaName(), a.TableName(), alias)
+}
+
+// FromSchema creates a new {{.Name}}Table in the given schema
+func (a {{.Name}}Table) FromSchema(schemaName string) *{{.Name}}Table {
+	return new{{.Name}}Table(schemaName, a.TableName(), a.Alias())
+}
+
+func new{{.Name}}Table(schemaName, tableName, alias string) *{{.Name}}Table {
+	return &{{.Name}}Table{
+		{{.Type}}Table: new{{.Name}}TableImpl(schemaName, tableName, alias),
+	}
+}
+
+func new{{.Name}}TableImpl(schemaName, tableName, alias string) {{.Type}}Table {
+	var (
+{{- range .Columns}}
+		{{.Name}}Column = {{$.Dialect}}.{{.Constructor}}({{printf "%q" .ColumnName}})
+{{- end}}
+		allColumns     = {{.Dialect}}.ColumnList{ {{- range $i, $c := .Columns}}{{if $i}}, {{end}}{{$c.Name}}Column{{end -}} }
+		mutableColumns = {{.Dialect}}.ColumnList{ {{- range $i, $c := .Mutable}}{{if $i}}, {{end}}{{$c}}Column{{end -}} }
+	)
+
+	return {{.Type}}Table{
+		Table: {{.Dialect}}.NewTable(schemaName, tableName, alias, allColumns...),
+{{range .Columns}}
+		{{.Name}}: {{.Name}}Column,
+{{- end}}
+
+		AllColumns:     allColumns,
+		MutableColumns: mutableColumns,
+	}
+}
+`
+
+// JetUseSchemaTemplate is the template of the UseSchema function of the
+// go-jet table package
+const JetUseSchemaTemplate = `// Code generated by godb-orm. DO NOT EDIT.
+
+package {{.PackageName}}
+
+// UseSchema sets a new schema name for all the table SQL builder types
+func UseSchema(schema string) {
+{{- range .Names}}
+	{{.}} = {{.}}.FromSchema(schema)
+{{- end}}
+}
+`
+
+// JetTableData holds the data for JetTableTemplate
+type JetTableData struct {
+	PackageName string
+	Dialect     string // go-jet dialect package: mysql, postgres or sqlite
+	TableName   string
+	Name        string // Exported table variable, e.g. Users
+	Type        string // Unexported table type prefix, e.g. users
+	Columns     []JetColumn
+	Mutable     []string // Names of the columns that aren't part of the primary key
+}
+
+// JetColumn is a typed column of a go-jet table
+type JetColumn struct {
+	Name        string // Field name, as in the model
+	ColumnName  string
+	Kind        string // Column type, e.g. ColumnInteger
+	Constructor string // Column constructor, e.g. IntegerColumn
+}
+
+// GenerateJetTable renders the go-jet table descriptor of a table for the
+// SQL dialect of driver. Column fields are named like the model fields.
+func (g *Generator) GenerateJetTable(tableName, driver string) ([]byte, error) {
+	dialect, err := g.jetDialect(driver)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := g.introspector.GetTableMetadata(tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table metadata for %s: %w", tableName, err)
+	}
+
+	name := g.jetTableName(tableName)
+	data := &JetTableData{
+		PackageName: JetDir,
+		Dialect:     dialect,
+		TableName:   tableName,
+		Name:        name,
+		Type:        strcase.ToLowerCamel(name),
+	}
+	names := g.fieldNames(meta.Columns)
+	for i, col := range meta.Columns {
+		fieldName := names[i].name
+		if jetReservedFields[fieldName] {
+			fieldName += reservedFieldSuffix
+		}
+		kind := jetColumnKind(col, dialect)
+		data.Columns = append(data.Columns, JetColumn{
+			Name:        fieldName,
+			ColumnName:  col.Name,
+			Kind:        "Column" + kind,
+			Constructor: kind + "Column",
+		})
+		if !col.IsPrimaryKey {
+			data.Mutable = append(data.Mutable, fieldName)
+		}
+	}
+
+	return g.renderJet("jet", JetTableTemplate, data)
+}
+
+// GenerateJetTablesToFiles writes the go-jet table package of the given
+// tables to the JetDir directory next to outputDir and returns the paths
+// of its files. The package is written as one transaction.
+func (g *Generator) GenerateJetTablesToFiles(tableNames []string, outputDir, driver string) ([]string, error) {
+	dir := filepath.Join(filepath.Dir(filepath.Clean(outputDir)), JetDir)
+
+	var files []OutputFile
+	var names []string
+	for _, tableName := range tableNames {
+		content, err := g.GenerateJetTable(tableName, driver)
+		if err != nil {
+			return nil, err
+		}
+		name := g.jetTableName(tableName)
+		names = append(names, name)
+		files = append(files, OutputFile{
+			Path:    filepath.Join(dir, strcase.ToSnake(name)+".go"),
+			Content: content,
+			Table:   tableName,
+		})
+	}
+
+	content, err := g.renderJet("jet_schema", JetUseSchemaTemplate, struct {
+		PackageName string
+		Names       []string
+	}{JetDir, names})
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, OutputFile{Path: filepath.Join(dir, JetUseSchemaFileName), Content: content})
+
+	if err := WriteFiles(files); err != nil {
+		return nil, err
+	}
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = file.Path
+	}
+	return paths, nil
+}
+
+// jetDialect returns the go-jet dialect package for driver, preferring the
+// dialect the introspector reports (e.g. for schema dumps)
+func (g *Generator) jetDialect(driver string) (string, error) {
+	if g.dialect != "" {
+		driver = g.dialect
+	}
+	dialect, ok := jetDialects[strings.ToLower(driver)]
+	if !ok {
+		return "", fmt.Errorf("go-jet supports MySQL, PostgreSQL and SQLite, not %s", driver)
+	}
+	return dialect, nil
+}
+
+// jetTableName returns the name of the table variable, which go-jet names
+// after the table rather than the model (Users, not User)
+func (g *Generator) jetTableName(tableName string) string {
+	return g.namingConv.HandleAcronyms(pascalIdentifier(g.baseName(tableName)))
+}
+
+// renderJet executes a go-jet template and gofmt-formats the result. The
+// imports are complete, and goimports would go looking for a package for
+// the table variables UseSchema refers to.
+func (g *Generator) renderJet(name, text string, data interface{}) ([]byte, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	src, err := g.withBanners(JetDir, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	formatted, err := format.Source(src)
+	if err != nil {
+		return src, fmt.Errorf("gofmt failed (returning unformatted): %w", err)
+	}
+	return formatted, nil
+}
+
+// jetColumnKind returns the go-jet column type of a column, without the
+// Column prefix. Types go-jet has no column type for are strings.
+func jetColumnKind(col database.ColumnMetadata, dialect string) string {
+	dataType := strings.ToLower(col.DataType)
+	rawType := strings.ToLower(col.RawType)
+	base, _, _ := strings.Cut(rawType, "(")
+	base = strings.TrimSpace(strings.TrimSuffix(base, " unsigned"))
+	postgres := dialect == "postgres"
+
+	switch {
+	case base == "tinyint" && strings.HasPrefix(rawType, "tinyint(1)"), base == "bool", base == "boolean":
+		return "Bool"
+	case strings.Contains(base, "int") && !strings.Contains(base, "interval") && !strings.Contains(base, "point"),
+		strings.Contains(base, "serial"):
+		return "Integer"
+	case base == "float", base == "double", base == "double precision", base == "real",
+		base == "decimal", base == "numeric", base == "float4", base == "float8":
+		return "Float"
+	case base == "date":
+		return "Date"
+	case postgres && (base == "timetz" || dataType == "time with time zone"):
+		return "Timez"
+	case base == "time" || strings.HasPrefix(base, "time without"):
+		return "Time"
+	case postgres && (base == "timestamptz" || dataType == "timestamp with time zone"):
+		return "Timestampz"
+	case base == "timestamp" || base == "datetime" || strings.HasPrefix(base, "timestamp without"):
+		return "Timestamp"
+	case postgres && base == "interval":
+		return "Interval"
+	}
+	return "String"
+}
@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestGenerateJetTable(t *testing.T) {
+	fake := newFakeUsers()
+	fake.tables["users"].Columns = append(fake.tables["users"].Columns,
+		database.ColumnMetadata{Name: "active", DataType: "tinyint", RawType: "tinyint(1)"},
+		database.ColumnMetadata{Name: "created_at", DataType: "timestamp", RawType: "timestamp"},
+		database.ColumnMetadata{Name: "table", DataType: "varchar", RawType: "varchar(20)"},
+	)
+
+	code, err := NewGenerator(fake).GenerateJetTable("users", "mysql")
+	if err != nil {
+		t.Fatalf("GenerateJetTable() error = %v", err)
+	}
+	got := strings.Join(strings.Fields(string(code)), " ")
+	for _, want := range []string{
+		"package table",
+		`import "github.com/go-jet/jet/v2/mysql"`,
+		`var Users = newUsersTable("", "users", "")`,
+		"ID mysql.ColumnInteger",
+		"Email mysql.ColumnString",
+		"Active mysql.ColumnBool",
+		"CreatedAt mysql.ColumnTimestamp",
+		"TableField mysql.ColumnString",
+		`IDColumn = mysql.IntegerColumn("id")`,
+		"allColumns = mysql.ColumnList{IDColumn, EmailColumn, ActiveColumn, CreatedAtColumn, TableFieldColumn}",
+		"mutableColumns = mysql.ColumnList{EmailColumn, ActiveColumn, CreatedAtColumn, TableFieldColumn}",
+		"func (a UsersTable) AS(alias string) *UsersTable {",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("GenerateJetTable() missing %q:\n%s", want, code)
+		}
+	}
+
+	if _, err := NewGenerator(fake).GenerateJetTable("users", "firebird"); err == nil {
+		t.Error("GenerateJetTable() for firebird succeeded; want an unsupported dialect error")
+	}
+}
+
+func TestGenerateJetTablesToFiles(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "models")
+
+	files, err := NewGenerator(newFakeUsers()).GenerateJetTablesToFiles([]string{"users"}, outputDir, "postgres")
+	if err != nil {
+		t.Fatalf("GenerateJetTablesToFiles() error = %v", err)
+	}
+	dir := filepath.Join(filepath.Dir(outputDir), JetDir)
+	want := []string{filepath.Join(dir, "users.go"), filepath.Join(dir, JetUseSchemaFileName)}
+	if strings.Join(files, ",") != strings.Join(want, ",") {
+		t.Fatalf("files = %v; want %v", files, want)
+	}
+
+	data, err := os.ReadFile(want[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Users = Users.FromSchema(schema)") {
+		t.Errorf("%s:\n%s", JetUseSchemaFileName, data)
+	}
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// fieldIndent is the width gofmt gives the tab indenting a struct field
+const fieldIndent = 8
+
+// gormDefaultTypes lists the column types GORM's AutoMigrate creates for a
+// Go type without a type: option (MySQL and PostgreSQL spellings). A type:
+// option naming one of them can be dropped without changing the schema.
+var gormDefaultTypes = map[string][]string{
+	"bool":      {"boolean", "bool", "tinyint(1)"},
+	"int8":      {"tinyint"},
+	"int16":     {"smallint", "int2"},
+	"int32":     {"int", "integer", "int4"},
+	"int64":     {"bigint", "int8"},
+	"uint8":     {"tinyint unsigned"},
+	"uint16":    {"smallint unsigned"},
+	"uint32":    {"int unsigned"},
+	"uint64":    {"bigint unsigned"},
+	"float32":   {"float", "real", "float4"},
+	"float64":   {"double", "double precision", "float8"},
+	"string":    {"text", "longtext"},
+	"[]byte":    {"bytea", "longblob"},
+	"time.Time": {"timestamptz", "timestamp with time zone", "datetime(3)"},
+}
+
+// typeOptionPattern matches the type: option of a gorm tag value
+var typeOptionPattern = regexp.MustCompile(`(^|;)type:[^;]*`)
+
+// fitLineWidth shortens the field lines that would exceed the configured
+// maximum width once gofmt aligns them. A type: option GORM would infer
+// from the Go type anyway is dropped first; if the line is still too long,
+// its trailing comment moves above the field.
+func (g *Generator) fitLineWidth(meta *database.TableMetadata, fields []StructField) {
+	if g.maxLineWidth <= 0 {
+		return
+	}
+
+	columns := make(map[string]database.ColumnMetadata, len(meta.Columns))
+	for _, col := range meta.Columns {
+		columns[col.Name] = col
+	}
+
+	for pass := 0; pass < 2; pass++ {
+		nameWidth, typeWidth := fieldColumnWidths(fields)
+		for i := range fields {
+			field := &fields[i]
+			if fieldLineWidth(*field, nameWidth, typeWidth) <= g.maxLineWidth {
+				continue
+			}
+			col, ok := columns[field.Column]
+			if pass == 0 && ok && inferredType(field.Type, col.RawType) {
+				field.Tags = dropTypeOption(field.Tags)
+			} else if pass == 1 && field.Comment != "" {
+				field.Doc = append(field.Doc, strings.TrimSpace(strings.TrimPrefix(field.Comment, "//")))
+				field.Comment = ""
+			}
+		}
+	}
+}
+
+// fieldColumnWidths returns the widths of the name and type columns gofmt
+// aligns struct fields in
+func fieldColumnWidths(fields []StructField) (int, int) {
+	nameWidth, typeWidth := 0, 0
+	for _, field := range fields {
+		nameWidth = max(nameWidth, len(field.Name))
+		typeWidth = max(typeWidth, len(field.Type))
+	}
+	return nameWidth, typeWidth
+}
+
+// fieldLineWidth returns the width of a field line after gofmt alignment
+func fieldLineWidth(field StructField, nameWidth, typeWidth int) int {
+	width := fieldIndent + nameWidth + 1 + typeWidth + 1 + len(tagSource(field.Tags))
+	if field.Comment != "" {
+		width += 1 + len(field.Comment)
+	}
+	return width
+}
+
+// inferredType reports whether GORM creates rawType for goType by itself
+func inferredType(goType, rawType string) bool {
+	rawType = strings.ToLower(strings.TrimSpace(rawType))
+	for _, candidate := range gormDefaultTypes[strings.TrimPrefix(goType, "*")] {
+		if candidate == rawType {
+			return true
+		}
+	}
+	return false
+}
+
+// dropTypeOption removes the type: option from the gorm tag of a field
+func dropTypeOption(tags string) string {
+	parsed := parseTags(tags)
+	parts := make([]string, len(parsed))
+	for i, tag := range parsed {
+		if tag.key == "gorm" {
+			tag.value = strings.TrimPrefix(typeOptionPattern.ReplaceAllString(tag.value, ""), ";")
+		}
+		parts[i] = formatTag(tag)
+	}
+	return strings.Join(parts, " ")
+}
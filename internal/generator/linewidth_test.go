@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func newFakeWide() *fakeIntrospector {
+	return &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"events": {
+			Name: "events",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "bigint", RawType: "bigint", IsPrimaryKey: true, IsAutoIncrement: true},
+				{Name: "payload_description", DataType: "text", RawType: "text"},
+				{Name: "status", DataType: "enum", RawType: "enum('pending','processing','delivered','failed')",
+					EnumValues: []string{"pending", "processing", "delivered", "failed"}},
+			},
+		},
+	}}
+}
+
+func TestMaxLineWidth(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeWide(), GeneratorConfig{MaxLineWidth: 80})
+	code, err := gen.GenerateString("events")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`gorm:"primaryKey;autoIncrement;column:id" json:"id"`,
+		`gorm:"column:payload_description;not null" json:"payload_description"`,
+		"\t// enum('pending','processing','delivered','failed')\n\tStatus",
+		// A type GORM can't infer is kept
+		`type:enum('pending','processing','delivered','failed')`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+}
+
+func TestMaxLineWidth_Unlimited(t *testing.T) {
+	code, err := NewGenerator(newFakeWide()).GenerateString("events")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if !strings.Contains(code, "type:text") || !strings.Contains(code, `json:"status"`+"` // enum(") {
+		t.Errorf("unlimited width should keep type: options and trailing comments:\n%s", code)
+	}
+}
+
+func TestDropTypeOption(t *testing.T) {
+	tests := map[string]string{
+		`gorm:"column:id;type:int;not null" json:"id"`: `gorm:"column:id;not null" json:"id"`,
+		`gorm:"type:int;column:id" json:"id"`:          `gorm:"column:id" json:"id"`,
+		`gorm:"column:id;type:int"`:                    `gorm:"column:id"`,
+	}
+	for tags, want := range tests {
+		if got := dropTypeOption(tags); got != want {
+			t.Errorf("dropTypeOption(%q) = %q; want %q", tags, got, want)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// LintSeverity ranks a lint issue
+type LintSeverity string
+
+// Lint severities, from least to most severe
+const (
+	LintInfo    LintSeverity = "info"
+	LintWarning LintSeverity = "warning"
+	LintError   LintSeverity = "error"
+)
+
+// Lint rules reported by Lint
+const (
+	LintNoPrimaryKey = "no-primary-key"
+	LintUnknownType  = "unknown-type"
+	LintNullableBool = "nullable-bool"
+	LintUntypedEnum  = "untyped-enum"
+)
+
+// severityRanks orders the lint severities
+var severityRanks = map[LintSeverity]int{LintInfo: 0, LintWarning: 1, LintError: 2}
+
+// ParseLintSeverity parses a severity name (info, warning or error)
+func ParseLintSeverity(name string) (LintSeverity, error) {
+	severity := LintSeverity(strings.ToLower(strings.TrimSpace(name)))
+	if _, ok := severityRanks[severity]; !ok {
+		return "", fmt.Errorf("unknown lint severity %q (want info, warning or error)", name)
+	}
+	return severity, nil
+}
+
+// AtLeast reports whether s is as severe as min or more
+func (s LintSeverity) AtLeast(min LintSeverity) bool {
+	return severityRanks[s] >= severityRanks[min]
+}
+
+// LintIssue is a schema smell found in a table by Lint
+type LintIssue struct {
+	Table    string       `json:"table"`
+	Column   string       `json:"column,omitempty"` // Empty for table-level issues
+	Rule     string       `json:"rule"`
+	Severity LintSeverity `json:"severity"`
+	Message  string       `json:"message"`
+}
+
+// Lint reports the schema smells of a table that affect its generated model:
+// a missing primary key, columns mapped to interface{}, nullable booleans
+// and enum columns generated as plain strings. Columns are checked with the
+// Go types they would be generated with, so type rules and overrides that
+// fix a smell silence it.
+func (g *Generator) Lint(tableName string) ([]LintIssue, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+	meta, err := g.tableMetadata(tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table metadata: %w", err)
+	}
+	return g.lintTable(meta), nil
+}
+
+// lintTable checks the table and its generated column fields
+func (g *Generator) lintTable(meta *database.TableMetadata) []LintIssue {
+	var issues []LintIssue
+	// Documents always have an _id, and Trino models are never written
+	if g.dialect != "mongodb" && g.dialect != "trino" && !hasPrimaryKey(meta) {
+		issue := LintIssue{Table: meta.Name, Rule: LintNoPrimaryKey, Severity: LintError}
+		switch g.noPrimaryKey {
+		case NoPrimaryKeySkip:
+			issue.Severity, issue.Message = LintInfo, "no primary key: skipped (generator.no_primary_key)"
+		case NoPrimaryKeyReadOnly:
+			issue.Severity, issue.Message = LintInfo, "no primary key: generated read-only (generator.no_primary_key)"
+		default:
+			issue.Message = "no primary key: GORM can't Save, Update or Delete single rows of this model"
+		}
+		issues = append(issues, issue)
+	}
+
+	cols := map[string]database.ColumnMetadata{}
+	for _, col := range meta.Columns {
+		cols[col.Name] = col
+	}
+	for _, field := range g.columnFields(meta) {
+		col := cols[field.Column]
+		issue := LintIssue{Table: meta.Name, Column: col.Name}
+		switch goType := strings.TrimPrefix(field.Type, "*"); {
+		case goType == "interface{}":
+			issue.Rule, issue.Severity = LintUnknownType, LintWarning
+			issue.Message = fmt.Sprintf("type %s maps to interface{}: add a type rule or column override", col.RawType)
+		case goType == "bool" && col.IsNullable && field.Type == "bool":
+			issue.Rule, issue.Severity = LintNullableBool, LintWarning
+			issue.Message = "nullable boolean generated as bool: NULL reads as false (set generator.null_strategy: pointer or make it NOT NULL)"
+		case goType == "bool" && col.IsNullable:
+			issue.Rule, issue.Severity = LintNullableBool, LintInfo
+			issue.Message = "nullable boolean: the model has three states (nil, false, true)"
+		case goType == "string" && len(col.EnumValues) > 0:
+			issue.Rule, issue.Severity = LintUntypedEnum, LintInfo
+			issue.Message = fmt.Sprintf("enum generated as string: values (%s) are only checked by the database", strings.Join(col.EnumValues, ", "))
+		default:
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
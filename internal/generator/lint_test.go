@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestLint(t *testing.T) {
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"audit_log": {
+			Name: "audit_log",
+			Columns: []database.ColumnMetadata{
+				{Name: "payload", DataType: "geography", RawType: "geography"},
+				{Name: "archived", DataType: "boolean", RawType: "boolean", IsNullable: true},
+				{Name: "level", DataType: "enum", RawType: "enum('info','error')", EnumValues: []string{"info", "error"}},
+				{Name: "shape", DataType: "geometry", RawType: "geometry"},
+			},
+		},
+	}}
+
+	gen := NewGeneratorWithConfig(fake, GeneratorConfig{
+		TypeRules: []config.TypeRule{{Match: "^geometry$", Type: "[]byte"}},
+	})
+	issues, err := gen.Lint("audit_log")
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	var got [][3]string
+	for _, issue := range issues {
+		got = append(got, [3]string{issue.Column, issue.Rule, string(issue.Severity)})
+	}
+	want := [][3]string{
+		{"", LintNoPrimaryKey, "error"},
+		{"payload", LintUnknownType, "warning"},
+		{"archived", LintNullableBool, "warning"},
+		{"level", LintUntypedEnum, "info"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() = %v, want %v", got, want)
+	}
+
+	pointer := NewGeneratorWithConfig(fake, GeneratorConfig{NullStrategy: NullStrategyPointer})
+	issues, err = pointer.Lint("audit_log")
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	for _, issue := range issues {
+		if issue.Rule == LintNullableBool && issue.Severity != LintInfo {
+			t.Errorf("nullable *bool severity = %s, want info", issue.Severity)
+		}
+	}
+}
+
+func TestParseLintSeverity(t *testing.T) {
+	severity, err := ParseLintSeverity("Warning")
+	if err != nil || severity != LintWarning {
+		t.Fatalf("ParseLintSeverity(Warning) = %q, %v", severity, err)
+	}
+	if !LintError.AtLeast(LintWarning) || LintInfo.AtLeast(LintWarning) {
+		t.Error("AtLeast does not order info < warning < error")
+	}
+	if _, err := ParseLintSeverity("fatal"); err == nil {
+		t.Error("ParseLintSeverity(fatal) accepted an unknown severity")
+	}
+}
@@ -0,0 +1,108 @@
+package generator
+
+// DateTimeMode controls how date-time columns without a time zone
+// (DATETIME, TIMESTAMP WITHOUT TIME ZONE) are represented in Go
+type DateTimeMode string
+
+const (
+	// DateTimeTime maps them to time.Time, which drivers may convert to or from UTC
+	DateTimeTime DateTimeMode = "time"
+	// DateTimeLocal maps them to the generated LocalTime type, which keeps
+	// the wall-clock value as stored
+	DateTimeLocal DateTimeMode = "local"
+	// DateTimeString maps them to string
+	DateTimeString DateTimeMode = "string"
+)
+
+// LocalTimeFileName is the file name for the generated LocalTime type
+const LocalTimeFileName = "localtime_gen.go"
+
+// LocalTimeTemplate is the template for the LocalTime helper file
+const LocalTimeTemplate = `// Code generated by godb-orm. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// localTimeLayout is the format LocalTime values are written in
+const localTimeLayout = "2006-01-02 15:04:05.999999"
+
+// LocalTime is a date and time without a time zone. It keeps the wall-clock
+// value as stored in the database, in the time.Local location, so no UTC
+// conversion happens when reading or writing.
+type LocalTime struct {
+	time.Time
+}
+
+// Scan implements sql.Scanner
+func (t *LocalTime) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		t.Time = time.Time{}
+	case time.Time:
+		t.Time = time.Date(v.Year(), v.Month(), v.Day(), v.Hour(), v.Minute(), v.Second(), v.Nanosecond(), time.Local)
+	case string:
+		return t.parse(v)
+	case []byte:
+		return t.parse(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into LocalTime", src)
+	}
+	return nil
+}
+
+// parse reads the text formats used by MySQL and PostgreSQL
+func (t *LocalTime) parse(s string) error {
+	if s == "" || strings.HasPrefix(s, "0000-00-00") {
+		t.Time = time.Time{}
+		return nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05.999999999", "2006-01-02T15:04:05.999999999", "2006-01-02"} {
+		if parsed, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot parse %q as LocalTime", s)
+}
+
+// Value implements driver.Valuer
+func (t LocalTime) Value() (driver.Value, error) {
+	return t.Format(localTimeLayout), nil
+}
+`
+
+// SetDateTimeMode sets how date-time columns without a time zone are mapped
+// (empty keeps the default). MySQL TIMESTAMP columns are converted to and
+// from UTC by the server, so for MySQL only DATETIME is affected. MongoDB
+// dates are always UTC, so MongoDB is not affected at all.
+func (tm *TypeMapper) SetDateTimeMode(mode DateTimeMode, dialect string) {
+	if dialect == "mongodb" {
+		return
+	}
+
+	var mapping TypeMapping
+	switch mode {
+	case DateTimeTime:
+		mapping = TypeMapping{GoType: "time.Time", ImportPath: "time"}
+	case DateTimeLocal:
+		mapping = TypeMapping{GoType: "LocalTime"}
+	case DateTimeString:
+		mapping = TypeMapping{GoType: "string"}
+	default:
+		return
+	}
+
+	types := []string{"datetime", "timestamp without time zone"}
+	if dialect != "mysql" {
+		types = append(types, "timestamp")
+	}
+	for _, dbType := range types {
+		tm.typeMap[dbType] = mapping
+	}
+}
@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func newFakeEvents() *fakeIntrospector {
+	return &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"events": {
+			Name: "events",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+				{Name: "starts_at", DataType: "datetime", RawType: "datetime"},
+				{Name: "logged_at", DataType: "timestamp", RawType: "timestamp"},
+				{Name: "sent_at", DataType: "timestamptz", RawType: "timestamptz"},
+			},
+		},
+	}}
+}
+
+func TestGenerate_DateTimeMode(t *testing.T) {
+	tests := []struct {
+		name         string
+		introspector database.DBIntrospector
+		mode         DateTimeMode
+		want         []string
+		wantHelper   bool
+	}{
+		{"default", newFakeEvents(), "", []string{"StartsAt time.Time", "LoggedAt time.Time", "SentAt   time.Time"}, false},
+		{"local", newFakeEvents(), DateTimeLocal, []string{"StartsAt LocalTime", "LoggedAt LocalTime", "SentAt   time.Time"}, true},
+		{"string", newFakeEvents(), DateTimeString, []string{"StartsAt string", "LoggedAt string", "SentAt   time.Time"}, false},
+		// MySQL TIMESTAMP is time zone aware
+		{"mysql local", fakeMySQL{newFakeEvents()}, DateTimeLocal, []string{"StartsAt LocalTime", "LoggedAt time.Time"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			gen := NewGeneratorWithConfig(tt.introspector, GeneratorConfig{DateTime: tt.mode})
+
+			filePath, err := gen.GenerateToFile("events", outputDir)
+			if err != nil {
+				t.Fatalf("GenerateToFile() error = %v", err)
+			}
+			code, _ := os.ReadFile(filePath)
+			for _, want := range tt.want {
+				if !strings.Contains(string(code), want) {
+					t.Errorf("generated code missing %q:\n%s", want, code)
+				}
+			}
+
+			_, err = os.Stat(filepath.Join(outputDir, LocalTimeFileName))
+			if got := err == nil; got != tt.wantHelper {
+				t.Errorf("helper written = %v, want %v", got, tt.wantHelper)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultPackageName is used when no package name is configured or detectable
+const DefaultPackageName = "models"
+
+// ModuleInfo describes the Go module that contains a directory
+type ModuleInfo struct {
+	Path string // Module path declared in go.mod (e.g., github.com/acme/app)
+	Root string // Absolute directory containing go.mod
+}
+
+// DetectModule walks up from dir looking for a go.mod file.
+// Returns nil (without error) if dir is not inside a Go module.
+func DetectModule(dir string) (*ModuleInfo, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+
+	for current := absDir; ; current = filepath.Dir(current) {
+		goMod := filepath.Join(current, "go.mod")
+		if _, err := os.Stat(goMod); err == nil {
+			modulePath, err := parseModulePath(goMod)
+			if err != nil {
+				return nil, err
+			}
+			return &ModuleInfo{Path: modulePath, Root: current}, nil
+		}
+
+		if parent := filepath.Dir(current); parent == current {
+			return nil, nil
+		}
+	}
+}
+
+// ImportPath returns the fully qualified import path of a directory inside the module
+func (m *ModuleInfo) ImportPath(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+
+	rel, err := filepath.Rel(m.Root, absDir)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside module %s", dir, m.Path)
+	}
+	if rel == "." {
+		return m.Path, nil
+	}
+	return m.Path + "/" + filepath.ToSlash(rel), nil
+}
+
+// parseModulePath extracts the module path from a go.mod file
+func parseModulePath(goMod string) (string, error) {
+	file, err := os.Open(goMod)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", goMod, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if rest, ok := strings.CutPrefix(line, "module"); ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+			modulePath := strings.Trim(strings.TrimSpace(rest), `"`)
+			if idx := strings.Index(modulePath, "//"); idx != -1 {
+				modulePath = strings.TrimSpace(modulePath[:idx])
+			}
+			if modulePath != "" {
+				return modulePath, nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", goMod, err)
+	}
+	return "", fmt.Errorf("no module directive in %s", goMod)
+}
+
+// DetectPackageName determines the package name for files written to dir:
+// the package of existing Go files in the directory, or DefaultPackageName.
+// The directory name is not used, so -o ./output still writes package models.
+func DetectPackageName(dir string) string {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	fset := token.NewFileSet()
+	for _, match := range matches {
+		if strings.HasSuffix(match, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, match, nil, parser.PackageClauseOnly)
+		if err == nil && file.Name != nil {
+			return file.Name.Name
+		}
+	}
+	return DefaultPackageName
+}
+
+// validatePackageName checks that a configured package name can be used in
+// a package clause; token.IsIdentifier rejects keywords such as type
+func validatePackageName(name string) error {
+	if !token.IsIdentifier(name) || name == "_" {
+		return fmt.Errorf("%q is not a valid package name", name)
+	}
+	return nil
+}
+
+// ResolvePackage returns the package name and import path for files written to
+// outputDir. An explicit packageName wins over detection; the import path is
+// empty when outputDir is not inside a Go module.
+func ResolvePackage(outputDir, packageName string) (string, string, error) {
+	if packageName == "" {
+		packageName = DetectPackageName(outputDir)
+	}
+
+	module, err := DetectModule(outputDir)
+	if err != nil || module == nil {
+		return packageName, "", err
+	}
+
+	importPath, err := module.ImportPath(outputDir)
+	if err != nil {
+		return packageName, "", nil
+	}
+	return packageName, importPath, nil
+}
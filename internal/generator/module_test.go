@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectModule(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module github.com/acme/app // comment\n\ngo 1.23\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modelsDir := filepath.Join(root, "internal", "models")
+	if err := os.MkdirAll(modelsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	module, err := DetectModule(modelsDir)
+	if err != nil {
+		t.Fatalf("DetectModule() error = %v", err)
+	}
+	if module == nil || module.Path != "github.com/acme/app" {
+		t.Fatalf("DetectModule() = %+v; want module github.com/acme/app", module)
+	}
+
+	importPath, err := module.ImportPath(modelsDir)
+	if err != nil {
+		t.Fatalf("ImportPath() error = %v", err)
+	}
+	if importPath != "github.com/acme/app/internal/models" {
+		t.Errorf("ImportPath() = %q; want %q", importPath, "github.com/acme/app/internal/models")
+	}
+}
+
+func TestDetectPackageName(t *testing.T) {
+	root := t.TempDir()
+
+	existing := filepath.Join(root, "entities")
+	if err := os.MkdirAll(existing, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(existing, "user.go"), []byte("package domain\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		dir      string
+		expected string
+	}{
+		{existing, "domain"},
+		{filepath.Join(root, "output"), DefaultPackageName},
+		{filepath.Join(root, "db-models"), DefaultPackageName},
+		{filepath.Join(root, "type"), DefaultPackageName},
+	}
+
+	for _, tt := range tests {
+		t.Run(filepath.Base(tt.dir), func(t *testing.T) {
+			result := DetectPackageName(tt.dir)
+			if result != tt.expected {
+				t.Errorf("DetectPackageName(%q) = %q; want %q", tt.dir, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidatePackageName(t *testing.T) {
+	for name, valid := range map[string]bool{"models": true, "entity2": true, "type": false, "func": false, "db-models": false, "_": false} {
+		if err := validatePackageName(name); (err == nil) != valid {
+			t.Errorf("validatePackageName(%q) = %v; want valid %v", name, err, valid)
+		}
+	}
+}
@@ -1,15 +1,47 @@
 package generator
 
 import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/iancoleman/strcase"
+	"golang.org/x/text/unicode/norm"
 )
 
 // NamingConverter handles name conversions using strcase library
-type NamingConverter struct{}
+type NamingConverter struct {
+	acronyms map[string]string // Lowercase word -> acronym spelling; nil disables acronyms
+}
 
-// NewNamingConverter creates a new NamingConverter instance
+// NewNamingConverter creates a new NamingConverter instance with the
+// built-in acronyms
 func NewNamingConverter() *NamingConverter {
-	return &NamingConverter{}
+	acronyms := make(map[string]string, len(commonAcronyms))
+	for _, acronym := range commonAcronyms {
+		acronyms[strings.ToLower(acronym)] = acronym
+	}
+	return &NamingConverter{acronyms: acronyms}
+}
+
+// SetAcronyms adds acronyms (e.g., SKU, VAT, IBAN) to the built-in ones,
+// spelled as given. An entry prefixed with "-" removes an acronym instead,
+// e.g. -DB keeps "Db". With disabled, names are plain PascalCase and no
+// word is upcased.
+func (nc *NamingConverter) SetAcronyms(acronyms []string, disabled bool) {
+	if disabled {
+		nc.acronyms = nil
+		return
+	}
+	for _, acronym := range acronyms {
+		if name, ok := strings.CutPrefix(acronym, "-"); ok {
+			delete(nc.acronyms, strings.ToLower(name))
+			continue
+		}
+		if acronym != "" {
+			nc.acronyms[strings.ToLower(acronym)] = acronym
+		}
+	}
 }
 
 // ToPascalCaseStrcase converts a string to PascalCase using strcase library
@@ -22,73 +54,162 @@ func (nc *NamingConverter) ToSnakeCaseStrcase(s string) string {
 	return strcase.ToSnake(s)
 }
 
-// ToGoFieldName converts a column name to a Go field name (PascalCase with acronym handling)
+// ToGoFieldName converts a column name to a Go field name (PascalCase with
+// acronym handling). It returns "" if the name has no letters or digits.
 func (nc *NamingConverter) ToGoFieldName(columnName string) string {
-	// Use strcase for base conversion
-	pascalCase := strcase.ToCamel(columnName)
-
-	// Handle common acronyms that strcase might not handle correctly
-	return handleAcronyms(pascalCase)
+	// Upcase acronyms that strcase leaves in title case
+	return exportedIdentifier(nc.HandleAcronyms(pascalIdentifier(columnName)))
 }
 
 // ToGoStructName converts a table name to a Go struct name (singular PascalCase)
 func (nc *NamingConverter) ToGoStructName(tableName string) string {
 	// First singularize, then convert to PascalCase
 	singular := singularize(tableName)
-	return strcase.ToCamel(singular)
+	if name := exportedIdentifier(pascalIdentifier(singular)); name != "" {
+		return name
+	}
+	return "Model"
 }
 
 // ToFileName converts a table name to a file name (snake_case.go)
 func (nc *NamingConverter) ToFileName(tableName string) string {
-	return strcase.ToSnake(tableName) + ".go"
+	name := strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, strcase.ToSnake(transliterate(tableName)))
+	if strings.Trim(name, "_") == "" {
+		name = "model"
+	}
+	return name + ".go"
 }
 
-// handleAcronyms handles common acronyms in Go naming
-func handleAcronyms(s string) string {
-	// Common acronyms that should be all uppercase
-	acronyms := map[string]string{
-		"Id":   "ID",
-		"Url":  "URL",
-		"Api":  "API",
-		"Http": "HTTP",
-		"Json": "JSON",
-		"Xml":  "XML",
-		"Sql":  "SQL",
-		"Uuid": "UUID",
-		"Ip":   "IP",
-		"Html": "HTML",
-		"Css":  "CSS",
-		"Db":   "DB",
-	}
-
-	result := s
-	for pattern, replacement := range acronyms {
-		// Only replace at word boundaries (start, after lowercase, or at end)
-		result = replaceAcronym(result, pattern, replacement)
-	}
-	return result
-}
-
-// replaceAcronym replaces an acronym while preserving word boundaries
-func replaceAcronym(s, pattern, replacement string) string {
-	// Simple replacement for common patterns
-	// This handles cases like "UserId" -> "UserID", "ApiUrl" -> "APIURL"
-	result := s
-
-	// Check if pattern exists in string
-	for i := 0; i <= len(result)-len(pattern); i++ {
-		if result[i:i+len(pattern)] == pattern {
-			// Check if this is at a word boundary (end of string or followed by uppercase)
-			isWordBoundary := i+len(pattern) >= len(result) ||
-				(result[i+len(pattern)] >= 'A' && result[i+len(pattern)] <= 'Z')
-			if isWordBoundary {
-				result = result[:i] + replacement + result[i+len(pattern):]
-				i += len(replacement) - 1
+// pascalIdentifier converts a database name to PascalCase made of letters
+// and digits only. Accented Latin letters are transliterated (größe ->
+// Grosse, ça_va -> CaVa); letters of other scripts are kept, as Go allows
+// them in identifiers. Spaces, dots and other punctuation separate words.
+func pascalIdentifier(name string) string {
+	name = transliterate(name)
+	if isASCII(name) {
+		return strcase.ToCamel(strings.Map(func(r rune) rune {
+			if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
 			}
+			return ' '
+		}, name))
+	}
+
+	// strcase drops non-ASCII letters, so split words by hand
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// exportedIdentifier prefixes a name that doesn't start with an upper case
+// letter with X, as digits and most non-Latin letters can't export it
+// (1st_place -> X1StPlace, 価格 -> X価格)
+func exportedIdentifier(name string) string {
+	if name == "" {
+		return ""
+	}
+	if r, _ := utf8.DecodeRuneInString(name); !unicode.IsUpper(r) {
+		return "X" + name
+	}
+	return name
+}
+
+// latinLetters are transliterations of letters that don't decompose into a
+// base letter and diacritics
+var latinLetters = strings.NewReplacer(
+	"ß", "ss", "ẞ", "SS", "æ", "ae", "Æ", "AE", "œ", "oe", "Œ", "OE",
+	"ø", "o", "Ø", "O", "đ", "d", "Đ", "D", "ł", "l", "Ł", "L",
+	"þ", "th", "Þ", "TH", "ð", "d", "Ð", "D", "ı", "i",
+)
+
+// transliterate replaces accented Latin letters with their ASCII base
+// letters (é -> e, ß -> ss); other characters are kept
+func transliterate(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range norm.NFD.String(latinLetters.Replace(s)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
 		}
+		b.WriteRune(r)
 	}
+	return norm.NFC.String(b.String())
+}
+
+// isASCII reports whether s only contains ASCII characters
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
 
-	return result
+// commonAcronyms lists the built-in acronyms, spelled as they appear in
+// Go names
+var commonAcronyms = []string{
+	"UUID", "HTTP", "HTML", "JSON", "URL", "API", "XML", "SQL", "CSS", "ID", "IP", "DB",
+}
+
+// handleAcronyms upcases the built-in acronyms in a PascalCase name
+func handleAcronyms(s string) string {
+	return NewNamingConverter().HandleAcronyms(s)
+}
+
+// HandleAcronyms upcases the words of a PascalCase name that are known
+// acronyms: "UserId" -> "UserID", "ApiUrl" -> "APIURL". Only whole words
+// match, so "Zip" and "Idle" are left alone.
+func (nc *NamingConverter) HandleAcronyms(s string) string {
+	if len(nc.acronyms) == 0 {
+		return s
+	}
+	var b strings.Builder
+	for _, word := range splitWords(s) {
+		if acronym, ok := nc.acronyms[strings.ToLower(word)]; ok {
+			word = acronym
+		}
+		b.WriteString(word)
+	}
+	return b.String()
+}
+
+// splitWords splits a PascalCase name into its words. A word starts at an
+// upper case letter following a lower case letter or digit, at the last
+// letter of an upper case run followed by a lower case letter ("HTTPServer"
+// -> "HTTP", "Server"), and where letters and digits meet ("Ip4Address" ->
+// "Ip", "4", "Address").
+func splitWords(s string) []string {
+	runes := []rune(s)
+	var words []string
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		boundary := unicode.IsUpper(cur) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) ||
+			unicode.IsUpper(prev) && unicode.IsUpper(cur) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) ||
+			unicode.IsDigit(prev) != unicode.IsDigit(cur)
+		if boundary {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
 }
 
 // singularize converts a plural table name to singular
@@ -166,3 +287,57 @@ func singularize(word string) string {
 
 	return word
 }
+
+// pluralize converts a singular word to plural, mirroring singularize
+func pluralize(word string) string {
+	if word == "" {
+		return word
+	}
+
+	irregulars := map[string]string{
+		"person": "people",
+		"child":  "children",
+		"man":    "men",
+		"woman":  "women",
+		"tooth":  "teeth",
+		"foot":   "feet",
+		"mouse":  "mice",
+		"goose":  "geese",
+	}
+	if plural, ok := irregulars[word]; ok {
+		return plural
+	}
+
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		// category -> categories
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		// box -> boxes, class -> classes
+		return word + "es"
+	}
+	return word + "s"
+}
+
+// DocLines turns a database comment into Go doc comment lines: line endings
+// are normalized, control characters dropped and surrounding blank lines
+// trimmed. It returns nil for an empty comment.
+func DocLines(comment string) []string {
+	comment = strings.NewReplacer("\r\n", "\n", "\r", "\n", "\t", " ").Replace(comment)
+	comment = strings.Map(func(r rune) rune {
+		if r != '\n' && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, comment)
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(comment), "\n") {
+		lines = append(lines, strings.TrimRightFunc(line, unicode.IsSpace))
+	}
+	if len(lines) == 1 && lines[0] == "" {
+		return nil
+	}
+	return lines
+}
@@ -1,7 +1,10 @@
 package generator
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
 )
 
 func TestNamingConverter_ToGoFieldName(t *testing.T) {
@@ -123,6 +126,12 @@ func TestHandleAcronyms(t *testing.T) {
 		{"Uuid", "UUID"},
 		{"IpAddress", "IPAddress"},
 		{"DbConnection", "DBConnection"},
+		{"ZipCode", "ZipCode"},
+		{"ShipIp", "ShipIP"},
+		{"Idle", "Idle"},
+		{"Ip4Address", "IP4Address"},
+		{"APIKey", "APIKey"},
+		{"HttpServerId", "HTTPServerID"},
 	}
 
 	for _, tt := range tests {
@@ -134,3 +143,57 @@ func TestHandleAcronyms(t *testing.T) {
 		})
 	}
 }
+
+func TestNamingConverter_SetAcronyms(t *testing.T) {
+	tests := []struct {
+		name     string
+		acronyms []string
+		disabled bool
+		input    string
+		expected string
+	}{
+		{"domain acronyms", []string{"SKU", "VAT", "IBAN"}, false, "product_sku_vat_iban", "ProductSKUVATIBAN"},
+		{"built-in kept", []string{"SKU"}, false, "user_id", "UserID"},
+		{"mixed case spelling", []string{"OAuth"}, false, "oauth_token", "OAuthToken"},
+		{"removed built-in", []string{"-DB"}, false, "db_name_id", "DbNameID"},
+		{"disabled", []string{"SKU"}, true, "api_url_sku", "ApiUrlSku"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nc := NewNamingConverter()
+			nc.SetAcronyms(tt.acronyms, tt.disabled)
+			if result := nc.ToGoFieldName(tt.input); result != tt.expected {
+				t.Errorf("ToGoFieldName(%q) = %q; want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGeneratorAcronyms(t *testing.T) {
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"products": {
+			Name: "products",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+				{Name: "sku", DataType: "varchar", RawType: "varchar(32)"},
+			},
+		},
+	}}
+
+	code, err := NewGeneratorWithConfig(fake, GeneratorConfig{Acronyms: []string{"SKU"}}).Generate("products")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.Contains(string(code), "SKU string") || !strings.Contains(string(code), "ID  int") {
+		t.Errorf("custom acronym not applied:\n%s", code)
+	}
+
+	code, err = NewGeneratorWithConfig(fake, GeneratorConfig{NoAcronyms: true}).Generate("products")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if !strings.Contains(string(code), "Id  int") {
+		t.Errorf("acronyms not disabled:\n%s", code)
+	}
+}
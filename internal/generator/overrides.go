@@ -0,0 +1,225 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// SetTableOverride replaces the override for a single table
+func (g *Generator) SetTableOverride(table string, override config.TableOverride) {
+	overrides := make(map[string]config.TableOverride, len(g.overrides)+1)
+	for name, o := range g.overrides {
+		if !strings.EqualFold(name, table) {
+			overrides[name] = o
+		}
+	}
+	if !override.IsZero() {
+		overrides[table] = override
+	}
+	g.overrides = overrides
+}
+
+// TableOverride returns the override configured for a table
+func (g *Generator) TableOverride(table string) config.TableOverride {
+	return config.LookupTable(g.overrides, table)
+}
+
+// columns returns the columns of a table that are not excluded by its override
+func (g *Generator) columns(meta *database.TableMetadata) []database.ColumnMetadata {
+	override := g.TableOverride(meta.Name)
+	if len(override.ExcludeColumns) == 0 {
+		return meta.Columns
+	}
+
+	var columns []database.ColumnMetadata
+	for _, col := range meta.Columns {
+		if !override.Excludes(col.Name) {
+			columns = append(columns, col)
+		}
+	}
+	return columns
+}
+
+// SetTablePrefix sets the prefix shared by the tables (e.g., wp_) that is
+// left out of struct and file names
+func (g *Generator) SetTablePrefix(prefix string) {
+	g.tablePrefix = prefix
+}
+
+// TablePrefix returns the configured table prefix
+func (g *Generator) TablePrefix() string {
+	return g.tablePrefix
+}
+
+// baseName returns the table name without the configured prefix. The prefix
+// is matched case-insensitively; a table consisting only of the prefix keeps
+// its full name.
+func (g *Generator) baseName(table string) string {
+	if g.tablePrefix == "" || len(table) <= len(g.tablePrefix) {
+		return table
+	}
+	if !strings.EqualFold(table[:len(g.tablePrefix)], g.tablePrefix) {
+		return table
+	}
+	return table[len(g.tablePrefix):]
+}
+
+// structName returns the struct name for a table, honouring overrides
+func (g *Generator) structName(table string) string {
+	if name := g.TableOverride(table).StructName; name != "" {
+		return name
+	}
+	return g.namingConv.ToGoStructName(g.baseName(table))
+}
+
+// fileName returns the output file name for a table, relative to the output
+// directory, honouring overrides and subpackage rules
+func (g *Generator) fileName(table string) string {
+	if name := g.TableOverride(table).FileName; name != "" {
+		return name
+	}
+	name := g.modelFileName(table)
+	if rule, ok := g.subpackage(table); ok {
+		return filepath.Join(filepath.FromSlash(rule.Dir), name)
+	}
+	return name
+}
+
+// filePackage returns the package clause for a table's file, honouring
+// overrides and subpackage rules
+func (g *Generator) filePackage(table string) string {
+	if pkg := g.TableOverride(table).Package; pkg != "" {
+		return pkg
+	}
+	if rule, ok := g.subpackage(table); ok {
+		return rule.PackageName()
+	}
+	return g.packageName
+}
+
+// subpackage returns the first subpackage rule matching a table
+func (g *Generator) subpackage(table string) (config.SubpackageRule, bool) {
+	for _, rule := range g.subpackages {
+		if rule.Matches(table) {
+			return rule, true
+		}
+	}
+	return config.SubpackageRule{}, false
+}
+
+// validateSubpackage checks that a subpackage rule has a valid pattern,
+// a directory and a usable package name
+func validateSubpackage(rule config.SubpackageRule) error {
+	if _, err := path.Match(rule.Match, ""); err != nil || rule.Match == "" {
+		return fmt.Errorf("invalid subpackage pattern %q", rule.Match)
+	}
+	if rule.Dir == "" {
+		return fmt.Errorf("subpackage rule %q has no dir", rule.Match)
+	}
+	if pkg := rule.PackageName(); !token.IsIdentifier(pkg) {
+		return fmt.Errorf("subpackage rule %q: %q is not a valid package name", rule.Match, pkg)
+	}
+	return nil
+}
+
+// FilePath returns the path the file for a table is written to in outputDir
+func (g *Generator) FilePath(table, outputDir string) string {
+	return filepath.Join(outputDir, g.fileName(table))
+}
+
+// applyColumnOverride replaces the field type and merges extra tags
+func applyColumnOverride(field *StructField, override config.ColumnOverride) {
+	if override.Type != "" {
+		field.Type = override.Type
+		field.ImportPath = override.Import
+	}
+	if override.Tags != "" {
+		field.Tags = MergeTags(field.Tags, override.Tags)
+	}
+}
+
+// MergeTags merges extra struct tags into base. A key present in both
+// (e.g. json) takes the value from extra; new keys are appended.
+func MergeTags(base, extra string) string {
+	merged := parseTags(base)
+	for _, tag := range parseTags(extra) {
+		replaced := false
+		for i := range merged {
+			if merged[i].key == tag.key {
+				merged[i].value = tag.value
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, tag)
+		}
+	}
+
+	parts := make([]string, len(merged))
+	for i, tag := range merged {
+		parts[i] = formatTag(tag)
+	}
+	return strings.Join(parts, " ")
+}
+
+// structTag is a single key:"value" pair of a struct tag
+type structTag struct {
+	key   string
+	value string
+}
+
+// formatTag renders a struct tag pair as key:"value"
+func formatTag(tag structTag) string {
+	return tag.key + ":" + strconv.Quote(tag.value)
+}
+
+// parseTags splits a struct tag string into its key:"value" pairs,
+// following the conventions of reflect.StructTag
+func parseTags(tags string) []structTag {
+	var result []structTag
+	for tags != "" {
+		tags = strings.TrimLeft(tags, " ")
+		if tags == "" {
+			break
+		}
+
+		// Key runs up to the colon
+		i := 0
+		for i < len(tags) && tags[i] > ' ' && tags[i] != ':' && tags[i] != '"' {
+			i++
+		}
+		if i == 0 || i+1 >= len(tags) || tags[i] != ':' || tags[i+1] != '"' {
+			break
+		}
+		key := tags[:i]
+		tags = tags[i+1:]
+
+		// Quoted value, honouring escapes
+		i = 1
+		for i < len(tags) && tags[i] != '"' {
+			if tags[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tags) {
+			break
+		}
+		value, err := strconv.Unquote(tags[:i+1])
+		if err != nil {
+			break
+		}
+		tags = tags[i+1:]
+
+		result = append(result, structTag{key: key, value: value})
+	}
+	return result
+}
@@ -0,0 +1,200 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestMergeTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		extra    string
+		expected string
+	}{
+		{
+			name:     "append new key",
+			base:     `gorm:"column:email" json:"email"`,
+			extra:    `validate:"required,email"`,
+			expected: `gorm:"column:email" json:"email" validate:"required,email"`,
+		},
+		{
+			name:     "replace existing key",
+			base:     `gorm:"column:password" json:"password"`,
+			extra:    `json:"-"`,
+			expected: `gorm:"column:password" json:"-"`,
+		},
+		{
+			name:     "escaped quotes",
+			base:     `json:"note"`,
+			extra:    `doc:"say \"hi\""`,
+			expected: `json:"note" doc:"say \"hi\""`,
+		},
+		{
+			name:     "empty extra",
+			base:     `json:"id"`,
+			extra:    "",
+			expected: `json:"id"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeTags(tt.base, tt.extra); got != tt.expected {
+				t.Errorf("MergeTags(%q, %q) = %q; want %q", tt.base, tt.extra, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTableOverrides(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeShop(), GeneratorConfig{
+		Relations: RelationsBelongsTo,
+		Overrides: map[string]config.TableOverride{
+			"users": {StructName: "Account"},
+			"orders": {
+				Package:  "billing",
+				FileName: "billing/order.go",
+				Columns: map[string]config.ColumnOverride{
+					"user_id": {Type: "uuid.UUID", Import: "github.com/google/uuid", Tags: `validate:"required"`},
+				},
+			},
+		},
+	})
+
+	code, err := gen.GenerateString("orders")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	code = strings.Join(strings.Fields(code), " ")
+
+	expected := []string{
+		"package billing",
+		`"github.com/google/uuid"`,
+		`UserID uuid.UUID `,
+		`json:"user_id" validate:"required"`,
+		"User *Account",
+	}
+	for _, want := range expected {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+
+	if got, want := gen.FilePath("orders", "models"), filepath.Join("models", "billing", "order.go"); got != want {
+		t.Errorf("FilePath() = %q; want %q", got, want)
+	}
+	if got, want := gen.FilePath("users", "models"), filepath.Join("models", "users.go"); got != want {
+		t.Errorf("FilePath() = %q; want %q", got, want)
+	}
+}
+
+func TestTablePrefix(t *testing.T) {
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"wp_posts": {
+			Name: "wp_posts",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+			},
+		},
+		"wp_": {
+			Name: "wp_",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+			},
+		},
+	}}
+	gen := NewGeneratorWithConfig(fake, GeneratorConfig{TablePrefix: "WP_"})
+
+	code, err := gen.GenerateString("wp_posts")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	for _, want := range []string{"type Post struct", `return "wp_posts"`} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+
+	tests := []struct {
+		table string
+		want  string
+	}{
+		{"wp_posts", "posts.go"},
+		{"wp_", "wp_.go"},
+		{"users", "users.go"},
+	}
+	for _, tt := range tests {
+		if got := filepath.Base(gen.FilePath(tt.table, "models")); got != tt.want {
+			t.Errorf("FilePath(%q) = %q; want %q", tt.table, got, tt.want)
+		}
+	}
+}
+
+func TestSubpackages(t *testing.T) {
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{}}
+	for _, name := range []string{"billing_invoices", "billing_payments", "users", "audit_log"} {
+		fake.tables[name] = &database.TableMetadata{
+			Name: name,
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+			},
+		}
+	}
+	gen := NewGeneratorWithConfig(fake, GeneratorConfig{
+		PackageName: "models",
+		Subpackages: []config.SubpackageRule{
+			{Match: "billing_*", Dir: "billing"},
+			{Match: "audit_*", Dir: "internal/audit", Package: "auditlog"},
+		},
+		Overrides: map[string]config.TableOverride{
+			"billing_payments": {FileName: "payments.go", Package: "models"},
+		},
+	})
+
+	outputDir := t.TempDir()
+	tests := []struct {
+		table   string
+		path    string
+		pkgLine string
+	}{
+		{"billing_invoices", filepath.Join("billing", "billing_invoices.go"), "package billing"},
+		{"audit_log", filepath.Join("internal", "audit", "audit_log.go"), "package auditlog"},
+		{"users", "users.go", "package models"},
+		{"billing_payments", "payments.go", "package models"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			filePath, err := gen.GenerateToFile(tt.table, outputDir)
+			if err != nil {
+				t.Fatalf("GenerateToFile() error = %v", err)
+			}
+			if want := filepath.Join(outputDir, tt.path); filePath != want {
+				t.Errorf("GenerateToFile() path = %q; want %q", filePath, want)
+			}
+			code, _ := os.ReadFile(filePath)
+			if !strings.Contains(string(code), tt.pkgLine+"\n") {
+				t.Errorf("generated code missing %q:\n%s", tt.pkgLine, code)
+			}
+		})
+	}
+}
+
+func TestSubpackages_Invalid(t *testing.T) {
+	rules := []config.SubpackageRule{
+		{Match: "billing_[", Dir: "billing"},
+		{Match: "billing_*"},
+		{Match: "billing_*", Dir: "billing-v2"},
+	}
+	for _, rule := range rules {
+		gen := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{Subpackages: []config.SubpackageRule{rule}})
+		if _, err := gen.Generate("users"); err == nil {
+			t.Errorf("Generate() with rule %+v: expected error", rule)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package generator
+
+import "strings"
+
+// DefaultPageSize is the page size of the generated list helpers when the
+// caller passes a size below 1
+const DefaultPageSize = 20
+
+// applyPagination fills in the paginated list helper of a model when
+// pagination is enabled. Pages are ordered by the primary key so that rows
+// don't move between pages from one query to the next.
+func (g *Generator) applyPagination(data *TemplateData) {
+	if !g.pagination {
+		return
+	}
+
+	data.ListFunc = "List" + pluralize(data.StructName)
+	data.PageSize = DefaultPageSize
+
+	var order []string
+	for _, col := range data.Table.Columns {
+		if col.IsPrimaryKey {
+			order = append(order, sqlIdent(data.TableName, g.dialect)+"."+sqlIdent(col.Name, g.dialect))
+		}
+	}
+	data.PageOrder = strings.Join(order, ", ")
+}
@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPagination(t *testing.T) {
+	code, err := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{Pagination: true}).GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	code = strings.Join(strings.Fields(code), " ")
+
+	for _, want := range []string{
+		"type UserFilter struct {",
+		"type UserPage struct { Items []User `json:\"items\"` Total int64 `json:\"total\"` Page int `json:\"page\"` Size int `json:\"size\"` }",
+		"func ListUsers(db *gorm.DB, page, size int, filter UserFilter) (*UserPage, error) {",
+		"if size < 1 { size = 20 }",
+		"db.Model(&User{}).Scopes(filter.Apply).Count(&result.Total)",
+		`query := db.Scopes(filter.Apply).Order("users.id")`,
+		"query.Offset((page - 1) * size).Limit(size).Find(&result.Items)",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+
+	filtersOnly, err := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{Filters: true}).GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if strings.Contains(filtersOnly, "ListUsers") {
+		t.Errorf("list helper generated without pagination:\n%s", filtersOnly)
+	}
+}
@@ -0,0 +1,164 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// Kinds of differences reported by CompareParity
+const (
+	ParityMissingTable  = "missing_table"
+	ParityMissingColumn = "missing_column"
+	ParityType          = "type"
+	ParityNullable      = "nullable"
+	ParityLength        = "length"
+	ParityPrecision     = "precision"
+)
+
+// ParityIssue is a difference between a table (or column) of the source
+// schema and the same table of the target schema. Source and Target
+// describe each side; one of them is empty for a missing table or column.
+type ParityIssue struct {
+	Table  string `json:"table"`
+	Column string `json:"column,omitempty"`
+	Kind   string `json:"kind"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// CompareParity compares the tables of the source generator's schema with
+// the same tables in the target's, e.g. a MySQL database and the
+// PostgreSQL database it is migrated to. Table and column names are
+// matched case-insensitively. Column types are compared through the Go
+// types each dialect maps them to, so int and integer match while int and
+// bigint don't; nullability, string lengths and the precision of decimal
+// columns are compared as declared. Tables only in the target are reported
+// when tables is empty (every table of the source).
+func CompareParity(source, target *Generator, tables []string) ([]ParityIssue, error) {
+	targetTables, err := target.introspector.GetTables()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target tables: %w", err)
+	}
+	targetNames := make(map[string]string, len(targetTables))
+	for _, name := range targetTables {
+		targetNames[strings.ToLower(name)] = name
+	}
+
+	all := len(tables) == 0
+	if all {
+		if tables, err = source.introspector.GetTables(); err != nil {
+			return nil, fmt.Errorf("failed to get source tables: %w", err)
+		}
+	}
+
+	var issues []ParityIssue
+	compared := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		compared[strings.ToLower(table)] = true
+		targetName, ok := targetNames[strings.ToLower(table)]
+		if !ok {
+			issues = append(issues, ParityIssue{Table: table, Kind: ParityMissingTable, Source: "table"})
+			continue
+		}
+
+		sourceMeta, err := source.tableMetadata(table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source table %s: %w", table, err)
+		}
+		targetMeta, err := target.tableMetadata(targetName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get target table %s: %w", targetName, err)
+		}
+		issues = append(issues, compareColumns(source, target, sourceMeta, targetMeta)...)
+	}
+
+	if all {
+		var extra []string
+		for lower, name := range targetNames {
+			if !compared[lower] {
+				extra = append(extra, name)
+			}
+		}
+		sort.Strings(extra)
+		for _, name := range extra {
+			issues = append(issues, ParityIssue{Table: name, Kind: ParityMissingTable, Target: "table"})
+		}
+	}
+	return issues, nil
+}
+
+// compareColumns compares the columns of a table in both schemas, in the
+// source's column order followed by the columns only in the target
+func compareColumns(source, target *Generator, sourceMeta, targetMeta *database.TableMetadata) []ParityIssue {
+	targetCols := make(map[string]database.ColumnMetadata, len(targetMeta.Columns))
+	for _, col := range targetMeta.Columns {
+		targetCols[strings.ToLower(col.Name)] = col
+	}
+
+	var issues []ParityIssue
+	seen := make(map[string]bool, len(sourceMeta.Columns))
+	for _, sourceCol := range sourceMeta.Columns {
+		seen[strings.ToLower(sourceCol.Name)] = true
+		issue := ParityIssue{Table: sourceMeta.Name, Column: sourceCol.Name}
+		targetCol, ok := targetCols[strings.ToLower(sourceCol.Name)]
+		if !ok {
+			issue.Kind, issue.Source = ParityMissingColumn, sourceCol.RawType
+			issues = append(issues, issue)
+			continue
+		}
+
+		sourceType, _, _ := source.typeMapper.GetGoType(sourceCol.RawType, false)
+		targetType, _, _ := target.typeMapper.GetGoType(targetCol.RawType, false)
+		if sourceType != targetType {
+			issue.Kind = ParityType
+			issue.Source = fmt.Sprintf("%s (%s)", sourceCol.RawType, sourceType)
+			issue.Target = fmt.Sprintf("%s (%s)", targetCol.RawType, targetType)
+			issues = append(issues, issue)
+		}
+		if sourceCol.IsNullable != targetCol.IsNullable {
+			issue.Kind = ParityNullable
+			issue.Source, issue.Target = nullability(sourceCol), nullability(targetCol)
+			issues = append(issues, issue)
+		}
+		if differs(sourceCol.CharMaxLength, targetCol.CharMaxLength) {
+			issue.Kind = ParityLength
+			issue.Source, issue.Target = sourceCol.RawType, targetCol.RawType
+			issues = append(issues, issue)
+		}
+		if isDecimal(sourceCol) && isDecimal(targetCol) && (differs(sourceCol.NumericPrecision, targetCol.NumericPrecision) || differs(sourceCol.NumericScale, targetCol.NumericScale)) {
+			issue.Kind = ParityPrecision
+			issue.Source, issue.Target = sourceCol.RawType, targetCol.RawType
+			issues = append(issues, issue)
+		}
+	}
+
+	for _, targetCol := range targetMeta.Columns {
+		if !seen[strings.ToLower(targetCol.Name)] {
+			issues = append(issues, ParityIssue{Table: sourceMeta.Name, Column: targetCol.Name, Kind: ParityMissingColumn, Target: targetCol.RawType})
+		}
+	}
+	return issues
+}
+
+// nullability describes whether a column accepts NULL
+func nullability(col database.ColumnMetadata) string {
+	if col.IsNullable {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
+
+// isDecimal reports whether a column is a fixed-point decimal, whose
+// precision and scale are meaningful to compare across dialects
+func isDecimal(col database.ColumnMetadata) bool {
+	dataType := strings.ToLower(col.DataType)
+	return dataType == "decimal" || dataType == "numeric"
+}
+
+// differs reports whether two optional sizes are both known and different
+func differs(a, b *int) bool {
+	return a != nil && b != nil && *a != *b
+}
@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestCompareParity(t *testing.T) {
+	mysql := database.NewDDLIntrospectorFromSource("CREATE TABLE `users` (\n"+
+		"  `id` int NOT NULL AUTO_INCREMENT,\n"+
+		"  `email` varchar(255) NOT NULL,\n"+
+		"  `bio` text,\n"+
+		"  `active` tinyint(1) NOT NULL,\n"+
+		"  `balance` decimal(10,2) NOT NULL,\n"+
+		"  `legacy` int,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		") ENGINE=InnoDB;\n", "mysql")
+	postgres := database.NewDDLIntrospectorFromSource(`SET search_path = public;
+CREATE TABLE public.users (
+    id bigint NOT NULL,
+    "Email" character varying(100) NOT NULL,
+    bio text NOT NULL,
+    active boolean NOT NULL,
+    balance numeric(12,2) NOT NULL,
+    created_at timestamp with time zone
+);
+CREATE TABLE public.audit (id integer);
+`, "postgres")
+	for _, introspector := range []database.DBIntrospector{mysql, postgres} {
+		if err := introspector.Connect(); err != nil {
+			t.Fatalf("Connect() error = %v", err)
+		}
+	}
+
+	issues, err := CompareParity(NewGenerator(mysql), NewGenerator(postgres), nil)
+	if err != nil {
+		t.Fatalf("CompareParity() error = %v", err)
+	}
+	want := []ParityIssue{
+		{Table: "users", Column: "id", Kind: ParityType, Source: "int (int32)", Target: "bigint (int64)"},
+		{Table: "users", Column: "email", Kind: ParityLength, Source: "varchar(255)", Target: "varchar(100)"},
+		{Table: "users", Column: "bio", Kind: ParityNullable, Source: "NULL", Target: "NOT NULL"},
+		{Table: "users", Column: "balance", Kind: ParityPrecision, Source: "decimal(10,2)", Target: "numeric(12,2)"},
+		{Table: "users", Column: "legacy", Kind: ParityMissingColumn, Source: "int"},
+		{Table: "users", Column: "created_at", Kind: ParityMissingColumn, Target: "timestamptz"},
+		{Table: "audit", Kind: ParityMissingTable, Target: "table"},
+	}
+	if !reflect.DeepEqual(issues, want) {
+		t.Errorf("CompareParity() =\n%+v\nwant\n%+v", issues, want)
+	}
+
+	issues, err = CompareParity(NewGenerator(postgres), NewGenerator(mysql), []string{"users", "orders"})
+	if err != nil {
+		t.Fatalf("CompareParity() error = %v", err)
+	}
+	if last := issues[len(issues)-1]; last != (ParityIssue{Table: "orders", Kind: ParityMissingTable, Source: "table"}) {
+		t.Errorf("last issue = %+v, want orders only in the source", last)
+	}
+	for _, issue := range issues {
+		if issue.Table == "audit" {
+			t.Errorf("table outside the selection reported: %+v", issue)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// PluginProtocolVersion is the version of the plugin request/response format
+const PluginProtocolVersion = 1
+
+// PluginExecutablePrefix is prepended to a plugin name to find its executable
+// on PATH, e.g. plugin "python" runs godb-orm-gen-python
+const PluginExecutablePrefix = "godb-orm-gen-"
+
+// PluginRequest is written as JSON to a plugin's stdin. Like protoc plugins,
+// a plugin reads the request, and writes a PluginResponse to stdout.
+type PluginRequest struct {
+	Version   int           `json:"version"`
+	Parameter string        `json:"parameter,omitempty"` // Free-form option string from the config
+	Driver    string        `json:"driver"`              // mysql or postgres
+	Package   string        `json:"package"`             // Go package name of the generated models
+	Tables    []PluginTable `json:"tables"`
+}
+
+// PluginTable describes a table in a PluginRequest
+type PluginTable struct {
+	Schema       string             `json:"schema,omitempty"`
+	Name         string             `json:"name"`
+	StructName   string             `json:"struct_name"` // Go struct name generated for the table
+	Comment      string             `json:"comment,omitempty"`
+	Columns      []PluginColumn     `json:"columns"`
+	ForeignKeys  []PluginForeignKey `json:"foreign_keys,omitempty"`
+	ReferencedBy []PluginForeignKey `json:"referenced_by,omitempty"`
+}
+
+// PluginColumn describes a column in a PluginRequest
+type PluginColumn struct {
+	Name             string   `json:"name"`
+	DataType         string   `json:"data_type"`
+	RawType          string   `json:"raw_type"`
+	Nullable         bool     `json:"nullable"`
+	PrimaryKey       bool     `json:"primary_key"`
+	AutoIncrement    bool     `json:"auto_increment"`
+	Unsigned         bool     `json:"unsigned,omitempty"`
+	Default          *string  `json:"default,omitempty"`
+	EnumValues       []string `json:"enum_values,omitempty"`
+	CharMaxLength    *int     `json:"char_max_length,omitempty"`
+	NumericPrecision *int     `json:"numeric_precision,omitempty"`
+	NumericScale     *int     `json:"numeric_scale,omitempty"`
+	Comment          string   `json:"comment,omitempty"`
+	GoName           string   `json:"go_name"` // Go field name generated for the column
+	GoType           string   `json:"go_type"` // Go type generated for the column
+}
+
+// PluginForeignKey describes a foreign key in a PluginRequest
+type PluginForeignKey struct {
+	Name             string `json:"name"`
+	Table            string `json:"table"`
+	Column           string `json:"column"`
+	ReferencedTable  string `json:"referenced_table"`
+	ReferencedColumn string `json:"referenced_column"`
+}
+
+// PluginResponse is read as JSON from a plugin's stdout
+type PluginResponse struct {
+	Files []PluginFile `json:"files"`
+	Error string       `json:"error,omitempty"` // Set by the plugin to report a failure
+}
+
+// PluginFile is a file produced by a plugin, relative to its output directory
+type PluginFile struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// FindPlugin resolves the executable for a plugin: its configured path, or
+// godb-orm-gen-<name> on PATH
+func FindPlugin(plugin config.PluginConfig) (string, error) {
+	if plugin.Path != "" {
+		return plugin.Path, nil
+	}
+	path, err := exec.LookPath(PluginExecutablePrefix + plugin.Name)
+	if err != nil {
+		return "", fmt.Errorf("plugin %s not found (install %s%s on PATH or set its path): %w",
+			plugin.Name, PluginExecutablePrefix, plugin.Name, err)
+	}
+	return path, nil
+}
+
+// BuildPluginRequest collects the metadata of the given tables into a plugin request
+func (g *Generator) BuildPluginRequest(tableNames []string, driver, parameter string) (*PluginRequest, error) {
+	req := &PluginRequest{
+		Version:   PluginProtocolVersion,
+		Parameter: parameter,
+		Driver:    driver,
+		Package:   g.packageName,
+		Tables:    make([]PluginTable, 0, len(tableNames)),
+	}
+
+	for _, tableName := range tableNames {
+		meta, err := g.introspector.GetTableMetadata(tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get table metadata for %s: %w", tableName, err)
+		}
+		req.Tables = append(req.Tables, g.pluginTable(meta))
+	}
+
+	return req, nil
+}
+
+// pluginTable converts table metadata to its plugin representation
+func (g *Generator) pluginTable(meta *database.TableMetadata) PluginTable {
+	table := PluginTable{
+		Schema:       meta.Schema,
+		Name:         meta.Name,
+		StructName:   g.structName(meta.Name),
+		Comment:      meta.Comment,
+		ForeignKeys:  pluginForeignKeys(meta.ForeignKeys),
+		ReferencedBy: pluginForeignKeys(meta.ReferencedBy),
+	}
+
+	override := g.TableOverride(meta.Name)
+	cols := g.columns(meta)
+	names := g.fieldNames(cols)
+	for i, col := range cols {
+		field := g.tagBuilder.BuildStructField(col, g.typeMapper)
+		field.Name = names[i].name
+		applyColumnOverride(&field, override.Column(col.Name))
+
+		table.Columns = append(table.Columns, PluginColumn{
+			Name:             col.Name,
+			DataType:         col.DataType,
+			RawType:          col.RawType,
+			Nullable:         col.IsNullable,
+			PrimaryKey:       col.IsPrimaryKey,
+			AutoIncrement:    col.IsAutoIncrement,
+			Unsigned:         col.IsUnsigned,
+			Default:          col.DefaultValue,
+			EnumValues:       col.EnumValues,
+			CharMaxLength:    col.CharMaxLength,
+			NumericPrecision: col.NumericPrecision,
+			NumericScale:     col.NumericScale,
+			Comment:          col.Comment,
+			GoName:           field.Name,
+			GoType:           field.Type,
+		})
+	}
+
+	return table
+}
+
+func pluginForeignKeys(fks []database.ForeignKey) []PluginForeignKey {
+	var out []PluginForeignKey
+	for _, fk := range fks {
+		out = append(out, PluginForeignKey{
+			Name:             fk.Name,
+			Table:            fk.Table,
+			Column:           fk.Column,
+			ReferencedTable:  fk.ReferencedTable,
+			ReferencedColumn: fk.ReferencedColumn,
+		})
+	}
+	return out
+}
+
+// RunPlugin executes a plugin with the request on stdin and returns its
+// response. The plugin's stderr is passed through for diagnostics.
+func RunPlugin(path string, req *PluginRequest) (*PluginResponse, error) {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command(path)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run plugin %s: %w", path, err)
+	}
+
+	var resp PluginResponse
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode plugin response: %w", err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("plugin %s: %s", path, resp.Error)
+	}
+
+	return &resp, nil
+}
+
+// GeneratePluginFiles runs a plugin for the given tables and writes the files
+// it returns below outputDir (or the plugin's own output directory)
+func (g *Generator) GeneratePluginFiles(plugin config.PluginConfig, tableNames []string, outputDir, driver string) ([]string, error) {
+	path, err := FindPlugin(plugin)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := g.BuildPluginRequest(tableNames, driver, plugin.Parameter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := RunPlugin(path, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if plugin.Out != "" {
+		outputDir = plugin.Out
+	}
+	return writePluginFiles(outputDir, resp.Files)
+}
+
+// writePluginFiles writes plugin output, refusing paths outside outputDir
+func writePluginFiles(outputDir string, files []PluginFile) ([]string, error) {
+	var written []string
+	for _, file := range files {
+		name := filepath.Clean(filepath.FromSlash(file.Name))
+		if file.Name == "" || filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return written, fmt.Errorf("plugin returned invalid file name %q", file.Name)
+		}
+
+		filePath := filepath.Join(outputDir, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return written, fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := os.WriteFile(filePath, []byte(file.Content), 0644); err != nil {
+			return written, fmt.Errorf("failed to write file: %w", err)
+		}
+		written = append(written, filePath)
+	}
+	return written, nil
+}
@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+)
+
+// TestMain lets the test binary act as a plugin when re-executed by RunPlugin
+func TestMain(m *testing.M) {
+	if os.Getenv("GODB_ORM_TEST_PLUGIN") == "1" {
+		runTestPlugin()
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// runTestPlugin emits one text file per table listing its columns
+func runTestPlugin() {
+	var req PluginRequest
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		json.NewEncoder(os.Stdout).Encode(PluginResponse{Error: err.Error()})
+		return
+	}
+
+	var resp PluginResponse
+	for _, table := range req.Tables {
+		var lines []string
+		for _, col := range table.Columns {
+			lines = append(lines, col.Name+" "+col.GoName+" "+col.GoType)
+		}
+		resp.Files = append(resp.Files, PluginFile{
+			Name:    req.Parameter + "/" + table.StructName + ".txt",
+			Content: strings.Join(lines, "\n"),
+		})
+	}
+	json.NewEncoder(os.Stdout).Encode(resp)
+}
+
+func TestGeneratePluginFiles(t *testing.T) {
+	t.Setenv("GODB_ORM_TEST_PLUGIN", "1")
+	outputDir := t.TempDir()
+	gen := NewGenerator(newFakeUsers())
+
+	plugin := config.PluginConfig{Name: "test", Path: os.Args[0], Parameter: "out"}
+	files, err := gen.GeneratePluginFiles(plugin, []string{"users"}, outputDir, "mysql")
+	if err != nil {
+		t.Fatalf("GeneratePluginFiles() error = %v", err)
+	}
+
+	want := filepath.Join(outputDir, "out", "User.txt")
+	if len(files) != 1 || files[0] != want {
+		t.Fatalf("GeneratePluginFiles() = %v, want [%s]", files, want)
+	}
+	content, err := os.ReadFile(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(content); got != "id ID int32\nemail Email string" {
+		t.Errorf("plugin output = %q", got)
+	}
+}
+
+func TestWritePluginFiles_RejectsEscapingPaths(t *testing.T) {
+	for _, name := range []string{"", "../x.go", "/etc/passwd", "a/../../x"} {
+		if _, err := writePluginFiles(t.TempDir(), []PluginFile{{Name: name}}); err == nil {
+			t.Errorf("writePluginFiles(%q) should fail", name)
+		}
+	}
+}
+
+func TestFindPlugin_NotFound(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	if _, err := FindPlugin(config.PluginConfig{Name: "missing"}); err == nil {
+		t.Error("FindPlugin() should fail for plugins not on PATH")
+	}
+}
@@ -0,0 +1,275 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// KeepMarker marks a hand-written field of a generated struct, in its doc or
+// line comment, so regeneration keeps it:
+//
+//	Score int `json:"score" gorm:"-"` // godb:keep
+const KeepMarker = "godb:keep"
+
+// generatedTagKeys are the struct tag keys the generator emits itself. They
+// always follow the current settings; any other key found on a field of the
+// existing file was added by hand and is kept.
+var generatedTagKeys = append([]string{"gorm", "json"}, knownTagSets...)
+
+// MergeExisting carries the hand edits of the file at filePath over to
+// freshly generated code: struct tags added to generated fields (e.g.
+// validate:"required") and extra fields marked with KeepMarker. A missing
+// file, or one that doesn't parse, leaves the code as generated.
+func MergeExisting(code []byte, filePath string) ([]byte, error) {
+	existing, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return code, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return MergePreserved(filepath.Base(filePath), code, existing)
+}
+
+// sourceEdit replaces the bytes between start and end with text
+type sourceEdit struct {
+	start, end int
+	text       string
+}
+
+// MergePreserved merges the hand edits of existing, a previous version of a
+// generated file, into generated. Structs are matched by name and fields by
+// field name; fields the generator no longer emits are dropped unless marked
+// with KeepMarker, and a kept field whose name is generated again gives way
+// to the generated one.
+func MergePreserved(fileName string, generated, existing []byte) ([]byte, error) {
+	oldSet := token.NewFileSet()
+	old, err := parser.ParseFile(oldSet, fileName, existing, parser.ParseComments)
+	if err != nil {
+		// A broken file has nothing reliable to carry over
+		return generated, nil
+	}
+	oldStructs := structTypes(old)
+	if len(oldStructs) == 0 {
+		return generated, nil
+	}
+
+	genSet := token.NewFileSet()
+	gen, err := parser.ParseFile(genSet, fileName, generated, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse generated code: %w", err)
+	}
+
+	offset := func(set *token.FileSet, pos token.Pos) int { return set.Position(pos).Offset }
+	var edits []sourceEdit
+	packages := make(map[string]bool) // Packages referenced by kept fields
+	for name, st := range structTypes(gen) {
+		prev, ok := oldStructs[name]
+		if !ok {
+			continue
+		}
+		prevFields := make(map[string]*ast.Field)
+		for _, field := range prev.Fields.List {
+			for _, ident := range field.Names {
+				prevFields[ident.Name] = field
+			}
+		}
+
+		generatedNames := make(map[string]bool)
+		for _, field := range st.Fields.List {
+			for _, ident := range field.Names {
+				generatedNames[ident.Name] = true
+			}
+			if len(field.Names) != 1 || prevFields[field.Names[0].Name] == nil {
+				continue
+			}
+			tag, ok := mergeHandTags(fieldTag(field), fieldTag(prevFields[field.Names[0].Name]))
+			if !ok {
+				continue
+			}
+			if field.Tag != nil {
+				edits = append(edits, sourceEdit{offset(genSet, field.Tag.Pos()), offset(genSet, field.Tag.End()), tagSource(tag)})
+			} else {
+				end := offset(genSet, field.Type.End())
+				edits = append(edits, sourceEdit{end, end, " " + tagSource(tag)})
+			}
+		}
+
+		var kept []string
+		for _, field := range prev.Fields.List {
+			if !keepMarked(field) || anyGenerated(field, generatedNames) {
+				continue
+			}
+			start, end := field.Pos(), field.End()
+			if field.Doc != nil {
+				start = field.Doc.Pos()
+			}
+			if field.Comment != nil {
+				end = field.Comment.End()
+			}
+			kept = append(kept, string(existing[offset(oldSet, start):offset(oldSet, end)]))
+			ast.Inspect(field.Type, func(n ast.Node) bool {
+				if sel, ok := n.(*ast.SelectorExpr); ok {
+					if ident, ok := sel.X.(*ast.Ident); ok {
+						packages[ident.Name] = true
+					}
+				}
+				return true
+			})
+		}
+		if len(kept) > 0 {
+			closing := offset(genSet, st.Fields.Closing)
+			edits = append(edits, sourceEdit{closing, closing, strings.Join(kept, "\n") + "\n"})
+		}
+	}
+	if len(edits) == 0 {
+		return generated, nil
+	}
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	merged := append([]byte(nil), generated...)
+	for _, e := range edits {
+		merged = append(merged[:e.start], append([]byte(e.text), merged[e.end:]...)...)
+	}
+
+	if len(packages) > 0 {
+		merged, err = addImports(fileName, merged, old, packages)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return FormatSource(fileName, merged)
+}
+
+// structTypes returns the struct types declared in a file by name
+func structTypes(file *ast.File) map[string]*ast.StructType {
+	structs := make(map[string]*ast.StructType)
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				structs[ts.Name.Name] = st
+			}
+		}
+	}
+	return structs
+}
+
+// fieldTag returns the unquoted struct tag of a field
+func fieldTag(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	return tag
+}
+
+// tagSource renders a struct tag as a Go literal, raw unless it contains a
+// backquote
+func tagSource(tag string) string {
+	if strings.Contains(tag, "`") {
+		return strconv.Quote(tag)
+	}
+	return "`" + tag + "`"
+}
+
+// mergeHandTags appends the hand-added pairs of existing to generated. It
+// reports false if there are none.
+func mergeHandTags(generated, existing string) (string, bool) {
+	have := make(map[string]bool)
+	for _, key := range generatedTagKeys {
+		have[key] = true
+	}
+	for _, tag := range parseTags(generated) {
+		have[tag.key] = true
+	}
+
+	merged := generated
+	for _, tag := range parseTags(existing) {
+		if have[tag.key] {
+			continue
+		}
+		have[tag.key] = true
+		if merged != "" {
+			merged += " "
+		}
+		merged += formatTag(tag)
+	}
+	return merged, merged != generated
+}
+
+// keepMarked reports whether the doc or line comment of a field carries
+// KeepMarker. Comments are matched raw: //godb:keep reads as a directive and
+// is left out of CommentGroup.Text.
+func keepMarked(field *ast.Field) bool {
+	for _, group := range []*ast.CommentGroup{field.Doc, field.Comment} {
+		if group == nil {
+			continue
+		}
+		for _, c := range group.List {
+			if strings.Contains(c.Text, KeepMarker) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// anyGenerated reports whether one of the names of a field is generated
+func anyGenerated(field *ast.Field, generated map[string]bool) bool {
+	for _, ident := range field.Names {
+		if generated[ident.Name] {
+			return true
+		}
+	}
+	return false
+}
+
+// addImports adds the imports of old that provide the given packages to src,
+// so kept fields keep compiling; FormatSource drops the ones unused after all
+func addImports(fileName string, src []byte, old *ast.File, packages map[string]bool) ([]byte, error) {
+	set := token.NewFileSet()
+	file, err := parser.ParseFile(set, fileName, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse merged code: %w", err)
+	}
+	for _, spec := range old.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(importPath)
+		alias := ""
+		if spec.Name != nil {
+			name, alias = spec.Name.Name, spec.Name.Name
+		}
+		if packages[name] {
+			astutil.AddNamedImport(set, file, alias, importPath)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, set, file); err != nil {
+		return nil, fmt.Errorf("failed to format merged code: %w", err)
+	}
+	return buf.Bytes(), nil
+}
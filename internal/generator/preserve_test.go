@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestGenerateToFilePreservesHandEdits(t *testing.T) {
+	outputDir := t.TempDir()
+	fake := newFakeUsers()
+	gen := NewGenerator(fake)
+
+	filePath, err := gen.GenerateToFile("users", outputDir)
+	if err != nil {
+		t.Fatalf("GenerateToFile() error = %v", err)
+	}
+	original, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Hand edits: a validate tag, a kept field needing an import and a kept
+	// field with a doc comment
+	edited := strings.Replace(string(original), `json:"email"`, `json:"email" validate:"required,email"`, 1)
+	edited = strings.Replace(edited, "package models\n", "package models\n\nimport \"net/netip\"\n", 1)
+	edited = strings.Replace(edited, "\n}\n", "\n\tLastIP netip.Addr `gorm:\"-\"` // godb:keep\n\t// Score is computed by the ranking job\n\t//godb:keep\n\tScore int `gorm:\"-\"`\n}\n", 1)
+	formatted, err := FormatSource("users.go", []byte(edited))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filePath, formatted, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if upToDate, err := gen.IsUpToDate("users", outputDir); err != nil || !upToDate {
+		t.Errorf("IsUpToDate() = %v, %v; hand edits should not make the file outdated", upToDate, err)
+	}
+
+	// Fields without the marker are generated code and get dropped
+	withScratch := strings.Replace(string(formatted), "\n}\n", "\n\tScratch string\n}\n", 1)
+	if err := os.WriteFile(filePath, []byte(withScratch), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A schema change regenerates the file around the hand edits
+	users := fake.tables["users"]
+	users.Columns = append(users.Columns, database.ColumnMetadata{Name: "name", DataType: "varchar", RawType: "varchar(100)"})
+	if _, err := gen.GenerateToFile("users", outputDir); err != nil {
+		t.Fatalf("GenerateToFile() error = %v", err)
+	}
+	code, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := string(code)
+	if _, err := parser.ParseFile(token.NewFileSet(), "users.go", code, 0); err != nil {
+		t.Fatalf("merged code does not parse: %v\n%s", err, src)
+	}
+	for _, want := range []string{
+		`json:"email" validate:"required,email"`,
+		"Name ",
+		`"net/netip"`,
+		"LastIP netip.Addr `gorm:\"-\"` // godb:keep",
+		"// Score is computed by the ranking job\n\t//godb:keep\n\tScore",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("missing %q:\n%s", want, src)
+		}
+	}
+	if strings.Contains(src, "Scratch") {
+		t.Errorf("unmarked field kept:\n%s", src)
+	}
+}
+
+func TestMergeHandTags(t *testing.T) {
+	tests := []struct {
+		generated string
+		existing  string
+		want      string
+		changed   bool
+	}{
+		{`json:"id"`, `json:"id" validate:"required"`, `json:"id" validate:"required"`, true},
+		{`json:"id"`, `json:"user_id"`, `json:"id"`, false},
+		{`gorm:"column:id" json:"id"`, `gorm:"column:id" json:"id" yaml:"id"`, `gorm:"column:id" json:"id"`, false},
+		{`json:"id"`, `binding:"required" json:"id" validate:"gt=0"`, `json:"id" binding:"required" validate:"gt=0"`, true},
+	}
+	for _, tt := range tests {
+		got, changed := mergeHandTags(tt.generated, tt.existing)
+		if got != tt.want || changed != tt.changed {
+			t.Errorf("mergeHandTags(%q, %q) = %q, %v; want %q, %v", tt.generated, tt.existing, got, changed, tt.want, tt.changed)
+		}
+	}
+}
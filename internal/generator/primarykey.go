@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// NoPrimaryKeyMode controls how tables without a primary key are generated.
+// GORM needs a primary key to Save, Update or Delete a single row.
+type NoPrimaryKeyMode string
+
+const (
+	// NoPrimaryKeyGenerate generates the struct like any other (default)
+	NoPrimaryKeyGenerate NoPrimaryKeyMode = "generate"
+	// NoPrimaryKeySkip leaves the table out, reporting ErrNoPrimaryKey
+	NoPrimaryKeySkip NoPrimaryKeyMode = "skip"
+	// NoPrimaryKeyReadOnly generates a struct GORM only reads, tagging
+	// every column gorm:"->"
+	NoPrimaryKeyReadOnly NoPrimaryKeyMode = "read_only"
+)
+
+// ErrNoPrimaryKey is returned for tables without a primary key when the
+// generator skips them (NoPrimaryKeySkip)
+var ErrNoPrimaryKey = errors.New("table has no primary key")
+
+// readOnlyDoc documents the structs of read-only tables
+const readOnlyDoc = "Read-only: the table has no primary key, so GORM only queries it."
+
+// applyLogicalKey marks the columns of the table's configured logical key
+// (overrides.<table>.primary_key) as primary key columns, so they get
+// primaryKey tags. The metadata is copied, leaving the introspector's
+// intact.
+func (g *Generator) applyLogicalKey(meta *database.TableMetadata) (*database.TableMetadata, error) {
+	key := g.TableOverride(meta.Name).PrimaryKey
+	if len(key) == 0 || hasPrimaryKey(meta) {
+		return meta, nil
+	}
+
+	copied := *meta
+	copied.Columns = append([]database.ColumnMetadata(nil), meta.Columns...)
+	for _, name := range key {
+		found := false
+		for i := range copied.Columns {
+			if strings.EqualFold(copied.Columns[i].Name, name) {
+				copied.Columns[i].IsPrimaryKey = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("primary_key column %q not found in table %s", name, meta.Name)
+		}
+	}
+	return &copied, nil
+}
+
+// noPrimaryKeyMode returns how a table is generated: the configured mode if
+// it has no primary key, or NoPrimaryKeyGenerate. Models without gorm tags
+// (Trino, MongoDB) are never written through GORM, so the mode doesn't
+// apply to them.
+func (g *Generator) noPrimaryKeyMode(meta *database.TableMetadata) NoPrimaryKeyMode {
+	if g.tagBuilder.noGormTag || hasPrimaryKey(meta) {
+		return NoPrimaryKeyGenerate
+	}
+	switch g.noPrimaryKey {
+	case NoPrimaryKeySkip, NoPrimaryKeyReadOnly:
+		return g.noPrimaryKey
+	}
+	return NoPrimaryKeyGenerate
+}
+
+// readOnlyTags adds GORM's read-only permission (->) to a field's gorm tag,
+// unless the field already sets a permission
+func readOnlyTags(tags string) string {
+	parsed := parseTags(tags)
+	parts := make([]string, 0, len(parsed)+1)
+	found := false
+	for _, tag := range parsed {
+		if tag.key == "gorm" {
+			if !strings.Contains(tag.value, "->") && !strings.Contains(tag.value, "<-") {
+				tag.value += ";->"
+			}
+			found = true
+		}
+		parts = append(parts, formatTag(tag))
+	}
+	if !found {
+		parts = append(parts, `gorm:"->"`)
+	}
+	return strings.Join(parts, " ")
+}
+
+// hasPrimaryKey reports whether a table has a primary key column
+func hasPrimaryKey(meta *database.TableMetadata) bool {
+	for _, col := range meta.Columns {
+		if col.IsPrimaryKey {
+			return true
+		}
+	}
+	return false
+}
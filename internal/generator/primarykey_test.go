@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func noPrimaryKeyIntrospector() *fakeIntrospector {
+	return &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"page_views": {
+			Name: "page_views",
+			Columns: []database.ColumnMetadata{
+				{Name: "page_id", DataType: "int", RawType: "int"},
+				{Name: "day", DataType: "date", RawType: "date"},
+				{Name: "views", DataType: "int", RawType: "int"},
+			},
+		},
+		"users": {
+			Name: "users",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+			},
+		},
+	}}
+}
+
+func TestNoPrimaryKeySkip(t *testing.T) {
+	fake := noPrimaryKeyIntrospector()
+	gen := NewGeneratorWithConfig(fake, GeneratorConfig{NoPrimaryKey: NoPrimaryKeySkip})
+
+	if _, err := gen.Generate("page_views"); !errors.Is(err, ErrNoPrimaryKey) {
+		t.Fatalf("Generate() error = %v, want ErrNoPrimaryKey", err)
+	}
+
+	dir := t.TempDir()
+	files, err := gen.GenerateAll(dir)
+	if err != nil {
+		t.Fatalf("GenerateAll() error = %v", err)
+	}
+	if len(files) != 1 || filepath.Base(files[0]) != "users.go" {
+		t.Errorf("GenerateAll() = %v, want only users.go", files)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "page_views.go")); !os.IsNotExist(err) {
+		t.Errorf("skipped table was written: %v", err)
+	}
+}
+
+func TestNoPrimaryKeyReadOnly(t *testing.T) {
+	fake := noPrimaryKeyIntrospector()
+	code, err := NewGeneratorWithConfig(fake, GeneratorConfig{NoPrimaryKey: NoPrimaryKeyReadOnly}).GenerateString("page_views")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	for _, want := range []string{
+		"// " + readOnlyDoc,
+		`gorm:"column:page_id;type:int;not null;->"`,
+		`gorm:"column:views;type:int;not null;->"`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+
+	code, err = NewGeneratorWithConfig(fake, GeneratorConfig{NoPrimaryKey: NoPrimaryKeyReadOnly}).GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if strings.Contains(code, "->") {
+		t.Errorf("table with a primary key generated read-only:\n%s", code)
+	}
+}
+
+func TestLogicalPrimaryKey(t *testing.T) {
+	fake := noPrimaryKeyIntrospector()
+	cfg := GeneratorConfig{
+		NoPrimaryKey: NoPrimaryKeySkip,
+		Overrides: map[string]config.TableOverride{
+			"page_views": {PrimaryKey: []string{"page_id", "day"}},
+		},
+	}
+	gen := NewGeneratorWithConfig(fake, cfg)
+
+	code, err := gen.GenerateString("page_views")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	for _, want := range []string{
+		`gorm:"primaryKey;column:page_id;type:int"`,
+		`gorm:"primaryKey;column:day;type:date"`,
+		`gorm:"column:views;type:int;not null"`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+	if fake.tables["page_views"].Columns[0].IsPrimaryKey {
+		t.Error("logical key modified the introspected metadata")
+	}
+
+	issues, err := gen.Lint("page_views")
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Lint() = %v, want no issues for a table with a logical key", issues)
+	}
+
+	cfg.Overrides["page_views"] = config.TableOverride{PrimaryKey: []string{"page"}}
+	if _, err := NewGeneratorWithConfig(fake, cfg).Generate("page_views"); err == nil || !strings.Contains(err.Error(), `"page"`) {
+		t.Errorf("Generate() error = %v, want unknown primary_key column", err)
+	}
+}
@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// generatedMethods are the model methods the generator may emit; a getter
+// with the same name is prefixed with Get instead
+var generatedMethods = map[string]bool{
+	"TableName":     true,
+	"ToMap":         true,
+	"Columns":       true,
+	"ScanRow":       true,
+	"WithTx":        true,
+	"TenantScope":   true,
+	"BeforeCreate":  true,
+	"Insert":        true,
+	"Update":        true,
+	"Delete":        true,
+	"Reload":        true,
+	"SelectColumns": true,
+	"InsertColumns": true,
+	"InsertValues":  true,
+}
+
+// validatePrivateFields rejects private fields combined with options whose
+// code hands the model itself to GORM. GORM only sees exported fields, so it
+// would find no columns, no primary key and no associations; private-field
+// models are written through ToMap() instead.
+func validatePrivateFields(cfg GeneratorConfig) error {
+	if !cfg.PrivateFields {
+		return nil
+	}
+	var conflicts []string
+	if cfg.Style == StyleFull {
+		conflicts = append(conflicts, "the full style")
+	}
+	if cfg.Repositories {
+		conflicts = append(conflicts, "repositories, handlers or DI providers")
+	}
+	if cfg.Pagination {
+		conflicts = append(conflicts, "pagination")
+	}
+	if (cfg.Relations != "" && cfg.Relations != RelationsNone) || cfg.InferRelations {
+		conflicts = append(conflicts, "relations")
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return fmt.Errorf("private fields cannot be combined with %s: GORM does not see unexported fields", strings.Join(conflicts, ", "))
+}
+
+// privatizeFields unexports the fields of a model in private-field mode,
+// recording the exported name their getter and setter are named after.
+// Code in the models package (ScanRow, constructors, fakes) keeps using
+// the fields directly. The tags are dropped, as encoding/json and GORM
+// ignore unexported fields.
+func (g *Generator) privatizeFields(fields []StructField) {
+	if !g.privateFields {
+		return
+	}
+	for i := range fields {
+		field := &fields[i]
+		field.Accessor = field.Name
+		field.Getter = field.Name
+		if generatedMethods[field.Getter] {
+			field.Getter = "Get" + field.Getter
+		}
+		field.Name = unexportedName(field.Name)
+		field.Tags = ""
+	}
+}
+
+// unexportedName lowercases the leading capital or acronym of a Go name:
+// ID becomes id, UserID userID and URLPath urlPath. Keywords get a
+// trailing underscore (type_).
+func unexportedName(name string) string {
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	// In URLPath the P starts the next word
+	if upper > 1 && upper < len(runes) && unicode.IsLower(runes[upper]) {
+		upper--
+	}
+	if upper == 0 {
+		upper = 1
+	}
+	for i := 0; i < upper && i < len(runes); i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	result := string(runes)
+	if token.IsKeyword(result) {
+		result += "_"
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestPrivateFields(t *testing.T) {
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"pages": {
+			Name: "pages",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+				{Name: "url_path", DataType: "varchar", RawType: "varchar(255)"},
+				{Name: "type", DataType: "varchar", RawType: "varchar(20)"},
+				{Name: "columns", DataType: "varchar", RawType: "varchar(64)"},
+			},
+		},
+	}}
+
+	code, err := NewGeneratorWithConfig(fake, GeneratorConfig{PrivateFields: true, Factories: true}).GenerateString("pages")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	code = strings.Join(strings.Fields(code), " ")
+
+	for _, want := range []string{
+		"id int32 urlPath string type_ string columns string }",
+		"func (m *Page) URLPath() string { return m.urlPath }",
+		"func (m *Page) SetURLPath(v string) { m.urlPath = v }",
+		"func (m *Page) Type() string { return m.type_ }",
+		"func (m *Page) GetColumns() string { return m.columns }",
+		"func (m *Page) SetColumns(v string) { m.columns = v }",
+		`"id": m.id, "url_path": m.urlPath, "type": m.type_, "columns": m.columns,`,
+		"func WithPageURLPath(v string) PageOption { return func(m *Page) { m.urlPath = v } }",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+
+	plain, err := NewGenerator(fake).GenerateString("pages")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if strings.Contains(plain, "ToMap") || strings.Contains(plain, "urlPath") {
+		t.Errorf("private fields generated without the option:\n%s", plain)
+	}
+}
+
+// TestPrivateFieldsTags checks what go vet's structtag check reports: tags
+// on unexported fields, which encoding/json and GORM ignore
+func TestPrivateFieldsTags(t *testing.T) {
+	code, err := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{PrivateFields: true, Swagger: true, ExtraTags: []string{"yaml"}}).GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "user.go", code, 0)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if field, ok := n.(*ast.Field); ok && field.Tag != nil {
+			for _, name := range field.Names {
+				if !name.IsExported() {
+					t.Errorf("unexported field %s has tag %s", name.Name, field.Tag.Value)
+				}
+			}
+		}
+		return true
+	})
+}
+
+func TestPrivateFieldsConflicts(t *testing.T) {
+	tests := map[string]GeneratorConfig{
+		"full style":   {PrivateFields: true, Style: StyleFull},
+		"repositories": {PrivateFields: true, Repositories: true},
+		"pagination":   {PrivateFields: true, Pagination: true},
+		"relations":    {PrivateFields: true, Relations: RelationsBelongsTo},
+		"inferred":     {PrivateFields: true, InferRelations: true},
+	}
+	for name, cfg := range tests {
+		if _, err := NewGeneratorWithConfig(newFakeUsers(), cfg).GenerateString("users"); err == nil || !strings.Contains(err.Error(), "private fields") {
+			t.Errorf("%s: GenerateString() error = %v, want a private fields error", name, err)
+		}
+	}
+}
+
+func TestUnexportedName(t *testing.T) {
+	tests := map[string]string{
+		"ID":      "id",
+		"UserID":  "userID",
+		"URLPath": "urlPath",
+		"Name":    "name",
+		"Type":    "type_",
+		"Func":    "func_",
+	}
+	for name, want := range tests {
+		if got := unexportedName(name); got != want {
+			t.Errorf("unexportedName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package generator
+
+import "fmt"
+
+// QueryBuilder selects the query builder the column helpers of a model are
+// tuned for
+type QueryBuilder string
+
+const (
+	// QueryBuilderNone emits no query builder helpers (default)
+	QueryBuilderNone QueryBuilder = "none"
+	// QueryBuilderSquirrel emits column names as []string, for
+	// sq.Select(cols...) and sq.Insert(t).Columns(cols...)
+	QueryBuilderSquirrel QueryBuilder = "squirrel"
+	// QueryBuilderGoqu emits goqu identifiers as []interface{}, for
+	// goqu.From(t).Select(cols...) and goqu.Insert(t).Cols(cols...)
+	QueryBuilderGoqu QueryBuilder = "goqu"
+)
+
+// queryBuilderMethods are the model methods emitted for a query builder
+var queryBuilderMethods = []string{"SelectColumns", "InsertColumns", "InsertValues"}
+
+// QueryColumn is a column of the query builder helpers
+type QueryColumn struct {
+	Field     string // Model field holding the column's value
+	Column    string // Column name, quoted for squirrel if needed
+	Qualified string // Column name qualified with the table name, for selects
+}
+
+// validateQueryBuilder checks that a query builder is known
+func validateQueryBuilder(builder QueryBuilder) error {
+	switch builder {
+	case "", QueryBuilderNone, QueryBuilderSquirrel, QueryBuilderGoqu:
+		return nil
+	}
+	return fmt.Errorf("unknown query builder %q (want squirrel or goqu)", builder)
+}
+
+// applyQueryBuilder fills in the columns of the SelectColumns, InsertColumns
+// and InsertValues helpers of a model. Auto-increment columns are left out
+// of inserts so the database assigns them. goqu helpers need goqu, which is
+// added to importMgr. squirrel takes raw SQL, so its names are quoted where
+// needed; goqu quotes identifiers itself.
+func (g *Generator) applyQueryBuilder(data *TemplateData, importMgr *ImportManager) {
+	if g.queryBuilder == "" || g.queryBuilder == QueryBuilderNone {
+		return
+	}
+	data.QueryBuilder = string(g.queryBuilder)
+	data.TableConst = g.tableConst(data.TableName)
+
+	autoIncrement := make(map[string]bool)
+	for _, col := range data.Table.Columns {
+		if col.IsAutoIncrement {
+			autoIncrement[col.Name] = true
+		}
+	}
+	for _, field := range data.Fields {
+		if field.Column == "" {
+			continue
+		}
+		col := QueryColumn{
+			Field:     field.Name,
+			Column:    field.Column,
+			Qualified: data.TableName + "." + field.Column,
+		}
+		if g.queryBuilder == QueryBuilderSquirrel {
+			col.Column = sqlIdent(field.Column, g.dialect)
+			col.Qualified = sqlIdent(data.TableName, g.dialect) + "." + col.Column
+		}
+		data.SelectColumns = append(data.SelectColumns, col)
+		if !autoIncrement[field.Column] {
+			data.InsertColumns = append(data.InsertColumns, col)
+		}
+	}
+
+	if g.queryBuilder == QueryBuilderGoqu {
+		importMgr.Add(WellKnownImports.Goqu)
+		data.Imports = importMgr.GenerateImportBlock()
+	}
+}
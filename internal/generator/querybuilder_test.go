@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQueryBuilderHelpers(t *testing.T) {
+	tests := []struct {
+		builder QueryBuilder
+		want    []string
+	}{
+		{
+			builder: QueryBuilderSquirrel,
+			want: []string{
+				"// sq.Select(User{}.SelectColumns()...).From(TableUsers)",
+				`func (User) SelectColumns() []string { return []string{ "users.id", "users.email", } }`,
+				`func (User) InsertColumns() []string { return []string{ "email", } }`,
+				"func (m *User) InsertValues() []interface{} { return []interface{}{ m.Email, } }",
+			},
+		},
+		{
+			builder: QueryBuilderGoqu,
+			want: []string{
+				`"github.com/doug-martin/goqu/v9"`,
+				`return []interface{}{ goqu.I("users.id"), goqu.I("users.email"), }`,
+				`func (User) InsertColumns() []interface{} { return []interface{}{ goqu.C("email"), } }`,
+				"// goqu.Insert(TableUsers).Cols(User{}.InsertColumns()...).Vals(m.InsertValues())",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.builder), func(t *testing.T) {
+			code, err := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{QueryBuilder: tt.builder}).GenerateString("users")
+			if err != nil {
+				t.Fatalf("GenerateString() error = %v", err)
+			}
+			code = strings.Join(strings.Fields(code), " ")
+			for _, want := range tt.want {
+				if !strings.Contains(code, want) {
+					t.Errorf("generated code missing %q:\n%s", want, code)
+				}
+			}
+		})
+	}
+
+	if err := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{QueryBuilder: "sqlx"}).Err(); err == nil {
+		t.Error("Err() = nil for an unknown query builder")
+	}
+}
@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/rowjak/godb-orm/internal/config"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// RelationMode controls which association fields are generated
+type RelationMode string
+
+const (
+	// RelationsNone emits no association fields (default)
+	RelationsNone RelationMode = "none"
+	// RelationsBelongsTo emits belongs-to fields for foreign keys declared on the table
+	RelationsBelongsTo RelationMode = "belongs_to"
+	// RelationsAll also emits reverse has-many collections, which can make
+	// structs for hub tables very large
+	RelationsAll RelationMode = "all"
+)
+
+// buildRelationFields creates association fields from the table's foreign keys.
+// columnFields are the table's column fields; relations never collide with
+// them and refer to renamed key columns by their field names.
+func (g *Generator) buildRelationFields(meta *database.TableMetadata, columnFields []StructField) []StructField {
+	if g.relationMode == "" || g.relationMode == RelationsNone {
+		return nil
+	}
+
+	names := columnFieldNames(columnFields)
+	existing := make(map[string]bool, len(columnFields))
+	for _, field := range columnFields {
+		existing[field.Name] = true
+	}
+	keyField := func(column string) string {
+		if name, ok := names[column]; ok {
+			return name
+		}
+		return g.namingConv.ToGoFieldName(column)
+	}
+
+	rule := config.LookupTable(g.relationRules, meta.Name)
+	var fields []StructField
+
+	add := func(field StructField, relatedTable string) {
+		if existing[field.Name] || !rule.Permits(relatedTable, field.Name) {
+			return
+		}
+		existing[field.Name] = true
+		fields = append(fields, field)
+	}
+
+	// Belongs-to: orders.user_id -> users.id gives Order.User *User
+	for _, fk := range meta.ForeignKeys {
+		name := g.belongsToFieldName(fk)
+		add(StructField{
+			Name: name,
+			Type: "*" + g.structName(fk.ReferencedTable),
+			Tags: relationTags(
+				keyField(fk.Column),
+				g.namingConv.ToGoFieldName(fk.ReferencedColumn),
+				g.tagBuilder.jsonName(strcase.ToSnake(name)),
+			),
+			Comment: relationComment(fk),
+		}, fk.ReferencedTable)
+	}
+
+	if g.relationMode != RelationsAll {
+		return fields
+	}
+
+	// Has-many: orders.user_id -> users.id gives User.Orders []Order
+	counts := make(map[string]int)
+	for _, fk := range meta.ReferencedBy {
+		counts[fk.Table]++
+	}
+	for _, fk := range meta.ReferencedBy {
+		name := g.namingConv.ToGoFieldName(g.baseName(fk.Table))
+		if counts[fk.Table] > 1 {
+			// Disambiguate several foreign keys from the same table
+			name += "By" + g.namingConv.ToGoFieldName(strings.TrimSuffix(fk.Column, "_id"))
+		}
+		add(StructField{
+			Name: name,
+			Type: "[]" + g.structName(fk.Table),
+			Tags: relationTags(
+				g.namingConv.ToGoFieldName(fk.Column),
+				keyField(fk.ReferencedColumn),
+				g.tagBuilder.jsonName(strcase.ToSnake(name)),
+			),
+			Comment: relationComment(fk),
+		}, fk.Table)
+	}
+
+	return fields
+}
+
+// belongsToFieldName derives the association name from the foreign key column
+// (user_id -> User), falling back to the referenced struct name
+func (g *Generator) belongsToFieldName(fk database.ForeignKey) string {
+	base := strings.TrimSuffix(strings.TrimSuffix(fk.Column, "_id"), "_ID")
+	if base == fk.Column || base == "" {
+		return g.structName(fk.ReferencedTable)
+	}
+	return g.namingConv.ToGoFieldName(base)
+}
+
+// relationTags builds the struct tags for an association field
+func relationTags(foreignKey, references, jsonName string) string {
+	return fmt.Sprintf(`gorm:"foreignKey:%s;references:%s" json:"%s,omitempty"`, foreignKey, references, jsonName)
+}
+
+// relationComment marks association fields whose foreign key was inferred
+// from naming conventions, so readers know the database does not enforce it
+func relationComment(fk database.ForeignKey) string {
+	if fk.Inferred {
+		return "// inferred"
+	}
+	return ""
+}
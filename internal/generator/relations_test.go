@@ -0,0 +1,221 @@
+package generator
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func newFakeShop() *fakeIntrospector {
+	orderUser := database.ForeignKey{Name: "fk_orders_user", Table: "orders", Column: "user_id", ReferencedTable: "users", ReferencedColumn: "id"}
+	reviewUser := database.ForeignKey{Name: "fk_reviews_user", Table: "reviews", Column: "user_id", ReferencedTable: "users", ReferencedColumn: "id"}
+
+	return &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"users": {
+			Name: "users",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+			},
+			ReferencedBy: []database.ForeignKey{orderUser, reviewUser},
+		},
+		"orders": {
+			Name: "orders",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+				{Name: "user_id", DataType: "int", RawType: "int"},
+			},
+			ForeignKeys: []database.ForeignKey{orderUser},
+		},
+	}}
+}
+
+func TestRelations(t *testing.T) {
+	tests := []struct {
+		name     string
+		table    string
+		cfg      GeneratorConfig
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "none by default",
+			table:    "orders",
+			excludes: []string{"*User"},
+		},
+		{
+			name:     "belongs to",
+			table:    "orders",
+			cfg:      GeneratorConfig{Relations: RelationsBelongsTo},
+			contains: []string{"User *User", `gorm:"foreignKey:UserID;references:ID" json:"user,omitempty"`},
+		},
+		{
+			name:     "belongs to skips has many",
+			table:    "users",
+			cfg:      GeneratorConfig{Relations: RelationsBelongsTo},
+			excludes: []string{"[]Order"},
+		},
+		{
+			name:     "all includes has many",
+			table:    "users",
+			cfg:      GeneratorConfig{Relations: RelationsAll},
+			contains: []string{"Orders []Order", "Reviews []Review"},
+		},
+		{
+			name:  "deny by table",
+			table: "users",
+			cfg: GeneratorConfig{
+				Relations:     RelationsAll,
+				RelationRules: map[string]config.RelationRule{"users": {Deny: []string{"reviews"}}},
+			},
+			contains: []string{"Orders []Order"},
+			excludes: []string{"[]Review"},
+		},
+		{
+			name:  "allow by field name",
+			table: "users",
+			cfg: GeneratorConfig{
+				Relations:     RelationsAll,
+				RelationRules: map[string]config.RelationRule{"users": {Allow: []string{"Reviews"}}},
+			},
+			contains: []string{"Reviews []Review"},
+			excludes: []string{"[]Order"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewGeneratorWithConfig(newFakeShop(), tt.cfg)
+			code, err := gen.GenerateString(tt.table)
+			if err != nil {
+				t.Fatalf("GenerateString() error = %v", err)
+			}
+			// Collapse gofmt alignment so expectations can use single spaces
+			code = strings.Join(strings.Fields(code), " ")
+			for _, want := range tt.contains {
+				if !strings.Contains(code, want) {
+					t.Errorf("generated code missing %q:\n%s", want, code)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(code, unwanted) {
+					t.Errorf("generated code should not contain %q:\n%s", unwanted, code)
+				}
+			}
+		})
+	}
+}
+
+func TestRelationsSkippedByScanHelpers(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeShop(), GeneratorConfig{Relations: RelationsBelongsTo, ScanHelpers: true})
+	code, err := gen.GenerateString("orders")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if strings.Contains(code, "&m.User,") {
+		t.Errorf("ScanRow should not scan association fields:\n%s", code)
+	}
+}
+
+func newFakeUndeclaredShop() *fakeIntrospector {
+	id := database.ColumnMetadata{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true, IsAutoIncrement: true}
+	return &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"categories": {Name: "categories", Columns: []database.ColumnMetadata{id}},
+		"products": {
+			Name: "products",
+			Columns: []database.ColumnMetadata{
+				id,
+				{Name: "category_id", DataType: "int", RawType: "int"},
+				{Name: "legacy_id", DataType: "int", RawType: "int"},
+			},
+		},
+	}}
+}
+
+func TestInferRelations(t *testing.T) {
+	tests := []struct {
+		name     string
+		table    string
+		cfg      GeneratorConfig
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "off by default",
+			table:    "products",
+			cfg:      GeneratorConfig{Relations: RelationsAll},
+			excludes: []string{"*Category"},
+		},
+		{
+			name:     "implies belongs to",
+			table:    "products",
+			cfg:      GeneratorConfig{InferRelations: true},
+			contains: []string{"Category *Category", `json:"category,omitempty"` + "` // inferred"},
+			excludes: []string{"*Legacy"},
+		},
+		{
+			name:     "has many with all relations",
+			table:    "categories",
+			cfg:      GeneratorConfig{InferRelations: true, Relations: RelationsAll},
+			contains: []string{"Products []Product", `json:"products,omitempty"` + "` // inferred"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewGeneratorWithConfig(newFakeUndeclaredShop(), tt.cfg)
+			code, err := gen.GenerateString(tt.table)
+			if err != nil {
+				t.Fatalf("GenerateString() error = %v", err)
+			}
+			code = strings.Join(strings.Fields(code), " ")
+			for _, want := range tt.contains {
+				if !strings.Contains(code, want) {
+					t.Errorf("generated code missing %q:\n%s", want, code)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(code, unwanted) {
+					t.Errorf("generated code should not contain %q:\n%s", unwanted, code)
+				}
+			}
+		})
+	}
+}
+
+func TestDeclaredRelationsNotMarkedInferred(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeShop(), GeneratorConfig{InferRelations: true})
+	code, err := gen.GenerateString("orders")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	code = strings.Join(strings.Fields(code), " ")
+	if !strings.Contains(code, "User *User") || strings.Contains(code, "// inferred") {
+		t.Errorf("declared foreign key should give an unmarked User field:\n%s", code)
+	}
+}
+
+func TestInferRelationsConcurrent(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeUndeclaredShop(), GeneratorConfig{InferRelations: true, Relations: RelationsAll})
+	tables := []string{"categories", "products"}
+
+	// Release all goroutines at once so the first calls overlap
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		for _, table := range tables {
+			wg.Add(1)
+			go func(table string) {
+				defer wg.Done()
+				<-start
+				if _, err := gen.GenerateString(table); err != nil {
+					t.Errorf("GenerateString(%q) error = %v", table, err)
+				}
+			}(table)
+		}
+	}
+	close(start)
+	wg.Wait()
+}
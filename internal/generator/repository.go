@@ -0,0 +1,57 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/iancoleman/strcase"
+)
+
+// MockTool selects the mock generator the go:generate line above a
+// repository interface runs
+type MockTool string
+
+const (
+	// MockToolMockery runs mockery for the interface (default)
+	MockToolMockery MockTool = "mockery"
+	// MockToolMockgen runs gomock's mockgen for the file
+	MockToolMockgen MockTool = "mockgen"
+)
+
+// MockDir is the directory, relative to the models package, mockgen writes
+// mocks to
+const MockDir = "mocks"
+
+// validateMockTool checks that a mock tool is known
+func validateMockTool(tool MockTool) error {
+	switch tool {
+	case "", MockToolMockery, MockToolMockgen:
+		return nil
+	}
+	return fmt.Errorf("unknown mock tool %q (want mockery or mockgen)", tool)
+}
+
+// applyRepository fills in the repository interface of a model and its GORM
+// implementation when repositories are enabled, with the go:generate line
+// creating a mock of the interface. Finding, updating and deleting a row
+// need a primary key and are left out for tables without one. The
+// repository needs context and gorm, which are added to importMgr.
+func (g *Generator) applyRepository(data *TemplateData, importMgr *ImportManager) {
+	if !g.repositories {
+		return
+	}
+
+	data.Repository = data.StructName + "Repository"
+	data.RepositoryImpl = strcase.ToLowerCamel(data.StructName) + "Repository"
+	g.applyKeyParams(data)
+
+	switch g.mockTool {
+	case MockToolMockgen:
+		data.MockDirective = fmt.Sprintf("mockgen -source=$GOFILE -destination=%s/mock_$GOFILE -package=%s", MockDir, MockDir)
+	default:
+		data.MockDirective = "mockery --name=" + data.Repository
+	}
+
+	importMgr.Add("context")
+	importMgr.Add(WellKnownImports.GormDriver)
+	data.Imports = importMgr.GenerateImportBlock()
+}
@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestRepository(t *testing.T) {
+	code, err := NewGeneratorWithConfig(newFakeUsers(), GeneratorConfig{Repositories: true}).GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	for _, want := range []string{
+		"\"context\"",
+		"//go:generate mockery --name=UserRepository\ntype UserRepository interface {",
+		"Find(ctx context.Context, id int32) (*User, error)",
+		"List(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) ([]User, error)",
+		"func NewUserRepository(db *gorm.DB) UserRepository {",
+		"return &userRepository{db: db}",
+		`r.db.WithContext(ctx).Where("users.id = ?", id).First(&m)`,
+		"func (r *userRepository) Delete(ctx context.Context, m *User) error {",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+
+	// Without a primary key, only listing and creating rows is possible
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"events": {Name: "events", Columns: []database.ColumnMetadata{{Name: "name", DataType: "varchar", RawType: "varchar(50)"}}},
+	}}
+	code, err = NewGeneratorWithConfig(fake, GeneratorConfig{Repositories: true, MockTool: MockToolMockgen}).GenerateString("events")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if !strings.Contains(code, "//go:generate mockgen -source=$GOFILE -destination=mocks/mock_$GOFILE -package=mocks") {
+		t.Errorf("mockgen directive missing:\n%s", code)
+	}
+	if strings.Contains(code, "Find(ctx") || strings.Contains(code, "Delete(ctx") {
+		t.Errorf("row methods generated for a table without a primary key:\n%s", code)
+	}
+}
@@ -0,0 +1,67 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// DefaultTenantColumn is the column scoped by the tenant scope when no
+// tenant column is configured
+const DefaultTenantColumn = "tenant_id"
+
+// applyScaffold fills in the hook, scope and transaction helpers enabled by
+// the generator options. The helpers need gorm, which is added to importMgr.
+func (g *Generator) applyScaffold(data *TemplateData, importMgr *ImportManager) {
+	primary := make(map[string]database.ColumnMetadata)
+	for _, col := range data.Table.Columns {
+		if col.IsPrimaryKey {
+			primary[col.Name] = col
+		}
+	}
+
+	var keys []StructField
+	for _, field := range data.Fields {
+		if field.Column == "" {
+			continue
+		}
+		if _, ok := primary[field.Column]; ok {
+			keys = append(keys, field)
+		}
+		if g.scopes && field.Column == g.tenantColumn() {
+			data.TenantColumn = field.Column
+			data.TenantType = strings.TrimPrefix(field.Type, "*")
+		}
+	}
+
+	if g.hooks && len(primary) == 1 && len(keys) == 1 && needsUUIDHook(keys[0], primary[keys[0].Column]) {
+		data.UUIDPrimaryKey = keys[0].Name
+		data.UUIDPrimaryKeyType = keys[0].Type
+		importMgr.Add(WellKnownImports.UUID)
+	}
+	data.WithTx = g.withTx
+
+	if data.UUIDPrimaryKey != "" || data.TenantColumn != "" || data.WithTx {
+		importMgr.Add(WellKnownImports.GormDriver)
+		data.Imports = importMgr.GenerateImportBlock()
+	}
+}
+
+// tenantColumn returns the configured tenant column or DefaultTenantColumn
+func (g *Generator) tenantColumn() string {
+	if g.tenantCol != "" {
+		return g.tenantCol
+	}
+	return DefaultTenantColumn
+}
+
+// needsUUIDHook reports whether a primary key must be assigned a UUID by
+// the application: it holds a UUID (a uuid.UUID field, or a string field
+// for a uuid column) and the database has no default such as
+// gen_random_uuid() to fill it in
+func needsUUIDHook(field StructField, col database.ColumnMetadata) bool {
+	if col.DefaultValue != nil {
+		return false
+	}
+	return field.Type == "uuid.UUID" || (field.Type == "string" && strings.EqualFold(col.DataType, "uuid"))
+}
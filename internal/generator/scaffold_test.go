@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func newFakeAccounts() *fakeIntrospector {
+	return &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"accounts": {
+			Name: "accounts",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "uuid", RawType: "uuid", IsPrimaryKey: true},
+				{Name: "tenant_id", DataType: "bigint", RawType: "bigint", IsNullable: true},
+				{Name: "name", DataType: "text", RawType: "text"},
+			},
+		},
+	}}
+}
+
+func TestScaffold(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeAccounts(), GeneratorConfig{
+		NullStrategy: NullStrategyPointer,
+		Hooks:        true,
+		Scopes:       true,
+		WithTx:       true,
+	})
+	code, err := gen.GenerateString("accounts")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`"gorm.io/gorm"`,
+		"func (m *Account) BeforeCreate(tx *gorm.DB) error {\n\tif m.ID == uuid.Nil {\n\t\tm.ID = uuid.New()",
+		"func (Account) TenantScope(tenantID int64) func(*gorm.DB) *gorm.DB {",
+		`db.Where("accounts.tenant_id = ?", tenantID)`,
+		"func (Account) WithTx(db *gorm.DB, fn func(tx *gorm.DB) error) error {",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+}
+
+func TestScaffold_Disabled(t *testing.T) {
+	code, err := NewGenerator(newFakeAccounts()).GenerateString("accounts")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	for _, unwanted := range []string{"gorm.io/gorm", "BeforeCreate", "TenantScope", "WithTx"} {
+		if strings.Contains(code, unwanted) {
+			t.Errorf("generated code contains %q without the options:\n%s", unwanted, code)
+		}
+	}
+}
+
+func TestScaffold_TenantColumn(t *testing.T) {
+	tests := []struct {
+		name   string
+		column string
+		want   bool
+	}{
+		{"default column", "", true},
+		{"custom column", "name", true},
+		{"missing column", "org_id", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewGeneratorWithConfig(newFakeAccounts(), GeneratorConfig{Scopes: true, TenantColumn: tt.column})
+			code, err := gen.GenerateString("accounts")
+			if err != nil {
+				t.Fatalf("GenerateString() error = %v", err)
+			}
+			if got := strings.Contains(code, "TenantScope"); got != tt.want {
+				t.Errorf("TenantScope emitted = %v; want %v\n%s", got, tt.want, code)
+			}
+		})
+	}
+}
+
+func TestScaffold_UUIDHook(t *testing.T) {
+	dbDefault := "gen_random_uuid()"
+	tests := []struct {
+		name      string
+		columns   []database.ColumnMetadata
+		overrides map[string]config.TableOverride
+		want      string // Expected assignment, empty if no hook is expected
+	}{
+		{
+			name:    "uuid key without default",
+			columns: []database.ColumnMetadata{{Name: "id", DataType: "uuid", RawType: "uuid", IsPrimaryKey: true}},
+			want:    "m.ID = uuid.New()",
+		},
+		{
+			name:    "uuid key with database default",
+			columns: []database.ColumnMetadata{{Name: "id", DataType: "uuid", RawType: "uuid", IsPrimaryKey: true, DefaultValue: &dbDefault}},
+		},
+		{
+			name:      "uuid key overridden to string",
+			columns:   []database.ColumnMetadata{{Name: "id", DataType: "uuid", RawType: "uuid", IsPrimaryKey: true}},
+			overrides: map[string]config.TableOverride{"tokens": {Columns: map[string]config.ColumnOverride{"id": {Type: "string"}}}},
+			want:      "m.ID = uuid.NewString()",
+		},
+		{
+			name:    "integer key",
+			columns: []database.ColumnMetadata{{Name: "id", DataType: "bigint", RawType: "bigint", IsPrimaryKey: true, IsAutoIncrement: true}},
+		},
+		{
+			name: "composite key",
+			columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "uuid", RawType: "uuid", IsPrimaryKey: true},
+				{Name: "version", DataType: "int", RawType: "int", IsPrimaryKey: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+				"tokens": {Name: "tokens", Columns: tt.columns},
+			}}
+			gen := NewGeneratorWithConfig(fake, GeneratorConfig{Hooks: true, Overrides: tt.overrides})
+			code, err := gen.GenerateString("tokens")
+			if err != nil {
+				t.Fatalf("GenerateString() error = %v", err)
+			}
+			if tt.want == "" {
+				if strings.Contains(code, "BeforeCreate") {
+					t.Errorf("unexpected BeforeCreate hook:\n%s", code)
+				}
+				return
+			}
+			if !strings.Contains(code, tt.want) {
+				t.Errorf("generated code missing %q:\n%s", tt.want, code)
+			}
+		})
+	}
+}
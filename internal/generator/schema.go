@@ -0,0 +1,164 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// SchemaFileName is the file name for the reconstructed schema
+const SchemaFileName = "schema.sql"
+
+// GenerateSchemaSQL reconstructs CREATE TABLE statements for the given tables,
+// suitable for feeding into sqlc in database-first projects.
+// dialect is the database driver ("mysql" or "postgres").
+func (g *Generator) GenerateSchemaSQL(tableNames []string, dialect string) ([]byte, error) {
+	var b strings.Builder
+	b.WriteString("-- Code generated by godb-orm. DO NOT EDIT.\n")
+
+	for _, tableName := range tableNames {
+		meta, err := g.introspector.GetTableMetadata(tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get table metadata for %s: %w", tableName, err)
+		}
+		b.WriteString("\n")
+		b.WriteString(BuildCreateTable(meta, dialect))
+	}
+
+	return []byte(b.String()), nil
+}
+
+// GenerateSchemaSQLToFile writes schema.sql for the given tables to outputDir
+func (g *Generator) GenerateSchemaSQLToFile(tableNames []string, outputDir, dialect string) (string, error) {
+	content, err := g.GenerateSchemaSQL(tableNames, dialect)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filePath := filepath.Join(outputDir, SchemaFileName)
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return filePath, nil
+}
+
+// BuildCreateTable renders a CREATE TABLE statement from table metadata
+func BuildCreateTable(meta *database.TableMetadata, dialect string) string {
+	isMySQL := dialect == "mysql"
+
+	var lines []string
+	var pkColumns []string
+	for _, col := range meta.Columns {
+		lines = append(lines, "  "+buildColumnDefinition(col, isMySQL))
+		if col.IsPrimaryKey {
+			pkColumns = append(pkColumns, quoteIdentifier(col.Name, isMySQL))
+		}
+	}
+	if len(pkColumns) > 0 {
+		lines = append(lines, "  PRIMARY KEY ("+strings.Join(pkColumns, ", ")+")")
+	}
+
+	var b strings.Builder
+	if meta.Comment != "" {
+		// Every line of a multi-line comment needs its own prefix
+		for _, line := range strings.Split(strings.TrimRight(meta.Comment, "\r\n"), "\n") {
+			b.WriteString(strings.TrimRight("-- "+strings.TrimRight(line, "\r"), " ") + "\n")
+		}
+	}
+	b.WriteString("CREATE TABLE " + quoteIdentifier(meta.Name, isMySQL) + " (\n")
+	b.WriteString(strings.Join(lines, ",\n"))
+	b.WriteString("\n);\n")
+	return b.String()
+}
+
+// buildColumnDefinition renders a single column definition
+func buildColumnDefinition(col database.ColumnMetadata, isMySQL bool) string {
+	parts := []string{quoteIdentifier(col.Name, isMySQL), schemaColumnType(col, isMySQL)}
+
+	if !col.IsNullable {
+		parts = append(parts, "NOT NULL")
+	}
+
+	if col.IsAutoIncrement && isMySQL {
+		parts = append(parts, "AUTO_INCREMENT")
+	}
+
+	if col.DefaultValue != nil && !(col.IsAutoIncrement && !isMySQL) {
+		parts = append(parts, "DEFAULT "+schemaDefaultValue(*col.DefaultValue, col, isMySQL))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// schemaColumnType returns the SQL type for a column
+func schemaColumnType(col database.ColumnMetadata, isMySQL bool) string {
+	rawType := col.RawType
+	if isMySQL {
+		return rawType
+	}
+
+	// PostgreSQL serial columns are reconstructed from their nextval() default
+	if col.IsAutoIncrement {
+		switch col.DataType {
+		case "bigint":
+			return "bigserial"
+		case "smallint":
+			return "smallserial"
+		default:
+			return "serial"
+		}
+	}
+
+	// Array types are normalized as "[]int4"; SQL spells them "int4[]"
+	if strings.HasPrefix(rawType, "[]") {
+		return rawType[2:] + "[]"
+	}
+	return rawType
+}
+
+// schemaDefaultValue returns a default expression suitable for DDL.
+// PostgreSQL reports defaults as SQL expressions already; MySQL reports
+// literal string defaults unquoted, so they are quoted here.
+func schemaDefaultValue(value string, col database.ColumnMetadata, isMySQL bool) string {
+	if !isMySQL {
+		return value
+	}
+
+	upper := strings.ToUpper(value)
+	if upper == "NULL" || strings.HasPrefix(upper, "CURRENT_") || strings.Contains(value, "(") {
+		return value
+	}
+	if strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") {
+		return value
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil && !isStringType(col.DataType) {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// isStringType reports whether a normalized data type holds text
+func isStringType(dataType string) bool {
+	switch strings.ToLower(dataType) {
+	case "char", "varchar", "text", "tinytext", "mediumtext", "longtext", "enum", "set":
+		return true
+	}
+	return false
+}
+
+// quoteIdentifier quotes a table or column name for the dialect
+func quoteIdentifier(name string, isMySQL bool) string {
+	if isMySQL {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
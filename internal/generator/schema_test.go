@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestBuildCreateTable_MySQL(t *testing.T) {
+	status := "active"
+	meta := &database.TableMetadata{
+		Name: "users",
+		Columns: []database.ColumnMetadata{
+			{Name: "id", DataType: "bigint", RawType: "bigint unsigned", IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "status", DataType: "enum", RawType: "enum('active','inactive')", DefaultValue: &status},
+			{Name: "bio", DataType: "text", RawType: "text", IsNullable: true},
+		},
+	}
+
+	expected := "CREATE TABLE `users` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `status` enum('active','inactive') NOT NULL DEFAULT 'active',\n" +
+		"  `bio` text,\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		");\n"
+
+	if result := BuildCreateTable(meta, "mysql"); result != expected {
+		t.Errorf("BuildCreateTable() =\n%s\nwant\n%s", result, expected)
+	}
+}
+
+func TestBuildCreateTable_Postgres(t *testing.T) {
+	nextval := "nextval('orders_id_seq'::regclass)"
+	meta := &database.TableMetadata{
+		Name: "orders",
+		Columns: []database.ColumnMetadata{
+			{Name: "id", DataType: "bigint", RawType: "bigint", IsPrimaryKey: true, IsAutoIncrement: true, DefaultValue: &nextval},
+			{Name: "tags", DataType: "[]text", RawType: "[]text", IsNullable: true},
+		},
+	}
+
+	result := BuildCreateTable(meta, "postgres")
+
+	for _, want := range []string{`CREATE TABLE "orders" (`, `"id" bigserial NOT NULL,`, `"tags" text[]`, `PRIMARY KEY ("id")`} {
+		if !strings.Contains(result, want) {
+			t.Errorf("BuildCreateTable() missing %q:\n%s", want, result)
+		}
+	}
+	if strings.Contains(result, "nextval") {
+		t.Errorf("BuildCreateTable() should drop nextval default for serial columns:\n%s", result)
+	}
+}
+
+func TestBuildCreateTable_MultiLineComment(t *testing.T) {
+	meta := &database.TableMetadata{
+		Name:    "users",
+		Comment: "Registered users.\r\n\nDROP TABLE users;",
+		Columns: []database.ColumnMetadata{
+			{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+		},
+	}
+
+	want := "-- Registered users.\n--\n-- DROP TABLE users;\nCREATE TABLE `users` ("
+	if result := BuildCreateTable(meta, "mysql"); !strings.HasPrefix(result, want) {
+		t.Errorf("BuildCreateTable() =\n%s\nwant prefix\n%s", result, want)
+	}
+}
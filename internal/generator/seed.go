@@ -0,0 +1,577 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// FakesFileName is the file name for the generated fake-data factories
+const FakesFileName = "fakes_gen.go"
+
+// SeedFileName is the file name for the generated INSERT seed statements
+const SeedFileName = "seed.sql"
+
+// fakeKind classifies what kind of fake value a column receives
+type fakeKind string
+
+const (
+	fakeSkip      fakeKind = ""
+	fakeEnum      fakeKind = "enum"
+	fakeEmail     fakeKind = "email"
+	fakeFirstName fakeKind = "first_name"
+	fakeLastName  fakeKind = "last_name"
+	fakeUsername  fakeKind = "username"
+	fakeName      fakeKind = "name"
+	fakePhone     fakeKind = "phone"
+	fakeURL       fakeKind = "url"
+	fakeCity      fakeKind = "city"
+	fakeCountry   fakeKind = "country"
+	fakeStreet    fakeKind = "street"
+	fakeZip       fakeKind = "zip"
+	fakeCompany   fakeKind = "company"
+	fakeIP        fakeKind = "ip"
+	fakePassword  fakeKind = "password"
+	fakeUUID      fakeKind = "uuid"
+	fakeSentence  fakeKind = "sentence"
+	fakeWord      fakeKind = "word"
+	fakeInt       fakeKind = "int"
+	fakeFloat     fakeKind = "float"
+	fakePrice     fakeKind = "price"
+	fakeBool      fakeKind = "bool"
+	fakeDate      fakeKind = "date"
+	fakeTime      fakeKind = "time"
+	fakeBytes     fakeKind = "bytes"
+)
+
+// fakeColumnNames maps column names (or _<name> suffixes) to the string
+// kind they hold, checked in order
+var fakeColumnNames = []struct {
+	names []string
+	kind  fakeKind
+}{
+	{[]string{"email", "email_address"}, fakeEmail},
+	{[]string{"first_name", "firstname", "given_name"}, fakeFirstName},
+	{[]string{"last_name", "lastname", "surname", "family_name"}, fakeLastName},
+	{[]string{"username", "user_name", "login"}, fakeUsername},
+	{[]string{"name", "full_name", "display_name"}, fakeName},
+	{[]string{"phone", "phone_number", "mobile"}, fakePhone},
+	{[]string{"url", "website", "homepage"}, fakeURL},
+	{[]string{"city"}, fakeCity},
+	{[]string{"country"}, fakeCountry},
+	{[]string{"street", "address"}, fakeStreet},
+	{[]string{"zip", "zipcode", "zip_code", "postal_code", "postcode"}, fakeZip},
+	{[]string{"company", "organization"}, fakeCompany},
+	{[]string{"ip", "ip_address"}, fakeIP},
+	{[]string{"password", "password_hash"}, fakePassword},
+	{[]string{"uuid", "guid"}, fakeUUID},
+	{[]string{"description", "bio", "body", "content", "note", "notes", "summary", "comment", "title"}, fakeSentence},
+}
+
+// fakeColumnKind returns the kind of fake value for a column with the given
+// Go type (without pointer). Auto-increment keys and types without a fake
+// value (JSON, spatial, custom types) are skipped.
+func fakeColumnKind(col database.ColumnMetadata, goType string) fakeKind {
+	if col.IsAutoIncrement {
+		return fakeSkip
+	}
+
+	switch goType {
+	case "bool":
+		return fakeBool
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return fakeInt
+	case "float32", "float64":
+		switch strings.ToLower(col.DataType) {
+		case "decimal", "numeric", "money":
+			return fakePrice
+		}
+		return fakeFloat
+	case "time.Time":
+		if strings.ToLower(col.DataType) == "date" {
+			return fakeDate
+		}
+		return fakeTime
+	case "[]byte":
+		return fakeBytes
+	case "string":
+	default:
+		return fakeSkip
+	}
+
+	if len(col.EnumValues) > 0 {
+		return fakeEnum
+	}
+	switch strings.ToLower(col.DataType) {
+	case "uuid", "uniqueidentifier":
+		return fakeUUID
+	case "json", "jsonb", "xml", "time", "time without time zone", "time with time zone", "interval":
+		return fakeSkip
+	}
+
+	name := strings.ToLower(col.Name)
+	for _, entry := range fakeColumnNames {
+		for _, candidate := range entry.names {
+			if name == candidate || strings.HasSuffix(name, "_"+candidate) {
+				return entry.kind
+			}
+		}
+	}
+	if isStringType(col.DataType) && !strings.Contains(strings.ToLower(col.DataType), "char") && col.DataType != "enum" {
+		return fakeSentence
+	}
+	return fakeWord
+}
+
+// fakeGoExpressions are the gofakeit calls producing each kind of string
+var fakeGoExpressions = map[fakeKind]string{
+	fakeEmail:     "gofakeit.Email()",
+	fakeFirstName: "gofakeit.FirstName()",
+	fakeLastName:  "gofakeit.LastName()",
+	fakeUsername:  "gofakeit.Username()",
+	fakeName:      "gofakeit.Name()",
+	fakePhone:     "gofakeit.Phone()",
+	fakeURL:       "gofakeit.URL()",
+	fakeCity:      "gofakeit.City()",
+	fakeCountry:   "gofakeit.Country()",
+	fakeStreet:    "gofakeit.Street()",
+	fakeZip:       "gofakeit.Zip()",
+	fakeCompany:   "gofakeit.Company()",
+	fakeIP:        "gofakeit.IPv4Address()",
+	fakePassword:  "gofakeit.Password(true, true, true, false, false, 16)",
+	fakeUUID:      "gofakeit.UUID()",
+	fakeSentence:  "gofakeit.LoremIpsumSentence(8)",
+	fakeWord:      "gofakeit.Word()",
+	fakeBool:      "gofakeit.Bool()",
+	fakeFloat:     "gofakeit.Float64Range(0, 1000)",
+	fakePrice:     "gofakeit.Price(1, 1000)",
+	fakeDate:      "gofakeit.PastDate().Truncate(24 * time.Hour)",
+	fakeTime:      "gofakeit.PastDate()",
+	fakeBytes:     "[]byte(gofakeit.LetterN(16))",
+}
+
+// fakeGoValue returns the Go expression filling a field with a fake value,
+// or "" if the field is left at its zero value
+func fakeGoValue(col database.ColumnMetadata, field StructField) string {
+	goType := strings.TrimPrefix(field.Type, "*")
+	kind := fakeColumnKind(col, goType)
+
+	var expr string
+	switch kind {
+	case fakeSkip:
+		return ""
+	case fakeEnum:
+		quoted := make([]string, len(col.EnumValues))
+		for i, value := range col.EnumValues {
+			quoted[i] = strconv.Quote(value)
+		}
+		expr = fmt.Sprintf("gofakeit.RandomString([]string{%s})", strings.Join(quoted, ", "))
+	case fakeInt:
+		expr = fmt.Sprintf("%s(gofakeit.Number(1, %d))", goType, fakeIntMax(goType))
+	case fakeFloat, fakePrice:
+		expr = fakeGoExpressions[kind]
+		if goType == "float32" {
+			expr = "float32(" + expr + ")"
+		}
+	default:
+		expr = fakeGoExpressions[kind]
+	}
+
+	if goType == "string" && col.CharMaxLength != nil && *col.CharMaxLength > 0 && kind != fakeEnum && kind != fakeUUID {
+		expr = fmt.Sprintf("fakeTruncate(%s, %d)", expr, *col.CharMaxLength)
+	}
+	if strings.HasPrefix(field.Type, "*") {
+		expr = "fakePtr(" + expr + ")"
+	}
+	return expr
+}
+
+// fakeIntMax returns the upper bound of fake integers, kept small enough
+// for every integer type
+func fakeIntMax(goType string) int {
+	switch goType {
+	case "int8":
+		return 100
+	case "uint8":
+		return 200
+	}
+	return 1000
+}
+
+// FakesTemplateData holds the data for the fakes template
+type FakesTemplateData struct {
+	PackageName string
+	NeedsTime   bool
+	Tables      []FakesTable
+}
+
+// FakesTable describes the factory emitted for one table
+type FakesTable struct {
+	TableName  string // Database table name
+	StructName string // Model struct name (e.g., User)
+	Fields     []FakesField
+}
+
+// FakesField is a field set by a factory
+type FakesField struct {
+	Name  string // Go field name
+	Value string // Go expression producing the fake value
+}
+
+// FakesTemplate is the template for the fake-data factories file
+const FakesTemplate = `// Code generated by godb-orm. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+{{- if .NeedsTime}}
+	"time"
+{{end}}
+	"github.com/brianvoe/gofakeit/v7"
+)
+{{range .Tables}}
+// NewFake{{.StructName}} returns a {{.StructName}} filled with random test data.
+// Call gofakeit.Seed first for reproducible values.
+func NewFake{{.StructName}}() *{{.StructName}} {
+	return &{{.StructName}}{
+{{- range .Fields}}
+		{{.Name}}: {{.Value}},
+{{- end}}
+	}
+}
+{{end}}
+// fakePtr returns a pointer to v
+func fakePtr[T any](v T) *T {
+	return &v
+}
+
+// fakeTruncate cuts s to at most n runes so it fits its column
+func fakeTruncate(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
+`
+
+// GenerateFakes generates a file with a NewFake<Model>() factory per table
+// that fills the model with gofakeit data matching its column types, enum
+// values and maximum lengths
+func (g *Generator) GenerateFakes(tableNames []string) ([]byte, error) {
+	data := &FakesTemplateData{PackageName: g.packageName}
+
+	for _, tableName := range tableNames {
+		meta, err := g.tableMetadata(tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get table metadata for %s: %w", tableName, err)
+		}
+
+		columns := make(map[string]database.ColumnMetadata, len(meta.Columns))
+		for _, col := range meta.Columns {
+			columns[col.Name] = col
+		}
+
+		fields := g.columnFields(meta)
+		g.privatizeFields(fields)
+
+		table := FakesTable{TableName: tableName, StructName: g.structName(tableName)}
+		for _, field := range fields {
+			col, ok := columns[field.Column]
+			if !ok {
+				continue
+			}
+			value := fakeGoValue(col, field)
+			if value == "" {
+				continue
+			}
+			data.NeedsTime = data.NeedsTime || strings.Contains(value, "time.")
+			table.Fields = append(table.Fields, FakesField{Name: field.Name, Value: value})
+		}
+		data.Tables = append(data.Tables, table)
+	}
+
+	tmpl, err := template.New("fakes").Parse(FakesTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	src, err := g.withBanners(g.packageName, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	formatted, err := FormatSource(FakesFileName, src)
+	if err != nil {
+		return src, err
+	}
+	return formatted, nil
+}
+
+// GenerateFakesToFile writes the fakes file for the given tables to outputDir
+func (g *Generator) GenerateFakesToFile(tableNames []string, outputDir string) (string, error) {
+	content, err := g.GenerateFakes(tableNames)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filePath := filepath.Join(outputDir, FakesFileName)
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return filePath, nil
+}
+
+// Word lists for fake SQL seed values
+var (
+	seedFirstNames = []string{"Ada", "Alan", "Grace", "Linus", "Margaret", "Dennis", "Barbara", "Ken", "Frances", "Rob"}
+	seedLastNames  = []string{"Lovelace", "Turing", "Hopper", "Torvalds", "Hamilton", "Ritchie", "Liskov", "Thompson", "Allen", "Pike"}
+	seedCities     = []string{"Jakarta", "Lisbon", "Nairobi", "Osaka", "Lima", "Oslo", "Denver", "Hanoi"}
+	seedCountries  = []string{"Indonesia", "Portugal", "Kenya", "Japan", "Peru", "Norway", "United States", "Vietnam"}
+	seedCompanies  = []string{"Acme", "Globex", "Initech", "Umbrella", "Hooli", "Stark Industries"}
+	seedWords      = []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india", "juliet", "kilo", "lima"}
+)
+
+// GenerateSeedSQL generates rows INSERT statements per table with fake
+// values, parents before the tables whose foreign keys reference them. seed
+// makes the values reproducible; dialect is the database driver ("mysql" or
+// "postgres"). Foreign key columns get a key the file inserts into the
+// referenced table; without one, nullable columns are left NULL.
+func (g *Generator) GenerateSeedSQL(tableNames []string, rows int, seed int64, dialect string) ([]byte, error) {
+	isMySQL := dialect == "mysql"
+	r := rand.New(rand.NewSource(seed))
+
+	tables := make([]*database.TableMetadata, 0, len(tableNames))
+	for _, tableName := range tableNames {
+		meta, err := g.tableMetadata(tableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get table metadata for %s: %w", tableName, err)
+		}
+		tables = append(tables, meta)
+	}
+
+	// Values inserted per table and column, which foreign keys draw from
+	keys := make(map[string]map[string][]string, len(tables))
+
+	var b strings.Builder
+	b.WriteString("-- Code generated by godb-orm. DO NOT EDIT.\n")
+
+	for _, meta := range seedOrder(tables) {
+		inserted := make(map[string][]string)
+		keys[meta.Name] = inserted
+
+		references := make(map[string]database.ForeignKey, len(meta.ForeignKeys))
+		for _, fk := range meta.ForeignKeys {
+			references[fk.Column] = fk
+		}
+
+		var columns []database.ColumnMetadata
+		var kinds []fakeKind
+		var autoIncrement []string
+		for _, col := range g.columns(meta) {
+			if col.IsAutoIncrement {
+				autoIncrement = append(autoIncrement, col.Name)
+			}
+			goType, _, _ := g.typeMapper.GetGoType(col.RawType, false)
+			if kind := fakeColumnKind(col, goType); kind != fakeSkip {
+				columns = append(columns, col)
+				kinds = append(kinds, kind)
+			}
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		// A fresh table numbers its rows from 1
+		for _, name := range autoIncrement {
+			for row := 1; row <= rows; row++ {
+				inserted[name] = append(inserted[name], strconv.Itoa(row))
+			}
+		}
+
+		names := make([]string, len(columns))
+		for i, col := range columns {
+			names[i] = quoteIdentifier(col.Name, isMySQL)
+		}
+
+		b.WriteString("\n")
+		for row := 1; row <= rows; row++ {
+			values := make([]string, len(columns))
+			for i, col := range columns {
+				fk, isForeignKey := references[col.Name]
+				switch {
+				case isForeignKey && len(keys[fk.ReferencedTable][fk.ReferencedColumn]) > 0:
+					parents := keys[fk.ReferencedTable][fk.ReferencedColumn]
+					values[i] = parents[r.Intn(len(parents))]
+				case isForeignKey && col.IsNullable:
+					values[i] = "NULL"
+				case kinds[i] == fakeInt && col.IsPrimaryKey:
+					values[i] = strconv.Itoa(row)
+				default:
+					values[i] = fakeSQLValue(r, col, kinds[i], row, isMySQL)
+				}
+			}
+			// Self-references draw from the rows inserted before
+			for i, col := range columns {
+				inserted[col.Name] = append(inserted[col.Name], values[i])
+			}
+			fmt.Fprintf(&b, "INSERT INTO %s (%s) VALUES (%s);\n",
+				quoteIdentifier(meta.Name, isMySQL), strings.Join(names, ", "), strings.Join(values, ", "))
+		}
+	}
+
+	return []byte(b.String()), nil
+}
+
+// seedOrder sorts tables so that the tables a foreign key references come
+// first, keeping the given order otherwise. Tables in a cycle stay in the
+// order they were reached.
+func seedOrder(tables []*database.TableMetadata) []*database.TableMetadata {
+	byName := make(map[string]*database.TableMetadata, len(tables))
+	for _, meta := range tables {
+		byName[meta.Name] = meta
+	}
+
+	ordered := make([]*database.TableMetadata, 0, len(tables))
+	visited := make(map[string]bool, len(tables))
+	var visit func(meta *database.TableMetadata)
+	visit = func(meta *database.TableMetadata) {
+		if visited[meta.Name] {
+			return
+		}
+		visited[meta.Name] = true
+		for _, fk := range meta.ForeignKeys {
+			if parent, ok := byName[fk.ReferencedTable]; ok {
+				visit(parent)
+			}
+		}
+		ordered = append(ordered, meta)
+	}
+	for _, meta := range tables {
+		visit(meta)
+	}
+	return ordered
+}
+
+// GenerateSeedSQLToFile writes seed.sql for the given tables to outputDir
+func (g *Generator) GenerateSeedSQLToFile(tableNames []string, outputDir string, rows int, seed int64, dialect string) (string, error) {
+	content, err := g.GenerateSeedSQL(tableNames, rows, seed, dialect)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	filePath := filepath.Join(outputDir, SeedFileName)
+	if err := os.WriteFile(filePath, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return filePath, nil
+}
+
+// fakeSQLValue returns a SQL literal with a fake value of the given kind.
+// row numbers keep values of unique-looking columns (emails, usernames)
+// distinct.
+func fakeSQLValue(r *rand.Rand, col database.ColumnMetadata, kind fakeKind, row int, isMySQL bool) string {
+	pick := func(list []string) string { return list[r.Intn(len(list))] }
+	first, last := pick(seedFirstNames), pick(seedLastNames)
+
+	var value string
+	switch kind {
+	case fakeInt:
+		limit := 1000
+		if strings.ToLower(col.DataType) == "tinyint" {
+			limit = 100
+		}
+		return strconv.Itoa(1 + r.Intn(limit))
+	case fakeFloat:
+		return strconv.FormatFloat(r.Float64()*1000, 'f', 4, 64)
+	case fakePrice:
+		return strconv.FormatFloat(float64(100+r.Intn(99900))/100, 'f', 2, 64)
+	case fakeBool:
+		if isMySQL {
+			return strconv.Itoa(r.Intn(2))
+		}
+		return strconv.FormatBool(r.Intn(2) == 1)
+	case fakeDate, fakeTime:
+		t := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(r.Int63n(int64(5 * 365 * 24 * time.Hour))))
+		if kind == fakeDate {
+			return "'" + t.Format("2006-01-02") + "'"
+		}
+		return "'" + t.Format("2006-01-02 15:04:05") + "'"
+	case fakeBytes:
+		hex := fmt.Sprintf("%016x", r.Uint64())
+		if isMySQL {
+			return "X'" + hex + "'"
+		}
+		return "'\\x" + hex + "'"
+	case fakeEnum:
+		value = pick(col.EnumValues)
+	case fakeEmail:
+		value = fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(first), strings.ToLower(last), row)
+	case fakeFirstName:
+		value = first
+	case fakeLastName:
+		value = last
+	case fakeUsername:
+		value = fmt.Sprintf("%s%d", strings.ToLower(first), row)
+	case fakeName:
+		value = first + " " + last
+	case fakePhone:
+		value = fmt.Sprintf("555-%04d", r.Intn(10000))
+	case fakeURL:
+		value = fmt.Sprintf("https://example.com/%s", pick(seedWords))
+	case fakeCity:
+		value = pick(seedCities)
+	case fakeCountry:
+		value = pick(seedCountries)
+	case fakeStreet:
+		value = fmt.Sprintf("%d %s Street", 1+r.Intn(999), last)
+	case fakeZip:
+		value = fmt.Sprintf("%05d", r.Intn(100000))
+	case fakeCompany:
+		value = pick(seedCompanies)
+	case fakeIP:
+		value = fmt.Sprintf("10.%d.%d.%d", r.Intn(256), r.Intn(256), 1+r.Intn(254))
+	case fakePassword:
+		value = fmt.Sprintf("%016x", r.Uint64())
+	case fakeUUID:
+		var u [16]byte
+		r.Read(u[:])
+		u[6] = u[6]&0x0f | 0x40
+		u[8] = u[8]&0x3f | 0x80
+		value = fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:])
+	case fakeSentence:
+		words := make([]string, 6)
+		for i := range words {
+			words[i] = pick(seedWords)
+		}
+		value = strings.ToUpper(words[0][:1]) + strings.Join(words, " ")[1:] + "."
+	default:
+		value = pick(seedWords)
+	}
+
+	if col.CharMaxLength != nil && *col.CharMaxLength > 0 && len(value) > *col.CharMaxLength {
+		value = value[:*col.CharMaxLength]
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
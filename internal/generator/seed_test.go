@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func newFakeProfiles() *fakeIntrospector {
+	length := 20
+	return &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"profiles": {
+			Name: "profiles",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+				{Name: "contact_email", DataType: "varchar", RawType: "varchar(20)", CharMaxLength: &length},
+				{Name: "role", DataType: "enum", RawType: "enum('admin','member')", EnumValues: []string{"admin", "member"}},
+				{Name: "score", DataType: "smallint", RawType: "smallint", IsNullable: true},
+				{Name: "born_on", DataType: "date", RawType: "date"},
+				{Name: "settings", DataType: "json", RawType: "json"},
+			},
+		},
+	}}
+}
+
+func TestGenerateFakes(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeProfiles(), GeneratorConfig{NullStrategy: NullStrategyPointer})
+	content, err := gen.GenerateFakes([]string{"profiles"})
+	if err != nil {
+		t.Fatalf("GenerateFakes() error = %v\n%s", err, content)
+	}
+	src := string(content)
+
+	for _, want := range []string{
+		`"github.com/brianvoe/gofakeit/v7"`,
+		`"time"`,
+		"func NewFakeProfile() *Profile {",
+		"ContactEmail: fakeTruncate(gofakeit.Email(), 20),",
+		`Role:         gofakeit.RandomString([]string{"admin", "member"}),`,
+		"Score:        fakePtr(int16(gofakeit.Number(1, 1000))),",
+		"BornOn:       gofakeit.PastDate().Truncate(24 * time.Hour),",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("GenerateFakes() missing %q:\n%s", want, src)
+		}
+	}
+	for _, unwanted := range []string{"ID:", "Settings:"} {
+		if strings.Contains(src, unwanted) {
+			t.Errorf("GenerateFakes() should not set %s:\n%s", unwanted, src)
+		}
+	}
+}
+
+func TestGenerateSeedSQL(t *testing.T) {
+	gen := NewGenerator(newFakeProfiles())
+	first, err := gen.GenerateSeedSQL([]string{"profiles"}, 3, 42, "postgres")
+	if err != nil {
+		t.Fatalf("GenerateSeedSQL() error = %v", err)
+	}
+	second, _ := gen.GenerateSeedSQL([]string{"profiles"}, 3, 42, "postgres")
+	if string(first) != string(second) {
+		t.Error("GenerateSeedSQL() is not reproducible for the same seed")
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(first)), "\n")
+	var inserts []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "INSERT") {
+			inserts = append(inserts, line)
+		}
+	}
+	if len(inserts) != 3 {
+		t.Fatalf("got %d INSERT statements, want 3:\n%s", len(inserts), first)
+	}
+	prefix := `INSERT INTO "profiles" ("contact_email", "role", "score", "born_on") VALUES (`
+	for _, insert := range inserts {
+		if !strings.HasPrefix(insert, prefix) {
+			t.Errorf("unexpected INSERT: %s", insert)
+		}
+		if !strings.Contains(insert, "'admin'") && !strings.Contains(insert, "'member'") {
+			t.Errorf("role is not an enum value: %s", insert)
+		}
+	}
+}
+
+func TestGenerateSeedSQLForeignKeys(t *testing.T) {
+	invoiceVendor := database.ForeignKey{Table: "invoices", Column: "vendor_id", ReferencedTable: "vendors", ReferencedColumn: "code"}
+	invoiceCoupon := database.ForeignKey{Table: "invoices", Column: "coupon_id", ReferencedTable: "coupons", ReferencedColumn: "id"}
+	vendorParent := database.ForeignKey{Table: "vendors", Column: "parent_id", ReferencedTable: "vendors", ReferencedColumn: "code"}
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"invoices": {
+			Name: "invoices",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+				{Name: "vendor_id", DataType: "int", RawType: "int"},
+				{Name: "coupon_id", DataType: "int", RawType: "int", IsNullable: true},
+			},
+			ForeignKeys: []database.ForeignKey{invoiceVendor, invoiceCoupon},
+		},
+		"vendors": {
+			Name: "vendors",
+			Columns: []database.ColumnMetadata{
+				{Name: "code", DataType: "int", RawType: "int", IsPrimaryKey: true},
+				{Name: "parent_id", DataType: "int", RawType: "int", IsNullable: true},
+			},
+			ForeignKeys: []database.ForeignKey{vendorParent},
+		},
+	}}
+
+	content, err := NewGenerator(fake).GenerateSeedSQL([]string{"invoices", "vendors"}, 5, 7, "postgres")
+	if err != nil {
+		t.Fatalf("GenerateSeedSQL() error = %v", err)
+	}
+	src := string(content)
+
+	if strings.Index(src, `INSERT INTO "vendors"`) > strings.Index(src, `INSERT INTO "invoices"`) {
+		t.Errorf("vendors should be inserted before invoices:\n%s", src)
+	}
+	if !strings.Contains(src, `INSERT INTO "vendors" ("code", "parent_id") VALUES (1, NULL);`) {
+		t.Errorf("first vendor should have no parent:\n%s", src)
+	}
+
+	var values [][]string
+	for _, line := range strings.Split(src, "\n") {
+		if i := strings.Index(line, "VALUES ("); i >= 0 {
+			values = append(values, strings.Split(strings.TrimSuffix(line[i+len("VALUES ("):], ");"), ", "))
+		}
+	}
+	if len(values) != 10 {
+		t.Fatalf("got %d INSERT statements, want 10:\n%s", len(values), src)
+	}
+	for row, vendor := range values[:5] {
+		if vendor[0] != strconv.Itoa(row+1) {
+			t.Errorf("vendor %d has code %s", row+1, vendor[0])
+		}
+		if parent, err := strconv.Atoi(vendor[1]); err == nil && (parent < 1 || parent > row) {
+			t.Errorf("vendor %d references missing parent %d", row+1, parent)
+		}
+	}
+	for _, invoice := range values[5:] {
+		if vendor, err := strconv.Atoi(invoice[0]); err != nil || vendor < 1 || vendor > 5 {
+			t.Errorf("invoice references missing vendor %s", invoice[0])
+		}
+		if invoice[1] != "NULL" {
+			t.Errorf("coupons are not seeded, coupon_id should be NULL: %s", invoice[1])
+		}
+	}
+}
+
+func TestFakeColumnKind(t *testing.T) {
+	tests := []struct {
+		col    database.ColumnMetadata
+		goType string
+		want   fakeKind
+	}{
+		{database.ColumnMetadata{Name: "first_name", DataType: "varchar"}, "string", fakeFirstName},
+		{database.ColumnMetadata{Name: "billing_city", DataType: "varchar"}, "string", fakeCity},
+		{database.ColumnMetadata{Name: "external_id", DataType: "uuid"}, "string", fakeUUID},
+		{database.ColumnMetadata{Name: "notes", DataType: "varchar"}, "string", fakeSentence},
+		{database.ColumnMetadata{Name: "body", DataType: "longtext"}, "string", fakeSentence},
+		{database.ColumnMetadata{Name: "code", DataType: "varchar"}, "string", fakeWord},
+		{database.ColumnMetadata{Name: "price", DataType: "numeric"}, "float64", fakePrice},
+		{database.ColumnMetadata{Name: "ratio", DataType: "real"}, "float32", fakeFloat},
+		{database.ColumnMetadata{Name: "opens_at", DataType: "time"}, "string", fakeSkip},
+		{database.ColumnMetadata{Name: "location", DataType: "point"}, "Point", fakeSkip},
+	}
+	for _, tt := range tests {
+		if got := fakeColumnKind(tt.col, tt.goType); got != tt.want {
+			t.Errorf("fakeColumnKind(%s %s) = %q, want %q", tt.col.Name, tt.col.DataType, got, tt.want)
+		}
+	}
+}
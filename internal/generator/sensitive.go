@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// isSensitive reports whether a column matches one of the sensitive column
+// patterns. Patterns use path.Match syntax and are matched
+// case-insensitively against the column name, or against table.column if
+// they contain a dot.
+func (g *Generator) isSensitive(table, column string) bool {
+	for _, pattern := range g.sensitive {
+		name := column
+		if strings.Contains(pattern, ".") {
+			name = table + "." + column
+		}
+		if matched, err := path.Match(strings.ToLower(pattern), strings.ToLower(name)); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSensitivePattern checks that a sensitive column pattern is a
+// valid glob
+func validateSensitivePattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid sensitive column pattern %q: %w", pattern, err)
+	}
+	return nil
+}
+
+// maskTags hides a sensitive field from JSON encoding and the extra tag sets
+// and, if writeOnly is set, stops GORM from reading it back from the database
+func maskTags(tags string, extraKeys []string, writeOnly bool) string {
+	tags = MergeTags(tags, `json:"-"`)
+	for _, key := range extraKeys {
+		tags = MergeTags(tags, key+`:"-"`)
+	}
+	if !writeOnly {
+		return tags
+	}
+
+	parsed := parseTags(tags)
+	parts := make([]string, 0, len(parsed)+1)
+	found := false
+	for _, tag := range parsed {
+		if tag.key == "gorm" {
+			tag.value += ";->:false"
+			found = true
+		}
+		parts = append(parts, formatTag(tag))
+	}
+	if !found {
+		parts = append(parts, `gorm:"->:false"`)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func newFakeCredentials() *fakeIntrospector {
+	return &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"users": {
+			Name: "users",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true, IsAutoIncrement: true},
+				{Name: "email", DataType: "varchar", RawType: "varchar(255)"},
+				{Name: "Password_Hash", DataType: "varchar", RawType: "varchar(255)"},
+				{Name: "api_token", DataType: "varchar", RawType: "varchar(64)"},
+				{Name: "ssn", DataType: "char", RawType: "char(11)"},
+			},
+		},
+	}}
+}
+
+func TestSensitiveColumns(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeCredentials(), GeneratorConfig{
+		Sensitive: []string{"*password*", "*token*", "users.ssn", "orders.email"},
+	})
+	code, err := gen.GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`gorm:"column:Password_Hash;type:varchar(255);not null" json:"-"`,
+		`gorm:"column:api_token;type:varchar(64);not null" json:"-"`,
+		`gorm:"column:ssn;type:char(11);not null" json:"-"`,
+		`json:"email"`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+}
+
+func TestSensitiveColumns_WriteOnly(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeCredentials(), GeneratorConfig{
+		Sensitive: []string{"*password*"},
+		WriteOnly: true,
+	})
+	code, err := gen.GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if want := `gorm:"column:Password_Hash;type:varchar(255);not null;->:false" json:"-"`; !strings.Contains(code, want) {
+		t.Errorf("generated code missing %q:\n%s", want, code)
+	}
+}
+
+func TestSensitiveColumns_InvalidPattern(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeCredentials(), GeneratorConfig{Sensitive: []string{"[password"}})
+	if _, err := gen.Generate("users"); err == nil || !strings.Contains(err.Error(), "invalid sensitive column pattern") {
+		t.Errorf("Generate() error = %v; want invalid sensitive column pattern", err)
+	}
+}
+
+func TestMaskTags(t *testing.T) {
+	tests := []struct {
+		tags      string
+		writeOnly bool
+		want      string
+	}{
+		{`gorm:"column:secret" json:"secret"`, false, `gorm:"column:secret" json:"-"`},
+		{`gorm:"column:secret" json:"secret"`, true, `gorm:"column:secret;->:false" json:"-"`},
+		{`json:"secret"`, true, `json:"-" gorm:"->:false"`},
+	}
+	for _, tt := range tests {
+		if got := maskTags(tt.tags, nil, tt.writeOnly); got != tt.want {
+			t.Errorf("maskTags(%q, %v) = %q; want %q", tt.tags, tt.writeOnly, got, tt.want)
+		}
+	}
+}
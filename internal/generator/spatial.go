@@ -0,0 +1,144 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// SpatialMode controls how MySQL spatial columns are represented in Go
+type SpatialMode string
+
+const (
+	// SpatialWKB maps spatial columns to []byte holding MySQL's internal
+	// format: a 4-byte little-endian SRID followed by WKB
+	SpatialWKB SpatialMode = "wkb"
+	// SpatialOrb maps spatial columns to github.com/paulmach/orb types, stored
+	// through a GORM serializer written to SpatialFileName next to the models
+	SpatialOrb SpatialMode = "orb"
+)
+
+// SpatialFileName is the file name for the generated spatial serializer
+const SpatialFileName = "spatial_gen.go"
+
+// spatialSerializer is the name the spatial serializer is registered under
+const spatialSerializer = "wkb"
+
+// orbTypes maps MySQL spatial types to orb types
+var orbTypes = map[string]TypeMapping{
+	"geometry":           {GoType: "orb.Geometry", IsSlice: true},
+	"point":              {GoType: "orb.Point"},
+	"linestring":         {GoType: "orb.LineString", IsSlice: true},
+	"polygon":            {GoType: "orb.Polygon", IsSlice: true},
+	"multipoint":         {GoType: "orb.MultiPoint", IsSlice: true},
+	"multilinestring":    {GoType: "orb.MultiLineString", IsSlice: true},
+	"multipolygon":       {GoType: "orb.MultiPolygon", IsSlice: true},
+	"geometrycollection": {GoType: "orb.Collection", IsSlice: true},
+	"geomcollection":     {GoType: "orb.Collection", IsSlice: true},
+}
+
+// SpatialTemplate is the template for the spatial serializer file
+const SpatialTemplate = `// Code generated by godb-orm. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/encoding/wkb"
+	"gorm.io/gorm/schema"
+)
+
+func init() {
+	schema.RegisterSerializer("wkb", WKBSerializer{})
+}
+
+// WKBSerializer stores orb geometries in MySQL spatial columns, which hold
+// WKB prefixed with a 4-byte SRID. Values are written with the SRID from the
+// field's srid tag setting (0 if unset).
+type WKBSerializer struct{}
+
+// Scan implements schema.SerializerInterface
+func (WKBSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	fieldValue := reflect.New(field.FieldType)
+	if dbValue != nil {
+		scanner := wkb.Scanner(nil)
+		if err := scanner.Scan(dbValue); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", field.Name, err)
+		}
+
+		target := fieldValue.Elem()
+		if field.FieldType.Kind() == reflect.Ptr {
+			target.Set(reflect.New(field.FieldType.Elem()))
+			target = target.Elem()
+		}
+		geometry := reflect.ValueOf(scanner.Geometry)
+		if !geometry.Type().AssignableTo(target.Type()) {
+			return fmt.Errorf("cannot scan %T into %s", scanner.Geometry, field.Name)
+		}
+		target.Set(geometry)
+	}
+	field.ReflectValueOf(ctx, dst).Set(fieldValue.Elem())
+	return nil
+}
+
+// Value implements schema.SerializerValuerInterface
+func (WKBSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	value := reflect.ValueOf(fieldValue)
+	if !value.IsValid() || (value.Kind() == reflect.Ptr && value.IsNil()) {
+		return nil, nil
+	}
+	value = reflect.Indirect(value)
+
+	geometry, ok := value.Interface().(orb.Geometry)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an orb.Geometry", field.Name)
+	}
+	data, err := wkb.Marshal(geometry, binary.LittleEndian)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", field.Name, err)
+	}
+
+	var srid uint64
+	if setting := field.TagSettings["SRID"]; setting != "" {
+		if srid, err = strconv.ParseUint(setting, 10, 32); err != nil {
+			return nil, fmt.Errorf("invalid srid for %s: %w", field.Name, err)
+		}
+	}
+	prefix := make([]byte, 4, 4+len(data))
+	binary.LittleEndian.PutUint32(prefix, uint32(srid))
+	return append(prefix, data...), nil
+}
+`
+
+// SetSpatialMode sets how MySQL spatial columns are mapped (empty keeps the
+// default). PostgreSQL geometric types share some names (point, polygon), so
+// the generator only applies this for MySQL schemas.
+func (tm *TypeMapper) SetSpatialMode(mode SpatialMode) {
+	for dbType, mapping := range orbTypes {
+		switch mode {
+		case SpatialWKB:
+			tm.typeMap[dbType] = TypeMapping{GoType: "[]byte", IsSlice: true, Comment: "// WKB with a 4-byte SRID prefix"}
+		case SpatialOrb:
+			mapping.ImportPath = "github.com/paulmach/orb"
+			mapping.Serializer = spatialSerializer
+			tm.typeMap[dbType] = mapping
+		}
+	}
+}
+
+// sridComment adds the SRID of a spatial column to its field comment
+func sridComment(comment string, col database.ColumnMetadata) string {
+	if col.SRID == nil {
+		return comment
+	}
+	if comment == "" {
+		return fmt.Sprintf("// SRID %d", *col.SRID)
+	}
+	return fmt.Sprintf("%s (SRID %d)", comment, *col.SRID)
+}
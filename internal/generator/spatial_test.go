@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// fakeMySQL is a fakeIntrospector reporting the mysql dialect
+type fakeMySQL struct {
+	*fakeIntrospector
+}
+
+func (fakeMySQL) Dialect() string { return "mysql" }
+
+func newFakePlaces() *fakeIntrospector {
+	srid := 4326
+	return &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"places": {
+			Name: "places",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "int", RawType: "int", IsPrimaryKey: true},
+				{Name: "location", DataType: "point", RawType: "point", SRID: &srid},
+				{Name: "area", DataType: "polygon", RawType: "polygon", IsNullable: true},
+			},
+		},
+	}}
+}
+
+func TestGenerate_Spatial(t *testing.T) {
+	tests := []struct {
+		name         string
+		introspector database.DBIntrospector
+		mode         SpatialMode
+		want         []string
+	}{
+		{
+			name:         "mysql default",
+			introspector: fakeMySQL{newFakePlaces()},
+			want: []string{
+				"Location []byte `gorm:\"column:location;type:point;srid:4326;not null\" json:\"location\"` // WKB with a 4-byte SRID prefix (SRID 4326)",
+				"Area     []byte",
+			},
+		},
+		{
+			name:         "mysql orb",
+			introspector: fakeMySQL{newFakePlaces()},
+			mode:         SpatialOrb,
+			want: []string{
+				"Location orb.Point   `gorm:\"column:location;type:point;serializer:wkb;srid:4326;not null\" json:\"location\"` // SRID 4326",
+				"Area     orb.Polygon `gorm:\"column:area;type:polygon;serializer:wkb\" json:\"area\"`",
+				"\"github.com/paulmach/orb\"",
+			},
+		},
+		{
+			name:         "postgres geometric types are unaffected",
+			introspector: newFakePlaces(),
+			mode:         SpatialOrb,
+			want:         []string{"Location string", "Area     string"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := NewGeneratorWithConfig(tt.introspector, GeneratorConfig{Spatial: tt.mode})
+			code, err := gen.GenerateString("places")
+			if err != nil {
+				t.Fatalf("GenerateString() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(code, want) {
+					t.Errorf("GenerateString() missing %q:\n%s", want, code)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateToFile_SpatialSerializer(t *testing.T) {
+	outputDir := t.TempDir()
+	gen := NewGeneratorWithConfig(fakeMySQL{newFakePlaces()}, GeneratorConfig{Spatial: SpatialOrb})
+
+	if _, err := gen.GenerateToFile("places", outputDir); err != nil {
+		t.Fatalf("GenerateToFile() error = %v", err)
+	}
+	helper, err := os.ReadFile(filepath.Join(outputDir, SpatialFileName))
+	if err != nil {
+		t.Fatalf("spatial serializer not written: %v", err)
+	}
+	if !strings.Contains(string(helper), `schema.RegisterSerializer("wkb", WKBSerializer{})`) {
+		t.Errorf("helper should register the serializer:\n%s", helper)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, HstoreFileName)); !os.IsNotExist(err) {
+		t.Errorf("unneeded helpers should not be written (stat err = %v)", err)
+	}
+}
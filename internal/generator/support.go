@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// supportFile is a package-level helper file written next to the models that
+// need it, such as the Hstore type
+type supportFile struct {
+	name     string                       // File name, e.g. hstore_gen.go
+	template string                       // Template executed with the package name
+	needed   func(field StructField) bool // Reports whether a model field depends on the file
+}
+
+// supportFiles lists the helper files the generator can emit
+var supportFiles = []supportFile{
+	{
+		name:     HstoreFileName,
+		template: HstoreTemplate,
+		needed: func(field StructField) bool {
+			return strings.TrimPrefix(field.Type, "*") == "Hstore"
+		},
+	},
+	{
+		name:     SpatialFileName,
+		template: SpatialTemplate,
+		needed: func(field StructField) bool {
+			return strings.Contains(field.Tags, "serializer:"+spatialSerializer)
+		},
+	},
+	{
+		name:     BitFileName,
+		template: BitTemplate,
+		needed: func(field StructField) bool {
+			goType := strings.TrimPrefix(field.Type, "*")
+			return goType == "BitBool" || goType == "BitUint64"
+		},
+	},
+	{
+		name:     LocalTimeFileName,
+		template: LocalTimeTemplate,
+		needed: func(field StructField) bool {
+			return strings.TrimPrefix(field.Type, "*") == "LocalTime"
+		},
+	},
+}
+
+// GenerateSupportFile generates the helper file with the given name (e.g.,
+// HstoreFileName) for packageName
+func (g *Generator) GenerateSupportFile(name, packageName string) ([]byte, error) {
+	for _, file := range supportFiles {
+		if file.name == name {
+			return g.generateSupportFile(file, packageName)
+		}
+	}
+	return nil, fmt.Errorf("unknown support file %s", name)
+}
+
+// generateSupportFile renders and formats a helper file
+func (g *Generator) generateSupportFile(file supportFile, packageName string) ([]byte, error) {
+	tmpl, err := template.New(file.name).Parse(file.template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ PackageName string }{packageName}); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+
+	src, err := g.withBanners(packageName, buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	formatted, err := FormatSource(file.name, src)
+	if err != nil {
+		return src, err
+	}
+	return formatted, nil
+}
+
+// WriteSupportFiles writes the helper files the model for tableName needs
+// next to modelPath, the file the model was saved to
+func (g *Generator) WriteSupportFiles(tableName, modelPath string) error {
+	meta, err := g.tableMetadata(tableName)
+	if err != nil {
+		return fmt.Errorf("failed to get table metadata: %w", err)
+	}
+	return g.writeSupportFiles(meta, modelPath)
+}
+
+// writeSupportFiles writes the helper files needed by the model of a table
+// next to its model file
+func (g *Generator) writeSupportFiles(meta *database.TableMetadata, modelPath string) error {
+	files, err := g.supportOutputs(meta, modelPath)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := os.WriteFile(file.Path, file.Content, 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+	}
+	return nil
+}
+
+// supportOutputs generates the helper files needed by the model of a table,
+// placed next to its model file
+func (g *Generator) supportOutputs(meta *database.TableMetadata, modelPath string) ([]OutputFile, error) {
+	var files []OutputFile
+	fields := g.columnFields(meta)
+	for _, file := range supportFiles {
+		needed := false
+		for _, field := range fields {
+			needed = needed || file.needed(field)
+		}
+		if !needed {
+			continue
+		}
+
+		content, err := g.generateSupportFile(file, g.filePackage(meta.Name))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, OutputFile{Path: filepath.Join(filepath.Dir(modelPath), file.name), Content: content})
+	}
+	return files, nil
+}
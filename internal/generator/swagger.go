@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// Examples used in the swaggo tags of columns whose type has a well-known
+// format
+const (
+	swaggerUUIDExample     = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+	swaggerDateExample     = "2024-01-15"
+	swaggerDateTimeExample = "2024-01-15T09:30:00Z"
+)
+
+// swaggerTypes maps Go types swag cannot document on its own (structs
+// marshaled as something else) to the swaggertype tag value describing them
+var swaggerTypes = map[string]string{
+	"datatypes.JSON":       "object",
+	"pgtype.Hstore":        "object",
+	"Hstore":               "object",
+	"bson.M":               "object",
+	"primitive.ObjectID":   "string",
+	"primitive.Decimal128": "string",
+	"pgvector.Vector":      "array,number",
+	"pgvector.HalfVector":  "array,number",
+	"BitBool":              "boolean",
+	"BitUint64":            "integer",
+	"interface{}":          "object",
+}
+
+// swaggerTags returns the swaggo tags documenting a column field: a
+// swaggertype for types swag cannot inspect, format and example hints for
+// UUIDs, dates and date-times, and the allowed values of enums
+func swaggerTags(col database.ColumnMetadata, goType string) string {
+	goType = strings.TrimPrefix(goType, "*")
+	rawType := strings.ToLower(strings.TrimSpace(col.RawType))
+
+	var tags []string
+	if swaggerType, ok := swaggerTypes[goType]; ok {
+		tags = append(tags, `swaggertype:"`+swaggerType+`"`)
+	}
+
+	switch {
+	case goType == "uuid.UUID":
+		tags = append(tags, `swaggertype:"string"`, `format:"uuid"`, `example:"`+swaggerUUIDExample+`"`)
+	case goType == "string" && rawType == "uuid":
+		tags = append(tags, `format:"uuid"`, `example:"`+swaggerUUIDExample+`"`)
+	case goType == "[]byte":
+		tags = append(tags, `swaggertype:"string"`, `format:"byte"`)
+	case rawType == "date" && (goType == "time.Time" || goType == "LocalTime" || goType == "string"):
+		tags = append(tags, `format:"date"`, `example:"`+swaggerDateExample+`"`)
+	case goType == "time.Time" || goType == "LocalTime":
+		if goType == "LocalTime" {
+			tags = append(tags, `swaggertype:"string"`)
+		}
+		tags = append(tags, `format:"date-time"`, `example:"`+swaggerDateTimeExample+`"`)
+	}
+
+	if len(col.EnumValues) > 0 && goType == "string" && !strings.ContainsAny(strings.Join(col.EnumValues, ""), `,"\`) {
+		tags = append(tags, `enums:"`+strings.Join(col.EnumValues, ",")+`"`, `example:"`+col.EnumValues[0]+`"`)
+	}
+	return strings.Join(tags, " ")
+}
+
+// addSwaggerTags appends the swaggo tags of a column to a field, keeping
+// any tag with the same key already set (e.g., by a column override)
+func addSwaggerTags(field *StructField, col database.ColumnMetadata) {
+	present := map[string]bool{}
+	for _, tag := range parseTags(field.Tags) {
+		present[tag.key] = true
+	}
+	parts := []string{field.Tags}
+	for _, tag := range parseTags(swaggerTags(col, field.Type)) {
+		if !present[tag.key] {
+			parts = append(parts, formatTag(tag))
+		}
+	}
+	field.Tags = strings.TrimSpace(strings.Join(parts, " "))
+}
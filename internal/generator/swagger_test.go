@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestSwagger(t *testing.T) {
+	fake := &fakeIntrospector{tables: map[string]*database.TableMetadata{
+		"users": {
+			Name:    "users",
+			Comment: "Registered accounts",
+			Columns: []database.ColumnMetadata{
+				{Name: "id", DataType: "uuid", RawType: "uuid", IsPrimaryKey: true},
+				{Name: "status", DataType: "enum", RawType: "enum('active','banned')", EnumValues: []string{"active", "banned"}},
+				{Name: "settings", DataType: "jsonb", RawType: "jsonb", IsNullable: true},
+				{Name: "birthday", DataType: "date", RawType: "date", IsNullable: true},
+				{Name: "created_at", DataType: "timestamp", RawType: "timestamp"},
+				{Name: "nickname", DataType: "varchar", RawType: "varchar(50)"},
+			},
+		},
+	}}
+
+	cfg := GeneratorConfig{
+		Swagger:      true,
+		NullStrategy: NullStrategyPointer,
+		Overrides: map[string]config.TableOverride{
+			"users": {Columns: map[string]config.ColumnOverride{"nickname": {Tags: `example:"neo"`}}},
+		},
+	}
+	code, err := NewGeneratorWithConfig(fake, cfg).GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"// User model\n//\n// Registered accounts\ntype User struct",
+		`json:"id" swaggertype:"string" format:"uuid" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`,
+		`json:"status" enums:"active,banned" example:"active"`,
+		`json:"settings" swaggertype:"object"`,
+		`json:"birthday" format:"date" example:"2024-01-15"`,
+		`json:"created_at" format:"date-time" example:"2024-01-15T09:30:00Z"`,
+		`json:"nickname" example:"neo"`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+
+	plain, err := NewGenerator(fake).GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if strings.Contains(plain, "format:") || strings.Contains(plain, "User model") {
+		t.Errorf("swagger annotations generated without the option:\n%s", plain)
+	}
+}
@@ -3,20 +3,104 @@ package generator
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/rowjak/godb-orm/internal/database"
 )
 
+// TagStyle controls how JSON tag names are derived from column names
+type TagStyle string
+
+const (
+	// TagStyleSnake uses the column name as-is (e.g., created_at)
+	TagStyleSnake TagStyle = "snake"
+	// TagStyleCamel uses lowerCamelCase (e.g., createdAt)
+	TagStyleCamel TagStyle = "camel"
+)
+
+// Default audit column names, tagged autoCreateTime and autoUpdateTime
+var (
+	DefaultAutoCreateTimeColumns = []string{"created_at"}
+	DefaultAutoUpdateTimeColumns = []string{"updated_at"}
+)
+
 // TagBuilder handles GORM tag generation
-type TagBuilder struct{}
+type TagBuilder struct {
+	tagStyle       TagStyle
+	autoCreateTime []string        // Columns GORM fills on create
+	autoUpdateTime []string        // Columns GORM fills on create and update
+	gormOptions    map[string]bool // Selected optional gorm tag options (nil uses DefaultGormOptions)
+	extraTags      []tagSet        // Extra tag sets emitted after the JSON tag (xml, yaml, ...)
+	noGormTag      bool            // Leave out the gorm tag (read-only sources such as Trino)
+	bsonTag        bool            // Lead with a bson tag (document databases such as MongoDB)
+}
 
 // NewTagBuilder creates a new TagBuilder instance
 func NewTagBuilder() *TagBuilder {
-	return &TagBuilder{}
+	return &TagBuilder{
+		tagStyle:       TagStyleSnake,
+		autoCreateTime: DefaultAutoCreateTimeColumns,
+		autoUpdateTime: DefaultAutoUpdateTimeColumns,
+	}
+}
+
+// SetAuditColumns sets the column names tagged autoCreateTime and
+// autoUpdateTime. A nil list keeps the default, an empty one disables the tag.
+func (tb *TagBuilder) SetAuditColumns(autoCreateTime, autoUpdateTime []string) {
+	if autoCreateTime != nil {
+		tb.autoCreateTime = autoCreateTime
+	}
+	if autoUpdateTime != nil {
+		tb.autoUpdateTime = autoUpdateTime
+	}
+}
+
+// gormTagOptions holds the parts of a GORM tag that depend on the Go type
+type gormTagOptions struct {
+	serializer string // GORM serializer, if the Go type can't be stored directly
+	autoTime   string // autoCreateTime or autoUpdateTime for audit columns
+}
+
+// autoTime returns the GORM timestamp tracking tag for an audit column, or an
+// empty string if the column isn't one or its Go type can't hold a timestamp
+func (tb *TagBuilder) autoTime(col database.ColumnMetadata, goType string) string {
+	switch strings.TrimPrefix(goType, "*") {
+	case "time.Time", "int", "int32", "int64", "uint", "uint32", "uint64":
+	default:
+		return ""
+	}
+
+	matches := func(names []string) bool {
+		for _, name := range names {
+			if strings.EqualFold(name, col.Name) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case matches(tb.autoUpdateTime):
+		return "autoUpdateTime"
+	case matches(tb.autoCreateTime):
+		return "autoCreateTime"
+	}
+	return ""
+}
+
+// SetTagStyle sets the JSON tag naming style (empty keeps the default)
+func (tb *TagBuilder) SetTagStyle(style TagStyle) {
+	if style != "" {
+		tb.tagStyle = style
+	}
 }
 
 // BuildGormTag generates a GORM struct tag for a column
 func (tb *TagBuilder) BuildGormTag(col database.ColumnMetadata) string {
+	return tb.buildGormTag(col, gormTagOptions{})
+}
+
+// buildGormTag generates a GORM struct tag with the type-dependent options
+func (tb *TagBuilder) buildGormTag(col database.ColumnMetadata, opts gormTagOptions) string {
 	var parts []string
 
 	// Primary key
@@ -30,13 +114,43 @@ func (tb *TagBuilder) BuildGormTag(col database.ColumnMetadata) string {
 	}
 
 	// Column name
-	parts = append(parts, fmt.Sprintf("column:%s", col.Name))
+	if tb.needsColumnOption(col) {
+		parts = append(parts, "column:"+gormTagValue(col.Name))
+	}
+
+	// Type (included by default for schema sync)
+	if tb.emits(GormOptionType) {
+		parts = append(parts, fmt.Sprintf("type:%s", col.RawType))
+	}
 
-	// Type (always include for schema sync)
-	parts = append(parts, fmt.Sprintf("type:%s", col.RawType))
+	// Size, a dialect-independent alternative to type: for strings
+	if tb.emits(GormOptionSize) {
+		if size := sizeOption(col); size != "" {
+			parts = append(parts, size)
+		}
+	}
+
+	// Precision and scale, the equivalent for decimals
+	if tb.emits(GormOptionPrecision) {
+		parts = append(parts, precisionOptions(col)...)
+	}
+
+	if opts.serializer != "" {
+		parts = append(parts, fmt.Sprintf("serializer:%s", opts.serializer))
+	}
+
+	// Spatial reference system (used by the generated spatial serializer)
+	if col.SRID != nil {
+		parts = append(parts, fmt.Sprintf("srid:%d", *col.SRID))
+	}
+
+	// Audit timestamps are set by GORM, so the database default is noise
+	if opts.autoTime != "" {
+		parts = append(parts, opts.autoTime)
+	}
 
 	// Default value
-	if col.DefaultValue != nil {
+	if col.DefaultValue != nil && opts.autoTime == "" && tb.emits(GormOptionDefault) {
 		defaultVal := *col.DefaultValue
 		// Clean up default values
 		defaultVal = tb.cleanDefaultValue(defaultVal)
@@ -46,10 +160,20 @@ func (tb *TagBuilder) BuildGormTag(col database.ColumnMetadata) string {
 	}
 
 	// Not null constraint (only if not nullable and not primary key)
-	if !col.IsNullable && !col.IsPrimaryKey {
+	if !col.IsNullable && !col.IsPrimaryKey && tb.emits(GormOptionNotNull) {
 		parts = append(parts, "not null")
 	}
 
+	// Column comment, so AutoMigrate carries it to new environments
+	if tb.emits(GormOptionComment) {
+		if comment := commentOption(col.Comment); comment != "" {
+			parts = append(parts, comment)
+		}
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
 	return fmt.Sprintf(`gorm:"%s"`, strings.Join(parts, ";"))
 }
 
@@ -68,10 +192,9 @@ func (tb *TagBuilder) cleanDefaultValue(defaultVal string) string {
 		return ""
 	}
 
-	// Remove parentheses wrapping from PostgreSQL defaults
-	if strings.HasPrefix(defaultVal, "(") && strings.HasSuffix(defaultVal, ")") {
-		defaultVal = defaultVal[1 : len(defaultVal)-1]
-	}
+	// Normalize PostgreSQL and MySQL spellings of the same default, e.g.
+	// ('active'::character varying) and 'active'
+	defaultVal = normalizeDefault(defaultVal)
 
 	// Handle NULL default
 	if strings.ToUpper(defaultVal) == "NULL" {
@@ -81,28 +204,121 @@ func (tb *TagBuilder) cleanDefaultValue(defaultVal string) string {
 	return defaultVal
 }
 
+// normalizeDefault trims whitespace, wrapping parentheses and PostgreSQL
+// type casts from a default expression so the same default always produces
+// the same tag
+func normalizeDefault(value string) string {
+	for {
+		value = strings.TrimSpace(value)
+		switch {
+		case wrappedInParens(value):
+			value = value[1 : len(value)-1]
+		case castIndex(value) >= 0:
+			value = value[:castIndex(value)]
+		default:
+			return value
+		}
+	}
+}
+
+// wrappedInParens reports whether the whole value is enclosed in one pair of
+// parentheses, as in (0) but not (a) + (b)
+func wrappedInParens(value string) bool {
+	if !strings.HasPrefix(value, "(") || !strings.HasSuffix(value, ")") {
+		return false
+	}
+	depth, quoted := 0, false
+	for i, r := range value {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+			if depth == 0 && i < len(value)-1 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+// castIndex returns the position of a trailing PostgreSQL type cast
+// (::character varying, ::numeric(10,2)) outside quotes, or -1
+func castIndex(value string) int {
+	quoted, depth := false, 0
+	for i := 0; i < len(value)-1; i++ {
+		switch c := value[i]; {
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ':' && value[i+1] == ':' && depth == 0:
+			if isTypeName(value[i+2:]) {
+				return i
+			}
+			return -1
+		}
+	}
+	return -1
+}
+
+// isTypeName reports whether s looks like a type name such as
+// "character varying", "numeric(10,2)" or "text[]"
+func isTypeName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(" _.,()[]\"", r) {
+			return false
+		}
+	}
+	return true
+}
+
 // BuildJSONTag generates a JSON struct tag for a column
 func (tb *TagBuilder) BuildJSONTag(col database.ColumnMetadata) string {
-	// Use snake_case column name for JSON
-	return fmt.Sprintf(`json:"%s"`, col.Name)
+	return fmt.Sprintf(`json:"%s"`, tb.jsonName(col.Name))
+}
+
+// jsonName applies the tag style to a snake_case name
+func (tb *TagBuilder) jsonName(name string) string {
+	return jsonTagName(styleName(tb.tagStyle, name))
 }
 
 // BuildAllTags generates all struct tags for a column
 func (tb *TagBuilder) BuildAllTags(col database.ColumnMetadata) string {
-	tags := []string{
-		tb.BuildGormTag(col),
-		tb.BuildJSONTag(col),
+	return tb.buildAllTags(col, gormTagOptions{})
+}
+
+// buildAllTags generates all struct tags with the type-dependent GORM options
+func (tb *TagBuilder) buildAllTags(col database.ColumnMetadata, opts gormTagOptions) string {
+	var tags []string
+	for _, build := range tb.tagFuncs() {
+		if tag := build(col, opts); tag != "" {
+			tags = append(tags, tag)
+		}
 	}
 	return strings.Join(tags, " ")
 }
 
 // StructField represents a Go struct field with its metadata
 type StructField struct {
-	Name       string // Go field name (PascalCase)
-	Type       string // Go type
-	Tags       string // Struct tags
-	Comment    string // Field comment (for enums, unknown types, etc.)
-	ImportPath string // Required import path if any
+	Name       string   // Go field name (PascalCase)
+	Column     string   // Database column name (empty for association fields)
+	Type       string   // Go type
+	Tags       string   // Struct tags
+	Comment    string   // Trailing field comment (for enums, unknown types, etc.)
+	Doc        []string // Doc comment lines from the column comment, emitted above the field
+	ImportPath string   // Required import path if any
+	Accessor   string   // Exported name of the getter and setter (private fields)
+	Getter     string   // Getter method name, Accessor unless it collides with a generated method
 }
 
 // BuildStructField creates a complete struct field from column metadata
@@ -112,9 +328,13 @@ func (tb *TagBuilder) BuildStructField(col database.ColumnMetadata, typeMapper *
 
 	// Build field
 	field := StructField{
-		Name:       ToPascalCase(col.Name),
-		Type:       goType,
-		Tags:       tb.BuildAllTags(col),
+		Name:   ToPascalCase(col.Name),
+		Column: col.Name,
+		Type:   goType,
+		Tags: tb.buildAllTags(col, gormTagOptions{
+			serializer: typeMapper.Serializer(col.RawType),
+			autoTime:   tb.autoTime(col, goType),
+		}),
 		ImportPath: importPath,
 	}
 
@@ -123,9 +343,9 @@ func (tb *TagBuilder) BuildStructField(col database.ColumnMetadata, typeMapper *
 		field.Comment = FormatEnumComment(col.EnumValues)
 	} else if typeComment != "" {
 		field.Comment = typeComment
-	} else if col.Comment != "" {
-		field.Comment = "// " + col.Comment
 	}
+	field.Comment = sridComment(field.Comment, col)
+	field.Doc = DocLines(col.Comment)
 
 	return field
 }
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/rowjak/godb-orm/internal/database"
@@ -94,6 +95,22 @@ func TestBuildJSONTag(t *testing.T) {
 	}
 }
 
+func TestBuildJSONTag_Camel(t *testing.T) {
+	tb := NewTagBuilder()
+	tb.SetTagStyle(TagStyleCamel)
+
+	col := database.ColumnMetadata{
+		Name: "created_at",
+	}
+
+	tag := tb.BuildJSONTag(col)
+	expected := `json:"createdAt"`
+
+	if tag != expected {
+		t.Errorf("BuildJSONTag() = %q; want %q", tag, expected)
+	}
+}
+
 func TestBuildAllTags(t *testing.T) {
 	tb := NewTagBuilder()
 
@@ -187,6 +204,58 @@ func TestBuildStructField_WithEnum(t *testing.T) {
 	}
 }
 
+func TestBuildStructField_AuditColumns(t *testing.T) {
+	now := "CURRENT_TIMESTAMP"
+	zero := "0"
+
+	tests := []struct {
+		name       string
+		autoCreate []string
+		col        database.ColumnMetadata
+		wantTag    string
+	}{
+		{
+			name:    "created_at",
+			col:     database.ColumnMetadata{Name: "created_at", RawType: "datetime", DefaultValue: &now},
+			wantTag: `gorm:"column:created_at;type:datetime;autoCreateTime;not null"`,
+		},
+		{
+			name:    "updated_at unix seconds",
+			col:     database.ColumnMetadata{Name: "updated_at", RawType: "bigint", DefaultValue: &zero},
+			wantTag: `gorm:"column:updated_at;type:bigint;autoUpdateTime;not null"`,
+		},
+		{
+			name:       "configured name",
+			autoCreate: []string{"inserted_on"},
+			col:        database.ColumnMetadata{Name: "Inserted_On", RawType: "timestamp", IsNullable: true},
+			wantTag:    `gorm:"column:Inserted_On;type:timestamp;autoCreateTime"`,
+		},
+		{
+			name:       "disabled",
+			autoCreate: []string{},
+			col:        database.ColumnMetadata{Name: "created_at", RawType: "timestamp", IsNullable: true},
+			wantTag:    `gorm:"column:created_at;type:timestamp"`,
+		},
+		{
+			name:    "not a timestamp type",
+			col:     database.ColumnMetadata{Name: "created_at", RawType: "varchar(20)", DefaultValue: &zero},
+			wantTag: `gorm:"column:created_at;type:varchar(20);default:0;not null"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := NewTagBuilder()
+			tb.SetAuditColumns(tt.autoCreate, nil)
+
+			field := tb.BuildStructField(tt.col, NewTypeMapper())
+			if !strings.HasPrefix(field.Tags, tt.wantTag) {
+				t.Errorf("Tags = %q, want prefix %q", field.Tags, tt.wantTag)
+			}
+		})
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
 }
@@ -199,3 +268,29 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestNormalizeDefault(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"'active'", "'active'"},
+		{"'active'::character varying", "'active'"},
+		{"('active'::character varying)", "'active'"},
+		{"((0))", "0"},
+		{"(0)::numeric(10,2)", "0"},
+		{"'{}'::text[]", "'{}'"},
+		{" 1 ", "1"},
+		{"'a::b'", "'a::b'"},
+		{"(1) + (2)", "(1) + (2)"},
+		{"'a'::text || 'b'::text", "'a'::text || 'b'::text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := normalizeDefault(tt.input); got != tt.want {
+				t.Errorf("normalizeDefault(%q) = %q; want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
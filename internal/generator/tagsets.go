@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// Additional naming policies available to extra tag sets
+const (
+	// TagStylePascal uses PascalCase (e.g., CreatedAt)
+	TagStylePascal TagStyle = "pascal"
+	// TagStyleKebab uses kebab-case (e.g., created-at)
+	TagStyleKebab TagStyle = "kebab"
+)
+
+// knownTagSets lists the struct tag keys that can be added with
+// generator.extra_tags
+var knownTagSets = []string{"xml", "yaml", "mapstructure", "bson"}
+
+// knownTagSetStyles lists the naming policies of extra tag sets
+var knownTagSetStyles = []TagStyle{TagStyleSnake, TagStyleCamel, TagStylePascal, TagStyleKebab}
+
+// tagFunc builds one struct tag for a column, or returns an empty string to
+// leave it out
+type tagFunc func(col database.ColumnMetadata, opts gormTagOptions) string
+
+// tagSet is an extra struct tag named after the column, e.g. yaml:"created_at"
+type tagSet struct {
+	key   string
+	style TagStyle // Naming policy (empty follows the JSON tag style)
+}
+
+// parseTagSet parses an extra tag set spec: a tag key, optionally followed
+// by a naming policy, as in yaml or yaml:camel
+func parseTagSet(spec string) (tagSet, error) {
+	key, style, _ := strings.Cut(strings.ToLower(strings.TrimSpace(spec)), ":")
+	set := tagSet{key: strings.TrimSpace(key), style: TagStyle(strings.TrimSpace(style))}
+
+	if !isKnownTagSet(set.key) {
+		return tagSet{}, fmt.Errorf("unknown extra tag %q (expected one of %s)", set.key, strings.Join(knownTagSets, ", "))
+	}
+	if set.style != "" && !isKnownTagSetStyle(set.style) {
+		return tagSet{}, fmt.Errorf("unknown naming policy %q for extra tag %s (expected snake, camel, pascal or kebab)", set.style, set.key)
+	}
+	return set, nil
+}
+
+// SetExtraTags sets the extra tag sets emitted after the JSON tag, e.g.
+// []string{"yaml", "xml:camel"}. It returns an error for unknown tag keys or
+// naming policies, or for a key listed twice.
+func (tb *TagBuilder) SetExtraTags(specs []string) error {
+	var sets []tagSet
+	for _, spec := range specs {
+		set, err := parseTagSet(spec)
+		if err != nil {
+			return err
+		}
+		for _, existing := range sets {
+			if existing.key == set.key {
+				return fmt.Errorf("extra tag %s is listed twice", set.key)
+			}
+		}
+		sets = append(sets, set)
+	}
+	tb.extraTags = sets
+	return nil
+}
+
+// extraTagKeys returns the keys of the configured extra tag sets
+func (tb *TagBuilder) extraTagKeys() []string {
+	keys := make([]string, len(tb.extraTags))
+	for i, set := range tb.extraTags {
+		keys[i] = set.key
+	}
+	return keys
+}
+
+// extraTagSpecs returns the configured extra tag sets in spec form, as
+// accepted by SetExtraTags
+func (tb *TagBuilder) extraTagSpecs() []string {
+	specs := make([]string, len(tb.extraTags))
+	for i, set := range tb.extraTags {
+		specs[i] = set.key
+		if set.style != "" {
+			specs[i] += ":" + string(set.style)
+		}
+	}
+	return specs
+}
+
+// tagFuncs returns the builders of every struct tag of a column field, in
+// the order they are emitted: gorm (or bson for documents), json, then the
+// extra tag sets
+func (tb *TagBuilder) tagFuncs() []tagFunc {
+	var funcs []tagFunc
+	if !tb.noGormTag {
+		funcs = append(funcs, tb.buildGormTag)
+	}
+	if tb.bsonTag {
+		funcs = append(funcs, tb.buildBSONTag)
+	}
+	funcs = append(funcs, func(col database.ColumnMetadata, _ gormTagOptions) string { return tb.BuildJSONTag(col) })
+	for _, set := range tb.extraTags {
+		// Document field names are never restyled in the bson tag
+		if tb.bsonTag && set.key == "bson" {
+			continue
+		}
+		funcs = append(funcs, tb.extraTagFunc(set))
+	}
+	return funcs
+}
+
+// extraTagFunc returns the builder of an extra tag set
+func (tb *TagBuilder) extraTagFunc(set tagSet) tagFunc {
+	return func(col database.ColumnMetadata, _ gormTagOptions) string {
+		style := set.style
+		if style == "" {
+			style = tb.tagStyle
+		}
+		return fmt.Sprintf(`%s:"%s"`, set.key, tagLiteral(styleName(style, col.Name)))
+	}
+}
+
+// styleName applies a naming policy to a snake_case column name
+func styleName(style TagStyle, name string) string {
+	switch style {
+	case TagStyleCamel:
+		return strcase.ToLowerCamel(name)
+	case TagStylePascal:
+		return strcase.ToCamel(name)
+	case TagStyleKebab:
+		return strcase.ToKebab(name)
+	}
+	// Use the column name as-is
+	return name
+}
+
+// isKnownTagSet reports whether key can be added with generator.extra_tags
+func isKnownTagSet(key string) bool {
+	for _, known := range knownTagSets {
+		if known == key {
+			return true
+		}
+	}
+	return false
+}
+
+// isKnownTagSetStyle reports whether style is a naming policy of extra tag sets
+func isKnownTagSetStyle(style TagStyle) bool {
+	for _, known := range knownTagSetStyles {
+		if known == style {
+			return true
+		}
+	}
+	return false
+}
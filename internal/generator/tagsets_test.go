@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestSetExtraTags(t *testing.T) {
+	tb := NewTagBuilder()
+	if err := tb.SetExtraTags([]string{"yaml", " XML:Camel ", "mapstructure:snake", "bson:kebab"}); err != nil {
+		t.Fatalf("SetExtraTags() error = %v", err)
+	}
+	if got, want := strings.Join(tb.extraTagSpecs(), ","), "yaml,xml:camel,mapstructure:snake,bson:kebab"; got != want {
+		t.Errorf("extraTagSpecs() = %q; want %q", got, want)
+	}
+
+	for _, specs := range [][]string{{"toml"}, {"yaml:upper"}, {"yaml", "yaml:camel"}} {
+		if err := NewTagBuilder().SetExtraTags(specs); err == nil {
+			t.Errorf("SetExtraTags(%v) should fail", specs)
+		}
+	}
+}
+
+func TestBuildAllTags_ExtraTags(t *testing.T) {
+	tb := NewTagBuilder()
+	tb.SetTagStyle(TagStyleCamel)
+	if err := tb.SetExtraTags([]string{"yaml", "xml:pascal", "mapstructure:snake", "bson:kebab"}); err != nil {
+		t.Fatalf("SetExtraTags() error = %v", err)
+	}
+	col := database.ColumnMetadata{Name: "created_at", DataType: "datetime", RawType: "datetime", IsNullable: true}
+	want := `gorm:"column:created_at;type:datetime" json:"createdAt" yaml:"createdAt" xml:"CreatedAt" mapstructure:"created_at" bson:"created-at"`
+	if got := tb.BuildAllTags(col); got != want {
+		t.Errorf("BuildAllTags() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExtraTags_Generate(t *testing.T) {
+	gen := NewGeneratorWithConfig(newFakeCredentials(), GeneratorConfig{
+		ExtraTags: []string{"yaml", "bson"},
+		Sensitive: []string{"*password*"},
+	})
+	code, err := gen.GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	for _, want := range []string{
+		`json:"email" yaml:"email" bson:"email"`,
+		`json:"-" yaml:"-" bson:"-"`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+
+	gen = NewGeneratorWithConfig(newFakeCredentials(), GeneratorConfig{ExtraTags: []string{"toml"}})
+	if _, err := gen.Generate("users"); err == nil || !strings.Contains(err.Error(), "unknown extra tag") {
+		t.Errorf("Generate() error = %v; want unknown extra tag", err)
+	}
+}
+
+// fakeTrino is a fakeIntrospector reporting the trino dialect
+type fakeTrino struct {
+	*fakeIntrospector
+}
+
+func (fakeTrino) Dialect() string { return "trino" }
+
+func TestGenerate_TrinoPlainStructs(t *testing.T) {
+	gen := NewGeneratorWithConfig(fakeTrino{newFakeUsers()}, GeneratorConfig{ExtraTags: []string{"yaml"}})
+	code, err := gen.GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	if strings.Contains(code, "gorm:") {
+		t.Errorf("trino models should have no gorm tags:\n%s", code)
+	}
+	if want := `json:"email" yaml:"email"`; !strings.Contains(code, want) {
+		t.Errorf("generated code missing %q:\n%s", want, code)
+	}
+}
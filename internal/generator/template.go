@@ -3,40 +3,535 @@ package generator
 import (
 	"bytes"
 	"text/template"
+
+	"github.com/rowjak/godb-orm/internal/database"
 )
 
-// TemplateData holds all data needed for struct template rendering
+// TemplateData holds all data needed for struct template rendering. It is
+// also the data passed to custom templates (generator.template), together
+// with the helpers from TemplateFuncs.
 type TemplateData struct {
-	PackageName string
-	Imports     string
-	StructName  string
-	TableName   string
-	Fields      []StructField
-	HasTime     bool
-	HasJSON     bool
-	HasUUID     bool
+	PackageName string                  // Package clause of the generated file
+	Imports     string                  // Rendered import block (goimports fixes it up after rendering)
+	StructName  string                  // Go struct name, e.g. User
+	TableName   string                  // Database table name, e.g. users
+	Fields      []StructField           // Fields in column order, followed by association fields
+	HasTime     bool                    // A field uses time.Time
+	HasJSON     bool                    // A field uses datatypes.JSON
+	HasUUID     bool                    // A field uses uuid.UUID
+	ScanHelpers bool                    // Emit Columns() and ScanRow() helpers for database/sql users
+	Table       *database.TableMetadata // Introspected metadata, for templates needing raw column details
+	Header      string                  // Rendered file header (generator.header, go:generate line)
+	Footer      string                  // Rendered file footer (generator.footer)
+	Doc         []string                // Doc comment lines from the table comment
+
+	UUIDPrimaryKey     string // Field name of the UUID primary key assigned in BeforeCreate (hooks)
+	UUIDPrimaryKeyType string // Go type of that field: uuid.UUID or string
+	TenantColumn       string // Column filtered by the TenantScope scope (scopes)
+	TenantType         string // Go type of the tenant column
+	WithTx             bool   // Emit the WithTx transaction helper
+
+	FactoryFields []FactoryField // Fields set by the New<Model> constructor's options (factories)
+	PrivateFields bool           // Fields are unexported, with getters, setters and ToMap()
+	Swagger       bool           // Document the model for swaggo (// <Model> model comment)
+	Enums         []EnumType     // Named string types of columns with a configured enum
+	Filters       bool           // Emit the <Model>Filter struct (filters, pagination)
+	FilterFields  []FilterField  // Optional conditions of the <Model>Filter struct
+	ListFunc      string         // Name of the paginated list helper, empty without pagination
+	PageOrder     string         // ORDER BY clause giving pages a stable order
+	PageSize      int            // Page size used when the caller passes none
+
+	FindFunc  string     // Name of the function finding a row by primary key (full style)
+	AllFunc   string     // Name of the function listing rows, empty unless full style
+	CountFunc string     // Name of the function counting rows (full style)
+	KeyParams []KeyParam // Primary key of the row functions, empty without one (full style)
+	KeyWhere  string     // WHERE clause selecting a row by KeyParams
+	Loaders   []Loader   // Association loaders (full style)
+
+	Repository     string // Name of the repository interface, empty unless repositories are enabled
+	RepositoryImpl string // Name of its unexported GORM implementation
+	MockDirective  string // Command of the go:generate line creating the repository mock
+
+	QueryBuilder  string        // Query builder the column helpers are for: squirrel or goqu, empty for none
+	TableConst    string        // Name of the table name constant (see GenerateConstants)
+	SelectColumns []QueryColumn // Columns returned by SelectColumns
+	InsertColumns []QueryColumn // Columns returned by InsertColumns, without auto-increment columns
+
+	naming  *NamingConverter // Naming of the template functions (nil uses the built-in acronyms)
+	dialect string           // SQL dialect sqlIdent quotes names for
 }
 
 // StructTemplate is the template for generating Go struct files
-const StructTemplate = `package {{.PackageName}}
+const StructTemplate = `{{template "header" .}}package {{.PackageName}}
 {{if .Imports}}
 
 {{.Imports}}
 {{end}}
 
-// {{.StructName}} represents the {{.TableName}} table
+// {{.StructName}} {{if .Swagger}}model{{else}}represents the {{.TableName}} table{{end}}
+{{- if .Doc}}
+//
+{{- range .Doc}}
+//{{if .}} {{.}}{{end}}
+{{- end}}
+{{- end}}
 type {{.StructName}} struct {
 {{- range .Fields}}
-	{{.Name}} {{.Type}} ` + "`{{.Tags}}`" + `{{if .Comment}} {{.Comment}}{{end}}
+{{- range .Doc}}
+	//{{if .}} {{.}}{{end}}
+{{- end}}
+	{{.Name}} {{.Type}}{{if .Tags}} {{structTag .Tags}}{{end}}{{if .Comment}} {{.Comment}}{{end}}
 {{- end}}
 }
 
 // TableName returns the table name for GORM
 func ({{.StructName}}) TableName() string {
-	return "{{.TableName}}"
+	return {{printf "%q" .TableName}}
+}
+{{- range .Enums}}
+
+// {{.Name}} is a value of the {{.Column}} column
+type {{.Name}} string
+
+// Values of {{.Name}}
+const (
+{{- $enum := .Name}}
+{{- range .Constants}}
+	{{.Name}} {{$enum}} = {{printf "%q" .Value}}
+{{- end}}
+)
+{{- end}}
+{{- if .PrivateFields}}
+{{- $struct := .StructName}}
+{{- range .Fields}}
+
+// {{.Getter}} returns {{if .Column}}the {{.Column}} column{{else}}the {{.Accessor}} association{{end}}
+func (m *{{$struct}}) {{.Getter}}() {{.Type}} {
+	return m.{{.Name}}
+}
+
+// Set{{.Accessor}} sets {{if .Column}}the {{.Column}} column{{else}}the {{.Accessor}} association{{end}}
+func (m *{{$struct}}) Set{{.Accessor}}(v {{.Type}}) {
+	m.{{.Name}} = v
+}
+{{- end}}
+
+// ToMap returns the column values keyed by column name, as accepted by
+// db.Model(&{{.StructName}}{}).Create and Updates
+func (m *{{.StructName}}) ToMap() map[string]interface{} {
+	return map[string]interface{}{
+{{- range .Fields}}{{if .Column}}
+		{{printf "%q" .Column}}: m.{{.Name}},
+{{- end}}{{end}}
+	}
+}
+{{- end}}
+{{- if .UUIDPrimaryKey}}
+
+// BeforeCreate assigns a new UUID to {{.UUIDPrimaryKey}} unless it is already set,
+// as the database does not generate one
+func (m *{{.StructName}}) BeforeCreate(tx *gorm.DB) error {
+{{- if eq .UUIDPrimaryKeyType "string"}}
+	if m.{{.UUIDPrimaryKey}} == "" {
+		m.{{.UUIDPrimaryKey}} = uuid.NewString()
+	}
+{{- else}}
+	if m.{{.UUIDPrimaryKey}} == uuid.Nil {
+		m.{{.UUIDPrimaryKey}} = uuid.New()
+	}
+{{- end}}
+	return nil
+}
+{{- end}}
+{{- if .TenantColumn}}
+
+// TenantScope restricts queries to the {{.TableName}} rows of one tenant:
+//
+//	db.Scopes({{.StructName}}{}.TenantScope(tenantID)).Find(&rows)
+func ({{.StructName}}) TenantScope(tenantID {{.TenantType}}) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where({{printf "%q" (print (sqlIdent .TableName) "." (sqlIdent .TenantColumn) " = ?")}}, tenantID)
+	}
+}
+{{- end}}
+{{- if .Filters}}
+
+// {{.StructName}}Filter holds optional conditions on the {{.TableName}} columns;
+// nil fields are ignored
+type {{.StructName}}Filter struct {
+{{- range .FilterFields}}
+	{{.Name}} *{{.Type}}
+{{- end}}
+}
+
+// Apply adds a WHERE condition for each set field of f:
+//
+//	db.Scopes({{.StructName}}Filter{ {{- with .FilterFields}}{{(index . 0).Name}}: &v{{end}}}.Apply).Find(&rows)
+func (f {{.StructName}}Filter) Apply(db *gorm.DB) *gorm.DB {
+{{- $table := .TableName}}
+{{- range .FilterFields}}
+	if f.{{.Name}} != nil {
+		db = db.Where({{printf "%q" (print (sqlIdent $table) "." (sqlIdent .Column) " = ?")}}, *f.{{.Name}})
+	}
+{{- end}}
+	return db
+}
+{{- end}}
+{{- if .ListFunc}}
+
+// {{.StructName}}Page is a page of {{.TableName}} rows returned by {{.ListFunc}}
+type {{.StructName}}Page struct {
+	Items []{{.StructName}} ` + "`" + `json:"items"` + "`" + `
+	Total int64 ` + "`" + `json:"total"` + "`" + `
+	Page  int   ` + "`" + `json:"page"` + "`" + `
+	Size  int   ` + "`" + `json:"size"` + "`" + `
+}
+
+// {{.ListFunc}} returns page (counted from 1) of the {{.TableName}} rows matching
+// filter, size rows per page, and the total number of matching rows
+func {{.ListFunc}}(db *gorm.DB, page, size int, filter {{.StructName}}Filter) (*{{.StructName}}Page, error) {
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = {{.PageSize}}
+	}
+
+	result := &{{.StructName}}Page{Items: []{{.StructName}}{}, Page: page, Size: size}
+	if err := db.Model(&{{.StructName}}{}).Scopes(filter.Apply).Count(&result.Total).Error; err != nil {
+		return nil, err
+	}
+	query := db.Scopes(filter.Apply){{if .PageOrder}}.Order({{printf "%q" .PageOrder}}){{end}}
+	if err := query.Offset((page - 1) * size).Limit(size).Find(&result.Items).Error; err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+{{- end}}
+{{- if .AllFunc}}
+{{- if .KeyParams}}
+
+// {{.FindFunc}} returns the {{.TableName}} row with the given primary key, or
+// gorm.ErrRecordNotFound
+func {{.FindFunc}}(db *gorm.DB{{range .KeyParams}}, {{.Name}} {{.Type}}{{end}}) (*{{.StructName}}, error) {
+	var m {{.StructName}}
+	if err := db.Where({{printf "%q" .KeyWhere}}{{range .KeyParams}}, {{.Name}}{{end}}).First(&m).Error; err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+{{- end}}
+
+// {{.AllFunc}} returns the {{.TableName}} rows selected by scopes{{if .Loaders}}; eager load
+// associations with Preload:
+//
+//	{{.AllFunc}}(db.Preload({{.StructName}}Rels.{{(index .Loaders 0).Name}}))
+{{- end}}
+func {{.AllFunc}}(db *gorm.DB, scopes ...func(*gorm.DB) *gorm.DB) ([]{{.StructName}}, error) {
+	var rows []{{.StructName}}
+	err := db.Scopes(scopes...).Find(&rows).Error
+	return rows, err
+}
+
+// {{.CountFunc}} returns the number of {{.TableName}} rows selected by scopes
+func {{.CountFunc}}(db *gorm.DB, scopes ...func(*gorm.DB) *gorm.DB) (int64, error) {
+	var n int64
+	err := db.Model(&{{.StructName}}{}).Scopes(scopes...).Count(&n).Error
+	return n, err
+}
+
+// Insert inserts m into {{.TableName}}. Given columns, only those are inserted
+// and the other columns get their database defaults.
+func (m *{{.StructName}}) Insert(db *gorm.DB, columns ...string) error {
+	if len(columns) > 0 {
+		db = db.Select(columns)
+	}
+	return db.Create(m).Error
+}
+{{- if .KeyParams}}
+
+// Update writes the columns of m to its row, all of them including zero
+// values unless columns are given, and returns the number of rows updated
+func (m *{{.StructName}}) Update(db *gorm.DB, columns ...string) (int64, error) {
+	if len(columns) == 0 {
+		columns = []string{"*"}
+	}
+	result := db.Model(m).Select(columns).Omit({{range $i, $k := .KeyParams}}{{if $i}}, {{end}}{{printf "%q" $k.Column}}{{end}}).Updates(m)
+	return result.RowsAffected, result.Error
+}
+
+// Delete deletes the row of m and returns the number of rows deleted
+func (m *{{.StructName}}) Delete(db *gorm.DB) (int64, error) {
+	result := db.Delete(m)
+	return result.RowsAffected, result.Error
+}
+
+// Reload refreshes m from its row
+func (m *{{.StructName}}) Reload(db *gorm.DB) error {
+	return db.Where({{printf "%q" .KeyWhere}}{{range .KeyParams}}, m.{{.Field}}{{end}}).First(m).Error
+}
+{{- end}}
+{{- if .Loaders}}
+
+// {{.StructName}}Rels names the associations of {{.StructName}} for Preload
+var {{.StructName}}Rels = struct {
+{{- range .Loaders}}
+	{{.Name}} string
+{{- end}}
+}{
+{{- range .Loaders}}
+	{{.Name}}: {{printf "%q" .Field}},
+{{- end}}
+}
+{{- $struct := .StructName}}
+{{- range .Loaders}}
+
+// Load{{.Name}} loads the {{.Name}} association of m
+func (m *{{$struct}}) Load{{.Name}}(db *gorm.DB) error {
+{{- if .Many}}
+	return db.Model(m).Association({{printf "%q" .Field}}).Find(&m.{{.Field}})
+{{- else}}
+	var related []{{.Struct}}
+	if err := db.Model(m).Association({{printf "%q" .Field}}).Find(&related); err != nil {
+		return err
+	}
+	m.{{.Field}} = nil
+	if len(related) > 0 {
+		m.{{.Field}} = &related[0]
+	}
+	return nil
+{{- end}}
+}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .FactoryFields}}
+
+// {{.StructName}}Option sets a field of a {{.StructName}} built by New{{.StructName}}
+type {{.StructName}}Option func(*{{.StructName}})
+
+// New{{.StructName}} returns a {{.StructName}} with the column defaults, then opts, applied
+func New{{.StructName}}(opts ...{{.StructName}}Option) *{{.StructName}} {
+	m := &{{.StructName}}{
+{{- range .FactoryFields}}{{if .Default}}
+		{{.Name}}: {{.Default}},
+{{- end}}{{end}}
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+{{- $struct := .StructName}}
+{{- range .FactoryFields}}
+
+// With{{$struct}}{{.Option}} sets {{.Option}}
+func With{{$struct}}{{.Option}}(v {{.Type}}) {{$struct}}Option {
+	return func(m *{{$struct}}) {
+		m.{{.Name}} = v
+	}
+}
+{{- end}}
+{{- end}}
+{{- if .WithTx}}
+
+// WithTx runs fn in a transaction, committing if it returns nil and
+// rolling back otherwise
+func ({{.StructName}}) WithTx(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	return db.Transaction(fn)
 }
+{{- end}}
+{{- if .ScanHelpers}}
+
+// Columns returns the column names of the {{.TableName}} table in field order
+func ({{.StructName}}) Columns() []string {
+	return []string{
+{{- range .Fields}}{{if .Column}}
+		{{printf "%q" .Column}},
+{{- end}}{{end}}
+	}
+}
+
+// ScanRow scans the current row into a new {{.StructName}}.
+// The query must select the columns returned by Columns, in the same order.
+func ({{.StructName}}) ScanRow(rows *sql.Rows) (*{{.StructName}}, error) {
+	var m {{.StructName}}
+	if err := rows.Scan(
+{{- range .Fields}}{{if .Column}}
+		&m.{{.Name}},
+{{- end}}{{end}}
+	); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+{{- end}}
+{{- if .Repository}}
+
+// {{.Repository}} reads and writes {{.TableName}} rows. Services depending on
+// it instead of *gorm.DB can be tested against a generated mock.
+//
+//go:generate {{.MockDirective}}
+type {{.Repository}} interface {
+{{- if .KeyParams}}
+	Find(ctx context.Context{{range .KeyParams}}, {{.Name}} {{.Type}}{{end}}) (*{{.StructName}}, error)
+{{- end}}
+	List(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) ([]{{.StructName}}, error)
+	Create(ctx context.Context, m *{{.StructName}}) error
+{{- if .KeyParams}}
+	Update(ctx context.Context, m *{{.StructName}}) error
+	Delete(ctx context.Context, m *{{.StructName}}) error
+{{- end}}
+}
+
+// New{{.Repository}} returns the GORM implementation of {{.Repository}}
+func New{{.Repository}}(db *gorm.DB) {{.Repository}} {
+	return &{{.RepositoryImpl}}{db: db}
+}
+
+type {{.RepositoryImpl}} struct {
+	db *gorm.DB
+}
+{{- if .KeyParams}}
+
+func (r *{{.RepositoryImpl}}) Find(ctx context.Context{{range .KeyParams}}, {{.Name}} {{.Type}}{{end}}) (*{{.StructName}}, error) {
+	var m {{.StructName}}
+	if err := r.db.WithContext(ctx).Where({{printf "%q" .KeyWhere}}{{range .KeyParams}}, {{.Name}}{{end}}).First(&m).Error; err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+{{- end}}
+
+func (r *{{.RepositoryImpl}}) List(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) ([]{{.StructName}}, error) {
+	var rows []{{.StructName}}
+	err := r.db.WithContext(ctx).Scopes(scopes...).Find(&rows).Error
+	return rows, err
+}
+
+func (r *{{.RepositoryImpl}}) Create(ctx context.Context, m *{{.StructName}}) error {
+	return r.db.WithContext(ctx).Create(m).Error
+}
+{{- if .KeyParams}}
+
+func (r *{{.RepositoryImpl}}) Update(ctx context.Context, m *{{.StructName}}) error {
+	return r.db.WithContext(ctx).Save(m).Error
+}
+
+func (r *{{.RepositoryImpl}}) Delete(ctx context.Context, m *{{.StructName}}) error {
+	return r.db.WithContext(ctx).Delete(m).Error
+}
+{{- end}}
+{{- end}}
+{{- if .QueryBuilder}}
+{{- $goqu := eq .QueryBuilder "goqu"}}
+
+// SelectColumns returns the columns of the {{.TableName}} table qualified with its
+// name, in field order:
+//
+{{- if $goqu}}
+//	goqu.From({{.TableConst}}).Select({{.StructName}}{}.SelectColumns()...)
+func ({{.StructName}}) SelectColumns() []interface{} {
+	return []interface{}{
+{{- range .SelectColumns}}
+		goqu.I({{printf "%q" .Qualified}}),
+{{- end}}
+	}
+}
+{{- else}}
+//	sq.Select({{.StructName}}{}.SelectColumns()...).From({{.TableConst}})
+func ({{.StructName}}) SelectColumns() []string {
+	return []string{
+{{- range .SelectColumns}}
+		{{printf "%q" .Qualified}},
+{{- end}}
+	}
+}
+{{- end}}
+
+// InsertColumns returns the columns an insert sets, leaving out auto-increment
+// columns; InsertValues returns their values:
+//
+{{- if $goqu}}
+//	goqu.Insert({{.TableConst}}).Cols({{.StructName}}{}.InsertColumns()...).Vals(m.InsertValues())
+func ({{.StructName}}) InsertColumns() []interface{} {
+	return []interface{}{
+{{- range .InsertColumns}}
+		goqu.C({{printf "%q" .Column}}),
+{{- end}}
+	}
+}
+{{- else}}
+//	sq.Insert({{.TableConst}}).Columns({{.StructName}}{}.InsertColumns()...).Values(m.InsertValues()...)
+func ({{.StructName}}) InsertColumns() []string {
+	return []string{
+{{- range .InsertColumns}}
+		{{printf "%q" .Column}},
+{{- end}}
+	}
+}
+{{- end}}
+
+// InsertValues returns the values of the columns returned by InsertColumns,
+// in the same order
+func (m *{{.StructName}}) InsertValues() []interface{} {
+	return []interface{}{
+{{- range .InsertColumns}}
+		m.{{.Field}},
+{{- end}}
+	}
+}
+{{- end}}
+{{- template "footer" .}}
 `
 
+// ConstantsFileName is the file name for the generated table/column name constants
+const ConstantsFileName = "columns_gen.go"
+
+// ConstantsTemplateData holds the data for the constants template
+type ConstantsTemplateData struct {
+	PackageName string
+	Tables      []ConstantsTable
+}
+
+// ConstantsTable describes the constants emitted for one table
+type ConstantsTable struct {
+	TableName  string // Database table name
+	ConstName  string // Table name constant (e.g., TableUsers)
+	StructName string // Model struct name (e.g., User)
+	Columns    []ConstantsColumn
+}
+
+// ConstantsColumn describes one column constant
+type ConstantsColumn struct {
+	FieldName  string // Go field name (e.g., Email)
+	ColumnName string // Database column name (e.g., email)
+}
+
+// ConstantsTemplate is the template for the table/column name constants file
+const ConstantsTemplate = `// Code generated by godb-orm. DO NOT EDIT.
+
+package {{.PackageName}}
+
+// Table names
+const (
+{{- range .Tables}}
+	{{.ConstName}} = {{printf "%q" .TableName}}
+{{- end}}
+)
+{{range .Tables}}
+// {{.StructName}}Columns holds the column names of the {{.TableName}} table
+var {{.StructName}}Columns = struct {
+{{- range .Columns}}
+	{{.FieldName}} string
+{{- end}}
+}{
+{{- range .Columns}}
+	{{.FieldName}}: {{printf "%q" .ColumnName}},
+{{- end}}
+}
+{{end}}`
+
 // TemplateRenderer handles template rendering
 type TemplateRenderer struct {
 	template *template.Template
@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// OutputFile is a generated file not yet written to disk
+type OutputFile struct {
+	Path    string
+	Content []byte
+	Table   string // Table the file models, empty for helper files
+}
+
+// WriteError reports a failed WriteFiles. Unless RollbackErr is set, every
+// file has its previous content again.
+type WriteError struct {
+	Path        string // File that could not be written
+	Err         error
+	RollbackErr error // Why restoring the files already replaced failed
+}
+
+func (e *WriteError) Error() string {
+	if e.RollbackErr != nil {
+		return fmt.Sprintf("failed to write %s: %v; restoring the files already written failed, the output is incomplete: %v", e.Path, e.Err, e.RollbackErr)
+	}
+	return fmt.Sprintf("failed to write %s: %v; no files were changed", e.Path, e.Err)
+}
+
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+// GenerateFiles generates the model file of a table for outputDir and the
+// helper files it needs, without writing them. Like GenerateToFile, the
+// model keeps the hand-added tags and fields of the file it replaces.
+func (g *Generator) GenerateFiles(tableName, outputDir string) ([]OutputFile, error) {
+	meta, err := g.tableMetadata(tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table metadata: %w", err)
+	}
+
+	content, err := g.GenerateFromMetadata(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := g.FilePath(tableName, outputDir)
+	content, err = MergeExisting(content, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	support, err := g.supportOutputs(meta, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return append([]OutputFile{{Path: filePath, Content: content, Table: tableName}}, support...), nil
+}
+
+// WriteFiles writes generated files as one transaction. Every file is first
+// staged in a temporary file next to its destination, then the staged files
+// are renamed into place. If a rename fails, the files already replaced get
+// their previous content back and files created are removed, so the output
+// directory is never left half-updated. Files listed twice are written once.
+func WriteFiles(files []OutputFile) error {
+	type staged struct {
+		path     string
+		tmp      string
+		previous []byte
+		existed  bool
+	}
+
+	var stages []staged
+	cleanup := func(from int) {
+		for _, s := range stages[from:] {
+			os.Remove(s.tmp)
+		}
+	}
+
+	seen := make(map[string]bool, len(files))
+	for _, file := range files {
+		if seen[file.Path] {
+			continue
+		}
+		seen[file.Path] = true
+
+		previous, readErr := os.ReadFile(file.Path)
+		tmp, err := stageFile(file)
+		if err != nil {
+			cleanup(0)
+			return &WriteError{Path: file.Path, Err: err}
+		}
+		stages = append(stages, staged{path: file.Path, tmp: tmp, previous: previous, existed: readErr == nil})
+	}
+
+	for i, s := range stages {
+		if err := os.Rename(s.tmp, s.path); err != nil {
+			cleanup(i)
+			var rollbackErrs []error
+			for _, done := range stages[:i] {
+				if err := restoreFile(done.path, done.previous, done.existed); err != nil {
+					rollbackErrs = append(rollbackErrs, err)
+				}
+			}
+			return &WriteError{Path: s.path, Err: err, RollbackErr: errors.Join(rollbackErrs...)}
+		}
+	}
+	return nil
+}
+
+// stageFile writes the content of a file to a temporary file in its
+// destination directory, so renaming it into place is atomic
+func stageFile(file OutputFile) (string, error) {
+	dir := filepath.Dir(file.Path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(file.Path)+".tmp-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	_, err = tmp.Write(file.Content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	return tmp.Name(), nil
+}
+
+// restoreFile puts back the content a replaced file had, or removes a file
+// that didn't exist before
+func restoreFile(path string, previous []byte, existed bool) error {
+	if !existed {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(path, previous, 0644); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFiles(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "user.go")
+	if err := os.WriteFile(existing, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []OutputFile{
+		{Path: existing, Content: []byte("user"), Table: "users"},
+		{Path: filepath.Join(dir, "sub", "order.go"), Content: []byte("order"), Table: "orders"},
+		{Path: existing, Content: []byte("duplicate")},
+	}
+	if err := WriteFiles(files); err != nil {
+		t.Fatalf("WriteFiles() error = %v", err)
+	}
+
+	for path, want := range map[string]string{existing: "user", files[1].Path: "order"} {
+		data, err := os.ReadFile(path)
+		if err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v; want %q", path, data, err, want)
+		}
+		if info, err := os.Stat(path); err == nil && info.Mode().Perm() != 0644 {
+			t.Errorf("%s mode = %v; want 0644", path, info.Mode().Perm())
+		}
+	}
+	assertNoStagedFiles(t, dir)
+}
+
+func TestWriteFilesRollsBack(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "user.go")
+	created := filepath.Join(dir, "order.go")
+	if err := os.WriteFile(existing, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// A non-empty directory in the way makes the last rename fail
+	blocked := filepath.Join(dir, "item.go")
+	if err := os.MkdirAll(filepath.Join(blocked, "keep"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	err := WriteFiles([]OutputFile{
+		{Path: existing, Content: []byte("user")},
+		{Path: created, Content: []byte("order")},
+		{Path: blocked, Content: []byte("item")},
+	})
+	var writeErr *WriteError
+	if !errors.As(err, &writeErr) {
+		t.Fatalf("WriteFiles() error = %v; want a *WriteError", err)
+	}
+	if writeErr.Path != blocked || writeErr.RollbackErr != nil {
+		t.Errorf("WriteError = %+v; want a rolled back failure on %s", writeErr, blocked)
+	}
+
+	if data, _ := os.ReadFile(existing); string(data) != "old" {
+		t.Errorf("user.go = %q; want the previous content restored", data)
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Errorf("order.go exists after rollback, stat error = %v", err)
+	}
+	assertNoStagedFiles(t, dir)
+}
+
+// assertNoStagedFiles fails if temporary files were left in dir
+func assertNoStagedFiles(t *testing.T, dir string) {
+	t.Helper()
+	matches, _ := filepath.Glob(filepath.Join(dir, ".*.tmp-*"))
+	if len(matches) > 0 {
+		t.Errorf("staged files left behind: %v", matches)
+	}
+}
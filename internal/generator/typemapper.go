@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 )
@@ -10,23 +11,74 @@ type TypeMapping struct {
 	GoType     string
 	ImportPath string // empty if no import needed
 	IsSlice    bool   // true for types like []byte that shouldn't get pointer prefix
+	Comment    string // Trailing field comment explaining the mapping, if any
+	Serializer string // GORM serializer needed to store the Go type (e.g., json), if any
 }
 
+// NullStrategy controls how nullable columns are represented in Go
+type NullStrategy string
+
+const (
+	// NullStrategyZero uses plain value types; GORM maps NULL to the zero value
+	NullStrategyZero NullStrategy = "zero"
+	// NullStrategyPointer uses pointer types (e.g., *string, *time.Time) for nullable columns
+	NullStrategyPointer NullStrategy = "pointer"
+)
+
+// TypeResolver maps a database type (lowercase, e.g. "hstore" or
+// "vector(3)") to a Go type. It reports false if it doesn't handle the type.
+type TypeResolver func(dbType string) (TypeMapping, bool)
+
 // TypeMapper handles database type to Go type conversion
 type TypeMapper struct {
 	// typeMap contains known type mappings
 	typeMap map[string]TypeMapping
+	// resolvers are consulted before typeMap, in registration order
+	resolvers []TypeResolver
+	// nullStrategy controls how nullable columns are mapped
+	nullStrategy NullStrategy
 }
 
 // NewTypeMapper creates a new TypeMapper instance
 func NewTypeMapper() *TypeMapper {
 	tm := &TypeMapper{
-		typeMap: make(map[string]TypeMapping),
+		typeMap:      make(map[string]TypeMapping),
+		nullStrategy: NullStrategyZero,
 	}
 	tm.initTypeMappings()
 	return tm
 }
 
+// SetNullStrategy sets how nullable columns are mapped (empty keeps the default)
+func (tm *TypeMapper) SetNullStrategy(strategy NullStrategy) {
+	if strategy != "" {
+		tm.nullStrategy = strategy
+	}
+}
+
+// RegisterResolver adds a resolver that takes precedence over the built-in mappings
+func (tm *TypeMapper) RegisterResolver(resolver TypeResolver) {
+	tm.resolvers = append(tm.resolvers, resolver)
+}
+
+// AddTypeRule registers a resolver mapping database types that match pattern
+// (a regular expression) to goType. Slice and map types are never made pointers.
+func (tm *TypeMapper) AddTypeRule(pattern, goType, importPath string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid type rule %q: %w", pattern, err)
+	}
+	mapping := TypeMapping{
+		GoType:     goType,
+		ImportPath: importPath,
+		IsSlice:    strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map["),
+	}
+	tm.RegisterResolver(func(dbType string) (TypeMapping, bool) {
+		return mapping, re.MatchString(dbType)
+	})
+	return nil
+}
+
 // initTypeMappings initializes all known type mappings
 func (tm *TypeMapper) initTypeMappings() {
 	// Integer types
@@ -56,6 +108,7 @@ func (tm *TypeMapper) initTypeMappings() {
 	tm.typeMap["double precision"] = TypeMapping{GoType: "float64"}
 	tm.typeMap["real"] = TypeMapping{GoType: "float32"}
 	tm.typeMap["money"] = TypeMapping{GoType: "float64"}
+	tm.typeMap["decfloat"] = TypeMapping{GoType: "float64"} // Firebird and Db2
 
 	// String types
 	tm.typeMap["varchar"] = TypeMapping{GoType: "string"}
@@ -67,6 +120,12 @@ func (tm *TypeMapper) initTypeMappings() {
 	tm.typeMap["mediumtext"] = TypeMapping{GoType: "string"}
 	tm.typeMap["tinytext"] = TypeMapping{GoType: "string"}
 	tm.typeMap["citext"] = TypeMapping{GoType: "string"}
+	tm.typeMap["clob"] = TypeMapping{GoType: "string"}               // Db2
+	tm.typeMap["dbclob"] = TypeMapping{GoType: "string"}             // Db2
+	tm.typeMap["graphic"] = TypeMapping{GoType: "string"}            // Db2
+	tm.typeMap["vargraphic"] = TypeMapping{GoType: "string"}         // Db2
+	tm.typeMap["long varchar"] = TypeMapping{GoType: "string"}       // Db2
+	tm.typeMap["blob sub_type text"] = TypeMapping{GoType: "string"} // Firebird
 
 	// Date/Time types
 	tm.typeMap["timestamp"] = TypeMapping{GoType: "time.Time", ImportPath: "time"}
@@ -122,6 +181,10 @@ func (tm *TypeMapper) initTypeMappings() {
 	tm.typeMap["path"] = TypeMapping{GoType: "string"}
 	tm.typeMap["polygon"] = TypeMapping{GoType: "string"}
 	tm.typeMap["circle"] = TypeMapping{GoType: "string"}
+	tm.typeMap["ltree"] = TypeMapping{GoType: "string", Comment: "// ltree: dot-separated label path"}
+	tm.typeMap["sparsevec"] = TypeMapping{GoType: "pgvector.SparseVector", ImportPath: pgvectorImport}
+	tm.SetHstoreMode(HstorePgtype)
+	tm.SetVectorMode(VectorPgvector)
 }
 
 // GetGoType converts a database type to a Go type
@@ -132,11 +195,20 @@ func (tm *TypeMapper) GetGoType(dbType string, isNullable bool) (string, string,
 	// Normalize the type: lowercase and trim
 	normalizedType := strings.ToLower(strings.TrimSpace(dbType))
 
-	// Extract base type without size specification
+	// Registered resolvers and type rules win over built-in mappings
+	for _, resolve := range tm.resolvers {
+		if mapping, ok := resolve(normalizedType); ok {
+			goType := tm.applyNullable(mapping.GoType, isNullable, mapping.IsSlice)
+			return goType, mapping.ImportPath, mapping.Comment
+		}
+	}
+
+	// Strip MySQL numeric attributes, then extract base type without size specification
+	normalizedType, unsigned := splitNumericAttributes(normalizedType)
 	baseType := tm.extractBaseType(normalizedType)
 
 	// Check for unsigned integers (MySQL specific)
-	if strings.Contains(normalizedType, "unsigned") {
+	if unsigned {
 		unsignedKey := baseType + " unsigned"
 		if mapping, ok := tm.typeMap[unsignedKey]; ok {
 			goType := tm.applyNullable(mapping.GoType, isNullable, mapping.IsSlice)
@@ -145,7 +217,7 @@ func (tm *TypeMapper) GetGoType(dbType string, isNullable bool) (string, string,
 	}
 
 	// Check if it's a tinyint(1) which is boolean in MySQL
-	if strings.HasPrefix(normalizedType, "tinyint(1)") && !strings.Contains(normalizedType, "unsigned") {
+	if normalizedType == "tinyint(1)" && !unsigned {
 		mapping := tm.typeMap["tinyint(1)"]
 		goType := tm.applyNullable(mapping.GoType, isNullable, mapping.IsSlice)
 		return goType, mapping.ImportPath, ""
@@ -154,13 +226,13 @@ func (tm *TypeMapper) GetGoType(dbType string, isNullable bool) (string, string,
 	// Check exact match first
 	if mapping, ok := tm.typeMap[normalizedType]; ok {
 		goType := tm.applyNullable(mapping.GoType, isNullable, mapping.IsSlice)
-		return goType, mapping.ImportPath, ""
+		return goType, mapping.ImportPath, mapping.Comment
 	}
 
 	// Check base type match
 	if mapping, ok := tm.typeMap[baseType]; ok {
 		goType := tm.applyNullable(mapping.GoType, isNullable, mapping.IsSlice)
-		return goType, mapping.ImportPath, ""
+		return goType, mapping.ImportPath, mapping.Comment
 	}
 
 	// Fallback: return interface{} with comment
@@ -175,6 +247,38 @@ func (tm *TypeMapper) GetGoTypeSimple(dbType string, isNullable bool) string {
 	return goType
 }
 
+// Serializer returns the GORM serializer required by the built-in mapping of
+// dbType, or an empty string if the Go type can be stored directly
+func (tm *TypeMapper) Serializer(dbType string) string {
+	normalizedType := strings.ToLower(strings.TrimSpace(dbType))
+	for _, resolve := range tm.resolvers {
+		if _, ok := resolve(normalizedType); ok {
+			return ""
+		}
+	}
+	return tm.typeMap[tm.extractBaseType(normalizedType)].Serializer
+}
+
+// splitNumericAttributes removes the MySQL signed, unsigned and zerofill
+// attributes from a type and reports whether it is unsigned. ZEROFILL implies
+// UNSIGNED, so "int(11) unsigned zerofill" and "int zerofill" are both
+// unsigned ints.
+func splitNumericAttributes(dbType string) (string, bool) {
+	words := strings.Fields(dbType)
+	kept := words[:0]
+	unsigned := false
+	for _, word := range words {
+		switch word {
+		case "unsigned", "zerofill":
+			unsigned = true
+		case "signed":
+		default:
+			kept = append(kept, word)
+		}
+	}
+	return strings.Join(kept, " "), unsigned
+}
+
 // extractBaseType extracts the base type from a type with size specification
 // e.g., "varchar(255)" -> "varchar", "decimal(10,2)" -> "decimal"
 func (tm *TypeMapper) extractBaseType(dbType string) string {
@@ -185,11 +289,14 @@ func (tm *TypeMapper) extractBaseType(dbType string) string {
 	return dbType
 }
 
-// applyNullable returns the Go type (GORM handles nullable with zero values)
-func (tm *TypeMapper) applyNullable(goType string, _ bool, _ bool) string {
-	// GORM automatically handles NULL values with Go zero values
-	// No pointer prefix needed
-	return goType
+// applyNullable returns the Go type for a possibly nullable column
+func (tm *TypeMapper) applyNullable(goType string, isNullable bool, isSlice bool) string {
+	// With the default strategy GORM handles NULL values with Go zero values,
+	// so no pointer prefix is needed. Slices are already nil-able.
+	if tm.nullStrategy != NullStrategyPointer || !isNullable || isSlice {
+		return goType
+	}
+	return "*" + goType
 }
 
 // ParseEnumValues extracts enum values from a MySQL enum definition
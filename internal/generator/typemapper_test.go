@@ -293,3 +293,96 @@ func TestFormatEnumComment(t *testing.T) {
 		})
 	}
 }
+
+func TestGetGoType_PointerStrategy(t *testing.T) {
+	tm := NewTypeMapper()
+	tm.SetNullStrategy(NullStrategyPointer)
+
+	tests := []struct {
+		dbType     string
+		isNullable bool
+		expected   string
+	}{
+		{"varchar(255)", true, "*string"},
+		{"varchar(255)", false, "string"},
+		{"timestamp", true, "*time.Time"},
+		{"bytea", true, "[]byte"}, // slices don't get pointer
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dbType, func(t *testing.T) {
+			result := tm.GetGoTypeSimple(tt.dbType, tt.isNullable)
+			if result != tt.expected {
+				t.Errorf("GetGoType(%q, %v) = %q; want %q", tt.dbType, tt.isNullable, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetGoType_DisplayWidthAndZerofill(t *testing.T) {
+	tm := NewTypeMapper()
+
+	tests := []struct {
+		dbType   string
+		expected string
+	}{
+		{"int(11) unsigned zerofill", "uint32"},
+		{"int unsigned zerofill", "uint32"},
+		{"int(5) zerofill", "uint32"},
+		{"INT(10) UNSIGNED", "uint32"},
+		{"bigint(20) unsigned", "uint64"},
+		{"smallint(5) unsigned zerofill", "uint16"},
+		{"tinyint(3) unsigned", "uint8"},
+		{"tinyint(1)", "bool"},
+		{"tinyint(1) unsigned", "uint8"},
+		{"int(11) signed", "int32"},
+		{"decimal(10,2) unsigned zerofill", "float64"},
+		{"double unsigned", "float64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dbType, func(t *testing.T) {
+			result := tm.GetGoTypeSimple(tt.dbType, false)
+			if result != tt.expected {
+				t.Errorf("GetGoType(%q) = %q; want %q", tt.dbType, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTypeMapper_TypeRules(t *testing.T) {
+	tm := NewTypeMapper()
+	tm.SetNullStrategy(NullStrategyPointer)
+	if err := tm.AddTypeRule(`^hstore$`, "map[string]string", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.AddTypeRule(`^numeric`, "decimal.Decimal", "github.com/shopspring/decimal"); err != nil {
+		t.Fatal(err)
+	}
+	tm.RegisterResolver(func(dbType string) (TypeMapping, bool) {
+		return TypeMapping{GoType: "string"}, dbType == "ltree"
+	})
+
+	tests := []struct {
+		dbType     string
+		nullable   bool
+		wantType   string
+		wantImport string
+	}{
+		{"hstore", true, "map[string]string", ""},
+		{"HSTORE", false, "map[string]string", ""},
+		{"numeric(10,2)", true, "*decimal.Decimal", "github.com/shopspring/decimal"},
+		{"ltree", false, "string", ""},
+		{"varchar(255)", false, "string", ""},
+	}
+	for _, tt := range tests {
+		goType, importPath, _ := tm.GetGoType(tt.dbType, tt.nullable)
+		if goType != tt.wantType || importPath != tt.wantImport {
+			t.Errorf("GetGoType(%q) = %q, %q; want %q, %q", tt.dbType, goType, importPath, tt.wantType, tt.wantImport)
+		}
+	}
+
+	if err := tm.AddTypeRule(`(`, "string", ""); err == nil {
+		t.Error("AddTypeRule() should reject invalid patterns")
+	}
+}
@@ -0,0 +1,28 @@
+package generator
+
+// VectorMode controls how pgvector columns (vector, halfvec) are represented in Go
+type VectorMode string
+
+const (
+	// VectorPgvector maps vector to pgvector.Vector and halfvec to
+	// pgvector.HalfVector from github.com/pgvector/pgvector-go
+	VectorPgvector VectorMode = "pgvector"
+	// VectorFloat32 maps vector and halfvec to []float32, stored through
+	// GORM's JSON serializer (the pgvector text format is a JSON array)
+	VectorFloat32 VectorMode = "float32"
+)
+
+// pgvectorImport is the import path of the pgvector Go package
+const pgvectorImport = "github.com/pgvector/pgvector-go"
+
+// SetVectorMode sets how pgvector columns are mapped (empty keeps the default)
+func (tm *TypeMapper) SetVectorMode(mode VectorMode) {
+	switch mode {
+	case VectorPgvector:
+		tm.typeMap["vector"] = TypeMapping{GoType: "pgvector.Vector", ImportPath: pgvectorImport}
+		tm.typeMap["halfvec"] = TypeMapping{GoType: "pgvector.HalfVector", ImportPath: pgvectorImport}
+	case VectorFloat32:
+		tm.typeMap["vector"] = TypeMapping{GoType: "[]float32", IsSlice: true, Serializer: "json"}
+		tm.typeMap["halfvec"] = TypeMapping{GoType: "[]float32", IsSlice: true, Serializer: "json"}
+	}
+}
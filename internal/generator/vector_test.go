@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+func TestBuildStructField_Vector(t *testing.T) {
+	tests := []struct {
+		mode     VectorMode
+		rawType  string
+		wantType string
+		wantTag  string
+	}{
+		{"", "vector(1536)", "pgvector.Vector", `gorm:"column:embedding;type:vector(1536);not null"`},
+		{VectorPgvector, "halfvec(768)", "pgvector.HalfVector", `gorm:"column:embedding;type:halfvec(768);not null"`},
+		{VectorPgvector, "sparsevec(1000)", "pgvector.SparseVector", `gorm:"column:embedding;type:sparsevec(1000);not null"`},
+		{VectorFloat32, "vector(3)", "[]float32", `gorm:"column:embedding;type:vector(3);serializer:json;not null"`},
+		{VectorFloat32, "vector", "[]float32", `gorm:"column:embedding;type:vector;serializer:json;not null"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode)+"/"+tt.rawType, func(t *testing.T) {
+			tm := NewTypeMapper()
+			tm.SetVectorMode(tt.mode)
+			col := database.ColumnMetadata{Name: "embedding", DataType: tt.rawType, RawType: tt.rawType}
+
+			field := NewTagBuilder().BuildStructField(col, tm)
+			if field.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", field.Type, tt.wantType)
+			}
+			if !strings.HasPrefix(field.Tags, tt.wantTag) {
+				t.Errorf("Tags = %q, want prefix %q", field.Tags, tt.wantTag)
+			}
+			if tt.mode != VectorFloat32 && field.ImportPath != "github.com/pgvector/pgvector-go" {
+				t.Errorf("ImportPath = %q", field.ImportPath)
+			}
+		})
+	}
+}
+
+func TestSerializer_TypeRuleWins(t *testing.T) {
+	tm := NewTypeMapper()
+	tm.SetVectorMode(VectorFloat32)
+	if err := tm.AddTypeRule(`^vector`, "Embedding", ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := tm.Serializer("vector(3)"); got != "" {
+		t.Errorf("Serializer() = %q, want none for types mapped by a rule", got)
+	}
+}
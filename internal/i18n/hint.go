@@ -0,0 +1,49 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// hints maps classified database errors to guidance, by driver; the ""
+// entry applies to the other drivers
+var hints = []struct {
+	err  error
+	keys map[string]Key
+}{
+	{database.ErrAuthFailed, map[string]Key{"": HintAuthFailed, "mysql": HintAuthFailedMySQL, "postgres": HintAuthFailedPostgres}},
+	{database.ErrUnknownDatabase, map[string]Key{"": HintUnknownDatabase, "mysql": HintUnknownDatabaseMySQL, "postgres": HintUnknownDatabasePostgres}},
+	{database.ErrTableNotFound, map[string]Key{"": HintTableNotFound, "postgres": HintTableNotFoundPostgres}},
+	{database.ErrPermissionDenied, map[string]Key{"": HintPermissionDenied, "mysql": HintPermissionDeniedMySQL, "postgres": HintPermissionDeniedPostgres}},
+	{database.ErrQueryTimeout, map[string]Key{"": HintQueryTimeout}},
+}
+
+// Hint returns what the user can do about err with the given database
+// driver, e.g. "check pg_hba.conf", or "" if err has no known cause
+func (t *Translator) Hint(err error, driver string) string {
+	if driver == "postgresql" {
+		driver = "postgres"
+	}
+	for _, hint := range hints {
+		if !errors.Is(err, hint.err) {
+			continue
+		}
+		key, ok := hint.keys[driver]
+		if !ok {
+			key = hint.keys[""]
+		}
+		return t.Sprintf(key)
+	}
+	return ""
+}
+
+// WithHint appends the hint for err, if any, to its message on a new line
+func (t *Translator) WithHint(err error, driver string) error {
+	hint := t.Hint(err, driver)
+	if hint == "" {
+		return err
+	}
+	return fmt.Errorf("%w\n%s", err, t.Sprintf(Hint, hint))
+}
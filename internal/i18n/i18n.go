@@ -0,0 +1,129 @@
+// Package i18n translates the status and error messages the GUI bridge
+// returns to the frontend. Messages are fmt formats looked up by key in a
+// per-locale catalog; missing translations fall back to English.
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Locale is a supported language, as a lowercase ISO 639-1 code
+type Locale string
+
+// Supported locales
+const (
+	English    Locale = "en"
+	Indonesian Locale = "id"
+)
+
+// DefaultLocale is used when no supported locale is requested or detected
+const DefaultLocale = English
+
+// Supported returns the locales with a message catalog
+func Supported() []Locale {
+	return []Locale{English, Indonesian}
+}
+
+// Parse normalizes a language tag such as "id", "id-ID" or "id_ID.UTF-8" to
+// a supported locale, reporting false if the language has no catalog
+func Parse(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_.@"); i >= 0 {
+		tag = tag[:i]
+	}
+	locale := Locale(tag)
+	_, ok := catalogs[locale]
+	return locale, ok
+}
+
+// Detect returns the locale of the environment (LC_ALL, LC_MESSAGES, then
+// LANG), or DefaultLocale if it isn't supported
+func Detect() Locale {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if locale, ok := Parse(value); ok {
+			return locale
+		}
+		return DefaultLocale
+	}
+	return DefaultLocale
+}
+
+// Translator formats messages in its current locale. It is safe for
+// concurrent use.
+type Translator struct {
+	mu     sync.RWMutex
+	locale Locale
+}
+
+// New returns a Translator for locale, or DefaultLocale if it isn't supported
+func New(locale Locale) *Translator {
+	if _, ok := catalogs[locale]; !ok {
+		locale = DefaultLocale
+	}
+	return &Translator{locale: locale}
+}
+
+// Locale returns the current locale
+func (t *Translator) Locale() Locale {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.locale
+}
+
+// SetLocale switches to the locale of a language tag (see Parse)
+func (t *Translator) SetLocale(tag string) error {
+	locale, ok := Parse(tag)
+	if !ok {
+		return t.Errorf(UnsupportedLocale, tag)
+	}
+	t.mu.Lock()
+	t.locale = locale
+	t.mu.Unlock()
+	return nil
+}
+
+// Sprintf formats the message of key in the current locale
+func (t *Translator) Sprintf(key Key, args ...interface{}) string {
+	return fmt.Sprintf(t.format(key), args...)
+}
+
+// Errorf formats the message of key in the current locale as an error.
+// Like fmt.Errorf, a %w verb wraps its argument.
+func (t *Translator) Errorf(key Key, args ...interface{}) error {
+	return fmt.Errorf(t.format(key), args...)
+}
+
+// Error returns err with the message of key in the current locale;
+// errors.Is(result, err) still holds, so callers can match sentinel errors
+func (t *Translator) Error(err error, key Key) error {
+	return &translatedError{message: t.format(key), err: err}
+}
+
+// format returns the message format of key, falling back to English and
+// then to the key itself
+func (t *Translator) format(key Key) string {
+	if format, ok := catalogs[t.Locale()][key]; ok {
+		return format
+	}
+	if format, ok := catalogs[DefaultLocale][key]; ok {
+		return format
+	}
+	return string(key)
+}
+
+// translatedError is an error whose message is translated
+type translatedError struct {
+	message string
+	err     error
+}
+
+func (e *translatedError) Error() string { return e.message }
+
+func (e *translatedError) Unwrap() error { return e.err }
@@ -0,0 +1,137 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// verbPattern matches fmt verbs
+var verbPattern = regexp.MustCompile(`%[-+# 0]*[a-zA-Z]`)
+
+func TestCatalogsComplete(t *testing.T) {
+	for key, format := range catalogs[DefaultLocale] {
+		want := verbPattern.FindAllString(format, -1)
+		for _, locale := range Supported() {
+			translated, ok := catalogs[locale][key]
+			if !ok {
+				t.Errorf("%s: missing translation of %s", locale, key)
+				continue
+			}
+			if got := verbPattern.FindAllString(translated, -1); !slices.Equal(got, want) {
+				t.Errorf("%s: %s has verbs %v, want %v", locale, key, got, want)
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want Locale
+		ok   bool
+	}{
+		{"id", Indonesian, true},
+		{"id-ID", Indonesian, true},
+		{"id_ID.UTF-8", Indonesian, true},
+		{"EN_us", English, true},
+		{"fr-FR", "fr", false},
+		{"C", "c", false},
+	}
+	for _, tt := range tests {
+		got, ok := Parse(tt.tag)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Parse(%q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "id_ID.UTF-8")
+	if got := Detect(); got != Indonesian {
+		t.Errorf("Detect() with LANG=id_ID.UTF-8 = %q, want id", got)
+	}
+
+	// LC_ALL takes precedence, even if unsupported
+	t.Setenv("LC_ALL", "fr_FR.UTF-8")
+	if got := Detect(); got != DefaultLocale {
+		t.Errorf("Detect() with LC_ALL=fr_FR.UTF-8 = %q, want %q", got, DefaultLocale)
+	}
+}
+
+func TestTranslator(t *testing.T) {
+	tr := New("xx")
+	if tr.Locale() != DefaultLocale {
+		t.Errorf("New(xx).Locale() = %q, want %q", tr.Locale(), DefaultLocale)
+	}
+
+	cause := errors.New("dial tcp: connection refused")
+	if got := tr.Errorf(Connect, cause).Error(); got != "failed to connect to database: dial tcp: connection refused" {
+		t.Errorf("Errorf() = %q", got)
+	}
+
+	if err := tr.SetLocale("id-ID"); err != nil {
+		t.Fatalf("SetLocale(id-ID) error = %v", err)
+	}
+	err := tr.Errorf(Connect, cause)
+	if err.Error() != "gagal terhubung ke database: dial tcp: connection refused" || !errors.Is(err, cause) {
+		t.Errorf("Errorf() = %v, want Indonesian message wrapping the cause", err)
+	}
+	if got := tr.Sprintf(SaveModelTitle, "users"); got != "Simpan model users" {
+		t.Errorf("Sprintf() = %q", got)
+	}
+
+	sentinel := errors.New("database not connected")
+	translated := tr.Error(sentinel, NotConnected)
+	if translated.Error() != "database belum terhubung" || !errors.Is(translated, sentinel) {
+		t.Errorf("Error() = %v, want translated message matching the sentinel", translated)
+	}
+
+	err = tr.SetLocale("fr")
+	if err == nil || err.Error() != `locale "fr" tidak didukung` {
+		t.Errorf("SetLocale(fr) error = %v", err)
+	}
+	if tr.Locale() != Indonesian {
+		t.Errorf("failed SetLocale changed the locale to %q", tr.Locale())
+	}
+
+	if got := tr.Sprintf("missing_key"); got != "missing_key" {
+		t.Errorf("Sprintf(missing_key) = %q, want the key", got)
+	}
+}
+
+func TestHint(t *testing.T) {
+	tr := New(English)
+	denied := fmt.Errorf("failed to query columns: %w", database.ErrPermissionDenied)
+
+	if got := tr.Hint(denied, "postgresql"); !strings.Contains(got, "GRANT USAGE ON SCHEMA") {
+		t.Errorf("postgres hint = %q", got)
+	}
+	if got := tr.Hint(denied, "mysql"); !strings.Contains(got, "GRANT SELECT ON db.*") {
+		t.Errorf("mysql hint = %q", got)
+	}
+	if got := tr.Hint(denied, "firebird"); got != "grant the user read access to the schema and its tables" {
+		t.Errorf("generic hint = %q", got)
+	}
+	if got := tr.Hint(errors.New("EOF"), "mysql"); got != "" {
+		t.Errorf("hint for an unclassified error = %q, want none", got)
+	}
+
+	auth := fmt.Errorf("failed to ping PostgreSQL: %w", database.ErrAuthFailed)
+	withHint := tr.WithHint(auth, "postgres")
+	if !errors.Is(withHint, database.ErrAuthFailed) || !strings.HasSuffix(withHint.Error(), "\nHint: check the password and that pg_hba.conf allows this user, database and client address") {
+		t.Errorf("WithHint() = %q", withHint)
+	}
+
+	tr.SetLocale("id")
+	if got := tr.Hint(auth, "postgres"); !strings.Contains(got, "pg_hba.conf mengizinkan") {
+		t.Errorf("Indonesian hint = %q", got)
+	}
+}
@@ -0,0 +1,164 @@
+package i18n
+
+// Key identifies a message in the catalogs
+type Key string
+
+// Message keys
+const (
+	Greeting              Key = "greeting"
+	UnsupportedLocale     Key = "unsupported_locale"
+	NotConnected          Key = "not_connected"
+	NoInspector           Key = "no_inspector"
+	CreateIntrospector    Key = "create_introspector"
+	Connect               Key = "connect"
+	NoRecentConnection    Key = "no_recent_connection"
+	UnknownConnection     Key = "unknown_connection"
+	CloseConnection       Key = "close_connection"
+	FetchTables           Key = "fetch_tables"
+	FetchTableSchema      Key = "fetch_table_schema"
+	FetchTableStats       Key = "fetch_table_stats"
+	AnalyzeColumn         Key = "analyze_column"
+	NoColumnAnalysis      Key = "no_column_analysis"
+	SuggestEnums          Key = "suggest_enums"
+	SaveOverrides         Key = "save_overrides"
+	SaveTablePrefix       Key = "save_table_prefix"
+	GenerateTable         Key = "generate_table"
+	GenerateAll           Key = "generate_all"
+	WriteModels           Key = "write_models"
+	BackUpFiles           Key = "back_up_files"
+	RestoreFile           Key = "restore_file"
+	OpenSaveDialog        Key = "open_save_dialog"
+	OpenDirectoryDialog   Key = "open_directory_dialog"
+	OpenPath              Key = "open_path"
+	CopyToClipboard       Key = "copy_to_clipboard"
+	CreateDirectory       Key = "create_directory"
+	WriteFile             Key = "write_file"
+	SaveModelTitle        Key = "save_model_title"
+	ChooseOutputTitle     Key = "choose_output_title"
+	ExportModelsTitle     Key = "export_models_title"
+	GoFilesFilter         Key = "go_files_filter"
+	ArchivesFilter        Key = "archives_filter"
+	ExportDictionaryTitle Key = "export_dictionary_title"
+	SpreadsheetsFilter    Key = "spreadsheets_filter"
+	Hint                  Key = "hint"
+
+	HintAuthFailed               Key = "hint_auth_failed"
+	HintAuthFailedMySQL          Key = "hint_auth_failed_mysql"
+	HintAuthFailedPostgres       Key = "hint_auth_failed_postgres"
+	HintUnknownDatabase          Key = "hint_unknown_database"
+	HintUnknownDatabaseMySQL     Key = "hint_unknown_database_mysql"
+	HintUnknownDatabasePostgres  Key = "hint_unknown_database_postgres"
+	HintTableNotFound            Key = "hint_table_not_found"
+	HintTableNotFoundPostgres    Key = "hint_table_not_found_postgres"
+	HintPermissionDenied         Key = "hint_permission_denied"
+	HintPermissionDeniedMySQL    Key = "hint_permission_denied_mysql"
+	HintPermissionDeniedPostgres Key = "hint_permission_denied_postgres"
+	HintQueryTimeout             Key = "hint_query_timeout"
+)
+
+// catalogs holds the message formats of every supported locale. The
+// formats of a key take the same arguments in every locale.
+var catalogs = map[Locale]map[Key]string{
+	English: {
+		Greeting:              "Hello %s, welcome to godb-orm!",
+		UnsupportedLocale:     "unsupported locale %q",
+		NotConnected:          "database not connected",
+		NoInspector:           "database inspector not initialized",
+		CreateIntrospector:    "failed to create introspector: %w",
+		Connect:               "failed to connect to database: %w",
+		NoRecentConnection:    "no recent connection %s",
+		UnknownConnection:     "no open connection %s",
+		CloseConnection:       "failed to close connection: %w",
+		FetchTables:           "failed to fetch tables: %w",
+		FetchTableSchema:      "failed to fetch schema for table %s: %w",
+		FetchTableStats:       "failed to fetch table statistics: %w",
+		AnalyzeColumn:         "failed to analyze column %s.%s: %w",
+		NoColumnAnalysis:      "column analysis is not supported for %s",
+		SuggestEnums:          "failed to suggest enums for table %s: %w",
+		SaveOverrides:         "failed to save overrides for table %s: %w",
+		SaveTablePrefix:       "failed to save table prefix: %w",
+		GenerateTable:         "failed to generate code for table %s: %w",
+		GenerateAll:           "failed to generate all tables: %w",
+		WriteModels:           "failed to save models: %w",
+		BackUpFiles:           "failed to back up the files before saving: %w",
+		RestoreFile:           "failed to restore %s: %w",
+		OpenSaveDialog:        "failed to open save dialog: %w",
+		OpenDirectoryDialog:   "failed to open directory dialog: %w",
+		OpenPath:              "failed to open %s: %w",
+		CopyToClipboard:       "failed to copy to clipboard: %w",
+		CreateDirectory:       "failed to create directory %s: %w",
+		WriteFile:             "failed to write file %s: %w",
+		SaveModelTitle:        "Save %s model",
+		ChooseOutputTitle:     "Choose the models directory",
+		ExportModelsTitle:     "Export models",
+		GoFilesFilter:         "Go files (*.go)",
+		ArchivesFilter:        "Archives (*%s)",
+		ExportDictionaryTitle: "Export data dictionary",
+		SpreadsheetsFilter:    "Spreadsheets (*%s)",
+		Hint:                  "Hint: %s",
+
+		HintAuthFailed:               "check the user name and password",
+		HintAuthFailedMySQL:          "check the password and that the account may connect from this host ('user'@'host')",
+		HintAuthFailedPostgres:       "check the password and that pg_hba.conf allows this user, database and client address",
+		HintUnknownDatabase:          "check the database name",
+		HintUnknownDatabaseMySQL:     "check the database name; SHOW DATABASES lists those the user can see",
+		HintUnknownDatabasePostgres:  "check the database name; \\l in psql lists the databases",
+		HintTableNotFound:            "check the table name and that the user has privileges on it",
+		HintTableNotFoundPostgres:    "check the table name, the selected schema and that the user has privileges on it",
+		HintPermissionDenied:         "grant the user read access to the schema and its tables",
+		HintPermissionDeniedMySQL:    "grant SELECT on the database, e.g. GRANT SELECT ON db.* TO 'user'@'host', so information_schema shows its tables",
+		HintPermissionDeniedPostgres: "grant USAGE on the schema and SELECT on its tables, e.g. GRANT USAGE ON SCHEMA public TO app; GRANT SELECT ON ALL TABLES IN SCHEMA public TO app",
+		HintQueryTimeout:             "increase query_timeout or check the database load",
+	},
+	Indonesian: {
+		Greeting:              "Halo %s, selamat datang di godb-orm!",
+		UnsupportedLocale:     "locale %q tidak didukung",
+		NotConnected:          "database belum terhubung",
+		NoInspector:           "inspektor database belum diinisialisasi",
+		CreateIntrospector:    "gagal membuat introspector: %w",
+		Connect:               "gagal terhubung ke database: %w",
+		NoRecentConnection:    "tidak ada koneksi terakhir %s",
+		UnknownConnection:     "tidak ada koneksi terbuka %s",
+		CloseConnection:       "gagal menutup koneksi: %w",
+		FetchTables:           "gagal mengambil daftar tabel: %w",
+		FetchTableSchema:      "gagal mengambil skema tabel %s: %w",
+		FetchTableStats:       "gagal mengambil statistik tabel: %w",
+		AnalyzeColumn:         "gagal menganalisis kolom %s.%s: %w",
+		NoColumnAnalysis:      "analisis kolom tidak didukung untuk %s",
+		SuggestEnums:          "gagal menyarankan enum untuk tabel %s: %w",
+		SaveOverrides:         "gagal menyimpan override tabel %s: %w",
+		SaveTablePrefix:       "gagal menyimpan prefiks tabel: %w",
+		GenerateTable:         "gagal membuat kode untuk tabel %s: %w",
+		GenerateAll:           "gagal membuat kode untuk semua tabel: %w",
+		WriteModels:           "gagal menyimpan model: %w",
+		BackUpFiles:           "gagal mencadangkan file sebelum menyimpan: %w",
+		RestoreFile:           "gagal memulihkan %s: %w",
+		OpenSaveDialog:        "gagal membuka dialog simpan: %w",
+		OpenDirectoryDialog:   "gagal membuka dialog direktori: %w",
+		OpenPath:              "gagal membuka %s: %w",
+		CopyToClipboard:       "gagal menyalin ke clipboard: %w",
+		CreateDirectory:       "gagal membuat direktori %s: %w",
+		WriteFile:             "gagal menulis file %s: %w",
+		SaveModelTitle:        "Simpan model %s",
+		ChooseOutputTitle:     "Pilih direktori model",
+		ExportModelsTitle:     "Ekspor model",
+		GoFilesFilter:         "File Go (*.go)",
+		ArchivesFilter:        "Arsip (*%s)",
+		ExportDictionaryTitle: "Ekspor kamus data",
+		SpreadsheetsFilter:    "Lembar kerja (*%s)",
+		Hint:                  "Petunjuk: %s",
+
+		HintAuthFailed:               "periksa nama pengguna dan kata sandi",
+		HintAuthFailedMySQL:          "periksa kata sandi dan pastikan akun boleh terhubung dari host ini ('user'@'host')",
+		HintAuthFailedPostgres:       "periksa kata sandi dan pastikan pg_hba.conf mengizinkan pengguna, database, dan alamat klien ini",
+		HintUnknownDatabase:          "periksa nama database",
+		HintUnknownDatabaseMySQL:     "periksa nama database; SHOW DATABASES menampilkan database yang dapat dilihat pengguna",
+		HintUnknownDatabasePostgres:  "periksa nama database; \\l di psql menampilkan daftar database",
+		HintTableNotFound:            "periksa nama tabel dan pastikan pengguna memiliki hak akses ke tabel tersebut",
+		HintTableNotFoundPostgres:    "periksa nama tabel, skema yang dipilih, dan pastikan pengguna memiliki hak akses ke tabel tersebut",
+		HintPermissionDenied:         "berikan pengguna akses baca ke skema dan tabelnya",
+		HintPermissionDeniedMySQL:    "berikan SELECT pada database, mis. GRANT SELECT ON db.* TO 'user'@'host', agar information_schema menampilkan tabelnya",
+		HintPermissionDeniedPostgres: "berikan USAGE pada skema dan SELECT pada tabelnya, mis. GRANT USAGE ON SCHEMA public TO app; GRANT SELECT ON ALL TABLES IN SCHEMA public TO app",
+		HintQueryTimeout:             "naikkan query_timeout atau periksa beban database",
+	},
+}
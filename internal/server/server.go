@@ -0,0 +1,258 @@
+// Package server exposes introspection and generation over an HTTP/JSON API
+// for `godb-orm serve`, so CI jobs can fetch models from a central instance
+// running next to the database.
+package server
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/database"
+	"github.com/rowjak/godb-orm/internal/generator"
+)
+
+// ArchiveName is the file name suggested for the zip of generated models
+const ArchiveName = "models.zip"
+
+// Server handles API requests for one database connection
+type Server struct {
+	introspector database.DBIntrospector
+	generator    *generator.Generator
+	token        string
+}
+
+// New creates a Server. If token is not empty, every API request must send
+// it as "Authorization: Bearer <token>".
+func New(introspector database.DBIntrospector, gen *generator.Generator, token string) *Server {
+	return &Server{introspector: introspector, generator: gen, token: token}
+}
+
+// ColumnInfo describes a column in API responses
+type ColumnInfo struct {
+	Name            string   `json:"name"`
+	DataType        string   `json:"dataType"`
+	RawType         string   `json:"rawType"`
+	IsNullable      bool     `json:"isNullable"`
+	IsPrimaryKey    bool     `json:"isPrimaryKey"`
+	IsAutoIncrement bool     `json:"isAutoIncrement"`
+	DefaultValue    *string  `json:"defaultValue"`
+	EnumValues      []string `json:"enumValues,omitempty"`
+	Comment         string   `json:"comment,omitempty"`
+}
+
+// ForeignKeyInfo describes a foreign key in API responses
+type ForeignKeyInfo struct {
+	Name             string `json:"name"`
+	Table            string `json:"table"`
+	Column           string `json:"column"`
+	ReferencedTable  string `json:"referencedTable"`
+	ReferencedColumn string `json:"referencedColumn"`
+	Inferred         bool   `json:"inferred,omitempty"`
+}
+
+// TableInfo describes a table in API responses
+type TableInfo struct {
+	Schema       string           `json:"schema,omitempty"`
+	Name         string           `json:"name"`
+	Comment      string           `json:"comment,omitempty"`
+	Columns      []ColumnInfo     `json:"columns"`
+	ForeignKeys  []ForeignKeyInfo `json:"foreignKeys,omitempty"`
+	ReferencedBy []ForeignKeyInfo `json:"referencedBy,omitempty"`
+}
+
+// Handler returns the HTTP handler serving the API:
+//
+//	GET /healthz                  liveness and database connectivity
+//	GET /api/tables               table names
+//	GET /api/tables/{table}       table metadata
+//	GET /api/tables/{table}/code  generated model file
+//	GET /api/models.zip           zip of generated models (?tables=a,b to select)
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("GET /api/tables", s.authorize(s.handleTables))
+	mux.HandleFunc("GET /api/tables/{table}", s.authorize(s.handleTable))
+	mux.HandleFunc("GET /api/tables/{table}/code", s.authorize(s.handleCode))
+	mux.HandleFunc("GET /api/"+ArchiveName, s.authorize(s.handleArchive))
+	return mux
+}
+
+// authorize rejects requests without the configured bearer token
+func (s *Server) authorize(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.token != "" {
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) != 1 {
+				writeError(w, http.StatusUnauthorized, fmt.Errorf("missing or invalid token"))
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if pinger, ok := s.introspector.(database.Pinger); ok {
+		if err := pinger.Ping(); err != nil {
+			writeError(w, http.StatusServiceUnavailable, fmt.Errorf("database unreachable: %w", err))
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleTables(w http.ResponseWriter, r *http.Request) {
+	tables, err := s.introspector.GetTables()
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Errorf("failed to get tables: %w", err))
+		return
+	}
+	if tables == nil {
+		tables = []string{}
+	}
+	writeJSON(w, http.StatusOK, tables)
+}
+
+func (s *Server) handleTable(w http.ResponseWriter, r *http.Request) {
+	table, ok := s.lookupTable(w, r)
+	if !ok {
+		return
+	}
+	meta, err := s.introspector.GetTableMetadata(table)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Errorf("failed to get table metadata: %w", err))
+		return
+	}
+	writeJSON(w, http.StatusOK, tableInfo(meta))
+}
+
+func (s *Server) handleCode(w http.ResponseWriter, r *http.Request) {
+	table, ok := s.lookupTable(w, r)
+	if !ok {
+		return
+	}
+	code, err := s.generator.Generate(table)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to generate code for table %s: %w", table, err))
+		return
+	}
+	w.Header().Set("Content-Type", "text/x-go; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filepath.Base(s.generator.FilePath(table, ""))))
+	w.Write(code)
+}
+
+func (s *Server) handleArchive(w http.ResponseWriter, r *http.Request) {
+	var tables []string
+	if list := r.URL.Query().Get("tables"); list != "" {
+		known, err := s.knownTables()
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err)
+			return
+		}
+		for _, table := range strings.Split(list, ",") {
+			table = strings.TrimSpace(table)
+			if table == "" {
+				continue
+			}
+			if !known[table] {
+				writeError(w, http.StatusNotFound, fmt.Errorf("table %s not found", table))
+				return
+			}
+			tables = append(tables, table)
+		}
+	}
+
+	// Build the archive before writing headers so errors can still be reported
+	var buf bytes.Buffer
+	if err := s.generator.WriteArchive(&buf, tables, generator.ArchiveZip); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", ArchiveName))
+	w.Write(buf.Bytes())
+}
+
+// lookupTable returns the {table} path value, writing a 404 response if the
+// database has no such table
+func (s *Server) lookupTable(w http.ResponseWriter, r *http.Request) (string, bool) {
+	table := r.PathValue("table")
+	known, err := s.knownTables()
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return "", false
+	}
+	if !known[table] {
+		writeError(w, http.StatusNotFound, fmt.Errorf("table %s not found", table))
+		return "", false
+	}
+	return table, true
+}
+
+// knownTables returns the set of table names in the database
+func (s *Server) knownTables() (map[string]bool, error) {
+	tables, err := s.introspector.GetTables()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tables: %w", err)
+	}
+	known := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		known[table] = true
+	}
+	return known, nil
+}
+
+// tableInfo converts table metadata to its API representation
+func tableInfo(meta *database.TableMetadata) TableInfo {
+	info := TableInfo{
+		Schema:       meta.Schema,
+		Name:         meta.Name,
+		Comment:      meta.Comment,
+		Columns:      []ColumnInfo{},
+		ForeignKeys:  foreignKeyInfos(meta.ForeignKeys),
+		ReferencedBy: foreignKeyInfos(meta.ReferencedBy),
+	}
+	for _, col := range meta.Columns {
+		info.Columns = append(info.Columns, ColumnInfo{
+			Name:            col.Name,
+			DataType:        col.DataType,
+			RawType:         col.RawType,
+			IsNullable:      col.IsNullable,
+			IsPrimaryKey:    col.IsPrimaryKey,
+			IsAutoIncrement: col.IsAutoIncrement,
+			DefaultValue:    col.DefaultValue,
+			EnumValues:      col.EnumValues,
+			Comment:         col.Comment,
+		})
+	}
+	return info
+}
+
+// foreignKeyInfos converts foreign keys to their API representation
+func foreignKeyInfos(fks []database.ForeignKey) []ForeignKeyInfo {
+	var infos []ForeignKeyInfo
+	for _, fk := range fks {
+		infos = append(infos, ForeignKeyInfo(fk))
+	}
+	return infos
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
+
+// writeError writes an error as a JSON response of the form {"error": "..."}
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
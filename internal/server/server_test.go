@@ -0,0 +1,130 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/generator"
+	"github.com/rowjak/godb-orm/pkg/databasetest"
+)
+
+func newTestServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	users := databasetest.Table("users",
+		databasetest.PrimaryKey(databasetest.Column("id", "bigint")),
+		databasetest.Column("email", "varchar(255)"),
+	)
+	orders := databasetest.Table("orders",
+		databasetest.PrimaryKey(databasetest.Column("id", "bigint")),
+		databasetest.Column("user_id", "bigint"),
+	)
+	databasetest.References(orders, "user_id", users, "id")
+	fake := databasetest.New("mysql", users, orders)
+
+	gen := generator.NewGeneratorWithConfig(fake, generator.GeneratorConfig{PackageName: "models"})
+	ts := httptest.NewServer(New(fake, gen, token).Handler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func get(t *testing.T, url, token string) (*http.Response, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, body
+}
+
+func TestServer(t *testing.T) {
+	ts := newTestServer(t, "")
+
+	resp, body := get(t, ts.URL+"/api/tables", "")
+	if resp.StatusCode != http.StatusOK || strings.TrimSpace(string(body)) != `["orders","users"]` {
+		t.Errorf("GET /api/tables = %d %s", resp.StatusCode, body)
+	}
+
+	resp, body = get(t, ts.URL+"/api/tables/orders", "")
+	var info TableInfo
+	if err := json.Unmarshal(body, &info); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("GET /api/tables/orders = %d %s (%v)", resp.StatusCode, body, err)
+	}
+	if len(info.Columns) != 2 || len(info.ForeignKeys) != 1 || info.ForeignKeys[0].ReferencedTable != "users" {
+		t.Errorf("GET /api/tables/orders = %+v", info)
+	}
+
+	resp, body = get(t, ts.URL+"/api/tables/users/code", "")
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "type User struct") {
+		t.Errorf("GET /api/tables/users/code = %d %s", resp.StatusCode, body)
+	}
+
+	resp, body = get(t, ts.URL+"/api/tables/missing/code", "")
+	if resp.StatusCode != http.StatusNotFound || !strings.Contains(string(body), "table missing not found") {
+		t.Errorf("GET /api/tables/missing/code = %d %s", resp.StatusCode, body)
+	}
+}
+
+func TestServer_Archive(t *testing.T) {
+	ts := newTestServer(t, "")
+
+	for query, want := range map[string][]string{
+		"":                {"orders.go", "users.go"},
+		"?tables=users":   {"users.go"},
+		"?tables=missing": nil,
+	} {
+		resp, body := get(t, ts.URL+"/api/models.zip"+query, "")
+		if want == nil {
+			if resp.StatusCode != http.StatusNotFound {
+				t.Errorf("GET /api/models.zip%s = %d; want 404", query, resp.StatusCode)
+			}
+			continue
+		}
+
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			t.Fatalf("GET /api/models.zip%s: %v", query, err)
+		}
+		var names []string
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(want, ",") {
+			t.Errorf("GET /api/models.zip%s files = %v; want %v", query, names, want)
+		}
+	}
+}
+
+func TestServer_Token(t *testing.T) {
+	ts := newTestServer(t, "secret")
+
+	if resp, _ := get(t, ts.URL+"/api/tables", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET /api/tables without token = %d; want 401", resp.StatusCode)
+	}
+	if resp, _ := get(t, ts.URL+"/api/tables", "wrong"); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GET /api/tables with wrong token = %d; want 401", resp.StatusCode)
+	}
+	if resp, _ := get(t, ts.URL+"/api/tables", "secret"); resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /api/tables with token = %d; want 200", resp.StatusCode)
+	}
+	if resp, _ := get(t, ts.URL+"/healthz", ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /healthz = %d; want 200", resp.StatusCode)
+	}
+}
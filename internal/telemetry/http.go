@@ -0,0 +1,46 @@
+package telemetry
+
+import (
+	"net/http"
+	"strings"
+)
+
+// statusRecorder captures the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Handler returns next wrapped to record a server span per request, named
+// after the matched route pattern (GET /api/tables/{table}) to keep
+// cardinality low. Without a Provider next is returned as is.
+func (p *Provider) Handler(next http.Handler) http.Handler {
+	if p == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		span := p.startSpan(nil, r.Method, kindServer, []Attr{
+			String("http.request.method", r.Method),
+			String("url.path", r.URL.Path),
+		})
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		// ServeMux sets the pattern while routing
+		if r.Pattern != "" {
+			route := strings.TrimPrefix(r.Pattern, r.Method+" ")
+			span.name = r.Method + " " + route
+			span.SetAttributes(String("http.route", route))
+		}
+		span.SetAttributes(Int("http.response.status_code", rec.status))
+		if rec.status >= 500 {
+			span.err = http.StatusText(rec.status)
+		}
+		span.End()
+	})
+}
@@ -0,0 +1,73 @@
+package telemetry
+
+import "github.com/rowjak/godb-orm/internal/database"
+
+// introspector traces the calls of a wrapped DBIntrospector
+type introspector struct {
+	database.DBIntrospector
+	provider *Provider
+	parent   *Span
+}
+
+// WrapIntrospector returns an introspector recording a span per call of
+// inner, nested in parent or, if parent is nil, each starting its own
+// trace. Dialect and Ping are forwarded. Without a Provider inner is
+// returned as is.
+func WrapIntrospector(inner database.DBIntrospector, p *Provider, parent *Span) database.DBIntrospector {
+	if p == nil {
+		return inner
+	}
+	return &introspector{DBIntrospector: inner, provider: p, parent: parent}
+}
+
+func (i *introspector) span(operation string, attrs ...Attr) *Span {
+	return i.provider.startSpan(i.parent, "introspect."+operation, kindClient, attrs)
+}
+
+// GetTables traces the listing of tables
+func (i *introspector) GetTables() ([]string, error) {
+	span := i.span("GetTables")
+	defer span.End()
+	tables, err := i.DBIntrospector.GetTables()
+	span.Fail(err)
+	span.SetAttributes(Int("godb_orm.table_count", len(tables)))
+	return tables, err
+}
+
+// GetColumns traces the column query of a table
+func (i *introspector) GetColumns(tableName string) ([]database.ColumnMetadata, error) {
+	span := i.span("GetColumns", String("db.sql.table", tableName))
+	defer span.End()
+	columns, err := i.DBIntrospector.GetColumns(tableName)
+	span.Fail(err)
+	span.SetAttributes(Int("godb_orm.column_count", len(columns)))
+	return columns, err
+}
+
+// GetTableMetadata traces the metadata queries of a table
+func (i *introspector) GetTableMetadata(tableName string) (*database.TableMetadata, error) {
+	span := i.span("GetTableMetadata", String("db.sql.table", tableName))
+	defer span.End()
+	meta, err := i.DBIntrospector.GetTableMetadata(tableName)
+	span.Fail(err)
+	if meta != nil {
+		span.SetAttributes(Int("godb_orm.column_count", len(meta.Columns)))
+	}
+	return meta, err
+}
+
+// Dialect forwards to the wrapped introspector, if it knows its dialect
+func (i *introspector) Dialect() string {
+	if d, ok := i.DBIntrospector.(database.Dialecter); ok {
+		return d.Dialect()
+	}
+	return ""
+}
+
+// Ping forwards to the wrapped introspector, if it has a live connection
+func (i *introspector) Ping() error {
+	if p, ok := i.DBIntrospector.(database.Pinger); ok {
+		return p.Ping()
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DurationMetric is the histogram of operation durations in milliseconds,
+// broken down by operation (the span name) and status
+const DurationMetric = "godb_orm.operation.duration"
+
+// durationBounds are the histogram bucket boundaries in milliseconds
+var durationBounds = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// scopeName is the instrumentation scope of all telemetry
+const scopeName = "github.com/rowjak/godb-orm"
+
+// histogramKey identifies the data point of an operation's durations
+type histogramKey struct {
+	operation string
+	failed    bool
+}
+
+// histogram is a cumulative explicit-bucket histogram
+type histogram struct {
+	count    uint64
+	sum      float64
+	min, max float64
+	buckets  []uint64
+}
+
+func newHistogram() *histogram {
+	return &histogram{
+		min:     math.Inf(1),
+		max:     math.Inf(-1),
+		buckets: make([]uint64, len(durationBounds)+1),
+	}
+}
+
+func (h *histogram) record(value float64) {
+	h.count++
+	h.sum += value
+	h.min = math.Min(h.min, value)
+	h.max = math.Max(h.max, value)
+	i := 0
+	for i < len(durationBounds) && value > durationBounds[i] {
+		i++
+	}
+	h.buckets[i]++
+}
+
+// Flush exports the spans ended since the last flush and the current
+// duration histograms. Spans are dropped even if the export fails.
+func (p *Provider) Flush(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	spans := p.spans
+	p.spans = nil
+	metrics := p.encodeMetrics(time.Now())
+	p.mu.Unlock()
+
+	if len(spans) > 0 {
+		if err := p.post(ctx, "/v1/traces", p.encodeTraces(spans)); err != nil {
+			return fmt.Errorf("failed to export traces: %w", err)
+		}
+	}
+	if metrics != nil {
+		if err := p.post(ctx, "/v1/metrics", metrics); err != nil {
+			return fmt.Errorf("failed to export metrics: %w", err)
+		}
+	}
+	return nil
+}
+
+// post sends an OTLP/JSON request to the collector
+func (p *Provider) post(ctx context.Context, path string, body interface{}) error {
+	content, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.Endpoint+path, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range p.cfg.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("collector returned %s", resp.Status)
+	}
+	return nil
+}
+
+// object is a JSON object of the OTLP encoding
+type object = map[string]interface{}
+
+func (p *Provider) resource() object {
+	return object{"attributes": encodeAttrs([]Attr{String("service.name", p.cfg.ServiceName)})}
+}
+
+// encodeTraces builds an ExportTraceServiceRequest
+func (p *Provider) encodeTraces(spans []*Span) object {
+	encoded := make([]object, 0, len(spans))
+	for _, s := range spans {
+		span := object{
+			"traceId":           s.traceID,
+			"spanId":            s.spanID,
+			"name":              s.name,
+			"kind":              s.kind,
+			"startTimeUnixNano": unixNano(s.start),
+			"endTimeUnixNano":   unixNano(s.end),
+			"attributes":        encodeAttrs(s.attrs),
+		}
+		if s.parentID != "" {
+			span["parentSpanId"] = s.parentID
+		}
+		if s.err != "" {
+			span["status"] = object{"code": 2, "message": s.err}
+		}
+		encoded = append(encoded, span)
+	}
+	return object{"resourceSpans": []object{{
+		"resource":   p.resource(),
+		"scopeSpans": []object{{"scope": object{"name": scopeName}, "spans": encoded}},
+	}}}
+}
+
+// encodeMetrics builds an ExportMetricsServiceRequest with the cumulative
+// duration histograms, or returns nil if nothing was recorded yet. The
+// caller holds p.mu.
+func (p *Provider) encodeMetrics(now time.Time) object {
+	if len(p.histograms) == 0 {
+		return nil
+	}
+
+	var points []object
+	for key, h := range p.histograms {
+		status := "ok"
+		if key.failed {
+			status = "error"
+		}
+		buckets := make([]string, len(h.buckets))
+		for i, n := range h.buckets {
+			buckets[i] = strconv.FormatUint(n, 10)
+		}
+		points = append(points, object{
+			"attributes":        encodeAttrs([]Attr{String("operation", key.operation), String("status", status)}),
+			"startTimeUnixNano": unixNano(p.started),
+			"timeUnixNano":      unixNano(now),
+			"count":             strconv.FormatUint(h.count, 10),
+			"sum":               h.sum,
+			"min":               h.min,
+			"max":               h.max,
+			"bucketCounts":      buckets,
+			"explicitBounds":    durationBounds,
+		})
+	}
+
+	metric := object{
+		"name":        DurationMetric,
+		"description": "Duration of introspection queries, model generation and API requests",
+		"unit":        "ms",
+		"histogram":   object{"aggregationTemporality": 2, "dataPoints": points},
+	}
+	return object{"resourceMetrics": []object{{
+		"resource":     p.resource(),
+		"scopeMetrics": []object{{"scope": object{"name": scopeName}, "metrics": []object{metric}}},
+	}}}
+}
+
+// encodeAttrs encodes attributes as OTLP KeyValues
+func encodeAttrs(attrs []Attr) []object {
+	encoded := make([]object, 0, len(attrs))
+	for _, attr := range attrs {
+		var value object
+		switch v := attr.Value.(type) {
+		case string:
+			value = object{"stringValue": v}
+		case int64:
+			value = object{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = object{"doubleValue": v}
+		case bool:
+			value = object{"boolValue": v}
+		default:
+			value = object{"stringValue": fmt.Sprint(v)}
+		}
+		encoded = append(encoded, object{"key": attr.Key, "value": value})
+	}
+	return encoded
+}
+
+// unixNano formats a time as the decimal string OTLP/JSON uses for fixed64
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -0,0 +1,235 @@
+// Package telemetry records OpenTelemetry traces and metrics of
+// introspection and generation runs and exports them to an OTLP/HTTP
+// collector, so platform teams can monitor large schema runs and
+// `godb-orm serve` instances. It speaks the OTLP JSON encoding directly and
+// needs no SDK.
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultServiceName is the service.name resource attribute used when
+// neither the config nor OTEL_SERVICE_NAME sets one
+const DefaultServiceName = "godb-orm"
+
+// maxPendingSpans bounds the spans buffered between exports; older spans
+// are dropped first
+const maxPendingSpans = 4096
+
+// Span kinds, as numbered by OTLP
+const (
+	kindInternal = 1
+	kindServer   = 2
+	kindClient   = 3
+)
+
+// Config selects the collector telemetry is exported to
+type Config struct {
+	Endpoint    string            // OTLP/HTTP base URL, e.g. http://localhost:4318
+	ServiceName string            // service.name resource attribute
+	Headers     map[string]string // Extra request headers, e.g. an API key
+}
+
+// Attr is a span attribute; values are strings, ints, floats or bools
+type Attr struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute
+func String(key, value string) Attr { return Attr{Key: key, Value: value} }
+
+// Int returns an integer attribute
+func Int(key string, value int) Attr { return Attr{Key: key, Value: int64(value)} }
+
+// Bool returns a boolean attribute
+func Bool(key string, value bool) Attr { return Attr{Key: key, Value: value} }
+
+// Provider buffers finished spans and duration histograms until they are
+// exported. A nil Provider records nothing, so callers need no checks when
+// telemetry is disabled.
+type Provider struct {
+	cfg     Config
+	client  *http.Client
+	started time.Time
+
+	mu         sync.Mutex
+	spans      []*Span
+	histograms map[histogramKey]*histogram
+}
+
+// New returns a Provider exporting to cfg.Endpoint, or nil if no endpoint
+// is configured. Unset fields fall back to the standard
+// OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_SERVICE_NAME and
+// OTEL_EXPORTER_OTLP_HEADERS environment variables.
+func New(cfg Config) *Provider {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	if cfg.Endpoint == "" {
+		return nil
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = os.Getenv("OTEL_SERVICE_NAME")
+	}
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = DefaultServiceName
+	}
+	if cfg.Headers == nil {
+		cfg.Headers = parseHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"))
+	}
+
+	return &Provider{
+		cfg:        cfg,
+		client:     &http.Client{Timeout: 10 * time.Second},
+		started:    time.Now(),
+		histograms: make(map[histogramKey]*histogram),
+	}
+}
+
+// Start begins a root span, starting a new trace
+func (p *Provider) Start(name string, attrs ...Attr) *Span {
+	return p.startSpan(nil, name, kindInternal, attrs)
+}
+
+// Run exports buffered telemetry every interval until ctx is done, then
+// exports what is left. Export errors are passed to onError, if set.
+func (p *Provider) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	if p == nil {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := p.Flush(shutdownCtx); err != nil && onError != nil {
+				onError(err)
+			}
+			return
+		case <-ticker.C:
+			if err := p.Flush(ctx); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func (p *Provider) startSpan(parent *Span, name string, kind int, attrs []Attr) *Span {
+	if p == nil {
+		return nil
+	}
+	s := &Span{
+		provider: p,
+		name:     name,
+		kind:     kind,
+		start:    time.Now(),
+		attrs:    attrs,
+		spanID:   randomHex(8),
+	}
+	if parent != nil {
+		s.traceID = parent.traceID
+		s.parentID = parent.spanID
+	} else {
+		s.traceID = randomHex(16)
+	}
+	return s
+}
+
+// finish buffers an ended span and records its duration
+func (p *Provider) finish(s *Span) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.spans) >= maxPendingSpans {
+		p.spans = p.spans[1:]
+	}
+	p.spans = append(p.spans, s)
+
+	key := histogramKey{operation: s.name, failed: s.err != ""}
+	h, ok := p.histograms[key]
+	if !ok {
+		h = newHistogram()
+		p.histograms[key] = h
+	}
+	h.record(float64(s.end.Sub(s.start)) / float64(time.Millisecond))
+}
+
+// Span is a timed operation in a trace. A nil Span records nothing.
+type Span struct {
+	provider *Provider
+	name     string
+	kind     int
+	traceID  string
+	spanID   string
+	parentID string
+	start    time.Time
+	end      time.Time
+	attrs    []Attr
+	err      string
+}
+
+// Child begins a span nested in s
+func (s *Span) Child(name string, attrs ...Attr) *Span {
+	if s == nil {
+		return nil
+	}
+	return s.provider.startSpan(s, name, kindInternal, attrs)
+}
+
+// SetAttributes adds attributes to s
+func (s *Span) SetAttributes(attrs ...Attr) {
+	if s == nil {
+		return
+	}
+	s.attrs = append(s.attrs, attrs...)
+}
+
+// Fail marks s as failed with err; a nil err is ignored
+func (s *Span) Fail(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.err = err.Error()
+}
+
+// End finishes s; it must be called exactly once
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.end = time.Now()
+	s.provider.finish(s)
+}
+
+// randomHex returns n random bytes, hex encoded as OTLP/JSON IDs are
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// parseHeaders parses OTEL_EXPORTER_OTLP_HEADERS: comma-separated
+// key=value pairs
+func parseHeaders(value string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			continue
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return headers
+}
@@ -0,0 +1,243 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rowjak/godb-orm/pkg/databasetest"
+)
+
+// collector records the OTLP requests it receives, by path
+type collector struct {
+	mu       sync.Mutex
+	requests map[string][]map[string]interface{}
+	headers  http.Header
+}
+
+func newCollector(t *testing.T, status int) (*collector, *httptest.Server) {
+	t.Helper()
+	c := &collector{requests: make(map[string][]map[string]interface{})}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid OTLP/JSON body: %v", err)
+		}
+		c.mu.Lock()
+		c.requests[r.URL.Path] = append(c.requests[r.URL.Path], body)
+		c.headers = r.Header.Clone()
+		c.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(ts.Close)
+	return c, ts
+}
+
+// spans returns the spans of all trace exports
+func (c *collector) spans() []map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var spans []map[string]interface{}
+	for _, req := range c.requests["/v1/traces"] {
+		for _, rs := range req["resourceSpans"].([]interface{}) {
+			for _, ss := range rs.(map[string]interface{})["scopeSpans"].([]interface{}) {
+				for _, span := range ss.(map[string]interface{})["spans"].([]interface{}) {
+					spans = append(spans, span.(map[string]interface{}))
+				}
+			}
+		}
+	}
+	return spans
+}
+
+func spanNamed(spans []map[string]interface{}, name string) map[string]interface{} {
+	for _, span := range spans {
+		if span["name"] == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func TestProviderExport(t *testing.T) {
+	c, ts := newCollector(t, http.StatusOK)
+	p := New(Config{Endpoint: ts.URL + "/", ServiceName: "ci", Headers: map[string]string{"X-Api-Key": "secret"}})
+
+	run := p.Start("godb-orm generate", String("db.system", "mysql"))
+	table := run.Child("generate.table", String("db.sql.table", "users"))
+	table.Fail(errors.New("boom"))
+	table.End()
+	run.End()
+
+	if err := p.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got := c.headers.Get("X-Api-Key"); got != "secret" {
+		t.Errorf("X-Api-Key header = %q, want secret", got)
+	}
+
+	spans := c.spans()
+	if len(spans) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(spans))
+	}
+	root, child := spanNamed(spans, "godb-orm generate"), spanNamed(spans, "generate.table")
+	if root == nil || child == nil {
+		t.Fatalf("spans missing: %v", spans)
+	}
+	if child["traceId"] != root["traceId"] || child["parentSpanId"] != root["spanId"] {
+		t.Errorf("child span not nested in root: %v %v", child, root)
+	}
+	if len(root["traceId"].(string)) != 32 || len(root["spanId"].(string)) != 16 {
+		t.Errorf("IDs are not hex encoded: %v", root)
+	}
+	if status, _ := child["status"].(map[string]interface{}); status["code"] != float64(2) || status["message"] != "boom" {
+		t.Errorf("child status = %v, want error boom", child["status"])
+	}
+
+	c.mu.Lock()
+	metrics := c.requests["/v1/metrics"]
+	c.mu.Unlock()
+	if len(metrics) != 1 {
+		t.Fatalf("got %d metric exports, want 1", len(metrics))
+	}
+	encoded, _ := json.Marshal(metrics[0])
+	for _, want := range []string{`"name":"` + DurationMetric + `"`, `"stringValue":"ci"`, `"stringValue":"generate.table"`, `"stringValue":"error"`, `"aggregationTemporality":2`} {
+		if !strings.Contains(string(encoded), want) {
+			t.Errorf("metrics missing %s:\n%s", want, encoded)
+		}
+	}
+
+	// Spans are exported once, histograms are cumulative
+	if err := p.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(c.spans()) != 2 {
+		t.Errorf("spans exported twice")
+	}
+}
+
+func TestProviderExportError(t *testing.T) {
+	_, ts := newCollector(t, http.StatusServiceUnavailable)
+	p := New(Config{Endpoint: ts.URL})
+	p.Start("run").End()
+
+	err := p.Flush(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("Flush() error = %v, want 503", err)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	p := New(Config{})
+	if p != nil {
+		t.Fatalf("New() without endpoint = %v, want nil", p)
+	}
+
+	// A nil provider and its nil spans record nothing
+	span := p.Start("run")
+	span.Child("child").End()
+	span.Fail(errors.New("boom"))
+	span.End()
+	if err := p.Flush(context.Background()); err != nil {
+		t.Errorf("Flush() error = %v", err)
+	}
+
+	fake := databasetest.New("mysql")
+	if WrapIntrospector(fake, p, nil) != fake {
+		t.Errorf("WrapIntrospector() without provider should return the introspector")
+	}
+}
+
+func TestEnvConfig(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
+	t.Setenv("OTEL_SERVICE_NAME", "schema-ci")
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "api-key=abc, x-team = data")
+
+	p := New(Config{})
+	if p == nil {
+		t.Fatal("New() = nil, want provider from environment")
+	}
+	if p.cfg.Endpoint != "http://collector:4318" || p.cfg.ServiceName != "schema-ci" {
+		t.Errorf("config = %+v", p.cfg)
+	}
+	if p.cfg.Headers["api-key"] != "abc" || p.cfg.Headers["x-team"] != "data" {
+		t.Errorf("headers = %v", p.cfg.Headers)
+	}
+}
+
+func TestWrapIntrospector(t *testing.T) {
+	c, ts := newCollector(t, http.StatusOK)
+	p := New(Config{Endpoint: ts.URL})
+
+	users := databasetest.Table("users",
+		databasetest.PrimaryKey(databasetest.Column("id", "bigint")),
+		databasetest.Column("email", "varchar(255)"),
+	)
+	run := p.Start("run")
+	introspector := WrapIntrospector(databasetest.New("postgres", users), p, run)
+
+	if tables, err := introspector.GetTables(); err != nil || len(tables) != 1 {
+		t.Fatalf("GetTables() = %v, %v", tables, err)
+	}
+	if _, err := introspector.GetTableMetadata("users"); err != nil {
+		t.Fatalf("GetTableMetadata() error = %v", err)
+	}
+	if _, err := introspector.GetColumns("missing"); err == nil {
+		t.Fatal("GetColumns(missing) error = nil")
+	}
+	if d, ok := introspector.(interface{ Dialect() string }); !ok || d.Dialect() != "postgres" {
+		t.Errorf("Dialect not forwarded")
+	}
+	run.End()
+
+	if err := p.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	spans := c.spans()
+	meta := spanNamed(spans, "introspect.GetTableMetadata")
+	if meta == nil || meta["kind"] != float64(kindClient) || meta["parentSpanId"] == nil {
+		t.Fatalf("GetTableMetadata span = %v", meta)
+	}
+	encoded, _ := json.Marshal(meta["attributes"])
+	if !strings.Contains(string(encoded), `"stringValue":"users"`) || !strings.Contains(string(encoded), `"intValue":"2"`) {
+		t.Errorf("GetTableMetadata attributes = %s", encoded)
+	}
+	if columns := spanNamed(spans, "introspect.GetColumns"); columns == nil || columns["status"] == nil {
+		t.Errorf("failed GetColumns span should have an error status: %v", columns)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	c, ts := newCollector(t, http.StatusOK)
+	p := New(Config{Endpoint: ts.URL})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/tables/{table}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	api := httptest.NewServer(p.Handler(mux))
+	defer api.Close()
+
+	resp, err := http.Get(api.URL + "/api/tables/users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if err := p.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	span := spanNamed(c.spans(), "GET /api/tables/{table}")
+	if span == nil {
+		t.Fatalf("no span named after the route: %v", c.spans())
+	}
+	if span["kind"] != float64(kindServer) || span["status"] == nil {
+		t.Errorf("request span = %v", span)
+	}
+}
@@ -0,0 +1,411 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2/quick"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rowjak/godb-orm/internal/database"
+	"github.com/rowjak/godb-orm/internal/generator"
+)
+
+// view identifies which pane the TUI is currently showing
+type view int
+
+const (
+	viewTables view = iota
+	viewColumns
+	viewPreview
+)
+
+// Styles used across the TUI
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("39"))
+	cursorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true)
+	selectedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	helpStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	statusStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+)
+
+// Model is the bubbletea model for the table browser
+type Model struct {
+	introspector database.DBIntrospector
+	generator    *generator.Generator
+	typeMapper   *generator.TypeMapper
+	outputDir    string
+
+	tables   []string
+	selected map[string]bool
+	cursor   int
+
+	view    view
+	columns []database.ColumnMetadata
+	code    string
+	scroll  int
+
+	width  int
+	height int
+
+	status string
+	err    error
+}
+
+// NewModel creates a new TUI model backed by a connected introspector
+func NewModel(introspector database.DBIntrospector, gen *generator.Generator, outputDir string) *Model {
+	return &Model{
+		introspector: introspector,
+		generator:    gen,
+		typeMapper:   generator.NewTypeMapper(),
+		outputDir:    outputDir,
+		selected:     make(map[string]bool),
+		height:       24,
+	}
+}
+
+// Run starts the TUI program and blocks until the user quits
+func Run(introspector database.DBIntrospector, gen *generator.Generator, outputDir string) error {
+	p := tea.NewProgram(NewModel(introspector, gen, outputDir), tea.WithAltScreen())
+	_, err := p.Run()
+	return err
+}
+
+// tablesLoadedMsg is sent when the table list has been fetched
+type tablesLoadedMsg struct {
+	tables []string
+	err    error
+}
+
+// columnsLoadedMsg is sent when the columns of a table have been fetched
+type columnsLoadedMsg struct {
+	table   string
+	columns []database.ColumnMetadata
+	err     error
+}
+
+// previewMsg is sent when the highlighted code of a table has been generated
+type previewMsg struct {
+	table string
+	code  string
+	err   error
+}
+
+// generatedMsg is sent when generation of the selected tables finishes
+type generatedMsg struct {
+	files []string
+	err   error
+}
+
+// Init loads the table list
+func (m *Model) Init() tea.Cmd {
+	return m.loadTables
+}
+
+func (m *Model) loadTables() tea.Msg {
+	tables, err := m.introspector.GetTables()
+	return tablesLoadedMsg{tables: tables, err: err}
+}
+
+// Update handles incoming messages and key presses
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tablesLoadedMsg:
+		m.tables = msg.tables
+		m.err = msg.err
+		return m, nil
+
+	case columnsLoadedMsg:
+		m.status = ""
+		// A result for a table the cursor has left is stale
+		if msg.table != m.currentTable() {
+			return m, nil
+		}
+		m.err = msg.err
+		if msg.err == nil {
+			m.columns = msg.columns
+			m.view = viewColumns
+			m.scroll = 0
+		}
+		return m, nil
+
+	case previewMsg:
+		m.status = ""
+		if msg.table != m.currentTable() {
+			return m, nil
+		}
+		m.err = msg.err
+		if msg.err == nil {
+			m.code = msg.code
+			m.view = viewPreview
+			m.scroll = 0
+		}
+		return m, nil
+
+	case generatedMsg:
+		m.err = msg.err
+		if msg.err == nil {
+			m.status = fmt.Sprintf("Generated %d file(s) in %s", len(msg.files), m.outputDir)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+
+	return m, nil
+}
+
+// handleKey dispatches key presses depending on the active view
+func (m *Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+	case "esc", "backspace":
+		if m.view != viewTables {
+			m.view = viewTables
+			m.scroll = 0
+		}
+		return m, nil
+	}
+
+	if m.view != viewTables {
+		return m.handleDetailKey(msg)
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.tables)-1 {
+			m.cursor++
+		}
+	case " ", "x":
+		if table := m.currentTable(); table != "" {
+			m.selected[table] = !m.selected[table]
+		}
+	case "a":
+		allSelected := len(m.selectedTables()) == len(m.tables)
+		for _, t := range m.tables {
+			m.selected[t] = !allSelected
+		}
+	case "enter", "c":
+		return m, m.showColumns()
+	case "p":
+		return m, m.showPreview()
+	case "g":
+		m.status = "Generating..."
+		return m, m.generateSelected
+	case "r":
+		m.status = ""
+		return m, m.loadTables
+	}
+
+	return m, nil
+}
+
+// handleDetailKey handles scrolling and switching between the column and preview views
+func (m *Model) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.scroll > 0 {
+			m.scroll--
+		}
+	case "down", "j":
+		m.scroll++
+	case "tab":
+		if m.view == viewColumns {
+			return m, m.showPreview()
+		}
+		return m, m.showColumns()
+	}
+	return m, nil
+}
+
+func (m *Model) currentTable() string {
+	if m.cursor < 0 || m.cursor >= len(m.tables) {
+		return ""
+	}
+	return m.tables[m.cursor]
+}
+
+func (m *Model) selectedTables() []string {
+	var tables []string
+	for t, ok := range m.selected {
+		if ok {
+			tables = append(tables, t)
+		}
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// showColumns returns a command fetching the columns of the current table,
+// so a slow database doesn't freeze the UI
+func (m *Model) showColumns() tea.Cmd {
+	table := m.currentTable()
+	if table == "" {
+		return nil
+	}
+	m.status = "Loading columns of " + table + "..."
+	return func() tea.Msg {
+		columns, err := m.introspector.GetColumns(table)
+		return columnsLoadedMsg{table: table, columns: columns, err: err}
+	}
+}
+
+// showPreview returns a command generating and highlighting the code of
+// the current table
+func (m *Model) showPreview() tea.Cmd {
+	table := m.currentTable()
+	if table == "" {
+		return nil
+	}
+	m.status = "Generating preview of " + table + "..."
+	return func() tea.Msg {
+		code, err := m.generator.GenerateString(table)
+		if err != nil {
+			return previewMsg{table: table, err: err}
+		}
+		return previewMsg{table: table, code: highlight(code)}
+	}
+}
+
+// generateSelected writes the selected tables (or the current one if none are selected)
+func (m *Model) generateSelected() tea.Msg {
+	tables := m.selectedTables()
+	if len(tables) == 0 {
+		if table := m.currentTable(); table != "" {
+			tables = []string{table}
+		}
+	}
+
+	var files []string
+	for _, table := range tables {
+		filePath, err := m.generator.GenerateToFile(table, m.outputDir)
+		if err != nil {
+			return generatedMsg{files: files, err: fmt.Errorf("failed to generate %s: %w", table, err)}
+		}
+		files = append(files, filePath)
+	}
+	return generatedMsg{files: files}
+}
+
+// View renders the current state
+func (m *Model) View() string {
+	var b strings.Builder
+
+	switch m.view {
+	case viewTables:
+		m.renderTables(&b)
+	case viewColumns:
+		m.renderColumns(&b)
+	case viewPreview:
+		m.renderPreview(&b)
+	}
+
+	if m.err != nil {
+		b.WriteString("\n" + errorStyle.Render("Error: "+m.err.Error()) + "\n")
+	} else if m.status != "" {
+		b.WriteString("\n" + statusStyle.Render(m.status) + "\n")
+	}
+
+	return b.String()
+}
+
+func (m *Model) renderTables(b *strings.Builder) {
+	b.WriteString(titleStyle.Render(fmt.Sprintf("Tables (%d, %d selected)", len(m.tables), len(m.selectedTables()))) + "\n\n")
+
+	start, end := m.window(len(m.tables), m.cursor)
+	for i := start; i < end; i++ {
+		table := m.tables[i]
+		check := "[ ]"
+		if m.selected[table] {
+			check = selectedStyle.Render("[x]")
+		}
+		line := fmt.Sprintf("%s %s", check, table)
+		if i == m.cursor {
+			line = cursorStyle.Render("> ") + line
+		} else {
+			line = "  " + line
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n" + helpStyle.Render("↑/↓ move • space select • a all • enter columns • p preview • g generate • r reload • q quit") + "\n")
+}
+
+func (m *Model) renderColumns(b *strings.Builder) {
+	b.WriteString(titleStyle.Render("Columns: "+m.currentTable()) + "\n\n")
+
+	lines := []string{fmt.Sprintf("%-28s %-24s %-18s %s", "NAME", "TYPE", "GO TYPE", "FLAGS")}
+	for _, col := range m.columns {
+		goType, _, _ := m.typeMapper.GetGoType(col.RawType, col.IsNullable)
+		var flags []string
+		if col.IsPrimaryKey {
+			flags = append(flags, "PK")
+		}
+		if col.IsAutoIncrement {
+			flags = append(flags, "AI")
+		}
+		if col.IsNullable {
+			flags = append(flags, "NULL")
+		}
+		lines = append(lines, fmt.Sprintf("%-28s %-24s %-18s %s", col.Name, col.RawType, goType, strings.Join(flags, ",")))
+	}
+	m.renderScrolled(b, lines)
+
+	b.WriteString("\n" + helpStyle.Render("↑/↓ scroll • tab preview • esc back • q quit") + "\n")
+}
+
+func (m *Model) renderPreview(b *strings.Builder) {
+	b.WriteString(titleStyle.Render("Preview: "+m.currentTable()) + "\n\n")
+	m.renderScrolled(b, strings.Split(m.code, "\n"))
+	b.WriteString("\n" + helpStyle.Render("↑/↓ scroll • tab columns • esc back • q quit") + "\n")
+}
+
+// renderScrolled writes as many lines as fit the terminal, starting at the scroll offset
+func (m *Model) renderScrolled(b *strings.Builder, lines []string) {
+	visible := m.visibleRows()
+	if m.scroll > len(lines)-visible {
+		m.scroll = max(len(lines)-visible, 0)
+	}
+	end := min(m.scroll+visible, len(lines))
+	for _, line := range lines[m.scroll:end] {
+		b.WriteString(line + "\n")
+	}
+}
+
+// window returns the visible range of a list so that the cursor stays on screen
+func (m *Model) window(total, cursor int) (int, int) {
+	visible := m.visibleRows()
+	start := 0
+	if cursor >= visible {
+		start = cursor - visible + 1
+	}
+	return start, min(start+visible, total)
+}
+
+// visibleRows returns the number of content rows available (minus title, help, status)
+func (m *Model) visibleRows() int {
+	return max(m.height-7, 1)
+}
+
+// highlight applies terminal syntax highlighting to Go code, falling back to plain text
+func highlight(code string) string {
+	var buf bytes.Buffer
+	if err := quick.Highlight(&buf, code, "go", "terminal256", "monokai"); err != nil {
+		return code
+	}
+	return buf.String()
+}
@@ -0,0 +1,158 @@
+// Package vcs stages and commits regenerated models with the git command
+// line tool, so regular regeneration ends in a reviewable commit or patch
+// instead of a dirty working tree. It needs git on the PATH and no library.
+package vcs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotRepository means the output directory is not inside a git work tree
+var ErrNotRepository = errors.New("not a git repository")
+
+// Repo runs git in a directory of a work tree. Every operation is limited to
+// that directory (pathspec ".") and the paths added with Include, so changes
+// elsewhere in the tree, staged or not, are left alone.
+type Repo struct {
+	dir     string
+	include []string // Paths relative to dir added to every operation
+	exclude []string // Paths relative to dir left out of every operation
+}
+
+// Change is a changed path as reported by git status
+type Change struct {
+	Status string // Two-letter porcelain status, e.g. " M", "??", "D "
+	Path   string // Path relative to the root of the work tree
+}
+
+// Open returns the repository containing dir
+func Open(dir string) (*Repo, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("git not found: %w", err)
+	}
+	r := &Repo{dir: dir}
+	out, err := r.run("rev-parse", "--is-inside-work-tree")
+	if err != nil || strings.TrimSpace(out) != "true" {
+		return nil, fmt.Errorf("%s: %w", dir, ErrNotRepository)
+	}
+	return r, nil
+}
+
+// Exclude leaves paths, relative to the directory, out of every operation,
+// e.g. state files that are not part of the generated code
+func (r *Repo) Exclude(paths ...string) {
+	r.exclude = append(r.exclude, paths...)
+}
+
+// Include adds files outside the directory to every operation, e.g. code
+// generated next to the models. Relative paths are taken relative to the
+// current working directory, like paths on the command line.
+func (r *Repo) Include(paths ...string) error {
+	dir, err := filepath.Abs(r.dir)
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, abs)
+		if err != nil {
+			return err
+		}
+		r.include = append(r.include, filepath.ToSlash(rel))
+	}
+	return nil
+}
+
+// pathspecs returns the pathspecs limiting an operation to the directory
+// and the included files
+func (r *Repo) pathspecs() []string {
+	specs := append([]string{"."}, r.include...)
+	for _, path := range r.exclude {
+		specs = append(specs, ":(exclude)"+path)
+	}
+	return specs
+}
+
+// run runs a git command in the directory and returns its standard output.
+// Failures carry git's own message.
+func (r *Repo) run(args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
+
+// Changes returns the added, modified and deleted files in the directory,
+// untracked files included
+func (r *Repo) Changes() ([]Change, error) {
+	out, err := r.run(append([]string{"status", "--porcelain=v1", "-z", "--untracked-files=all", "--"}, r.pathspecs()...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	entries := strings.Split(out, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		change := Change{Status: entry[:2], Path: entry[3:]}
+		// Renames and copies are followed by the original path
+		if change.Status[0] == 'R' || change.Status[0] == 'C' {
+			i++
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// Stage adds every change in the directory to the index
+func (r *Repo) Stage() error {
+	_, err := r.run(append([]string{"add", "--all", "--"}, r.pathspecs()...)...)
+	return err
+}
+
+// StagedDiff returns the staged changes of the directory as a patch that
+// git apply accepts at the root of the work tree
+func (r *Repo) StagedDiff() ([]byte, error) {
+	out, err := r.run(append([]string{"diff", "--cached", "--binary", "--"}, r.pathspecs()...)...)
+	return []byte(out), err
+}
+
+// Commit commits the staged changes of the directory alone and returns the
+// abbreviated hash of the new commit
+func (r *Repo) Commit(message string) (string, error) {
+	if _, err := r.run(append([]string{"commit", "--quiet", "--only", "-m", message, "--"}, r.pathspecs()...)...); err != nil {
+		return "", err
+	}
+	out, err := r.run("rev-parse", "--short", "HEAD")
+	return strings.TrimSpace(out), err
+}
+
+// Branch switches to the named branch, creating it from the current commit
+// if it doesn't exist yet. Uncommitted changes are carried over.
+func (r *Repo) Branch(name string) error {
+	if _, err := r.run("rev-parse", "--verify", "--quiet", "refs/heads/"+name); err == nil {
+		_, err = r.run("switch", "--quiet", name)
+		return err
+	}
+	_, err := r.run("switch", "--quiet", "--create", name)
+	return err
+}
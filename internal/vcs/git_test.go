@@ -0,0 +1,153 @@
+package vcs
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newRepo creates a repository with one commit outside the models directory
+func newRepo(t *testing.T) (root string, models *Repo) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	root = t.TempDir()
+	r := &Repo{dir: root}
+	for _, args := range [][]string{
+		{"init", "--quiet", "--initial-branch=main"},
+		{"config", "user.email", "dev@example.com"},
+		{"config", "user.name", "Dev"},
+	} {
+		if _, err := r.run(args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(t, filepath.Join(root, "README.md"), "readme\n")
+	write(t, filepath.Join(root, "models", "user.go"), "package models\n")
+	if _, err := r.run("add", "."); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.run("commit", "--quiet", "-m", "initial"); err != nil {
+		t.Fatal(err)
+	}
+
+	models, err := Open(filepath.Join(root, "models"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	return root, models
+}
+
+func write(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCommitLimitedToDirectory(t *testing.T) {
+	root, repo := newRepo(t)
+	write(t, filepath.Join(root, "models", "user.go"), "package models\n\ntype User struct{}\n")
+	write(t, filepath.Join(root, "models", "order.go"), "package models\n")
+	write(t, filepath.Join(root, "README.md"), "edited\n")
+
+	if err := repo.Stage(); err != nil {
+		t.Fatalf("Stage() error = %v", err)
+	}
+	changes, err := repo.Changes()
+	if err != nil {
+		t.Fatalf("Changes() error = %v", err)
+	}
+	want := []Change{{"A ", "models/order.go"}, {"M ", "models/user.go"}}
+	if len(changes) != len(want) || changes[0] != want[0] || changes[1] != want[1] {
+		t.Errorf("Changes() = %q; want %q", changes, want)
+	}
+
+	patch, err := repo.StagedDiff()
+	if err != nil || !strings.Contains(string(patch), "+++ b/models/order.go") || strings.Contains(string(patch), "README") {
+		t.Errorf("StagedDiff() = %s, %v", patch, err)
+	}
+
+	if err := repo.Branch("models-update"); err != nil {
+		t.Fatalf("Branch() error = %v", err)
+	}
+	if _, err := repo.Commit("regenerate models"); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	if changes, _ := repo.Changes(); len(changes) != 0 {
+		t.Errorf("Changes() after commit = %q", changes)
+	}
+
+	// The change outside the directory stays uncommitted
+	status, err := repo.run("status", "--porcelain", "--", root)
+	if err != nil || strings.TrimSpace(status) != "M README.md" {
+		t.Errorf("status = %q, %v; want README.md still modified", status, err)
+	}
+	branch, _ := repo.run("branch", "--show-current")
+	if strings.TrimSpace(branch) != "models-update" {
+		t.Errorf("branch = %q", branch)
+	}
+
+	// Switching to an existing branch doesn't fail
+	if err := repo.Branch("main"); err != nil {
+		t.Errorf("Branch(main) error = %v", err)
+	}
+}
+
+func TestExclude(t *testing.T) {
+	root, repo := newRepo(t)
+	repo.Exclude(".state")
+	write(t, filepath.Join(root, "models", "order.go"), "package models\n")
+	write(t, filepath.Join(root, "models", ".state"), "{}\n")
+
+	if err := repo.Stage(); err != nil {
+		t.Fatalf("Stage() error = %v", err)
+	}
+	if _, err := repo.Commit("regenerate models"); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	status, err := repo.run("status", "--porcelain", "--untracked-files=all", "--", root)
+	if err != nil || strings.TrimSpace(status) != "?? models/.state" {
+		t.Errorf("status = %q, %v; want models/.state untracked", status, err)
+	}
+}
+
+func TestInclude(t *testing.T) {
+	root, repo := newRepo(t)
+	handler := filepath.Join(root, "handlers", "order.go")
+	write(t, filepath.Join(root, "models", "order.go"), "package models\n")
+	write(t, handler, "package handlers\n")
+	write(t, filepath.Join(root, "notes.txt"), "not generated\n")
+	if err := repo.Include(handler); err != nil {
+		t.Fatalf("Include() error = %v", err)
+	}
+
+	if err := repo.Stage(); err != nil {
+		t.Fatalf("Stage() error = %v", err)
+	}
+	if _, err := repo.Commit("regenerate models"); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	status, err := repo.run("status", "--porcelain", "--untracked-files=all", "--", root)
+	if err != nil || strings.TrimSpace(status) != "?? notes.txt" {
+		t.Errorf("status = %q, %v; want only notes.txt untracked", status, err)
+	}
+}
+
+func TestOpenOutsideRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	t.Setenv("GIT_CEILING_DIRECTORIES", filepath.Dir(dir))
+	if _, err := Open(dir); !errors.Is(err, ErrNotRepository) {
+		t.Errorf("Open() error = %v; want ErrNotRepository", err)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+
+	"github.com/rowjak/godb-orm/internal/i18n"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// SelectOutputDirectory asks for the directory to save the models in with
+// the native directory dialog, starting at the default output directory. It
+// returns the chosen directory, or an empty string if the dialog was
+// cancelled.
+func (a *App) SelectOutputDirectory() (string, error) {
+	defaultDir, err := filepath.Abs(defaultOutputDir)
+	if err != nil {
+		defaultDir = ""
+	}
+
+	dir, err := runtime.OpenDirectoryDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:                a.i18n.Sprintf(i18n.ChooseOutputTitle),
+		DefaultDirectory:     defaultDir,
+		CanCreateDirectories: true,
+	})
+	if err != nil {
+		return "", a.i18n.Errorf(i18n.OpenDirectoryDialog, err)
+	}
+	return dir, nil
+}
+
+// OpenDirectory shows a directory, such as the one the models were saved
+// in, in the file manager of the OS
+func (a *App) OpenDirectory(dir string) error {
+	if _, err := os.Stat(dir); err != nil {
+		return a.i18n.Errorf(i18n.OpenPath, dir, err)
+	}
+	if err := startDetached(openCommand(dir)); err != nil {
+		return a.i18n.Errorf(i18n.OpenPath, dir, err)
+	}
+	return nil
+}
+
+// OpenInEditor opens a generated file in the user's editor: the command in
+// $VISUAL or $EDITOR if it is a GUI editor, else VS Code if it is on the
+// PATH, else the application the OS opens Go files with
+func (a *App) OpenInEditor(filePath string) error {
+	if _, err := os.Stat(filePath); err != nil {
+		return a.i18n.Errorf(i18n.OpenPath, filePath, err)
+	}
+	if err := startDetached(editorCommand(filePath, os.Getenv, exec.LookPath)); err != nil {
+		return a.i18n.Errorf(i18n.OpenPath, filePath, err)
+	}
+	return nil
+}
+
+// openCommand returns the command opening a file or directory with its
+// default application
+func openCommand(path string) []string {
+	switch goruntime.GOOS {
+	case "darwin":
+		return []string{"open", path}
+	case "windows":
+		return []string{"explorer", path}
+	default:
+		return []string{"xdg-open", path}
+	}
+}
+
+// guiEditors are the editors honoured from $VISUAL and $EDITOR, by command
+// name. The variables usually name terminal editors such as vim or nano,
+// which the GUI has no terminal to run in.
+var guiEditors = map[string]bool{
+	"code": true, "code-insiders": true, "codium": true, "cursor": true,
+	"subl": true, "zed": true, "atom": true, "mate": true, "bbedit": true,
+	"gvim": true, "mvim": true, "gedit": true, "kate": true,
+	"goland": true, "idea": true, "notepad++": true,
+}
+
+// editorCommand returns the command opening filePath in the user's editor.
+// The editor variables may hold arguments, e.g. "code --wait".
+func editorCommand(filePath string, getenv func(string) string, lookPath func(string) (string, error)) []string {
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		editor := strings.Fields(getenv(name))
+		if len(editor) > 0 && guiEditors[editorName(editor[0])] {
+			return append(editor, filePath)
+		}
+	}
+	if code, err := lookPath("code"); err == nil {
+		return []string{code, filePath}
+	}
+	return openCommand(filePath)
+}
+
+// editorName returns the command name of an editor, without directory or
+// extension: "code" for /usr/local/bin/code and for code.cmd
+func editorName(command string) string {
+	name := strings.ToLower(path.Base(strings.ReplaceAll(command, `\`, "/")))
+	return strings.TrimSuffix(name, path.Ext(name))
+}
+
+// startDetached starts a command without waiting for it, as file managers
+// and editors run until the user closes them. Explorer exits with a failure
+// status even when it opened the path, so only failing to start counts.
+func startDetached(args []string) error {
+	cmd := exec.Command(args[0], args[1:]...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
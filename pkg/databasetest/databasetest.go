@@ -0,0 +1,196 @@
+// Package databasetest provides an in-memory introspector so code built on
+// the generator (custom templates, overrides, plugins) can be unit tested
+// without a live database.
+//
+//	fake := databasetest.New("postgres",
+//		databasetest.Table("users",
+//			databasetest.PrimaryKey(databasetest.Column("id", "bigint")),
+//			databasetest.Column("email", "varchar(255)"),
+//		),
+//	)
+package databasetest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// Metadata types served by FakeIntrospector
+type (
+	TableMetadata  = database.TableMetadata
+	ColumnMetadata = database.ColumnMetadata
+	ForeignKey     = database.ForeignKey
+)
+
+// FakeIntrospector serves table metadata fixtures from memory. It is safe
+// for concurrent use.
+type FakeIntrospector struct {
+	// Err, if set, is returned by every introspection call
+	Err error
+
+	mu      sync.Mutex
+	dialect string
+	tables  map[string]*TableMetadata
+	calls   map[string]int
+}
+
+// New creates a FakeIntrospector for a dialect (mysql, postgres, or empty
+// for none) serving the given tables
+func New(dialect string, tables ...*TableMetadata) *FakeIntrospector {
+	f := &FakeIntrospector{
+		dialect: dialect,
+		tables:  make(map[string]*TableMetadata),
+		calls:   make(map[string]int),
+	}
+	for _, table := range tables {
+		f.AddTable(table)
+	}
+	return f
+}
+
+// AddTable adds or replaces a table fixture
+func (f *FakeIntrospector) AddTable(table *TableMetadata) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tables[table.Name] = table
+}
+
+// Connect does nothing and returns Err
+func (f *FakeIntrospector) Connect() error {
+	return f.Err
+}
+
+// Close does nothing
+func (f *FakeIntrospector) Close() error {
+	return nil
+}
+
+// Ping returns Err
+func (f *FakeIntrospector) Ping() error {
+	return f.Err
+}
+
+// Dialect returns the dialect passed to New
+func (f *FakeIntrospector) Dialect() string {
+	return f.dialect
+}
+
+// GetTables returns the table names in sorted order
+func (f *FakeIntrospector) GetTables() ([]string, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	names := make([]string, 0, len(f.tables))
+	for name := range f.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// GetColumns returns the columns of a table
+func (f *FakeIntrospector) GetColumns(tableName string) ([]ColumnMetadata, error) {
+	meta, err := f.GetTableMetadata(tableName)
+	if err != nil {
+		return nil, err
+	}
+	return meta.Columns, nil
+}
+
+// GetTableMetadata returns the fixture for a table
+func (f *FakeIntrospector) GetTableMetadata(tableName string) (*TableMetadata, error) {
+	if f.Err != nil {
+		return nil, f.Err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls[tableName]++
+	meta, ok := f.tables[tableName]
+	if !ok {
+		return nil, fmt.Errorf("table %s: %w", tableName, database.ErrTableNotFound)
+	}
+	return meta, nil
+}
+
+// Calls returns how often the metadata of a table was requested, e.g. to
+// check that unchanged tables are served from the generator cache
+func (f *FakeIntrospector) Calls(tableName string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[tableName]
+}
+
+// Table builds a table fixture, numbering the columns in order
+func Table(name string, columns ...ColumnMetadata) *TableMetadata {
+	for i := range columns {
+		columns[i].OrdinalPosition = i + 1
+	}
+	return &TableMetadata{Name: name, Columns: columns}
+}
+
+// Column builds a NOT NULL column fixture from a raw database type such as
+// varchar(255) or int unsigned. DataType, IsUnsigned and CharMaxLength are
+// derived from the raw type.
+func Column(name, rawType string) ColumnMetadata {
+	dataType := strings.ToLower(rawType)
+	if i := strings.IndexAny(dataType, "( "); i >= 0 {
+		dataType = dataType[:i]
+	}
+
+	col := ColumnMetadata{
+		Name:       name,
+		DataType:   dataType,
+		RawType:    rawType,
+		IsUnsigned: strings.Contains(strings.ToLower(rawType), "unsigned"),
+	}
+	if strings.Contains(dataType, "char") {
+		var length int
+		if _, err := fmt.Sscanf(rawType[len(dataType):], "(%d)", &length); err == nil {
+			col.CharMaxLength = &length
+		}
+	}
+	return col
+}
+
+// Nullable marks a column fixture as nullable
+func Nullable(col ColumnMetadata) ColumnMetadata {
+	col.IsNullable = true
+	return col
+}
+
+// PrimaryKey marks a column fixture as an auto-incrementing primary key
+func PrimaryKey(col ColumnMetadata) ColumnMetadata {
+	col.IsPrimaryKey = true
+	col.IsAutoIncrement = true
+	return col
+}
+
+// Default sets the default value of a column fixture
+func Default(col ColumnMetadata, value string) ColumnMetadata {
+	col.DefaultValue = &value
+	return col
+}
+
+// References adds a foreign key from column on table to refTable.refColumn,
+// recording it on both tables so belongs-to and has-many relations resolve
+func References(table *TableMetadata, column string, refTable *TableMetadata, refColumn string) {
+	fk := ForeignKey{
+		Name:             fmt.Sprintf("fk_%s_%s", table.Name, column),
+		Table:            table.Name,
+		Column:           column,
+		ReferencedTable:  refTable.Name,
+		ReferencedColumn: refColumn,
+	}
+	table.ForeignKeys = append(table.ForeignKeys, fk)
+	refTable.ReferencedBy = append(refTable.ReferencedBy, fk)
+}
@@ -0,0 +1,60 @@
+package databasetest_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/internal/generator"
+	"github.com/rowjak/godb-orm/pkg/databasetest"
+)
+
+func TestFakeIntrospector(t *testing.T) {
+	users := databasetest.Table("users",
+		databasetest.PrimaryKey(databasetest.Column("id", "bigint unsigned")),
+		databasetest.Column("email", "varchar(255)"),
+		databasetest.Nullable(databasetest.Column("nickname", "varchar(50)")),
+	)
+	orders := databasetest.Table("orders",
+		databasetest.PrimaryKey(databasetest.Column("id", "int")),
+		databasetest.Column("user_id", "bigint unsigned"),
+	)
+	databasetest.References(orders, "user_id", users, "id")
+	fake := databasetest.New("mysql", users, orders)
+
+	tables, err := fake.GetTables()
+	if err != nil {
+		t.Fatalf("GetTables() error = %v", err)
+	}
+	if want := []string{"orders", "users"}; !reflect.DeepEqual(tables, want) {
+		t.Errorf("GetTables() = %v; want %v", tables, want)
+	}
+
+	email := users.Columns[1]
+	if email.DataType != "varchar" || email.CharMaxLength == nil || *email.CharMaxLength != 255 || email.OrdinalPosition != 2 {
+		t.Errorf("Column() = %+v", email)
+	}
+	if !users.Columns[0].IsUnsigned {
+		t.Error("Column() should derive IsUnsigned from the raw type")
+	}
+
+	gen := generator.NewGeneratorWithConfig(fake, generator.GeneratorConfig{Relations: generator.RelationsAll})
+	code, err := gen.GenerateString("users")
+	if err != nil {
+		t.Fatalf("GenerateString() error = %v", err)
+	}
+	for _, want := range []string{"ID       uint64", "Nickname string", "Orders   []Order"} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+	if got := fake.Calls("users"); got != 1 {
+		t.Errorf("Calls() = %d; want 1", got)
+	}
+
+	fake.Err = errors.New("connection refused")
+	if _, err := gen.GenerateString("users"); err == nil {
+		t.Error("GenerateString() should fail when the introspector fails")
+	}
+}
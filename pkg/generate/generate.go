@@ -0,0 +1,219 @@
+// Package generate renders GORM model structs from table metadata, the same
+// way the godb-orm CLI and GUI do.
+//
+//	gen, err := generate.New(db, generate.Options{PackageName: "models", NullStrategy: "pointer"})
+//	if err != nil {
+//		return err
+//	}
+//	code, err := gen.Generate("users")
+package generate
+
+import (
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/generator"
+	"github.com/rowjak/godb-orm/pkg/introspect"
+)
+
+// Customization types used in Options
+type (
+	TableOverride  = config.TableOverride
+	ColumnOverride = config.ColumnOverride
+	RelationRule   = config.RelationRule
+	TypeRule       = config.TypeRule
+	SubpackageRule = config.SubpackageRule
+)
+
+// Options configures a Generator. The zero value generates package models
+// with the CLI's defaults.
+type Options struct {
+	PackageName string // Package clause of generated files (default models)
+	ImportPath  string // Fully qualified import path of the models package (optional)
+
+	NullStrategy   string // zero (default) or pointer
+	TagStyle       string // JSON tag names: snake (default) or camel
+	Relations      string // Association fields: none (default), belongs_to or all
+	InferRelations bool   // Also relate <singular_table>_id columns without a declared foreign key
+	Hstore         string // PostgreSQL hstore columns: pgtype (default) or map
+	Vector         string // pgvector columns: pgvector (default) or float32
+	Spatial        string // MySQL spatial columns: wkb (default) or orb
+	Bit            string // MySQL BIT(n>1) columns: bytes (default) or uint64
+	DateTime       string // Date-times without time zone: time (default), local or string
+	FieldOrder     string // Field order: ordinal (default), pk_first, alphabetical or grouped
+	NoPrimaryKey   string // Tables without a primary key: generate (default), skip or read_only
+	Style          string // Code per table: model (default) or full, adding CRUD functions and relationship loaders
+	MaxLineWidth   int    // Width struct field lines should fit in (0 for no limit)
+	ScanHelpers    bool   // Emit Columns() and ScanRow() for database/sql users
+	QueryBuilder   string // Emit SelectColumns(), InsertColumns() and InsertValues() for squirrel or goqu
+
+	TablePrefix  string // Prefix left out of struct and file names, e.g. wp_
+	FilePattern  string // File name template, e.g. {{.Table}}.gen.go
+	BuildTag     string // Build constraint added as //go:build to every file
+	TemplateFile string // Custom struct template file replacing the built-in one
+	Header       string // Template prepended to every file, as comments
+	Footer       string // Template appended to every file, as comments
+	GoGenerate   bool   // Add a //go:generate line regenerating each table
+
+	Acronyms   []string // Acronyms upcased in Go names besides ID, URL, API, ..., e.g. SKU
+	NoAcronyms bool     // Keep Go names in plain PascalCase (UserId instead of UserID)
+
+	Hooks         bool   // Emit a BeforeCreate hook assigning uuid.New() to UUID primary keys
+	Scopes        bool   // Emit a TenantScope scope for tables with the tenant column
+	TenantColumn  string // Column scoped by TenantScope (default tenant_id)
+	WithTx        bool   // Emit a WithTx transaction helper per model
+	Repositories  bool   // Emit a <Model>Repository interface with a GORM implementation
+	MockTool      string // Mock generator of the repositories' go:generate line: mockery (default) or mockgen
+	Factories     bool   // Emit a New<Model> constructor with a functional option per column
+	PrivateFields bool   // Emit unexported fields with getters, setters and ToMap()
+	Filters       bool   // Emit a <Model>Filter struct with an Apply(*gorm.DB) method
+	Pagination    bool   // Emit a List<Models> helper returning a page and the total count
+	Swagger       bool   // Annotate models for swaggo with model comments and format/example tags
+
+	GormTag            []string // Optional gorm tag options: column, type, default, not_null, size, precision, comment (nil for the defaults)
+	ExtraTags          []string // Extra tag sets: xml, yaml, mapstructure or bson, optionally as key:style (e.g., yaml:camel)
+	Sensitive          []string // Column patterns (e.g., *password*) tagged json:"-"
+	SensitiveWriteOnly bool     // Also tag sensitive columns gorm:"->:false"
+
+	AutoCreateTime []string // Columns tagged autoCreateTime (nil means created_at)
+	AutoUpdateTime []string // Columns tagged autoUpdateTime (nil means updated_at)
+
+	Overrides     map[string]TableOverride // Per-table customizations, keyed by table name
+	RelationRules map[string]RelationRule  // Per-table allow/deny lists for association fields
+	TypeRules     []TypeRule               // Type mappings taking precedence over the built-in ones
+	Subpackages   []SubpackageRule         // Route tables matching a pattern into subpackages
+}
+
+// Generator renders model files for the tables of an introspector
+type Generator struct {
+	gen *generator.Generator
+}
+
+// New creates a Generator. It returns an error if an option has an invalid
+// value.
+func New(introspector introspect.Introspector, opts Options) (*Generator, error) {
+	modes := []struct{ key, value string }{
+		{"generator.null_strategy", opts.NullStrategy},
+		{"generator.tag_style", opts.TagStyle},
+		{"generator.relations", opts.Relations},
+		{"generator.hstore", opts.Hstore},
+		{"generator.vector", opts.Vector},
+		{"generator.spatial", opts.Spatial},
+		{"generator.bit", opts.Bit},
+		{"generator.datetime", opts.DateTime},
+		{"generator.field_order", opts.FieldOrder},
+		{"generator.no_primary_key", opts.NoPrimaryKey},
+		{"generator.style", opts.Style},
+	}
+	for _, mode := range modes {
+		if mode.value == "" {
+			continue
+		}
+		if err := config.ValidateValue(mode.key, mode.value); err != nil {
+			return nil, err
+		}
+	}
+
+	gen := generator.NewGeneratorWithConfig(introspector, generator.GeneratorConfig{
+		PackageName:    opts.PackageName,
+		ImportPath:     opts.ImportPath,
+		ScanHelpers:    opts.ScanHelpers,
+		QueryBuilder:   generator.QueryBuilder(opts.QueryBuilder),
+		NullStrategy:   generator.NullStrategy(opts.NullStrategy),
+		TagStyle:       generator.TagStyle(opts.TagStyle),
+		Relations:      generator.RelationMode(opts.Relations),
+		InferRelations: opts.InferRelations,
+		RelationRules:  opts.RelationRules,
+		Overrides:      opts.Overrides,
+		TemplateFile:   opts.TemplateFile,
+		Header:         opts.Header,
+		Footer:         opts.Footer,
+		GoGenerate:     opts.GoGenerate,
+		TypeRules:      opts.TypeRules,
+		Hstore:         generator.HstoreMode(opts.Hstore),
+		Vector:         generator.VectorMode(opts.Vector),
+		Spatial:        generator.SpatialMode(opts.Spatial),
+		Bit:            generator.BitMode(opts.Bit),
+		DateTime:       generator.DateTimeMode(opts.DateTime),
+		FieldOrder:     generator.FieldOrder(opts.FieldOrder),
+		NoPrimaryKey:   generator.NoPrimaryKeyMode(opts.NoPrimaryKey),
+		Style:          generator.Style(opts.Style),
+		MaxLineWidth:   opts.MaxLineWidth,
+		AutoCreate:     opts.AutoCreateTime,
+		AutoUpdate:     opts.AutoUpdateTime,
+		TablePrefix:    opts.TablePrefix,
+		Acronyms:       opts.Acronyms,
+		NoAcronyms:     opts.NoAcronyms,
+		Subpackages:    opts.Subpackages,
+		FilePattern:    opts.FilePattern,
+		BuildTag:       opts.BuildTag,
+		Hooks:          opts.Hooks,
+		Scopes:         opts.Scopes,
+		TenantColumn:   opts.TenantColumn,
+		WithTx:         opts.WithTx,
+		Repositories:   opts.Repositories,
+		MockTool:       generator.MockTool(opts.MockTool),
+		Factories:      opts.Factories,
+		PrivateFields:  opts.PrivateFields,
+		Filters:        opts.Filters,
+		Pagination:     opts.Pagination,
+		Swagger:        opts.Swagger,
+		GormOptions:    opts.GormTag,
+		ExtraTags:      opts.ExtraTags,
+		Sensitive:      opts.Sensitive,
+		WriteOnly:      opts.SensitiveWriteOnly,
+	})
+	if err := gen.Err(); err != nil {
+		return nil, err
+	}
+	return &Generator{gen: gen}, nil
+}
+
+// Generate returns the formatted model file for a table
+func (g *Generator) Generate(table string) ([]byte, error) {
+	return g.gen.Generate(table)
+}
+
+// GenerateToFile writes the model file (and any helper files it needs) for
+// a table below outputDir and returns its path
+func (g *Generator) GenerateToFile(table, outputDir string) (string, error) {
+	return g.gen.GenerateToFile(table, outputDir)
+}
+
+// GenerateAll writes the model files of every table below outputDir and
+// returns their paths
+func (g *Generator) GenerateAll(outputDir string) ([]string, error) {
+	return g.gen.GenerateAll(outputDir)
+}
+
+// GenerateConstants returns a file with table and column name constants for
+// the given tables
+func (g *Generator) GenerateConstants(tables []string) ([]byte, error) {
+	return g.gen.GenerateConstants(tables)
+}
+
+// GenerateAvroSchema returns the Avro record schema (.avsc) of a table
+func (g *Generator) GenerateAvroSchema(table string) ([]byte, error) {
+	return g.gen.GenerateAvroSchema(table)
+}
+
+// GenerateDebeziumSchema returns the key and value schemas of a table's
+// Debezium CDC topic, named with topicPrefix; dialect is mysql or postgres
+func (g *Generator) GenerateDebeziumSchema(table, topicPrefix, dialect string) ([]byte, error) {
+	return g.gen.GenerateDebeziumSchema(table, topicPrefix, dialect)
+}
+
+// GenerateFakes returns a file with a NewFake<Model>() gofakeit factory per
+// table, for test fixtures
+func (g *Generator) GenerateFakes(tables []string) ([]byte, error) {
+	return g.gen.GenerateFakes(tables)
+}
+
+// FilePath returns the path the model file of a table is written to below
+// outputDir
+func (g *Generator) FilePath(table, outputDir string) string {
+	return g.gen.FilePath(table, outputDir)
+}
+
+// PackageName returns the package clause used for generated files
+func (g *Generator) PackageName() string {
+	return g.gen.PackageName()
+}
@@ -0,0 +1,69 @@
+package generate_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rowjak/godb-orm/pkg/databasetest"
+	"github.com/rowjak/godb-orm/pkg/generate"
+	"github.com/rowjak/godb-orm/pkg/introspect"
+)
+
+const ddl = `CREATE TABLE public.wp_users (
+    id bigserial PRIMARY KEY,
+    email character varying(255) NOT NULL,
+    nickname text
+);`
+
+func TestGenerate(t *testing.T) {
+	db, err := introspect.FromDDL(ddl, "postgres")
+	if err != nil {
+		t.Fatalf("FromDDL() error = %v", err)
+	}
+	if got := introspect.Dialect(db); got != "postgres" {
+		t.Errorf("Dialect() = %q; want postgres", got)
+	}
+
+	gen, err := generate.New(db, generate.Options{PackageName: "entity", NullStrategy: "pointer", TablePrefix: "wp_"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	code, err := gen.Generate("wp_users")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, want := range []string{"package entity", "type User struct", "*string", `return "wp_users"`} {
+		if !strings.Contains(string(code), want) {
+			t.Errorf("generated code missing %q:\n%s", want, code)
+		}
+	}
+
+	outputDir := t.TempDir()
+	paths, err := gen.GenerateAll(outputDir)
+	if err != nil {
+		t.Fatalf("GenerateAll() error = %v", err)
+	}
+	if want := filepath.Join(outputDir, "users.go"); len(paths) != 1 || paths[0] != want || gen.FilePath("wp_users", outputDir) != want {
+		t.Errorf("GenerateAll() = %v; want [%s]", paths, want)
+	}
+	if _, err := os.Stat(paths[0]); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNew_InvalidOptions(t *testing.T) {
+	fake := databasetest.New("")
+	for _, opts := range []generate.Options{
+		{NullStrategy: "nil"},
+		{Relations: "has_many"},
+		{FilePattern: "{{.Table}}.txt"},
+		{TypeRules: []generate.TypeRule{{Match: "(", Type: "string"}}},
+	} {
+		if _, err := generate.New(fake, opts); err == nil {
+			t.Errorf("New(%+v): expected error", opts)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Package introspect reads table metadata from a live MySQL, PostgreSQL,
+// Firebird, Db2 or Trino database, a DuckDB file, a MongoDB database (fields
+// inferred from sampled documents), or a schema dump, for use with package
+// generate. Firebird, Db2, Trino and DuckDB need the build tags
+// firebird, db2, trino and duckdb.
+//
+//	db, err := introspect.Open(introspect.Config{
+//		Driver: "postgres", Host: "localhost", Port: 5432,
+//		User: "postgres", Password: "secret", DBName: "shop",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	defer db.Close()
+//
+//	meta, err := db.GetTableMetadata("users")
+package introspect
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rowjak/godb-orm/internal/config"
+	"github.com/rowjak/godb-orm/internal/database"
+)
+
+// Metadata types returned by an Introspector
+type (
+	TableMetadata  = database.TableMetadata
+	ColumnMetadata = database.ColumnMetadata
+	ForeignKey     = database.ForeignKey
+)
+
+// Introspector reads table metadata from a schema. It is implemented by the
+// introspectors returned from this package and by databasetest.FakeIntrospector.
+type Introspector = database.DBIntrospector
+
+// Errors matched with errors.Is, detected from MySQL and PostgreSQL error
+// codes or, for ErrTableNotFound, from a table without visible columns
+var (
+	ErrAuthFailed       = database.ErrAuthFailed
+	ErrUnknownDatabase  = database.ErrUnknownDatabase
+	ErrTableNotFound    = database.ErrTableNotFound
+	ErrPermissionDenied = database.ErrPermissionDenied
+	ErrQueryTimeout     = database.ErrQueryTimeout
+)
+
+// Config describes the database to introspect
+type Config struct {
+	Driver   string // mysql, postgres, firebird, db2, trino, duckdb or mongodb
+	Host     string
+	Port     int
+	User     string
+	Password string
+	DBName   string
+	// Schema selects the PostgreSQL schema (default public), the Db2 schema
+	// (default the user), the Trino schema (default "default"; DBName is
+	// the catalog) or the DuckDB schema (default main; DBName is the file)
+	Schema string
+	// QueryTimeout bounds each introspection query (default 30s)
+	QueryTimeout time.Duration
+	// SampleSize is the number of documents sampled per MongoDB collection
+	// (default 100)
+	SampleSize int
+}
+
+// Open connects to the database described by cfg. The caller must Close the
+// returned Introspector.
+func Open(cfg Config) (Introspector, error) {
+	dbCfg := &config.DBConfig{
+		Host:         cfg.Host,
+		Port:         cfg.Port,
+		User:         cfg.User,
+		Password:     cfg.Password,
+		DBName:       cfg.DBName,
+		Driver:       cfg.Driver,
+		QueryTimeout: int(cfg.QueryTimeout / time.Second),
+		SampleSize:   cfg.SampleSize,
+	}
+	if dbCfg.QueryTimeout <= 0 {
+		dbCfg.QueryTimeout = config.DefaultQueryTimeout
+	}
+
+	introspector, err := database.NewIntrospector(dbCfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := introspector.Connect(); err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if s, ok := introspector.(interface{ SetSchema(string) }); ok && cfg.Schema != "" {
+		s.SetSchema(cfg.Schema)
+	}
+	return introspector, nil
+}
+
+// FromDDL parses CREATE TABLE statements, such as a mysqldump --no-data or
+// pg_dump --schema-only file. The dialect is detected from the statements,
+// falling back to fallbackDialect (mysql or postgres).
+func FromDDL(ddl, fallbackDialect string) (Introspector, error) {
+	introspector := database.NewDDLIntrospectorFromSource(ddl, fallbackDialect)
+	if err := introspector.Connect(); err != nil {
+		return nil, err
+	}
+	return introspector, nil
+}
+
+// Dialect returns the SQL dialect of an introspector's schema (mysql or
+// postgres), or an empty string if it is unknown
+func Dialect(introspector Introspector) string {
+	if d, ok := introspector.(database.Dialecter); ok {
+		return d.Dialect()
+	}
+	return ""
+}
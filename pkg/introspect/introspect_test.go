@@ -0,0 +1,27 @@
+package introspect
+
+import "testing"
+
+func TestOpen_UnsupportedDriver(t *testing.T) {
+	if _, err := Open(Config{Driver: "oracle"}); err == nil {
+		t.Error("Open() with an unsupported driver: expected error")
+	}
+}
+
+func TestFromDDL(t *testing.T) {
+	db, err := FromDDL("CREATE TABLE `users` (`id` int NOT NULL, PRIMARY KEY (`id`)) ENGINE=InnoDB;", "")
+	if err != nil {
+		t.Fatalf("FromDDL() error = %v", err)
+	}
+	if got := Dialect(db); got != "mysql" {
+		t.Errorf("Dialect() = %q; want mysql", got)
+	}
+	meta, err := db.GetTableMetadata("users")
+	if err != nil || len(meta.Columns) != 1 || !meta.Columns[0].IsPrimaryKey {
+		t.Errorf("GetTableMetadata() = %+v, %v", meta, err)
+	}
+
+	if _, err := FromDDL("SELECT 1;", "postgres"); err == nil {
+		t.Error("FromDDL() without CREATE TABLE: expected error")
+	}
+}